@@ -39,3 +39,7 @@ func (a *judgeStoreAdapter) InsertJudgeScore(ctx context.Context, sc *domain.Jud
 func (a *judgeStoreAdapter) SumJudgeCostSince(ctx context.Context, since time.Time) (float64, error) {
 	return a.inner.SumJudgeCostSince(ctx, since)
 }
+
+func (a *judgeStoreAdapter) GetJudgeBacklog(ctx context.Context, lookback time.Duration) (*domain.JudgeBacklog, error) {
+	return a.inner.GetJudgeBacklog(ctx, lookback)
+}
@@ -22,12 +22,14 @@ import (
 	apimw "github.com/donaldgifford/server-price-tracker/internal/api/middleware"
 	"github.com/donaldgifford/server-price-tracker/internal/api/web"
 	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/currency"
 	"github.com/donaldgifford/server-price-tracker/internal/ebay"
 	"github.com/donaldgifford/server-price-tracker/internal/engine"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	"github.com/donaldgifford/server-price-tracker/internal/notify"
 	"github.com/donaldgifford/server-price-tracker/internal/observability"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
 	"github.com/donaldgifford/server-price-tracker/pkg/extract"
 	"github.com/donaldgifford/server-price-tracker/pkg/judge"
 	sptlog "github.com/donaldgifford/server-price-tracker/pkg/logger"
@@ -72,8 +74,11 @@ func startServer(opts *Options) error {
 	// --- eBay client ---
 	ebayClient, rateLimiter, analyticsClient := buildEbayClient(cfg, slogger)
 
+	// --- Hardware catalog ---
+	hwCatalog := loadCatalog(&cfg.Catalog, slogger)
+
 	// --- LLM extractor (Langfuse-decorated when langfuse client is real) ---
-	extractor := buildExtractor(cfg, slogger, lfClient)
+	extractor, shadow := buildExtractor(cfg, slogger, lfClient, pgStore)
 
 	// --- Notifier ---
 	notifier := buildNotifier(cfg, slogger)
@@ -83,8 +88,8 @@ func startServer(opts *Options) error {
 
 	// --- Engine + Scheduler ---
 	eng, scheduler := buildEngine(
-		cfg, pgStore, ebayClient, extractor, notifier,
-		analyticsClient, rateLimiter, lfClient, slogger,
+		workerCtx, cfg, pgStore, ebayClient, extractor, notifier,
+		analyticsClient, rateLimiter, lfClient, hwCatalog, slogger,
 	)
 	if eng != nil {
 		eng.StartExtractionWorkers(workerCtx)
@@ -95,9 +100,10 @@ func startServer(opts *Options) error {
 	e, humaAPI := buildHTTPServer(slogger)
 
 	// --- Routes ---
-	registerRoutes(humaAPI, pgStore, ebayClient, extractor, eng, rateLimiter, cfg.Observability.Langfuse.Endpoint)
+	registerRoutes(humaAPI, pgStore, ebayClient, extractor, eng, rateLimiter, hwCatalog,
+		cfg.Observability.Langfuse.Endpoint, &cfg.Alerts.Sellers)
 
-	if err := registerAlertsUI(e, cfg, pgStore, notifier, lfClient, slogger); err != nil {
+	if err := registerAlertsUI(e, cfg, pgStore, notifier, eng, lfClient, slogger); err != nil {
 		workerCancel()
 		return err
 	}
@@ -126,16 +132,18 @@ func startServer(opts *Options) error {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	return shutdownServer(e, scheduler, workerCancel, slogger)
+	return shutdownServer(e, scheduler, shadow, workerCancel, slogger)
 }
 
 // shutdownServer runs the orderly shutdown sequence: cancel extraction
-// workers, drain the scheduler, then close the HTTP server with a
-// 10-second deadline. Extracted from startServer to keep its statement
-// count under the funlen budget; behaviour is unchanged.
+// workers, drain the scheduler, close the HTTP server, then let running
+// shadow comparisons finish recording, all within a 10-second deadline.
+// Extracted from startServer to keep its statement count under the
+// funlen budget.
 func shutdownServer(
 	e *echo.Echo,
 	scheduler *engine.Scheduler,
+	shadow *extract.Shadow,
 	workerCancel context.CancelFunc,
 	logger *slog.Logger,
 ) error {
@@ -156,11 +164,32 @@ func shutdownServer(
 	if err := e.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("shutting down server: %w", err)
 	}
-
 	logger.Info("server stopped")
+
+	// Nothing starts a comparison once the workers and handlers are
+	// gone, so this only waits out the ones already running.
+	if shadow != nil {
+		waitShadow(shutdownCtx, shadow, logger)
+	}
 	return nil
 }
 
+// waitShadow waits for running shadow comparisons to be recorded, giving
+// up when ctx is done. Comparisons still running then are lost.
+func waitShadow(ctx context.Context, shadow *extract.Shadow, logger *slog.Logger) {
+	done := make(chan struct{})
+	go func() {
+		shadow.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logger.Info("shadow comparisons recorded")
+	case <-ctx.Done():
+		logger.Warn("shutdown deadline reached before shadow comparisons finished")
+	}
+}
+
 // initLangfuse builds a Langfuse client from cfg. When disabled, returns
 // langfuse.NoopClient + a no-op shutdown — the rest of the app never
 // has to branch on "is Langfuse enabled". When enabled, wraps the
@@ -234,17 +263,24 @@ func initOTel(cfg config.OtelConfig, logger *slog.Logger) (func(), error) {
 // registerAlertsUI mounts the alert review UI under /alerts and the static
 // asset handler under /static/* when web.enabled is true and the store is
 // available. Lives outside Huma because it serves HTML, not JSON on /api/v1.
+// The engine, when there is one, applies extraction corrections from the
+// alert detail page.
 func registerAlertsUI(
 	e *echo.Echo,
 	cfg *config.Config,
 	pgStore store.Store,
 	notifier notify.Notifier,
+	eng *engine.Engine,
 	lf langfuse.Client,
 	logger *slog.Logger,
 ) error {
 	if !cfg.Web.Enabled || pgStore == nil {
 		return nil
 	}
+	var corrector handlers.ExtractionCorrector
+	if eng != nil {
+		corrector = eng
+	}
 	alertsUI := handlers.NewAlertsUIHandler(&handlers.AlertsUIDeps{
 		Store:            pgStore,
 		Notifier:         notifier,
@@ -252,6 +288,7 @@ func registerAlertsUI(
 		LangfuseEndpoint: cfg.Observability.Langfuse.Endpoint,
 		JudgeEnabled:     cfg.Observability.Judge.Enabled,
 		AlertsURLBase:    cfg.Web.AlertsURLBase,
+		Corrector:        corrector,
 		Logger:           logger,
 	})
 	handlers.RegisterAlertsUIRoutes(e, alertsUI)
@@ -295,7 +332,7 @@ func buildHTTPServer(logger *slog.Logger) (*echo.Echo, huma.API) {
 // registerRoutes sets up all HTTP routes on the Huma API. The
 // langfuseEndpoint is wired through to the alerts trace handler so
 // trace deep-links can be resolved by clients without re-reading
-// config.
+// config, and the global seller lists to watch previews.
 func registerRoutes(
 	humaAPI huma.API,
 	s store.Store,
@@ -303,22 +340,35 @@ func registerRoutes(
 	extractor extract.Extractor,
 	eng *engine.Engine,
 	rl *ebay.RateLimiter,
+	hwCatalog *catalog.Catalog,
 	langfuseEndpoint string,
+	sellers *config.SellerListsConfig,
 ) {
 	// Health endpoints (Huma).
 	healthH := handlers.NewHealthHandler(s)
 	handlers.RegisterHealthRoutes(humaAPI, healthH)
 
 	// Quota endpoint (always registered; returns zeroes when rl is nil).
-	quotaH := handlers.NewQuotaHandler(rl)
+	// The budget plan comes from the engine, when there is one.
+	var plans handlers.BudgetPlanSource
+	if eng != nil {
+		plans = eng
+	}
+	quotaH := handlers.NewQuotaHandler(rl, plans)
 	handlers.RegisterQuotaRoutes(humaAPI, quotaH)
 
+	// Hardware catalog (Huma).
+	handlers.RegisterCatalogRoutes(humaAPI, handlers.NewCatalogHandler(hwCatalog))
+
 	// Store-dependent routes (Huma).
 	if s != nil {
 		listingsH := handlers.NewListingsHandler(s)
 		handlers.RegisterListingRoutes(humaAPI, listingsH)
 
-		watchH := handlers.NewWatchHandler(s)
+		sellersH := handlers.NewSellersHandler(s)
+		handlers.RegisterSellerRoutes(humaAPI, sellersH)
+
+		watchH := handlers.NewWatchHandler(s, handlers.WithGlobalSellerLists(sellers.Blocklist, sellers.Allowlist))
 		handlers.RegisterWatchRoutes(humaAPI, watchH)
 
 		rescoreH := handlers.NewRescoreHandler(eng)
@@ -327,8 +377,12 @@ func registerRoutes(
 		baselinesH := handlers.NewBaselinesHandler(s)
 		handlers.RegisterBaselineRoutes(humaAPI, baselinesH)
 
+		productKeysH := handlers.NewProductKeysHandler(s, eng)
+		handlers.RegisterProductKeyRoutes(humaAPI, productKeysH)
+
 		extractionStatsH := handlers.NewExtractionStatsHandler(s)
 		handlers.RegisterExtractionStatsRoutes(humaAPI, extractionStatsH)
+		handlers.RegisterShadowRoutes(humaAPI, handlers.NewShadowHandler(s))
 
 		systemStateH := handlers.NewSystemStateHandler(s)
 		handlers.RegisterSystemStateRoutes(humaAPI, systemStateH)
@@ -347,6 +401,14 @@ func registerRoutes(
 
 		jobsH := handlers.NewJobsHandler(s)
 		handlers.RegisterJobRoutes(humaAPI, jobsH)
+
+		// Extraction corrections. The engine applies them; without one
+		// the correction endpoint responds 503.
+		var corrector handlers.ExtractionCorrector
+		if eng != nil {
+			corrector = eng
+		}
+		handlers.RegisterCorrectionRoutes(humaAPI, handlers.NewCorrectionsHandler(s, corrector))
 	}
 
 	// Search (Huma).
@@ -420,11 +482,52 @@ func buildEbayClient(
 	return client, rl, ac
 }
 
-func buildExtractor(cfg *config.Config, logger *slog.Logger, lf langfuse.Client) extract.Extractor {
-	backend := buildLLMBackend(cfg, logger)
+// buildRateProvider loads the exchange rate table for USD normalization
+// and starts refreshing it until ctx is done. Returns nil when no rate
+// source is configured; the engine then converts USD listings only. A
+// failed first load is logged, not fatal: the provider retries on every
+// refresh and converts USD only until one succeeds.
+func buildRateProvider(ctx context.Context, cfg *config.CurrencyConfig, logger *slog.Logger) *currency.Provider {
+	var source currency.Source
+	switch {
+	case cfg.RatesFile != "":
+		source = currency.FileSource(cfg.RatesFile)
+	case cfg.RatesURL != "":
+		source = &currency.HTTPSource{URL: cfg.RatesURL}
+	default:
+		logger.Info("no exchange rate source configured, only USD listings get USD prices")
+		return nil
+	}
+
+	p := currency.NewProvider(source,
+		currency.WithRefreshInterval(cfg.RefreshInterval),
+		currency.WithProviderLogger(logger),
+	)
+	if err := p.Refresh(ctx); err != nil {
+		logger.Warn("initial exchange rate load failed", "error", err)
+	} else {
+		table, _ := p.Table()
+		logger.Info("exchange rates loaded",
+			"currencies", len(table.Rates),
+			"refresh_interval", cfg.RefreshInterval,
+		)
+	}
+	p.Start(ctx)
+	return p
+}
+
+// buildExtractor builds the LLM extractor, and the shadow extractor it
+// feeds when llm.shadow is on so shutdown can wait for its comparisons.
+func buildExtractor(
+	cfg *config.Config,
+	logger *slog.Logger,
+	lf langfuse.Client,
+	s store.Store,
+) (extract.Extractor, *extract.Shadow) {
+	backend := buildLLMBackend(&cfg.LLM, logger)
 	if backend == nil {
 		logger.Warn("llm extractor disabled")
-		return nil
+		return nil, nil
 	}
 	// Decorate with Langfuse when the client is more than the no-op.
 	// NoopClient.LogGeneration is a free non-op so the wrap is safe
@@ -442,10 +545,60 @@ func buildExtractor(cfg *config.Config, logger *slog.Logger, lf langfuse.Client)
 		logger.Info("llm extractor wrapped with langfuse decorator")
 	}
 	logger.Info("llm extractor configured", "backend", cfg.LLM.Backend)
-	return extract.NewLLMExtractor(
-		backend,
+	opts := []extract.LLMExtractorOption{
 		extract.WithLogger(logger),
 		extract.WithLangfuseClient(lf),
+	}
+	shadow := buildShadow(cfg, s, logger)
+	if shadow != nil {
+		opts = append(opts, extract.WithShadow(shadow))
+	}
+	return extract.NewLLMExtractor(backend, opts...), shadow
+}
+
+// buildShadow builds the shadow extractor that repeats a sample of live
+// extractions for comparison, or returns nil when llm.shadow is off or
+// can't run. The shadow backend isn't Langfuse-decorated and the shadow
+// extractor posts no scores, so shadow calls stay out of the live
+// traces.
+func buildShadow(cfg *config.Config, s store.Store, logger *slog.Logger) *extract.Shadow {
+	sc := &cfg.LLM.Shadow
+	if !sc.Enabled {
+		return nil
+	}
+	if s == nil {
+		logger.Warn("shadow extraction enabled but no database to record comparisons; skipping")
+		return nil
+	}
+	llm := sc.BackendConfig(&cfg.LLM)
+	backend := buildLLMBackend(&llm, logger)
+	if backend == nil {
+		logger.Warn("shadow extraction enabled but its llm backend is unavailable; skipping")
+		return nil
+	}
+	extractorOpts := []extract.LLMExtractorOption{extract.WithLogger(logger)}
+	if sc.PromptDir != "" {
+		prompts, err := extract.LoadPrompts(sc.PromptDir)
+		if err != nil {
+			logger.Error("loading shadow prompts failed; skipping shadow extraction", "prompt_dir", sc.PromptDir, "error", err)
+			return nil
+		}
+		extractorOpts = append(extractorOpts, extract.WithPrompts(prompts))
+	}
+	logger.Info("shadow extraction enabled",
+		"backend", llm.Backend,
+		"prompt_version", sc.PromptVersion,
+		"sample_rate", sc.SampleRate,
+	)
+	return extract.NewShadow(
+		extract.NewLLMExtractor(backend, extractorOpts...),
+		s,
+		extract.WithShadowSampleRate(sc.SampleRate),
+		extract.WithShadowPromptVersion(sc.PromptVersion),
+		extract.WithShadowCosts(cfg.Observability.Langfuse.ModelCosts),
+		extract.WithShadowConcurrency(sc.Concurrency),
+		extract.WithShadowTimeout(sc.Timeout),
+		extract.WithShadowLogger(logger),
 	)
 }
 
@@ -464,7 +617,21 @@ func buildNotifier(cfg *config.Config, logger *slog.Logger) notify.Notifier {
 	return notify.NewNoOpNotifier(logger)
 }
 
+// loadCatalog loads the hardware catalog, layering cfg.Path over the
+// embedded data. A bad catalog file is logged and the server continues
+// without a catalog: enrichment is skipped and the catalog API responds
+// 503.
+func loadCatalog(cfg *config.CatalogConfig, logger *slog.Logger) *catalog.Catalog {
+	c, err := catalog.Load(cfg.Path)
+	if err != nil {
+		logger.Error("loading hardware catalog failed, continuing without catalog", "path", cfg.Path, "error", err)
+		return nil
+	}
+	return c
+}
+
 func buildEngine(
+	ctx context.Context,
 	cfg *config.Config,
 	s store.Store,
 	ebayClient ebay.EbayClient,
@@ -473,6 +640,7 @@ func buildEngine(
 	ac *ebay.AnalyticsClient,
 	rl *ebay.RateLimiter,
 	lf langfuse.Client,
+	hwCatalog *catalog.Catalog,
 	logger *slog.Logger,
 ) (*engine.Engine, *engine.Scheduler) {
 	if s == nil || ebayClient == nil || extractor == nil {
@@ -483,14 +651,24 @@ func buildEngine(
 	opts := []engine.EngineOption{
 		engine.WithLogger(logger),
 		engine.WithBaselineWindowDays(cfg.Scoring.BaselineWindowDays),
+		engine.WithScoringWeights(engine.WeightsFromConfig(cfg.Scoring)),
 		engine.WithStaggerOffset(cfg.Schedule.StaggerOffset),
+		engine.WithPollSchedule(cfg.Schedule.IngestionInterval, cfg.Schedule.PollTick),
 		engine.WithAlertsConfig(cfg.Alerts),
+		engine.WithRelistDetection(cfg.Scoring.Relists),
+		engine.WithPartOutEstimates(cfg.Scoring.PartOut),
+		engine.WithCorrectionsDataset(lf, cfg.Observability.Langfuse.CorrectionsDatasetID),
 		engine.WithAlertProcessing(engine.AlertProcessingConfig{
 			SummaryOnly:   cfg.Notifications.Discord.SummaryOnly,
 			AlertsURLBase: cfg.Web.AlertsURLBase,
+			JudgeGate:     cfg.Alerts.JudgeGate,
 		}),
 	}
 
+	if cfg.Catalog.Enabled && hwCatalog != nil {
+		opts = append(opts, engine.WithCatalog(hwCatalog))
+		logger.Info("catalog enrichment enabled", "versions", hwCatalog.Versions())
+	}
 	if ac != nil {
 		opts = append(opts, engine.WithAnalyticsClient(ac))
 	}
@@ -509,6 +687,9 @@ func buildEngine(
 		opts = append(opts, engine.WithMaxCallsPerCycle(cfg.Ebay.MaxCallsPerCycle))
 	}
 	opts = append(opts, engine.WithWorkerCount(cfg.LLM.Concurrency))
+	if rates := buildRateProvider(ctx, &cfg.Currency, logger); rates != nil {
+		opts = append(opts, engine.WithCurrencyConverter(rates))
+	}
 
 	eng := engine.NewEngine(s, ebayClient, extractor, notifier, opts...)
 	logger.Info("engine created")
@@ -538,7 +719,7 @@ func buildEngine(
 	sched, err := engine.NewScheduler(
 		eng,
 		s,
-		cfg.Schedule.IngestionInterval,
+		cfg.Schedule.PollTick,
 		cfg.Schedule.BaselineInterval,
 		cfg.Schedule.ReExtractionInterval,
 		logger,
@@ -549,9 +730,23 @@ func buildEngine(
 	}
 	logger.Info("scheduler configured",
 		"ingestion_interval", cfg.Schedule.IngestionInterval,
+		"poll_tick", cfg.Schedule.PollTick,
 		"baseline_interval", cfg.Schedule.BaselineInterval,
 	)
 
+	if cfg.Alerts.Auctions.Enabled {
+		if err := sched.AddAuctionTracking(cfg.Alerts.Auctions.CheckInterval); err != nil {
+			logger.Error("auction tracking registration failed", "error", err)
+		} else {
+			logger.Info("auction tracking registered",
+				"check_interval", cfg.Alerts.Auctions.CheckInterval,
+				"refresh_window", cfg.Alerts.Auctions.RefreshWindow,
+				"refresh_interval", cfg.Alerts.Auctions.RefreshInterval,
+				"reminder_lead", cfg.Alerts.Auctions.ReminderLead,
+			)
+		}
+	}
+
 	// Recover any job runs that were left in 'running' state at last crash.
 	sched.RecoverStaleJobRuns(context.Background())
 
@@ -598,7 +793,7 @@ func registerJudgeWorker(
 	if !cfg.Observability.Judge.Enabled {
 		return nil, nil //nolint:nilnil // documented surface: nil + nil signals "judge disabled"
 	}
-	backend := buildLLMBackend(cfg, logger)
+	backend := buildLLMBackend(&cfg.LLM, logger)
 	if backend == nil {
 		logger.Warn("judge enabled but llm backend is nil; skipping registration")
 		return nil, nil //nolint:nilnil // disabled-equivalent outcome — handler responds 503
@@ -617,14 +812,16 @@ func registerJudgeWorker(
 		return nil, fmt.Errorf("constructing LLM judge: %w", err)
 	}
 	worker, err := judge.NewWorker(&judge.WorkerConfig{
-		Judge:          llmJudge,
-		Store:          newJudgeStoreAdapter(s),
-		Metrics:        metrics.JudgeRecorder{},
-		Langfuse:       lf,
-		Logger:         logger,
-		Lookback:       cfg.Observability.Judge.Lookback,
-		BatchSize:      cfg.Observability.Judge.BatchSize,
-		DailyBudgetUSD: cfg.Observability.Judge.DailyBudgetUSD,
+		Judge:            llmJudge,
+		Store:            newJudgeStoreAdapter(s),
+		Metrics:          metrics.JudgeRecorder{},
+		Langfuse:         lf,
+		Logger:           logger,
+		Lookback:         cfg.Observability.Judge.Lookback,
+		BatchSize:        cfg.Observability.Judge.BatchSize,
+		DailyBudgetUSD:   cfg.Observability.Judge.DailyBudgetUSD,
+		Concurrency:      cfg.Observability.Judge.Concurrency,
+		EstimatedCostUSD: cfg.Observability.Judge.EstimatedCostUSD,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("constructing judge worker: %w", err)
@@ -637,41 +834,42 @@ func registerJudgeWorker(
 		"lookback", cfg.Observability.Judge.Lookback,
 		"batch_size", cfg.Observability.Judge.BatchSize,
 		"daily_budget_usd", cfg.Observability.Judge.DailyBudgetUSD,
+		"concurrency", cfg.Observability.Judge.Concurrency,
 	)
 	return worker, nil
 }
 
-func buildLLMBackend(cfg *config.Config, logger *slog.Logger) extract.LLMBackend {
-	switch cfg.LLM.Backend {
+func buildLLMBackend(llm *config.LLMConfig, logger *slog.Logger) extract.LLMBackend {
+	switch llm.Backend {
 	case "ollama":
-		if cfg.LLM.Ollama.Endpoint == "" {
+		if llm.Ollama.Endpoint == "" {
 			logger.Warn("ollama endpoint not configured")
 			return nil
 		}
-		timeout := cfg.LLM.Timeout
+		timeout := llm.Timeout
 		if timeout == 0 {
 			timeout = 120 * time.Second
 		}
 		return extract.NewOllamaBackend(
-			cfg.LLM.Ollama.Endpoint,
-			cfg.LLM.Ollama.Model,
+			llm.Ollama.Endpoint,
+			llm.Ollama.Model,
 			extract.WithOllamaHTTPClient(&http.Client{Timeout: timeout}),
 		)
 	case "anthropic":
 		return extract.NewAnthropicBackend(
-			extract.WithAnthropicModel(cfg.LLM.Anthropic.Model),
+			extract.WithAnthropicModel(llm.Anthropic.Model),
 		)
 	case "openai_compat":
-		if cfg.LLM.OpenAICompat.Endpoint == "" {
+		if llm.OpenAICompat.Endpoint == "" {
 			logger.Warn("openai_compat endpoint not configured")
 			return nil
 		}
 		return extract.NewOpenAICompatBackend(
-			cfg.LLM.OpenAICompat.Endpoint,
-			cfg.LLM.OpenAICompat.Model,
+			llm.OpenAICompat.Endpoint,
+			llm.OpenAICompat.Model,
 		)
 	default:
-		logger.Error("unknown LLM backend", "backend", cfg.LLM.Backend)
+		logger.Error("unknown LLM backend", "backend", llm.Backend)
 		return nil
 	}
 }
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func catalogCmd() *cobra.Command {
+	var componentType, model string
+
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Show the hardware catalog",
+		Long: "Show the hardware catalog used to enrich extracted attributes with\n" +
+			"specs the title leaves out (cores, TDP, release year, FP16 TFLOPS).",
+		Example: `  # Every GPU in the catalog
+  spt catalog --component-type gpu
+
+  # The entry a model resolves to, matched the way enrichment matches it
+  spt catalog --model "Xeon Gold 6148"`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			resp, err := c.ListCatalog(context.Background(), componentType, model)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(resp)
+			}
+
+			if len(resp.Entries) == 0 {
+				fmt.Println("No catalog entries found.")
+				return nil
+			}
+
+			tw := newTabWriter(os.Stdout)
+			tw.writef("ID\tSPECS\n")
+			for i := range resp.Entries {
+				e := &resp.Entries[i]
+				specs := make([]string, 0, len(e.Specs))
+				for _, k := range slices.Sorted(maps.Keys(e.Specs)) {
+					specs = append(specs, fmt.Sprintf("%s=%v", k, e.Specs[k]))
+				}
+				tw.writef("%s\t%s\n", e.ID, strings.Join(specs, " "))
+			}
+			return tw.finish()
+		},
+	}
+	cmd.Flags().StringVar(&componentType, "component-type", "", "only entries of this component type (cpu, gpu)")
+	cmd.Flags().StringVar(&model, "model", "", "only the entry this model resolves to")
+
+	return cmd
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/server-price-tracker/internal/regression"
+)
+
+func correctionsCmd() *cobra.Command {
+	correctionsRoot := &cobra.Command{
+		Use:   "corrections",
+		Short: "Correct extractions and export them as labelled examples",
+		Long: "Operators correct a listing's extraction from the alert detail page\n" +
+			"or with 'spt corrections apply'. A corrected listing is locked against\n" +
+			"re-extraction, and each correction is kept as a labelled example that\n" +
+			"can be exported to the golden classification dataset.",
+	}
+
+	correctionsRoot.AddCommand(
+		correctionsApplyCmd(),
+		correctionsListCmd(),
+		correctionsExportCmd(),
+	)
+
+	return correctionsRoot
+}
+
+func correctionsApplyCmd() *cobra.Command {
+	var (
+		componentType string
+		attributes    string
+		note          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply <listing-id>",
+		Short: "Correct a listing's extraction",
+		Long: "Replace a listing's component type and attributes. The product key is\n" +
+			"rebuilt, the listing re-scored and locked against re-extraction.\n" +
+			"Attributes use the component type's extraction schema; condition\n" +
+			"defaults to the listing's.",
+		Example: `  spt corrections apply 3f2c... --component-type hba \
+    --attributes '{"manufacturer":"lsi","model":"9300-8i","mode":"IT"}' \
+    --note "classified as nic"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var attrs map[string]any
+			if err := json.Unmarshal([]byte(attributes), &attrs); err != nil {
+				return fmt.Errorf("--attributes must be a JSON object: %w", err)
+			}
+
+			c := newClient()
+			corr, err := c.CorrectExtraction(context.Background(), args[0], componentType, attrs, note)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(corr)
+			}
+
+			fmt.Printf("Corrected %s: %s → %s\n", corr.ListingID, orDash(corr.PreviousProductKey), corr.ProductKey)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&componentType, "component-type", "", "corrected component type (required)")
+	cmd.Flags().StringVar(&attributes, "attributes", "{}", "corrected attributes as a JSON object")
+	cmd.Flags().StringVar(&note, "note", "", "why the extraction was wrong")
+	_ = cmd.MarkFlagRequired("component-type")
+
+	return cmd
+}
+
+func correctionsListCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded corrections",
+		Example: `  spt corrections list
+  spt corrections list --limit 20 --output json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			corrections, err := c.ListExtractionCorrections(context.Background(), limit)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(corrections)
+			}
+
+			if len(corrections) == 0 {
+				fmt.Println("No extraction corrections.")
+				return nil
+			}
+
+			tw := newTabWriter(os.Stdout)
+			tw.writef("CREATED\tLISTING\tFROM\tTO\tNOTE\n")
+			for i := range corrections {
+				corr := &corrections[i]
+				tw.writef("%s\t%s\t%s\t%s\t%s\n",
+					corr.CreatedAt.Format("2006-01-02 15:04"), corr.ListingID,
+					orDash(corr.PreviousProductKey), corr.ProductKey, corr.Note,
+				)
+			}
+			return tw.finish()
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "maximum corrections to list (default 500)")
+
+	return cmd
+}
+
+func correctionsExportCmd() *cobra.Command {
+	var (
+		path  string
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Merge corrections into the golden classification dataset",
+		Long: "Add every recorded correction to the golden dataset file as a labelled\n" +
+			"item. A correction of a title already in the file replaces that item;\n" +
+			"the latest correction of a title wins.",
+		Example: `  spt corrections export --file testdata/golden_classifications.json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			corrections, err := c.ListExtractionCorrections(context.Background(), limit)
+			if err != nil {
+				return err
+			}
+
+			existing, err := regression.LoadDataset(path)
+			if err != nil {
+				return fmt.Errorf("loading %s: %w", path, err)
+			}
+
+			// Corrections arrive newest first; add oldest first so the
+			// newest correction of a title is the one kept.
+			items := make([]regression.Item, 0, len(corrections))
+			for i := len(corrections) - 1; i >= 0; i-- {
+				items = append(items, regression.FromCorrection(&corrections[i]))
+			}
+			merged, added := regression.MergeItems(existing, items)
+			if err := regression.WriteDataset(path, merged); err != nil {
+				return err
+			}
+
+			fmt.Printf("Exported %d corrections to %s (%d new, %d items total).\n",
+				len(corrections), path, added, len(merged))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&path, "file", "testdata/golden_classifications.json", "golden dataset file to merge into")
+	cmd.Flags().IntVar(&limit, "limit", 10000, "maximum corrections to export")
+
+	return cmd
+}
+
+// orDash renders an empty string as "-" in table output.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
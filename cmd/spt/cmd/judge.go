@@ -3,13 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
-// judgeCmd is the parent command for LLM-as-judge operations. Today
-// only `run` is exposed; future subcommands (e.g. status, replay) can
-// be added here without disturbing the root command file.
+// judgeCmd is the parent command for LLM-as-judge operations: `run`
+// and `status`; future subcommands (e.g. replay) can be added here
+// without disturbing the root command file.
 func judgeCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "judge",
@@ -17,6 +18,7 @@ func judgeCmd() *cobra.Command {
 		Long:  "Manual triggers and inspection helpers for the LLM-as-judge worker (DESIGN-0016 / IMPL-0019 Phase 5).",
 	}
 	cmd.AddCommand(judgeRunCmd())
+	cmd.AddCommand(judgeStatusCmd())
 	return cmd
 }
 
@@ -44,3 +46,41 @@ func judgeRunCmd() *cobra.Command {
 		},
 	}
 }
+
+// judgeStatusCmd issues GET /api/v1/judge/status and prints the
+// budget ledger, the backlog of un-judged alerts and the last tick —
+// enough to tell whether the worker is keeping up.
+func judgeStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "status",
+		Short:   "Show LLM-as-judge backlog and budget",
+		Long:    "Shows the LLM-as-judge worker's concurrency, today's spend and outstanding budget reservations, the backlog of un-judged alerts, and how far the last tick got through it.",
+		Example: `  spt judge status`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			st, err := c.GetJudgeStatus(context.Background())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Concurrency: %d  batch: %d  lookback: %s\n",
+				st.Concurrency, st.BatchSize, time.Duration(st.LookbackSeconds*float64(time.Second)))
+			fmt.Printf("Budget: $%.4f spent + $%.4f reserved of $%.2f today (estimate $%.4f/call)\n",
+				st.SpentTodayUSD, st.ReservedUSD, st.DailyBudgetUSD, st.EstimatedCostUSD)
+			if st.Backlog != nil {
+				fmt.Printf("Backlog: %d pending, oldest %s, %d aged out unjudged in the last 24h\n",
+					st.Backlog.Pending,
+					time.Duration(st.OldestAgeSeconds*float64(time.Second)).Round(time.Second),
+					st.Backlog.AgedOut)
+			}
+			if t := st.LastTick; t != nil {
+				fmt.Printf("Last tick: %s — judged %d of %d candidates, %d failed, took %.1fs",
+					t.StartedAt.Local().Format(time.DateTime), t.Judged, t.Candidates, t.Failed, t.DurationSeconds)
+				if t.BudgetExhausted {
+					fmt.Print(" (budget exhausted)")
+				}
+				fmt.Println()
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func keysCmd() *cobra.Command {
+	keysRoot := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage product key aliases",
+		Long: "Merge near-duplicate product keys so their listings share one\n" +
+			"baseline. A merged key becomes an alias: its listings are re-keyed,\n" +
+			"and new extractions that produce it are filed under the canonical key.",
+	}
+
+	keysRoot.AddCommand(
+		keysSimilarCmd(),
+		keysMergeCmd(),
+		keysAliasesCmd(),
+	)
+
+	return keysRoot
+}
+
+func keysSimilarCmd() *cobra.Command {
+	var (
+		maxDistance   int
+		componentType string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "similar",
+		Short: "Suggest product keys to merge",
+		Example: `  # Near-duplicates within 2 edits, punctuation-only differences first
+  spt keys similar
+
+  # Only RAM keys one edit apart
+  spt keys similar --component-type ram --max-distance 1`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			suggestions, err := c.SimilarProductKeys(context.Background(), maxDistance, componentType)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(suggestions)
+			}
+
+			if len(suggestions) == 0 {
+				fmt.Println("No similar product keys found.")
+				return nil
+			}
+
+			tw := newTabWriter(os.Stdout)
+			tw.writef("FROM\tLISTINGS\tTO\tLISTINGS\tDISTANCE\tPUNCTUATION ONLY\n")
+			for i := range suggestions {
+				s := &suggestions[i]
+				tw.writef("%s\t%d\t%s\t%d\t%d\t%t\n",
+					s.From, s.FromListings, s.To, s.ToListings, s.Distance, s.PunctuationOnly,
+				)
+			}
+			return tw.finish()
+		},
+	}
+	cmd.Flags().IntVar(&maxDistance, "max-distance", 0, "largest edit distance to suggest (default 2)")
+	cmd.Flags().StringVar(&componentType, "component-type", "", "only suggest keys of this component type")
+
+	return cmd
+}
+
+func keysMergeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge <from> <to>",
+		Short: "Merge one product key into another",
+		Long: "Make <from> an alias of <to>. Listings under <from> are re-keyed to\n" +
+			"<to>, <from>'s baseline is dropped, and <to>'s baseline is recomputed\n" +
+			"and its listings re-scored.",
+		Example: `  spt keys merge "cpu:intel:xeon:e5-2690v4" "cpu:intel:xeon:e5-2690_v4"`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			c := newClient()
+			resp, err := c.MergeProductKeys(context.Background(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(resp)
+			}
+
+			fmt.Printf("Merged %s into %s (%d listings moved).\n", resp.From, resp.To, resp.ListingsMoved)
+			return nil
+		},
+	}
+}
+
+func keysAliasesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "aliases",
+		Short: "List product key aliases",
+		Example: `  spt keys aliases
+  spt keys aliases --output json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			aliases, err := c.ListProductKeyAliases(context.Background())
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(aliases)
+			}
+
+			if len(aliases) == 0 {
+				fmt.Println("No product key aliases.")
+				return nil
+			}
+
+			tw := newTabWriter(os.Stdout)
+			tw.writef("ALIAS\tCANONICAL\tMERGED\n")
+			for i := range aliases {
+				tw.writef("%s\t%s\t%s\n",
+					aliases[i].Alias, aliases[i].Canonical, aliases[i].CreatedAt.Format("2006-01-02"),
+				)
+			}
+			return tw.finish()
+		},
+	}
+}
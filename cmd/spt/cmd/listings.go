@@ -27,13 +27,15 @@ func listingsCmd() *cobra.Command {
 
 func listingsListCmd() *cobra.Command {
 	var (
-		componentType string
-		productKey    string
-		minScore      int
-		maxScore      int
-		limit         int
-		offset        int
-		orderBy       string
+		componentType   string
+		productKey      string
+		valueClass      string
+		maxPricePerUnit float64
+		minScore        int
+		maxScore        int
+		limit           int
+		offset          int
+		orderBy         string
 	)
 
 	cmd := &cobra.Command{
@@ -51,17 +53,22 @@ func listingsListCmd() *cobra.Command {
   spt listings list --order-by price --limit 20 --offset 40
 
   # Filter by product key
-  spt listings list --product-key "ram:ddr4:ecc_reg:32gb:2666"`,
+  spt listings list --product-key "ram:ddr4:ecc_reg:32gb:2666"
+
+  # Cheapest $/GB of DDR4 RDIMM, whatever the stick size
+  spt listings list --value-class ram:ddr4:ecc_reg --order-by price_per_unit`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			c := newClient()
 			resp, err := c.ListListings(context.Background(), &apiclient.ListListingsParams{
-				ComponentType: componentType,
-				ProductKey:    productKey,
-				MinScore:      minScore,
-				MaxScore:      maxScore,
-				Limit:         limit,
-				Offset:        offset,
-				OrderBy:       orderBy,
+				ComponentType:   componentType,
+				ProductKey:      productKey,
+				ValueClass:      valueClass,
+				MaxPricePerUnit: maxPricePerUnit,
+				MinScore:        minScore,
+				MaxScore:        maxScore,
+				Limit:           limit,
+				Offset:          offset,
+				OrderBy:         orderBy,
 			})
 			if err != nil {
 				return err
@@ -82,12 +89,15 @@ func listingsListCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&componentType, "type", "", "component type filter")
 	cmd.Flags().StringVar(&productKey, "product-key", "", "product key filter")
+	cmd.Flags().StringVar(&valueClass, "value-class", "", "value class filter (e.g. ram:ddr4:ecc_reg)")
+	cmd.Flags().
+		Float64Var(&maxPricePerUnit, "max-price-per-unit", 0, "maximum USD price per value unit")
 	cmd.Flags().IntVar(&minScore, "min-score", 0, "minimum score filter")
 	cmd.Flags().IntVar(&maxScore, "max-score", 0, "maximum score filter")
 	cmd.Flags().IntVar(&limit, "limit", 50, "number of results")
 	cmd.Flags().IntVar(&offset, "offset", 0, "result offset")
 	cmd.Flags().
-		StringVar(&orderBy, "order-by", "", "sort order (score, price, first_seen_at)")
+		StringVar(&orderBy, "order-by", "", "sort order (score, price, price_per_unit, first_seen_at)")
 
 	return cmd
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
@@ -36,14 +37,16 @@ func (tw *tabWriter) finish() error {
 
 func printWatchTable(watches []domain.Watch) error {
 	tw := newTabWriter(os.Stdout)
-	tw.writef("ID\tNAME\tQUERY\tTYPE\tTHRESHOLD\tENABLED\n")
+	tw.writef("ID\tNAME\tQUERY\tTYPE\tTHRESHOLD\tINTERVAL\tPRIORITY\tENABLED\n")
 	for i := range watches {
-		tw.writef("%s\t%s\t%s\t%s\t%d\t%v\n",
+		tw.writef("%s\t%s\t%s\t%s\t%d\t%s\t%d\t%v\n",
 			watches[i].ID,
 			watches[i].Name,
 			watches[i].SearchQuery,
 			watches[i].ComponentType,
 			watches[i].ScoreThreshold,
+			pollIntervalLabel(watches[i].PollInterval),
+			watches[i].Priority,
 			watches[i].Enabled,
 		)
 	}
@@ -59,9 +62,36 @@ func printWatchDetail(w *domain.Watch) error {
 	tw.writef("Threshold:\t%d\n", w.ScoreThreshold)
 	tw.writef("Enabled:\t%v\n", w.Enabled)
 	tw.writef("Category:\t%s\n", w.CategoryID)
+	tw.writef("Poll interval:\t%s\n", pollIntervalLabel(w.PollInterval))
+	tw.writef("Priority:\t%d\n", w.Priority)
+	tw.writef("Marketplace:\t%s\n", marketplaceLabel(w.Marketplace))
+	if w.RealertDropPct > 0 {
+		tw.writef("Re-alert on drop:\t%g%%\n", w.RealertDropPct)
+	}
+	if w.LastPolledAt != nil {
+		tw.writef("Last polled:\t%s\n", w.LastPolledAt.Format("2006-01-02 15:04:05 MST"))
+	}
 	return tw.finish()
 }
 
+// pollIntervalLabel renders a watch's poll interval, naming the server
+// default when it has none.
+func pollIntervalLabel(d domain.Duration) string {
+	if d == 0 {
+		return "default"
+	}
+	return d.String()
+}
+
+// marketplaceLabel renders a watch's marketplace, naming the server
+// default when it has none.
+func marketplaceLabel(m string) string {
+	if m == "" {
+		return "default"
+	}
+	return m
+}
+
 func printListingsTable(listings []domain.Listing) error {
 	tw := newTabWriter(os.Stdout)
 	tw.writef("ID\tTITLE\tPRICE\tSCORE\tTYPE\tSELLER\n")
@@ -70,10 +100,10 @@ func printListingsTable(listings []domain.Listing) error {
 		if listings[i].Score != nil {
 			score = fmt.Sprintf("%d", *listings[i].Score)
 		}
-		tw.writef("%s\t%s\t$%.2f\t%s\t%s\t%s\n",
+		tw.writef("%s\t%s\t%s\t%s\t%s\t%s\n",
 			listings[i].ID,
 			truncate(listings[i].Title, 40),
-			listings[i].Price,
+			domain.FormatPrice(listings[i].Price, listings[i].Currency),
 			score,
 			listings[i].ComponentType,
 			listings[i].SellerName,
@@ -87,19 +117,56 @@ func printListingDetail(l *domain.Listing) error {
 	tw.writef("ID:\t%s\n", l.ID)
 	tw.writef("eBay ID:\t%s\n", l.EbayID)
 	tw.writef("Title:\t%s\n", l.Title)
-	tw.writef("Price:\t$%.2f %s\n", l.Price, l.Currency)
-	tw.writef("Unit Price:\t$%.2f\n", l.UnitPrice())
+	tw.writef("Price:\t%s\n", domain.FormatPrice(l.Price, l.Currency))
+	tw.writef("Unit Price:\t%s\n", domain.FormatPrice(l.UnitPrice(), l.Currency))
+	if usd, ok := l.UnitPriceUSD(); ok && l.Currency != "" && l.Currency != "USD" {
+		tw.writef("Unit Price (USD):\t%s\n", domain.FormatPrice(usd, "USD"))
+	}
 	tw.writef("Type:\t%s\n", l.ComponentType)
 	tw.writef("Condition:\t%s\n", l.ConditionNorm)
 	tw.writef("Seller:\t%s (%d, %.1f%%)\n", l.SellerName, l.SellerFeedback, l.SellerFeedbackPct)
 	if l.Score != nil {
 		tw.writef("Score:\t%d/100\n", *l.Score)
 	}
+	if l.RiskScore != nil {
+		var signals []string
+		for _, s := range l.RiskSignalList() {
+			signals = append(signals, s.Name)
+		}
+		tw.writef("Risk:\t%d/100 %s\n", *l.RiskScore, strings.Join(signals, ", "))
+	}
+	if l.PricePerUnit != nil {
+		tw.writef("Value:\t$%.4f/%s (%s)\n", *l.PricePerUnit, l.ValueUnit, l.ValueClass)
+	}
+	if l.PartOutValue != nil && l.PartOutRatio != nil {
+		tw.writef("Part-out:\t$%.2f (%.2fx price)\n", *l.PartOutValue, *l.PartOutRatio)
+	}
 	tw.writef("URL:\t%s\n", l.ItemURL)
 	tw.writef("Product Key:\t%s\n", l.ProductKey)
 	return tw.finish()
 }
 
+func printSellerDetail(s *domain.Seller) error {
+	tw := newTabWriter(os.Stdout)
+	tw.writef("Name:\t%s\n", s.Name)
+	tw.writef("Feedback:\t%d (%.1f%%)\n", s.FeedbackScore, s.FeedbackPct)
+	tw.writef("Top Rated:\t%t\n", s.TopRated)
+	tw.writef("First Seen:\t%s\n", s.FirstSeenAt.Format("2006-01-02"))
+	tw.writef("Last Seen:\t%s\n", s.LastSeenAt.Format("2006-01-02"))
+	tw.writef("Listings:\t%d (%d active)\n", s.ListingsTotal, s.ListingsActive)
+	tw.writef("Alerts:\t%d (%.0f%% of listings)\n", s.AlertsTotal, s.AlertRate*100)
+	tw.writef("Dismissed:\t%d (%.0f%% of alerts)\n", s.AlertsDismissed, s.DismissalRate*100)
+	if n := len(s.FeedbackHistory); n > 1 {
+		first := s.FeedbackHistory[0]
+		tw.writef("Feedback Since %s:\t%+d (%+.1f pts)\n",
+			first.RecordedAt.Format("2006-01-02"),
+			s.FeedbackScore-first.FeedbackScore,
+			s.FeedbackPct-first.FeedbackPct,
+		)
+	}
+	return tw.finish()
+}
+
 func printBaselinesTable(baselines []domain.PriceBaseline) error {
 	tw := newTabWriter(os.Stdout)
 	tw.writef("PRODUCT KEY\tSAMPLES\tP10\tP25\tP50\tP75\tP90\tMEAN\n")
@@ -49,14 +49,19 @@ func init() {
 
 	rootCmd.AddCommand(watchCmd())
 	rootCmd.AddCommand(listingsCmd())
+	rootCmd.AddCommand(sellersCmd())
 	rootCmd.AddCommand(searchCmd())
 	rootCmd.AddCommand(extractCmd())
 	rootCmd.AddCommand(baselinesCmd())
+	rootCmd.AddCommand(keysCmd())
+	rootCmd.AddCommand(correctionsCmd())
+	rootCmd.AddCommand(catalogCmd())
 	rootCmd.AddCommand(ingestCmd())
 	rootCmd.AddCommand(rescoreCmd())
 	rootCmd.AddCommand(reextractCmd())
 	rootCmd.AddCommand(jobsCmd())
 	rootCmd.AddCommand(judgeCmd())
+	rootCmd.AddCommand(shadowCmd())
 }
 
 func initConfig() {
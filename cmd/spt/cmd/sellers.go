@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func sellersCmd() *cobra.Command {
+	sellersRoot := &cobra.Command{
+		Use:   "sellers",
+		Short: "Inspect sellers",
+		Long: "Inspect eBay sellers seen during ingestion: feedback history,\n" +
+			"listing and alert counts, and active listings.",
+	}
+
+	sellersRoot.AddCommand(sellersGetCmd())
+
+	return sellersRoot
+}
+
+func sellersGetCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Show a seller and its active listings",
+		Example: `  # Seller reputation and its 50 best-scoring active listings
+  spt sellers get server_parts_inc
+
+  # Just the top 10
+  spt sellers get server_parts_inc --limit 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			c := newClient()
+			resp, err := c.GetSeller(context.Background(), args[0], limit)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput() {
+				return outputJSON(resp)
+			}
+
+			if err := printSellerDetail(&resp.Seller); err != nil {
+				return err
+			}
+			if len(resp.Listings) == 0 {
+				fmt.Println("\nNo active listings.")
+				return nil
+			}
+			fmt.Printf("\nActive listings (%d)\n\n", len(resp.Listings))
+			return printListingsTable(resp.Listings)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 50, "number of active listings")
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/client"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func shadowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shadow",
+		Short: "Inspect shadow extraction comparisons",
+		Long: "With llm.shadow enabled, a sample of live extractions is repeated\n" +
+			"through a candidate backend, model or prompt set. These commands\n" +
+			"compare the two.",
+	}
+	cmd.AddCommand(shadowReportCmd())
+	return cmd
+}
+
+func shadowReportCmd() *cobra.Command {
+	var params client.ShadowReportParams
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Compare shadow extractions with live extractions",
+		Long: "Show how often the shadow extraction agreed with the live one on\n" +
+			"component type, product key and each attribute, what each side\n" +
+			"cost, and the most recent disagreements.",
+		Example: `  spt shadow report
+  spt shadow report --window 7d --shadow-model claude-haiku-4-20250514
+  spt shadow report --prompt-version v2 --examples 50`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			r, err := newClient().GetShadowReport(context.Background(), params)
+			if err != nil {
+				return err
+			}
+			if jsonOutput() {
+				return outputJSON(r)
+			}
+			return printShadowReport(os.Stdout, r)
+		},
+	}
+	cmd.Flags().StringVar(&params.Window, "window", "", "look-back window, e.g. 7d or 72h (server default 30d)")
+	cmd.Flags().StringVar(&params.ShadowModel, "shadow-model", "", "only comparisons against this shadow model")
+	cmd.Flags().StringVar(&params.PromptVersion, "prompt-version", "", "only comparisons with this shadow prompt version")
+	cmd.Flags().IntVar(&params.Examples, "examples", 0, "recent disagreements to show (server default 20)")
+
+	return cmd
+}
+
+func printShadowReport(w io.Writer, r *domain.ShadowReport) error {
+	tw := newTabWriter(w)
+	tw.writef("Since:\t%s\n", r.Since.Format("2006-01-02 15:04 MST"))
+	tw.writef("Samples:\t%d (%d shadow errors)\n", r.Samples, r.ShadowErrors)
+	tw.writef("Component type agreement:\t%.1f%%\n", r.ComponentTypeAgreement*100)
+	tw.writef("Product key agreement:\t%.1f%%\n", r.ProductKeyAgreement*100)
+	tw.writef("Full agreement:\t%.1f%%\n", r.FullAgreement*100)
+	tw.writef("Tokens:\t%d live, %d shadow\n", r.PrimaryTokens, r.ShadowTokens)
+	cost := fmt.Sprintf("$%.4f live, $%.4f shadow (%d samples priced)", r.PrimaryCostUSD, r.ShadowCostUSD, r.CostedSamples)
+	if r.CostDeltaPct != nil {
+		cost += fmt.Sprintf(", %+.1f%%", *r.CostDeltaPct)
+	}
+	tw.writef("Cost:\t%s\n", cost)
+	tw.writef("Avg latency:\t%.0fms live, %.0fms shadow\n", r.AvgPrimaryLatencyMs, r.AvgShadowLatencyMs)
+	if err := tw.finish(); err != nil {
+		return err
+	}
+
+	if len(r.Components) > 0 {
+		fmt.Fprintln(w, "\nBy component type:")
+		tw = newTabWriter(w)
+		tw.writef("TYPE\tCOMPARED\tTYPE MATCH\tKEY AGREEMENT\n")
+		for _, c := range r.Components {
+			tw.writef("%s\t%d\t%d\t%.1f%%\n", c.ComponentType, c.Compared, c.ComponentTypeMatches, c.ProductKeyAgreement*100)
+		}
+		if err := tw.finish(); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Fields) > 0 {
+		fmt.Fprintln(w, "\nAttribute disagreements:")
+		tw = newTabWriter(w)
+		tw.writef("FIELD\tCOUNT\tRATE\n")
+		for _, f := range r.Fields {
+			tw.writef("%s\t%d\t%.1f%%\n", f.Field, f.Disagreements, f.Rate*100)
+		}
+		if err := tw.finish(); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Examples) > 0 {
+		fmt.Fprintln(w, "\nRecent disagreements:")
+		tw = newTabWriter(w)
+		tw.writef("TITLE\tLIVE KEY\tSHADOW KEY\tFIELDS\n")
+		for i := range r.Examples {
+			e := &r.Examples[i]
+			tw.writef("%s\t%s\t%s\t%d\n", truncate(e.Title, 50), e.PrimaryProductKey, e.ShadowProductKey, len(e.Disagreements))
+		}
+		return tw.finish()
+	}
+	return nil
+}
@@ -26,6 +26,10 @@ func watchCmd() *cobra.Command {
 		watchEnableCmd(),
 		watchDisableCmd(),
 		watchDeleteCmd(),
+		watchExportCmd(),
+		watchApplyCmd(),
+		watchPreviewCmd(),
+		watchStatsCmd(),
 	)
 
 	return watchRoot
@@ -83,6 +87,10 @@ func watchCreateCmd() *cobra.Command {
 		watchType       string
 		watchThreshold  int
 		watchFilterArgs []string
+		watchInterval   domain.Duration
+		watchPriority   int
+		watchMarket     string
+		watchDropPct    float64
 	)
 
 	cmd := &cobra.Command{
@@ -97,7 +105,23 @@ func watchCreateCmd() *cobra.Command {
   # Create a watch with a custom threshold and filters
   spt watches create --name "Dell R630" --query "Dell PowerEdge R630" \
     --type server --threshold 80 \
-    --filter "min_price=100" --filter "max_price=500"`,
+    --filter "min_price=100" --filter "max_price=500"
+
+  # Poll a hot watch every 5 minutes, ahead of the rest
+  spt watches create --name "RTX 3090" --query "RTX 3090" --type gpu \
+    --poll-interval 5m --priority 10
+
+  # Search eBay UK; prices are normalized to USD for scoring
+  spt watches create --name "UK DDR4" --query "DDR4 ECC 32GB" --type ram \
+    --marketplace EBAY_GB
+
+  # Alert again inside the re-alert cooldown on a 15% price drop
+  spt watches create --name "R740xd" --query "Dell R740xd" --type server \
+    --realert-drop-pct 15
+
+  # Exclude caddies and trays with a filter expression
+  spt watches create --name "3.5in SAS" --query "3.5 SAS HDD" --type drive \
+    --filter 'expr=not title contains ["caddy", "tray"] and attrs.capacity_gb >= 8000'`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			if watchName == "" || watchQuery == "" {
 				return fmt.Errorf("--name and --query are required")
@@ -113,6 +137,10 @@ func watchCreateCmd() *cobra.Command {
 				ScoreThreshold: watchThreshold,
 				Filters:        filters,
 				Enabled:        true,
+				PollInterval:   watchInterval,
+				Priority:       watchPriority,
+				Marketplace:    watchMarket,
+				RealertDropPct: watchDropPct,
 			}
 			c := newClient()
 			created, err := c.CreateWatch(context.Background(), w)
@@ -129,9 +157,16 @@ func watchCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&watchName, "name", "", "watch name")
 	cmd.Flags().StringVar(&watchQuery, "query", "", "eBay search query")
 	cmd.Flags().
-		StringVar(&watchType, "type", "", "component type (ram, drive, server, cpu, nic, gpu, workstation, desktop, other)")
+		StringVar(&watchType, "type", "", "component type (ram, drive, server, cpu, nic, gpu, hba, switch, transceiver, workstation, desktop, other)")
 	cmd.Flags().IntVar(&watchThreshold, "threshold", 75, "score threshold for alerts")
 	cmd.Flags().StringArrayVar(&watchFilterArgs, "filter", nil, "filters (key=value)")
+	cmd.Flags().
+		TextVar(&watchInterval, "poll-interval", domain.Duration(0), "how often to poll eBay, e.g. 5m, 2h, 1d (default: server ingestion interval)")
+	cmd.Flags().IntVar(&watchPriority, "priority", 0, "poll priority; higher goes first when the cycle budget is short")
+	cmd.Flags().
+		StringVar(&watchMarket, "marketplace", "", "eBay marketplace to search, e.g. EBAY_GB, EBAY_DE (default: server ebay.marketplace)")
+	cmd.Flags().
+		Float64Var(&watchDropPct, "realert-drop-pct", 0, "alert again inside the re-alert cooldown when the price drops by at least this percent (0 = never)")
 
 	return cmd
 }
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func watchExportCmd() *cobra.Command {
+	var outFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all watches as a manifest",
+		Long: "Write every watch on the server as a YAML manifest keyed by watch\n" +
+			"name, suitable for `spt watches apply -f`. Use --output json for a\n" +
+			"JSON manifest.",
+		Example: `  spt watches export > watches.yaml
+  spt watches export -f watches.yaml
+  spt watches export --output json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			c := newClient()
+			watches, err := c.ListWatches(context.Background())
+			if err != nil {
+				return err
+			}
+			m, err := manifestFromWatches(watches)
+			if err != nil {
+				return err
+			}
+
+			out := io.Writer(os.Stdout)
+			if outFile != "" {
+				fh, err := os.Create(outFile)
+				if err != nil {
+					return fmt.Errorf("creating %s: %w", outFile, err)
+				}
+				defer func() { _ = fh.Close() }()
+				out = fh
+			}
+			return writeManifest(out, m, jsonOutput())
+		},
+	}
+	cmd.Flags().StringVarP(&outFile, "file", "f", "", "write the manifest to this file instead of stdout")
+
+	return cmd
+}
+
+func watchApplyCmd() *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+		prune  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <manifest>",
+		Short: "Create or update watches from a manifest",
+		Long: "Converge the server's watches onto a YAML or JSON manifest keyed by\n" +
+			"watch name. Watches in the manifest are created or updated; with\n" +
+			"--prune, watches missing from the manifest are deleted. The server\n" +
+			"applies the whole plan in one transaction.\n\n" +
+			"Manifest format:\n\n" +
+			"  watches:\n" +
+			"    ddr4-32gb-rdimm:\n" +
+			"      search_query: DDR4 32GB ECC RDIMM\n" +
+			"      component_type: ram\n" +
+			"      score_threshold: 80      # default 75\n" +
+			"      enabled: true            # default true\n" +
+			"      filters:\n" +
+			"        price_max: 60\n" +
+			"        expr: not title contains [\"lot\", \"bundle\"]",
+		Example: `  # Preview the diff without changing anything
+  spt watches apply -f watches.yaml --dry-run
+
+  # Apply, deleting watches no longer in the file
+  spt watches apply -f watches.yaml --prune`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if file == "" {
+				return fmt.Errorf("-f/--file is required")
+			}
+			m, err := readManifestFile(file)
+			if err != nil {
+				return err
+			}
+
+			c := newClient()
+			result, err := c.ApplyWatches(context.Background(), m, prune, dryRun)
+			if err != nil {
+				return err
+			}
+			if jsonOutput() {
+				return outputJSON(result)
+			}
+			return printWatchApplyResult(os.Stdout, result)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "manifest file (YAML or JSON; - for stdin)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the diff without applying it")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete watches whose names are not in the manifest")
+
+	return cmd
+}
+
+func readManifestFile(path string) (*domain.WatchManifest, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	m, err := parseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// parseManifest accepts YAML or JSON (JSON is valid YAML). The document
+// is decoded generically and re-encoded as JSON so WatchFilters' JSON
+// tags apply, and unknown fields are rejected to catch typos like
+// `score_treshold` before they silently reset a watch to defaults.
+func parseManifest(data []byte) (*domain.WatchManifest, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("converting manifest to JSON: %w", err)
+	}
+
+	var m domain.WatchManifest
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	if len(m.Watches) == 0 {
+		return nil, fmt.Errorf("manifest has no watches")
+	}
+	return &m, nil
+}
+
+func manifestFromWatches(watches []domain.Watch) (*domain.WatchManifest, error) {
+	m := &domain.WatchManifest{Watches: make(map[string]domain.WatchSpec, len(watches))}
+	for i := range watches {
+		name := watches[i].Name
+		if _, dup := m.Watches[name]; dup {
+			return nil, fmt.Errorf(
+				"multiple watches named %q; rename one so the manifest can key them by name",
+				name,
+			)
+		}
+		m.Watches[name] = domain.WatchSpecFromWatch(&watches[i])
+	}
+	return m, nil
+}
+
+// writeManifest encodes m as indented JSON, or as YAML via a JSON round
+// trip so field names match the API (and sorted map keys keep the output
+// stable for git diffs).
+func writeManifest(w io.Writer, m *domain.WatchManifest, asJSON bool) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	if asJSON {
+		_, err = fmt.Fprintln(w, string(raw))
+		return err
+	}
+
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	return enc.Close()
+}
+
+// printWatchApplyResult renders the plan as a terraform-style diff:
+// + create, ~ update (with per-field old → new), - delete.
+func printWatchApplyResult(w io.Writer, r *domain.WatchApplyResult) error {
+	var b strings.Builder
+	counts := map[domain.WatchChangeAction]int{}
+	for i := range r.Changes {
+		ch := &r.Changes[i]
+		counts[ch.Action]++
+		switch ch.Action {
+		case domain.WatchActionCreate:
+			fmt.Fprintf(&b, "+ %s\n", ch.Name)
+		case domain.WatchActionDelete:
+			fmt.Fprintf(&b, "- %s (%s)\n", ch.Name, ch.ID)
+		case domain.WatchActionUpdate:
+			fmt.Fprintf(&b, "~ %s (%s)\n", ch.Name, ch.ID)
+			for _, d := range ch.Diff {
+				fmt.Fprintf(&b, "    %s: %s → %s\n", d.Field, diffValue(d.Old), diffValue(d.New))
+			}
+		}
+	}
+
+	verb := "Applied"
+	if r.DryRun {
+		verb = "Dry run"
+	}
+	fmt.Fprintf(&b, "%s: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		verb,
+		counts[domain.WatchActionCreate],
+		counts[domain.WatchActionUpdate],
+		counts[domain.WatchActionDelete],
+		counts[domain.WatchActionUnchanged],
+	)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func diffValue(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
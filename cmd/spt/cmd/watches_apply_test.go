@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestParseManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+		assert  func(t *testing.T, m *domain.WatchManifest)
+	}{
+		{
+			name: "yaml with filters",
+			input: `
+watches:
+  ddr4-32gb:
+    search_query: DDR4 32GB ECC RDIMM
+    component_type: ram
+    score_threshold: 80
+    filters:
+      price_max: 60
+      attribute_filters:
+        capacity_gb: {eq: 32}
+      expr: not title contains ["lot"]
+`,
+			assert: func(t *testing.T, m *domain.WatchManifest) {
+				t.Helper()
+				spec := m.Watches["ddr4-32gb"]
+				assert.Equal(t, "DDR4 32GB ECC RDIMM", spec.SearchQuery)
+				assert.Equal(t, domain.ComponentRAM, spec.ComponentType)
+				assert.Equal(t, 80, spec.ScoreThreshold)
+				require.NotNil(t, spec.Filters.PriceMax)
+				assert.InDelta(t, 60.0, *spec.Filters.PriceMax, 0.001)
+				assert.Equal(t, float64(32), spec.Filters.AttributeFilters["capacity_gb"].Equals)
+				assert.Equal(t, `not title contains ["lot"]`, spec.Filters.Expr)
+			},
+		},
+		{
+			name:  "json is accepted",
+			input: `{"watches": {"r630": {"search_query": "Dell R630", "component_type": "server"}}}`,
+			assert: func(t *testing.T, m *domain.WatchManifest) {
+				t.Helper()
+				assert.Equal(t, "Dell R630", m.Watches["r630"].SearchQuery)
+			},
+		},
+		{
+			name: "unknown field rejected",
+			input: `
+watches:
+  r630:
+    search_query: Dell R630
+    score_treshold: 80
+`,
+			wantErr: `unknown field "score_treshold"`,
+		},
+		{
+			name:    "empty manifest rejected",
+			input:   `watches: {}`,
+			wantErr: "manifest has no watches",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := parseManifest([]byte(tt.input))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.assert(t, m)
+		})
+	}
+}
+
+func TestManifest_ExportRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	priceMax := 450.0
+	watches := []domain.Watch{
+		{
+			ID:             "w1",
+			Name:           "r630",
+			SearchQuery:    "Dell PowerEdge R630",
+			ComponentType:  domain.ComponentServer,
+			Filters:        domain.WatchFilters{PriceMax: &priceMax},
+			ScoreThreshold: 80,
+			Enabled:        false,
+		},
+		{ID: "w2", Name: "ddr4", SearchQuery: "DDR4 ECC", ComponentType: domain.ComponentRAM, ScoreThreshold: 75, Enabled: true},
+	}
+
+	m, err := manifestFromWatches(watches)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeManifest(&buf, m, false))
+	assert.NotContains(t, buf.String(), "w1", "server-owned IDs are not exported")
+
+	parsed, err := parseManifest(buf.Bytes())
+	require.NoError(t, err)
+	for i := range watches {
+		spec := parsed.Watches[watches[i].Name]
+		got := spec.Watch(watches[i].Name)
+		got.ID = watches[i].ID
+		assert.Equal(t, watches[i], got)
+	}
+}
+
+func TestManifestFromWatches_DuplicateName(t *testing.T) {
+	t.Parallel()
+
+	_, err := manifestFromWatches([]domain.Watch{{Name: "dup"}, {Name: "dup"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"dup"`)
+}
+
+func TestPrintWatchApplyResult(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := printWatchApplyResult(&buf, &domain.WatchApplyResult{
+		DryRun: true,
+		Changes: []domain.WatchChange{
+			{Name: "a", Action: domain.WatchActionCreate},
+			{Name: "b", ID: "w2", Action: domain.WatchActionUpdate, Diff: []domain.WatchFieldDiff{
+				{Field: "score_threshold", Old: 75, New: 80},
+			}},
+			{Name: "c", ID: "w3", Action: domain.WatchActionDelete},
+			{Name: "d", ID: "w4", Action: domain.WatchActionUnchanged},
+		},
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "+ a\n")
+	assert.Contains(t, out, "~ b (w2)\n    score_threshold: 75 → 80\n")
+	assert.Contains(t, out, "- c (w3)\n")
+	assert.NotContains(t, out, "d (w4)")
+	assert.Contains(t, out, "Dry run: 1 to create, 1 to update, 1 to delete, 1 unchanged.")
+}
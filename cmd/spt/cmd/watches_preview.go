@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/client"
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func watchPreviewCmd() *cobra.Command {
+	var (
+		compType   string
+		threshold  int
+		filterArgs []string
+		limit      int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preview [id]",
+		Short: "Preview which stored listings a watch would alert on",
+		Long: "Evaluate a watch against current active listings without creating\n" +
+			"alerts. Pass an existing watch ID to try changes to it — --type,\n" +
+			"--threshold and --filter override the stored values (--filter\n" +
+			"replaces the whole filter block). Without an ID the flags describe\n" +
+			"a new watch.\n\n" +
+			"Near misses are listings that failed exactly one check; the\n" +
+			"REJECTED BY column names it.",
+		Example: `  # What would lowering an existing watch's threshold do?
+  spt watches preview abc123 --threshold 70
+
+  # Try a new filter before creating the watch
+  spt watches preview --type ram --threshold 80 \
+    --filter "attr:capacity_gb=eq:32" --filter 'expr=not title contains "lot"'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &client.WatchPreviewRequest{
+				ComponentType: domain.ComponentType(compType),
+				Limit:         limit,
+			}
+			if len(args) == 1 {
+				req.WatchID = args[0]
+			}
+			if cmd.Flags().Changed("threshold") {
+				req.ScoreThreshold = &threshold
+			}
+			if len(filterArgs) > 0 {
+				filters, err := handlers.ParseFilters(filterArgs)
+				if err != nil {
+					return fmt.Errorf("parsing --filter: %w", err)
+				}
+				req.Filters = &filters
+			}
+
+			preview, err := newClient().PreviewWatch(context.Background(), req)
+			if err != nil {
+				return err
+			}
+			if jsonOutput() {
+				return outputJSON(preview)
+			}
+			return printWatchPreview(os.Stdout, preview)
+		},
+	}
+	cmd.Flags().
+		StringVar(&compType, "type", "", "component type (ram, drive, server, cpu, nic, gpu, hba, switch, transceiver, workstation, desktop, other)")
+	cmd.Flags().IntVar(&threshold, "threshold", 0, "score threshold (default: the watch's, or 75 for a new watch)")
+	cmd.Flags().StringArrayVar(&filterArgs, "filter", nil, "filters (key=value, repeatable; replaces the watch's filters)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "max alerts and near misses to list (server default 25)")
+
+	return cmd
+}
+
+func printWatchPreview(w io.Writer, p *domain.WatchPreview) error {
+	scanned := fmt.Sprintf("%d", p.Scanned)
+	if p.Truncated {
+		scanned += "+ (scan cap reached)"
+	}
+	if _, err := fmt.Fprintf(w,
+		"Scanned %s active listings: %d pass filters, %d would alert.\n",
+		scanned, p.Matches, p.AlertCount,
+	); err != nil {
+		return err
+	}
+
+	if len(p.Alerts) > 0 {
+		if _, err := fmt.Fprintln(w, "\nWould alert:"); err != nil {
+			return err
+		}
+		if err := printPreviewItems(w, p.Alerts, false); err != nil {
+			return err
+		}
+	}
+	if len(p.NearMisses) > 0 {
+		if _, err := fmt.Fprintln(w, "\nNear misses:"); err != nil {
+			return err
+		}
+		if err := printPreviewItems(w, p.NearMisses, true); err != nil {
+			return err
+		}
+	}
+
+	if len(p.Rejections) > 0 {
+		reasons := make([]string, 0, len(p.Rejections))
+		for r := range p.Rejections {
+			reasons = append(reasons, r)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if p.Rejections[reasons[i]] != p.Rejections[reasons[j]] {
+				return p.Rejections[reasons[i]] > p.Rejections[reasons[j]]
+			}
+			return reasons[i] < reasons[j]
+		})
+		if _, err := fmt.Fprintln(w, "\nRejections by check:"); err != nil {
+			return err
+		}
+		tw := newTabWriter(w)
+		for _, r := range reasons {
+			tw.writef("  %s\t%d\n", r, p.Rejections[r])
+		}
+		return tw.finish()
+	}
+	return nil
+}
+
+func printPreviewItems(w io.Writer, items []domain.WatchPreviewItem, nearMiss bool) error {
+	tw := newTabWriter(w)
+	if nearMiss {
+		tw.writef("ID\tSCORE\tUNIT PRICE\tREJECTED BY\tTITLE\n")
+	} else {
+		tw.writef("ID\tSCORE\tUNIT PRICE\tTITLE\n")
+	}
+	for i := range items {
+		score := "-"
+		if items[i].Score != nil {
+			score = fmt.Sprintf("%d", *items[i].Score)
+		}
+		if nearMiss {
+			tw.writef("%s\t%s\t$%.2f\t%s\t%s\n",
+				items[i].ListingID, score, items[i].UnitPrice, items[i].RejectedBy, truncate(items[i].Title, 50))
+			continue
+		}
+		tw.writef("%s\t%s\t$%.2f\t%s\n",
+			items[i].ListingID, score, items[i].UnitPrice, truncate(items[i].Title, 50))
+	}
+	return tw.finish()
+}
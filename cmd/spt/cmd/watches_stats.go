@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func watchStatsCmd() *cobra.Command {
+	var window string
+
+	cmd := &cobra.Command{
+		Use:   "stats <id>",
+		Short: "Show per-watch analytics",
+		Long: "Show what a watch produced over a look-back window: polls and eBay\n" +
+			"pages used, listings ingested, alerts created, notified and\n" +
+			"dismissed, the median judge score and the best deal found.",
+		Example: `  spt watches stats abc123
+  spt watches stats abc123 --window 7d`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			st, err := newClient().GetWatchStats(context.Background(), args[0], window)
+			if err != nil {
+				return err
+			}
+			if jsonOutput() {
+				return outputJSON(st)
+			}
+			return printWatchStats(os.Stdout, st)
+		},
+	}
+	cmd.Flags().StringVar(&window, "window", "", "look-back window, e.g. 7d or 72h (server default 30d)")
+
+	return cmd
+}
+
+func printWatchStats(w io.Writer, st *domain.WatchStats) error {
+	median := "-"
+	if st.MedianJudgeScore != nil {
+		median = fmt.Sprintf("%.2f", *st.MedianJudgeScore)
+	}
+
+	tw := newTabWriter(w)
+	tw.writef("Since:\t%s\n", st.Since.Format("2006-01-02 15:04 MST"))
+	tw.writef("Polls:\t%d (%d failed)\n", st.Polls, st.PollErrors)
+	tw.writef("Pages used:\t%d (%.1f per poll)\n", st.PagesUsed, st.PagesPerPoll)
+	tw.writef("Listings seen:\t%d\n", st.ListingsSeen)
+	tw.writef("Listings ingested:\t%d (%.1f/day)\n", st.ListingsIngested, st.NewListingsPerDay)
+	tw.writef("Alerts created:\t%d\n", st.AlertsCreated)
+	tw.writef("Alerts notified:\t%d\n", st.AlertsNotified)
+	tw.writef("Alerts dismissed:\t%d (%.0f%%)\n", st.AlertsDismissed, st.DismissalRate*100)
+	tw.writef("Median judge score:\t%s (%d judged)\n", median, st.AlertsJudged)
+	if d := st.BestDeal; d != nil {
+		tw.writef("Best deal:\t[%d] $%.2f %s\n", d.Score, d.UnitPrice, truncate(d.Title, 50))
+		tw.writef("\t%s\n", d.ItemURL)
+	}
+	return tw.finish()
+}
@@ -33,6 +33,10 @@ type watchUpdateFlags struct {
 	compType     string
 	threshold    int
 	enabled      bool
+	pollInterval domain.Duration
+	priority     int
+	marketplace  string
+	dropPct      float64
 	filterFlag   []string
 	addFilter    []string
 	clearFilters bool
@@ -55,6 +59,9 @@ func watchUpdateCmd() *cobra.Command {
 		Example: `  # Tighten the score threshold without touching anything else
   spt watches update abc123 --threshold 80
 
+  # Poll a slow watch twice a day at low priority
+  spt watches update abc123 --poll-interval 12h --priority -5
+
   # Add a capacity_gb constraint without dropping existing filters
   spt watches update abc123 --add-filter "attr:capacity_gb=eq:32"
 
@@ -73,9 +80,16 @@ func watchUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&f.query, "query", "", "eBay search query")
 	cmd.Flags().StringVar(&f.category, "category", "", "category id")
 	cmd.Flags().
-		StringVar(&f.compType, "type", "", "component type (ram, drive, server, cpu, nic, gpu, workstation, desktop, other)")
+		StringVar(&f.compType, "type", "", "component type (ram, drive, server, cpu, nic, gpu, hba, switch, transceiver, workstation, desktop, other)")
 	cmd.Flags().IntVar(&f.threshold, "threshold", 0, "score threshold for alerts")
 	cmd.Flags().BoolVar(&f.enabled, "enabled", false, "enable or disable the watch")
+	cmd.Flags().
+		TextVar(&f.pollInterval, "poll-interval", domain.Duration(0), "how often to poll eBay, e.g. 5m, 2h, 1d (0 = server ingestion interval)")
+	cmd.Flags().IntVar(&f.priority, "priority", 0, "poll priority; higher goes first when the cycle budget is short")
+	cmd.Flags().
+		StringVar(&f.marketplace, "marketplace", "", `eBay marketplace to search, e.g. EBAY_GB ("" = server ebay.marketplace)`)
+	cmd.Flags().
+		Float64Var(&f.dropPct, "realert-drop-pct", 0, "alert again inside the re-alert cooldown on a price drop of at least this percent (0 = never)")
 	cmd.Flags().
 		StringArrayVar(&f.filterFlag, "filter", nil, "replace the entire filter block (key=value, repeatable)")
 	cmd.Flags().
@@ -151,6 +165,18 @@ func (f *watchUpdateFlags) applyScalarFlags(cmd *cobra.Command, w *domain.Watch)
 	if flags.Changed("enabled") {
 		w.Enabled = f.enabled
 	}
+	if flags.Changed("poll-interval") {
+		w.PollInterval = f.pollInterval
+	}
+	if flags.Changed("priority") {
+		w.Priority = f.priority
+	}
+	if flags.Changed("marketplace") {
+		w.Marketplace = f.marketplace
+	}
+	if flags.Changed("realert-drop-pct") {
+		w.RealertDropPct = f.dropPct
+	}
 }
 
 // applyFilterUpdates returns the new Filters value to PUT given the current
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
+)
+
+// CatalogResponse is the response from the catalog endpoint.
+type CatalogResponse struct {
+	Versions map[string]string `json:"versions"`
+	Entries  []catalog.Entry   `json:"entries"`
+}
+
+// ListCatalog returns hardware catalog entries, optionally limited to
+// one component type or to the entry a model resolves to.
+func (c *Client) ListCatalog(ctx context.Context, componentType, model string) (*CatalogResponse, error) {
+	q := url.Values{}
+	if componentType != "" {
+		q.Set("component_type", componentType)
+	}
+	if model != "" {
+		q.Set("model", model)
+	}
+	path := "/api/v1/catalog"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var resp CatalogResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
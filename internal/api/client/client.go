@@ -54,6 +54,11 @@ func (c *Client) put(ctx context.Context, path string, body, dst any) error {
 	return c.do(ctx, http.MethodPut, path, body, dst)
 }
 
+// patch performs a PATCH request with a JSON body and decodes the response into dst.
+func (c *Client) patch(ctx context.Context, path string, body, dst any) error {
+	return c.do(ctx, http.MethodPatch, path, body, dst)
+}
+
 // del performs a DELETE request and decodes the response into dst.
 func (c *Client) del(ctx context.Context, path string, dst any) error {
 	return c.do(ctx, http.MethodDelete, path, nil, dst)
@@ -284,6 +284,27 @@ func TestClient_GetListing(t *testing.T) {
 	assert.Equal(t, "Test Listing", result.Title)
 }
 
+func TestClient_GetSeller(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/sellers/server_parts_inc", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"server_parts_inc","feedback_score":5432,"listings":[{"id":"l1"}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	result, err := c.GetSeller(context.Background(), "server_parts_inc", 10)
+	require.NoError(t, err)
+	assert.Equal(t, "server_parts_inc", result.Name)
+	assert.Equal(t, 5432, result.FeedbackScore)
+	require.Len(t, result.Listings, 1)
+	assert.Equal(t, "l1", result.Listings[0].ID)
+}
+
 func TestClient_Rescore(t *testing.T) {
 	t.Parallel()
 
@@ -491,3 +512,145 @@ func TestWithHTTPClient(t *testing.T) {
 	c := New("http://example.com", WithHTTPClient(custom))
 	assert.Same(t, custom, c.httpClient)
 }
+
+func TestClient_SimilarProductKeys(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/product-keys/similar", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("max_distance"))
+		assert.Equal(t, "cpu", r.URL.Query().Get("component_type"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"from":"cpu:a-1","to":"cpu:a1","distance":1,"punctuation_only":true}]`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	result, err := c.SimilarProductKeys(context.Background(), 1, "cpu")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "cpu:a1", result[0].To)
+	assert.True(t, result[0].PunctuationOnly)
+}
+
+func TestClient_MergeProductKeys(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/product-keys/merge", r.URL.Path)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"from": "ram:a", "to": "ram:b"}, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"from":"ram:a","to":"ram:b","listings_moved":4}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	result, err := c.MergeProductKeys(context.Background(), "ram:a", "ram:b")
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.ListingsMoved)
+}
+
+func TestClient_ListCatalog(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/catalog", r.URL.Path)
+		assert.Equal(t, "cpu", r.URL.Query().Get("component_type"))
+		assert.Equal(t, "Gold 6148", r.URL.Query().Get("model"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"versions":{"cpu":"2026.10.1"},"entries":[{"id":"cpu:intel:xeon:6148","specs":{"cores":20}}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	result, err := c.ListCatalog(context.Background(), "cpu", "Gold 6148")
+	require.NoError(t, err)
+	assert.Equal(t, "2026.10.1", result.Versions["cpu"])
+	require.Len(t, result.Entries, 1)
+	assert.InDelta(t, 20.0, result.Entries[0].Specs["cores"], 0)
+}
+
+func TestClient_CorrectExtraction(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v1/listings/abc/extraction", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "hba", body["component_type"])
+		assert.Equal(t, "was nic", body["note"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"c1","listing_id":"abc","component_type":"hba","product_key":"hba:lsi:9300-8i:it"}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	corr, err := c.CorrectExtraction(context.Background(), "abc", "hba", map[string]any{"model": "9300-8i"}, "was nic")
+	require.NoError(t, err)
+	assert.Equal(t, "hba:lsi:9300-8i:it", corr.ProductKey)
+}
+
+func TestClient_ListExtractionCorrections(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/extraction-corrections", r.URL.Path)
+		assert.Equal(t, "25", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"c1","listing_id":"abc"},{"id":"c2","listing_id":"def"}]`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	corrections, err := c.ListExtractionCorrections(context.Background(), 25)
+	require.NoError(t, err)
+	require.Len(t, corrections, 2)
+	assert.Equal(t, "def", corrections[1].ListingID)
+}
+
+func TestClient_GetShadowReport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/extraction/shadow/report", r.URL.Path)
+		assert.Equal(t, "7d", r.URL.Query().Get("window"))
+		assert.Equal(t, "v2", r.URL.Query().Get("prompt_version"))
+		assert.False(t, r.URL.Query().Has("shadow_model"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"samples":40,"compared":38,"product_key_agreement":0.9}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	r, err := c.GetShadowReport(context.Background(), ShadowReportParams{Window: "7d", PromptVersion: "v2"})
+	require.NoError(t, err)
+	assert.Equal(t, 40, r.Samples)
+	assert.InDelta(t, 0.9, r.ProductKeyAgreement, 0)
+}
+
+func TestClient_GetJudgeStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/judge/status", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"concurrency":4,"reserved_usd":0.04,"backlog":{"pending":120,"aged_out_last_24h":7},` +
+			`"oldest_age_seconds":5400,"last_tick":{"candidates":50,"judged":48}}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL)
+	st, err := c.GetJudgeStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, st.Concurrency)
+	require.NotNil(t, st.Backlog)
+	assert.Equal(t, 120, st.Backlog.Pending)
+	assert.Equal(t, 7, st.Backlog.AgedOut)
+	require.NotNil(t, st.LastTick)
+	assert.Equal(t, 48, st.LastTick.Judged)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// CorrectExtraction replaces a listing's extraction with a correction,
+// locking it against re-extraction.
+func (c *Client) CorrectExtraction(
+	ctx context.Context,
+	listingID string,
+	componentType string,
+	attrs map[string]any,
+	note string,
+) (*domain.ExtractionCorrection, error) {
+	body := map[string]any{
+		"component_type": componentType,
+		"attributes":     attrs,
+	}
+	if note != "" {
+		body["note"] = note
+	}
+	var corr domain.ExtractionCorrection
+	if err := c.patch(ctx, "/api/v1/listings/"+url.PathEscape(listingID)+"/extraction", body, &corr); err != nil {
+		return nil, err
+	}
+	return &corr, nil
+}
+
+// ListExtractionCorrections returns up to limit recorded corrections
+// (0 for the server default), newest first.
+func (c *Client) ListExtractionCorrections(ctx context.Context, limit int) ([]domain.ExtractionCorrection, error) {
+	path := "/api/v1/extraction-corrections"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var corrections []domain.ExtractionCorrection
+	if err := c.get(ctx, path, &corrections); err != nil {
+		return nil, err
+	}
+	return corrections, nil
+}
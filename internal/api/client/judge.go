@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
 // JudgeRunResult mirrors handlers.JudgeRunOutput.Body — duplicated
@@ -21,3 +23,28 @@ func (c *Client) RunJudge(ctx context.Context) (*JudgeRunResult, error) {
 	}
 	return &resp, nil
 }
+
+// JudgeStatus mirrors judge.Status — duplicated here for the same
+// reason as JudgeRunResult.
+type JudgeStatus struct {
+	Concurrency      int                    `json:"concurrency"`
+	BatchSize        int                    `json:"batch_size"`
+	LookbackSeconds  float64                `json:"lookback_seconds"`
+	DailyBudgetUSD   float64                `json:"daily_budget_usd"`
+	SpentTodayUSD    float64                `json:"spent_today_usd"`
+	ReservedUSD      float64                `json:"reserved_usd"`
+	EstimatedCostUSD float64                `json:"estimated_cost_usd"`
+	Backlog          *domain.JudgeBacklog   `json:"backlog"`
+	OldestAgeSeconds float64                `json:"oldest_age_seconds"`
+	LastTick         *domain.JudgeTickStats `json:"last_tick,omitempty"`
+}
+
+// GetJudgeStatus GETs /api/v1/judge/status: the worker's budget
+// ledger, the backlog of un-judged alerts, and the last tick.
+func (c *Client) GetJudgeStatus(ctx context.Context) (*JudgeStatus, error) {
+	var resp JudgeStatus
+	if err := c.get(ctx, "/api/v1/judge/status", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -17,13 +17,15 @@ type ListingsResponse struct {
 
 // ListListingsParams defines query parameters for listing queries.
 type ListListingsParams struct {
-	ComponentType string
-	ProductKey    string
-	MinScore      int
-	MaxScore      int
-	Limit         int
-	Offset        int
-	OrderBy       string
+	ComponentType   string
+	ProductKey      string
+	ValueClass      string
+	MaxPricePerUnit float64
+	MinScore        int
+	MaxScore        int
+	Limit           int
+	Offset          int
+	OrderBy         string
 }
 
 // ListListings returns listings matching the given parameters.
@@ -38,6 +40,12 @@ func (c *Client) ListListings(
 	if params.ProductKey != "" {
 		q.Set("product_key", params.ProductKey)
 	}
+	if params.ValueClass != "" {
+		q.Set("value_class", params.ValueClass)
+	}
+	if params.MaxPricePerUnit > 0 {
+		q.Set("max_price_per_unit", strconv.FormatFloat(params.MaxPricePerUnit, 'f', -1, 64))
+	}
 	if params.MinScore > 0 {
 		q.Set("min_score", strconv.Itoa(params.MinScore))
 	}
@@ -75,6 +83,27 @@ func (c *Client) GetListing(ctx context.Context, id string) (*domain.Listing, er
 	return &l, nil
 }
 
+// SellerResponse is a seller with its active listings.
+type SellerResponse struct {
+	domain.Seller
+	Listings []domain.Listing `json:"listings"`
+}
+
+// GetSeller returns a seller by eBay username, with up to limit of its
+// active listings (0 uses the server default).
+func (c *Client) GetSeller(ctx context.Context, name string, limit int) (*SellerResponse, error) {
+	path := "/api/v1/sellers/" + url.PathEscape(name)
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+
+	var resp SellerResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Rescore triggers a full rescore of all listings.
 func (c *Client) Rescore(ctx context.Context) (int, error) {
 	var resp struct {
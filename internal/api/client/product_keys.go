@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// MergeProductKeysResponse is the response from merging product keys.
+type MergeProductKeysResponse struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	ListingsMoved int    `json:"listings_moved"`
+}
+
+// ListProductKeyAliases returns every product key alias.
+func (c *Client) ListProductKeyAliases(ctx context.Context) ([]domain.ProductKeyAlias, error) {
+	var aliases []domain.ProductKeyAlias
+	if err := c.get(ctx, "/api/v1/product-keys/aliases", &aliases); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// SimilarProductKeys returns suggested product key merges within
+// maxDistance edits (0 for the server default), optionally limited to
+// one component type.
+func (c *Client) SimilarProductKeys(
+	ctx context.Context,
+	maxDistance int,
+	componentType string,
+) ([]domain.ProductKeySuggestion, error) {
+	q := url.Values{}
+	if maxDistance > 0 {
+		q.Set("max_distance", strconv.Itoa(maxDistance))
+	}
+	if componentType != "" {
+		q.Set("component_type", componentType)
+	}
+	path := "/api/v1/product-keys/similar"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var suggestions []domain.ProductKeySuggestion
+	if err := c.get(ctx, path, &suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// MergeProductKeys makes from an alias of to and moves its listings.
+func (c *Client) MergeProductKeys(ctx context.Context, from, to string) (*MergeProductKeysResponse, error) {
+	body := map[string]string{"from": from, "to": to}
+	var resp MergeProductKeysResponse
+	if err := c.post(ctx, "/api/v1/product-keys/merge", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// ShadowReportParams scopes a shadow extraction report. Zero values use
+// the server defaults.
+type ShadowReportParams struct {
+	Window        string
+	ShadowModel   string
+	PromptVersion string
+	Examples      int
+}
+
+// GetShadowReport compares shadow extractions with the live
+// extractions they repeated.
+func (c *Client) GetShadowReport(ctx context.Context, p ShadowReportParams) (*domain.ShadowReport, error) {
+	q := url.Values{}
+	if p.Window != "" {
+		q.Set("window", p.Window)
+	}
+	if p.ShadowModel != "" {
+		q.Set("shadow_model", p.ShadowModel)
+	}
+	if p.PromptVersion != "" {
+		q.Set("prompt_version", p.PromptVersion)
+	}
+	if p.Examples > 0 {
+		q.Set("examples", strconv.Itoa(p.Examples))
+	}
+	path := "/api/v1/extraction/shadow/report"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var r domain.ShadowReport
+	if err := c.get(ctx, path, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
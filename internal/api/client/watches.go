@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
@@ -16,6 +17,10 @@ type watchRequest struct {
 	Filters        domain.WatchFilters  `json:"filters,omitempty"`
 	ScoreThreshold int                  `json:"score_threshold,omitempty"`
 	Enabled        bool                 `json:"enabled,omitempty"`
+	PollInterval   domain.Duration      `json:"poll_interval,omitempty"`
+	Priority       int                  `json:"priority,omitempty"`
+	Marketplace    string               `json:"marketplace,omitempty"`
+	RealertDropPct float64              `json:"realert_drop_pct,omitempty"`
 }
 
 // ListWatches returns all watches.
@@ -47,6 +52,10 @@ func (c *Client) CreateWatch(ctx context.Context, w *domain.Watch) (*domain.Watc
 		Filters:        w.Filters,
 		ScoreThreshold: w.ScoreThreshold,
 		Enabled:        w.Enabled,
+		PollInterval:   w.PollInterval,
+		Priority:       w.Priority,
+		Marketplace:    w.Marketplace,
+		RealertDropPct: w.RealertDropPct,
 	}
 	if err := c.post(ctx, "/api/v1/watches", req, &created); err != nil {
 		return nil, err
@@ -65,6 +74,10 @@ func (c *Client) UpdateWatch(ctx context.Context, w *domain.Watch) (*domain.Watc
 		Filters:        w.Filters,
 		ScoreThreshold: w.ScoreThreshold,
 		Enabled:        w.Enabled,
+		PollInterval:   w.PollInterval,
+		Priority:       w.Priority,
+		Marketplace:    w.Marketplace,
+		RealertDropPct: w.RealertDropPct,
 	}
 	if err := c.put(ctx, "/api/v1/watches/"+w.ID, req, &updated); err != nil {
 		return nil, err
@@ -82,3 +95,60 @@ func (c *Client) SetWatchEnabled(ctx context.Context, id string, enabled bool) e
 func (c *Client) DeleteWatch(ctx context.Context, id string) error {
 	return c.del(ctx, "/api/v1/watches/"+id, nil)
 }
+
+// applyWatchesRequest is the body of POST /api/v1/watches/apply.
+type applyWatchesRequest struct {
+	domain.WatchManifest
+	Prune  bool `json:"prune,omitempty"`
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ApplyWatches converges the server's watch set onto the manifest. With
+// dryRun the server returns the plan without writing; with prune it also
+// deletes watches whose names are not in the manifest.
+func (c *Client) ApplyWatches(
+	ctx context.Context,
+	m *domain.WatchManifest,
+	prune, dryRun bool,
+) (*domain.WatchApplyResult, error) {
+	var result domain.WatchApplyResult
+	req := applyWatchesRequest{WatchManifest: *m, Prune: prune, DryRun: dryRun}
+	if err := c.post(ctx, "/api/v1/watches/apply", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WatchPreviewRequest is the body of POST /api/v1/watches/preview. With
+// WatchID set, the stored watch is the base and non-nil fields override it.
+type WatchPreviewRequest struct {
+	WatchID        string               `json:"watch_id,omitempty"`
+	ComponentType  domain.ComponentType `json:"component_type,omitempty"`
+	Filters        *domain.WatchFilters `json:"filters,omitempty"`
+	ScoreThreshold *int                 `json:"score_threshold,omitempty"`
+	Limit          int                  `json:"limit,omitempty"`
+}
+
+// PreviewWatch evaluates a watch definition against stored listings
+// without creating alerts.
+func (c *Client) PreviewWatch(ctx context.Context, req *WatchPreviewRequest) (*domain.WatchPreview, error) {
+	var preview domain.WatchPreview
+	if err := c.post(ctx, "/api/v1/watches/preview", req, &preview); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// GetWatchStats returns analytics for one watch over window ("7d",
+// "72h"); an empty window uses the server default of 30 days.
+func (c *Client) GetWatchStats(ctx context.Context, id, window string) (*domain.WatchStats, error) {
+	path := "/api/v1/watches/" + id + "/stats"
+	if window != "" {
+		path += "?" + url.Values{"window": {window}}.Encode()
+	}
+	var st domain.WatchStats
+	if err := c.get(ctx, path, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
@@ -15,14 +15,17 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
 	"github.com/donaldgifford/server-price-tracker/internal/api/web/components"
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	"github.com/donaldgifford/server-price-tracker/internal/notify"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
 	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
@@ -55,6 +58,9 @@ func isHTMX(c echo.Context) bool {
 // LangfuseEndpoint is the bare base URL ("https://langfuse.example.com")
 // used to render trace deep-links in templ. Empty → the View Trace
 // button is suppressed across the UI.
+//
+// Corrector applies the detail page's extraction edit form. Nil hides
+// the form and makes the correction route respond 503.
 type AlertsUIDeps struct {
 	Store            store.Store
 	Notifier         notify.Notifier
@@ -62,6 +68,7 @@ type AlertsUIDeps struct {
 	LangfuseEndpoint string
 	JudgeEnabled     bool
 	AlertsURLBase    string // unused today; threaded through for the summary embed in Phase 6
+	Corrector        ExtractionCorrector
 	Logger           *slog.Logger
 }
 
@@ -138,7 +145,8 @@ func (h *AlertsUIHandler) scoreOperatorDismissValue(ctx context.Context, traceID
 }
 
 // RegisterAlertsUIRoutes wires every handler method onto e under the
-// /alerts route group. Caller must guard registration on cfg.Web.Enabled.
+// /alerts route group, plus the per-watch stats page alerts link to.
+// Caller must guard registration on cfg.Web.Enabled.
 func RegisterAlertsUIRoutes(e *echo.Echo, h *AlertsUIHandler) {
 	e.GET("/alerts", h.ListPage)
 	e.GET("/alerts.json", h.ListJSON)
@@ -147,6 +155,8 @@ func RegisterAlertsUIRoutes(e *echo.Echo, h *AlertsUIHandler) {
 	e.POST("/alerts/dismiss", h.DismissBulk)
 	e.POST("/alerts/:id/restore", h.Restore)
 	e.POST("/alerts/:id/retry", h.Retry)
+	e.POST("/alerts/:id/extraction", h.CorrectExtraction)
+	e.GET("/watches/:id/stats", h.WatchStatsPage)
 }
 
 // ListPage renders the full alerts page on a normal GET, or just the
@@ -210,6 +220,117 @@ func (h *AlertsUIHandler) DetailPage(c echo.Context) error {
 		Detail:           d,
 		LangfuseEndpoint: h.deps.LangfuseEndpoint,
 		JudgeScore:       judgeScore,
+		ComponentTypes:   h.correctionTypes(),
+	}).Render(ctx, c.Response().Writer)
+}
+
+// correctionTypes returns the component types the extraction edit form
+// offers, or nil to hide the form when corrections aren't available.
+func (h *AlertsUIHandler) correctionTypes() []domain.ComponentType {
+	if h.deps.Corrector == nil {
+		return nil
+	}
+	return extract.ComponentTypes()
+}
+
+// CorrectExtraction applies the detail page's extraction edit form to
+// the alert's listing. HTMX clients get the re-rendered extraction
+// panel, carrying the reason when the correction is rejected; plain
+// form submits are redirected back to the detail page.
+func (h *AlertsUIHandler) CorrectExtraction(c echo.Context) error {
+	if h.deps.Corrector == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "extraction corrections not available")
+	}
+	id := c.Param("id")
+	ctx := c.Request().Context()
+
+	d, err := h.deps.Store.GetAlertDetail(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "alert not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "fetching alert: "+err.Error())
+	}
+
+	card := components.NewExtractionCardData(id, &d.Listing, h.correctionTypes())
+	card.Attributes = c.FormValue("attributes")
+	card.Note = c.FormValue("note")
+	card.Selected = domain.ComponentType(c.FormValue("component_type"))
+
+	var attrs map[string]any
+	if err := json.Unmarshal([]byte(card.Attributes), &attrs); err != nil {
+		card.Error = "attributes must be a JSON object: " + err.Error()
+		return h.rejectCorrection(c, &card)
+	}
+	corrected, err := h.deps.Corrector.CorrectExtraction(
+		ctx, d.Listing.ID, card.Selected, attrs, card.Note,
+	)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidCorrection) {
+			card.Error = err.Error()
+			return h.rejectCorrection(c, &card)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "correcting extraction: "+err.Error())
+	}
+	if !isHTMX(c) {
+		return c.Redirect(http.StatusSeeOther, "/alerts/"+id)
+	}
+
+	l := d.Listing
+	l.ComponentType = corrected.ComponentType
+	l.Attributes = corrected.Attributes
+	l.ProductKey = corrected.ProductKey
+	l.ExtractionConfidence = 1.0
+	l.ExtractionLocked = true
+	card = components.NewExtractionCardData(id, &l, h.correctionTypes())
+	card.Saved = true
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return components.ExtractionCard(card).Render(ctx, c.Response().Writer)
+}
+
+// rejectCorrection re-renders the extraction panel with the rejection
+// reason and the operator's input for HTMX clients, which only swap
+// 2xx responses; plain form submits get a 422.
+func (h *AlertsUIHandler) rejectCorrection(c echo.Context, card *components.ExtractionCardData) error {
+	if !isHTMX(c) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, card.Error)
+	}
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return components.ExtractionCard(*card).Render(c.Request().Context(), c.Response().Writer)
+}
+
+// WatchStatsPage renders per-watch analytics over the ?window= look-back
+// (same syntax as the API: "7d", "72h"; default 30d).
+func (h *AlertsUIHandler) WatchStatsPage(c echo.Context) error {
+	id := c.Param("id")
+	ctx := c.Request().Context()
+
+	raw := c.QueryParam("window")
+	window, err := ParseStatsWindow(raw)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if raw == "" {
+		raw = "30d"
+	}
+
+	w, err := h.deps.Store.GetWatch(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "watch not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "fetching watch: "+err.Error())
+	}
+	st, err := loadWatchStats(ctx, h.deps.Store, id, window, time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "getting watch stats: "+err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return components.WatchStatsPage(components.WatchStatsData{
+		Watch:  w,
+		Stats:  st,
+		Window: raw,
 	}).Render(ctx, c.Response().Writer)
 }
 
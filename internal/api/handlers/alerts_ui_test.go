@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
 	notifymocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
 	storemocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
@@ -440,3 +442,120 @@ func TestRetry(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, rec.Code, "body=%s", rec.Body.String())
 }
+
+// TestCorrectExtraction covers the detail page's extraction edit form:
+// HTMX submits get the re-rendered extraction panel (with the rejection
+// reason when the correction fails), plain submits are redirected back
+// to the detail page.
+func TestCorrectExtraction(t *testing.T) {
+	t.Parallel()
+
+	detail := &domain.AlertDetail{
+		Alert:   domain.Alert{ID: "alert-1", Score: 88},
+		Listing: domain.Listing{ID: "listing-1", Title: "LSI 9300-8i HBA", ComponentType: domain.ComponentNIC},
+	}
+	validAttrs := `{"manufacturer":"lsi","model":"9300-8i","mode":"IT"}`
+
+	tests := []struct {
+		name       string
+		corrector  *fakeCorrector
+		attrs      string
+		htmx       bool
+		wantStatus int
+		wantBody   []string
+		wantCalled bool
+	}{
+		{
+			name:       "htmx renders saved panel",
+			corrector:  &fakeCorrector{},
+			attrs:      validAttrs,
+			htmx:       true,
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"Correction saved", "hba:lsi:9300-8i:it", "locked against re-extraction"},
+			wantCalled: true,
+		},
+		{
+			name:       "plain submit redirects to detail page",
+			corrector:  &fakeCorrector{},
+			attrs:      validAttrs,
+			wantStatus: http.StatusSeeOther,
+			wantCalled: true,
+		},
+		{
+			name:       "malformed attributes re-render with error",
+			corrector:  &fakeCorrector{},
+			attrs:      `{"model":`,
+			htmx:       true,
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"attributes must be a JSON object", `{&#34;model&#34;:`},
+		},
+		{
+			name:       "rejected correction re-renders with reason",
+			corrector:  &fakeCorrector{err: fmt.Errorf("%w: mode: invalid enum value", engine.ErrInvalidCorrection)},
+			attrs:      validAttrs,
+			htmx:       true,
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"mode: invalid enum value", `<option value="hba" selected`},
+			wantCalled: true,
+		},
+		{
+			name:       "rejected plain submit returns 422",
+			corrector:  &fakeCorrector{err: fmt.Errorf("%w: mode: invalid enum value", engine.ErrInvalidCorrection)},
+			attrs:      validAttrs,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := storemocks.NewMockStore(t)
+			s.EXPECT().GetAlertDetail(mock.Anything, "alert-1").Return(detail, nil).Once()
+			h := handlers.NewAlertsUIHandler(&handlers.AlertsUIDeps{Store: s, Corrector: tt.corrector})
+			e := echo.New()
+			handlers.RegisterAlertsUIRoutes(e, h)
+
+			form := url.Values{
+				"component_type": {"hba"},
+				"attributes":     {tt.attrs},
+				"note":           {"classified as nic"},
+			}
+			req := httptest.NewRequest(http.MethodPost, "/alerts/alert-1/extraction", strings.NewReader(form.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, "body=%s", rec.Body.String())
+			for _, want := range tt.wantBody {
+				assert.Contains(t, rec.Body.String(), want)
+			}
+			if tt.wantStatus == http.StatusSeeOther {
+				assert.Equal(t, "/alerts/alert-1", rec.Header().Get(echo.HeaderLocation))
+			}
+			if tt.wantCalled {
+				assert.Equal(t, "listing-1", tt.corrector.listingID)
+				assert.Equal(t, domain.ComponentHBA, tt.corrector.ct)
+				assert.Equal(t, "classified as nic", tt.corrector.note)
+			} else {
+				assert.Empty(t, tt.corrector.listingID, "corrector not called")
+			}
+		})
+	}
+}
+
+func TestCorrectExtraction_NoCorrectorReturns503(t *testing.T) {
+	t.Parallel()
+
+	e, _, _ := newAlertsUITestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/alerts/alert-1/extraction", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code, "body=%s", rec.Body.String())
+}
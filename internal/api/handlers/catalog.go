@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
+)
+
+// CatalogHandler serves the hardware catalog.
+type CatalogHandler struct {
+	catalog *catalog.Catalog
+}
+
+// NewCatalogHandler creates a new CatalogHandler. A nil catalog makes
+// the endpoint respond 503.
+func NewCatalogHandler(c *catalog.Catalog) *CatalogHandler {
+	return &CatalogHandler{catalog: c}
+}
+
+// ListCatalogInput is the input for listing catalog entries.
+type ListCatalogInput struct {
+	ComponentType string `query:"component_type" doc:"Only entries of this component type (cpu, gpu)"`
+	Model         string `query:"model"          doc:"Only the entry this model resolves to, matched as enrichment does (e.g. 'Xeon Gold 6148')"`
+}
+
+// ListCatalogOutput is the response for listing catalog entries.
+type ListCatalogOutput struct {
+	Body struct {
+		Versions map[string]string `json:"versions" doc:"Catalog data version per component type"`
+		Entries  []catalog.Entry   `json:"entries"`
+	}
+}
+
+// List returns the catalog's entries and data versions.
+func (h *CatalogHandler) List(
+	_ context.Context,
+	input *ListCatalogInput,
+) (*ListCatalogOutput, error) {
+	if h.catalog == nil {
+		return nil, huma.Error503ServiceUnavailable("hardware catalog not loaded")
+	}
+
+	entries := []catalog.Entry{}
+	if input.Model != "" {
+		for _, e := range h.catalog.Entries(input.ComponentType) {
+			if match, ok := h.catalog.Lookup(e.ComponentType, input.Model); ok && match.ID == e.ID {
+				entries = append(entries, e)
+			}
+		}
+	} else if all := h.catalog.Entries(input.ComponentType); all != nil {
+		entries = all
+	}
+
+	resp := &ListCatalogOutput{}
+	resp.Body.Versions = h.catalog.Versions()
+	resp.Body.Entries = entries
+	return resp, nil
+}
+
+// RegisterCatalogRoutes registers hardware catalog endpoints with the
+// Huma API.
+func RegisterCatalogRoutes(api huma.API, h *CatalogHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-catalog",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/catalog",
+		Summary:     "List hardware catalog entries",
+		Description: "Returns the hardware catalog used to enrich extracted attributes, with its data versions.",
+		Tags:        []string{"catalog"},
+		Errors:      []int{http.StatusServiceUnavailable},
+	}, h.List)
+}
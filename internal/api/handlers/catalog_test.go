@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
+)
+
+func TestCatalogHandler_List(t *testing.T) {
+	t.Parallel()
+
+	c, err := catalog.Default()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		catalog    *catalog.Catalog
+		path       string
+		wantStatus int
+		wantIDs    []string
+		wantType   string
+	}{
+		{
+			name:       "model lookup",
+			catalog:    c,
+			path:       "/api/v1/catalog?model=Xeon+Gold+6148",
+			wantStatus: http.StatusOK,
+			wantIDs:    []string{"cpu:intel:xeon:6148"},
+		},
+		{
+			name:       "component type filter",
+			catalog:    c,
+			path:       "/api/v1/catalog?component_type=gpu",
+			wantStatus: http.StatusOK,
+			wantType:   "gpu",
+		},
+		{
+			name:       "unknown model returns empty list",
+			catalog:    c,
+			path:       "/api/v1/catalog?model=nothing",
+			wantStatus: http.StatusOK,
+			wantIDs:    []string{},
+		},
+		{
+			name:       "no catalog returns 503",
+			path:       "/api/v1/catalog",
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, api := humatest.New(t)
+			handlers.RegisterCatalogRoutes(api, handlers.NewCatalogHandler(tt.catalog))
+
+			resp := api.Get(tt.path)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Versions map[string]string `json:"versions"`
+				Entries  []catalog.Entry   `json:"entries"`
+			}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.Versions["cpu"])
+			if tt.wantIDs != nil {
+				ids := []string{}
+				for _, e := range body.Entries {
+					ids = append(ids, e.ID)
+				}
+				assert.Equal(t, tt.wantIDs, ids)
+			}
+			if tt.wantType != "" {
+				require.NotEmpty(t, body.Entries)
+				for _, e := range body.Entries {
+					assert.Equal(t, tt.wantType, e.ComponentType)
+				}
+			}
+		})
+	}
+}
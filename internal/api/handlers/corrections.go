@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// ExtractionCorrector is the engine surface the correction endpoint
+// and the alert detail form need. The production implementation is
+// *engine.Engine, which rebuilds the product key, locks the listing
+// against re-extraction and re-scores it.
+type ExtractionCorrector interface {
+	CorrectExtraction(
+		ctx context.Context,
+		listingID string,
+		componentType domain.ComponentType,
+		attrs map[string]any,
+		note string,
+	) (*domain.ExtractionCorrection, error)
+}
+
+// CorrectionsHandler handles operator extraction correction endpoints.
+type CorrectionsHandler struct {
+	store     store.Store
+	corrector ExtractionCorrector
+}
+
+// NewCorrectionsHandler creates a new CorrectionsHandler. A nil
+// corrector (no engine) makes the correction endpoint respond 503;
+// listing recorded corrections still works.
+func NewCorrectionsHandler(s store.Store, c ExtractionCorrector) *CorrectionsHandler {
+	return &CorrectionsHandler{store: s, corrector: c}
+}
+
+// CorrectExtractionInput is the input for correcting a listing's
+// extraction.
+type CorrectExtractionInput struct {
+	ID   string `path:"id" doc:"Listing ID"`
+	Body struct {
+		ComponentType domain.ComponentType `json:"component_type"  doc:"Corrected component type"     enum:"ram,drive,server,cpu,nic,gpu,hba,switch,transceiver,workstation,desktop,other"`
+		Attributes    map[string]any       `json:"attributes"      doc:"Corrected attributes; condition defaults to the listing's"`
+		Note          string               `json:"note,omitempty"  doc:"Why the extraction was wrong" maxLength:"500"`
+	}
+}
+
+// CorrectExtractionOutput is the response for correcting a listing's
+// extraction.
+type CorrectExtractionOutput struct {
+	Body *domain.ExtractionCorrection
+}
+
+// Correct applies an operator's correction to a listing's extraction.
+func (h *CorrectionsHandler) Correct(
+	ctx context.Context,
+	input *CorrectExtractionInput,
+) (*CorrectExtractionOutput, error) {
+	if h.corrector == nil {
+		return nil, huma.Error503ServiceUnavailable("engine not configured")
+	}
+	c, err := h.corrector.CorrectExtraction(
+		ctx, input.ID, input.Body.ComponentType, input.Body.Attributes, input.Body.Note,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, huma.Error404NotFound("listing not found")
+		case errors.Is(err, engine.ErrInvalidCorrection):
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("correction failed: " + err.Error())
+	}
+	return &CorrectExtractionOutput{Body: c}, nil
+}
+
+// ListCorrectionsInput is the input for listing corrections.
+type ListCorrectionsInput struct {
+	Limit int `query:"limit" doc:"Maximum corrections to return" default:"500" minimum:"1" maximum:"10000"`
+}
+
+// ListCorrectionsOutput is the response for listing corrections.
+type ListCorrectionsOutput struct {
+	Body []domain.ExtractionCorrection
+}
+
+// List returns recorded corrections, newest first.
+func (h *CorrectionsHandler) List(
+	ctx context.Context,
+	input *ListCorrectionsInput,
+) (*ListCorrectionsOutput, error) {
+	corrections, err := h.store.ListExtractionCorrections(ctx, input.Limit)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("listing corrections failed: " + err.Error())
+	}
+	if corrections == nil {
+		corrections = []domain.ExtractionCorrection{}
+	}
+	return &ListCorrectionsOutput{Body: corrections}, nil
+}
+
+// RegisterCorrectionRoutes registers extraction correction endpoints
+// with the Huma API.
+func RegisterCorrectionRoutes(api huma.API, h *CorrectionsHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "correct-listing-extraction",
+		Method:      http.MethodPatch,
+		Path:        "/api/v1/listings/{id}/extraction",
+		Summary:     "Correct a listing's extraction",
+		Description: "Replaces the listing's extracted component type and attributes, recomputes the product key, " +
+			"locks the listing against re-extraction and re-scores it. The correction is recorded as a labelled example.",
+		Tags:   []string{"listings"},
+		Errors: []int{http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusServiceUnavailable},
+	}, h.Correct)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-extraction-corrections",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/extraction-corrections",
+		Summary:     "List extraction corrections",
+		Description: "Returns operator extraction corrections, newest first, for export to the golden dataset.",
+		Tags:        []string{"listings"},
+	}, h.List)
+}
@@ -0,0 +1,186 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// fakeCorrector satisfies handlers.ExtractionCorrector. Validation,
+// product key rebuilding and re-scoring are exercised in the engine
+// package.
+type fakeCorrector struct {
+	err       error
+	listingID string
+	ct        domain.ComponentType
+	attrs     map[string]any
+	note      string
+}
+
+func (f *fakeCorrector) CorrectExtraction(
+	_ context.Context,
+	listingID string,
+	ct domain.ComponentType,
+	attrs map[string]any,
+	note string,
+) (*domain.ExtractionCorrection, error) {
+	f.listingID, f.ct, f.attrs, f.note = listingID, ct, attrs, note
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.ExtractionCorrection{
+		ListingID:     listingID,
+		ComponentType: ct,
+		Attributes:    attrs,
+		ProductKey:    "hba:lsi:9300-8i:it",
+		Note:          note,
+	}, nil
+}
+
+func TestCorrectionsHandler_Correct(t *testing.T) {
+	t.Parallel()
+
+	validBody := map[string]any{
+		"component_type": "hba",
+		"attributes":     map[string]any{"manufacturer": "lsi", "model": "9300-8i", "mode": "IT"},
+		"note":           "classified as nic",
+	}
+
+	tests := []struct {
+		name       string
+		corrector  handlers.ExtractionCorrector
+		body       map[string]any
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "applies correction",
+			corrector:  &fakeCorrector{},
+			body:       validBody,
+			wantStatus: http.StatusOK,
+			wantBody:   `"product_key":"hba:lsi:9300-8i:it"`,
+		},
+		{
+			name:       "unknown component type returns 422",
+			corrector:  &fakeCorrector{},
+			body:       map[string]any{"component_type": "tape", "attributes": map[string]any{}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid correction returns 422",
+			corrector:  &fakeCorrector{err: fmt.Errorf("%w: model: missing required field", engine.ErrInvalidCorrection)},
+			body:       validBody,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "model: missing required field",
+		},
+		{
+			name:       "unknown listing returns 404",
+			corrector:  &fakeCorrector{err: fmt.Errorf("getting listing l1: %w", pgx.ErrNoRows)},
+			body:       validBody,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "engine error returns 500",
+			corrector:  &fakeCorrector{err: errors.New("db down")},
+			body:       validBody,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "correction failed",
+		},
+		{
+			name:       "no engine returns 503",
+			body:       validBody,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, api := humatest.New(t)
+			handlers.RegisterCorrectionRoutes(api, handlers.NewCorrectionsHandler(storeMocks.NewMockStore(t), tt.corrector))
+
+			resp := api.Patch("/api/v1/listings/l1/extraction", tt.body)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.wantBody)
+			if tt.wantStatus == http.StatusOK {
+				f := tt.corrector.(*fakeCorrector)
+				assert.Equal(t, "l1", f.listingID)
+				assert.Equal(t, domain.ComponentHBA, f.ct)
+				assert.Equal(t, "9300-8i", f.attrs["model"])
+				assert.Equal(t, "classified as nic", f.note)
+			}
+		})
+	}
+}
+
+func TestCorrectionsHandler_List(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "returns corrections",
+			path: "/api/v1/extraction-corrections",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListExtractionCorrections(mock.Anything, 500).Return([]domain.ExtractionCorrection{
+					{ID: "c1", Title: "LSI 9300-8i", ComponentType: domain.ComponentHBA, ProductKey: "hba:lsi:9300-8i:it"},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"product_key":"hba:lsi:9300-8i:it"`,
+		},
+		{
+			name: "limit is passed through",
+			path: "/api/v1/extraction-corrections?limit=20",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListExtractionCorrections(mock.Anything, 20).Return(nil, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name: "store error returns 500",
+			path: "/api/v1/extraction-corrections",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListExtractionCorrections(mock.Anything, 500).Return(nil, errors.New("db error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "listing corrections failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterCorrectionRoutes(api, handlers.NewCorrectionsHandler(ms, nil))
+
+			resp := api.Get(tt.path)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.wantBody)
+		})
+	}
+}
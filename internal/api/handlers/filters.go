@@ -16,10 +16,18 @@ import (
 //	seller_min_feedback=500
 //	seller_min_feedback_pct=95.0
 //	seller_top_rated_only=true
+//	seller_blocklist=junk_dealer,another_seller
+//	seller_allowlist=server_parts_inc
 //	conditions=used_working,new
+//	buying_options=buy_it_now,best_offer
+//	item_location_country=US
+//	max_risk=40
+//	min_partout_ratio=1.2
+//	max_price_per_unit=1.5
 //	attr:capacity_gb=32
 //	attr:ddr_gen=eq:ddr4
 //	attr:speed_mhz=min:2400
+//	expr=not title contains ["caddy", "bracket"]
 func ParseFilters(filters []string) (domain.WatchFilters, error) {
 	var wf domain.WatchFilters
 	wf.AttributeFilters = make(map[string]domain.AttributeFilter)
@@ -85,16 +93,69 @@ func parseStandardFilter(wf *domain.WatchFilters, key, value string) error {
 			return fmt.Errorf("invalid seller_top_rated_only %q: %w", value, err)
 		}
 		wf.SellerTopRatedOnly = v
+	case "seller_blocklist":
+		wf.SellerBlocklist = parseSellerList(value)
+	case "seller_allowlist":
+		wf.SellerAllowlist = parseSellerList(value)
+	case "expr":
+		wf.Expr = value
+		if err := wf.Validate(); err != nil {
+			return fmt.Errorf("invalid expr: %w", err)
+		}
 	case "conditions":
 		for _, cond := range strings.Split(value, ",") {
 			wf.Conditions = append(wf.Conditions, domain.Condition(strings.TrimSpace(cond)))
 		}
+	case "buying_options":
+		for _, opt := range strings.Split(value, ",") {
+			lt := domain.ListingType(strings.TrimSpace(opt))
+			if !validBuyingOption(lt) {
+				return fmt.Errorf("invalid buying_options %q: want auction, buy_it_now or best_offer", opt)
+			}
+			wf.BuyingOptions = append(wf.BuyingOptions, lt)
+		}
+	case "item_location_country":
+		c := strings.ToUpper(strings.TrimSpace(value))
+		if !validCountryCode(c) {
+			return fmt.Errorf("invalid item_location_country %q: want a two-letter code like US", value)
+		}
+		wf.ItemLocationCountry = c
+	case "max_risk":
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || v > 100 {
+			return fmt.Errorf("invalid max_risk %q: want an integer from 0 to 100", value)
+		}
+		wf.MaxRisk = &v
+	case "min_partout_ratio":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("invalid min_partout_ratio %q: want a positive number like 1.2", value)
+		}
+		wf.MinPartOutRatio = &v
+	case "max_price_per_unit":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("invalid max_price_per_unit %q: want a positive USD amount like 1.5", value)
+		}
+		wf.MaxPricePerUnit = &v
 	default:
 		return fmt.Errorf("unknown filter key %q", key)
 	}
 	return nil
 }
 
+// parseSellerList splits a comma-separated list of seller names,
+// dropping blanks.
+func parseSellerList(value string) []string {
+	var names []string
+	for name := range strings.SplitSeq(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func parseAttributeFilter(value string) (domain.AttributeFilter, error) {
 	var af domain.AttributeFilter
 
@@ -50,6 +50,24 @@ func TestParseFilters(t *testing.T) {
 			filters: []string{"seller_top_rated_only=true"},
 			want:    domain.WatchFilters{SellerTopRatedOnly: true},
 		},
+		{
+			name:    "seller lists",
+			filters: []string{"seller_blocklist=junk_dealer, another_seller", "seller_allowlist=server_parts_inc"},
+			want: domain.WatchFilters{
+				SellerBlocklist: []string{"junk_dealer", "another_seller"},
+				SellerAllowlist: []string{"server_parts_inc"},
+			},
+		},
+		{
+			name:    "expr keeps embedded equals signs",
+			filters: []string{`expr=attrs.ddr_gen == "ddr4" or unit_price < 40`},
+			want:    domain.WatchFilters{Expr: `attrs.ddr_gen == "ddr4" or unit_price < 40`},
+		},
+		{
+			name:    "expr compile error",
+			filters: []string{`expr=price < `},
+			wantErr: "invalid expr: column 9",
+		},
 		{
 			name:    "conditions single",
 			filters: []string{"conditions=used_working"},
@@ -67,6 +85,58 @@ func TestParseFilters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "buying options",
+			filters: []string{"buying_options=buy_it_now, best_offer"},
+			want: domain.WatchFilters{
+				BuyingOptions: []domain.ListingType{domain.ListingBuyItNow, domain.ListingBestOffer},
+			},
+		},
+		{
+			name:    "unknown buying option",
+			filters: []string{"buying_options=classified"},
+			wantErr: "invalid buying_options",
+		},
+		{
+			name:    "item location country is upper-cased",
+			filters: []string{"item_location_country=us"},
+			want:    domain.WatchFilters{ItemLocationCountry: "US"},
+		},
+		{
+			name:    "item location country must be two letters",
+			filters: []string{"item_location_country=USA"},
+			wantErr: "invalid item_location_country",
+		},
+		{
+			name:    "max risk",
+			filters: []string{"max_risk=40"},
+			want:    domain.WatchFilters{MaxRisk: ptr(40)},
+		},
+		{
+			name:    "max risk out of range",
+			filters: []string{"max_risk=140"},
+			wantErr: "invalid max_risk",
+		},
+		{
+			name:    "min part-out ratio",
+			filters: []string{"min_partout_ratio=1.2"},
+			want:    domain.WatchFilters{MinPartOutRatio: ptr(1.2)},
+		},
+		{
+			name:    "min part-out ratio not positive",
+			filters: []string{"min_partout_ratio=0"},
+			wantErr: "invalid min_partout_ratio",
+		},
+		{
+			name:    "max price per unit",
+			filters: []string{"max_price_per_unit=1.5"},
+			want:    domain.WatchFilters{MaxPricePerUnit: ptr(1.5)},
+		},
+		{
+			name:    "max price per unit not a number",
+			filters: []string{"max_price_per_unit=cheap"},
+			wantErr: "invalid max_price_per_unit",
+		},
 		{
 			name:    "attr numeric exact match",
 			filters: []string{"attr:capacity_gb=32"},
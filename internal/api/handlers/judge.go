@@ -16,6 +16,7 @@ import (
 // without dragging the whole worker constructor into the test setup.
 type JudgeRunner interface {
 	Run(ctx context.Context) (int, error)
+	Status(ctx context.Context) (*judge.Status, error)
 }
 
 // JudgeHandler exposes a manual-trigger endpoint for the LLM-as-judge
@@ -78,10 +79,29 @@ func (h *JudgeHandler) RunJudge(ctx context.Context, _ *JudgeRunInput) (*JudgeRu
 	return resp, nil
 }
 
-// RegisterJudgeRoutes registers the manual-trigger endpoint. Mounts
-// regardless of whether the worker is configured — the handler
-// responds 503 in the disabled case so the OpenAPI surface stays
-// stable across deployments.
+// JudgeStatusOutput is the response body for the worker status.
+type JudgeStatusOutput struct {
+	Body *judge.Status
+}
+
+// JudgeStatus reports the worker's budget ledger, the live backlog of
+// un-judged alerts and the last tick's catch-up stats. Returns 503
+// when the judge worker isn't configured.
+func (h *JudgeHandler) JudgeStatus(ctx context.Context, _ *struct{}) (*JudgeStatusOutput, error) {
+	if h.worker == nil {
+		return nil, huma.Error503ServiceUnavailable("judge worker not configured")
+	}
+	st, err := h.worker.Status(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("judge status failed: " + err.Error())
+	}
+	return &JudgeStatusOutput{Body: st}, nil
+}
+
+// RegisterJudgeRoutes registers the manual-trigger and status
+// endpoints. Mounts regardless of whether the worker is configured —
+// the handlers respond 503 in the disabled case so the OpenAPI surface
+// stays stable across deployments.
 func RegisterJudgeRoutes(api huma.API, h *JudgeHandler) {
 	huma.Register(api, huma.Operation{
 		OperationID: "run-judge",
@@ -91,4 +111,16 @@ func RegisterJudgeRoutes(api huma.API, h *JudgeHandler) {
 		Description: "Triggers one tick of the LLM-as-judge worker, grading any un-judged alerts in the configured lookback window. Honours the configured daily USD budget cap. Returns 503 when the judge feature is disabled.",
 		Tags:        []string{"judge"},
 	}, h.RunJudge)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "judge-status",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/judge/status",
+		Summary:     "Show LLM-as-judge worker status",
+		Description: "Reports the judge worker's concurrency, today's spend and outstanding budget reservations, " +
+			"the backlog of un-judged alerts (count, oldest age, and alerts that aged out of the lookback window " +
+			"unjudged in the last 24h), and the last tick's catch-up stats. Returns 503 when the judge feature is disabled.",
+		Tags:   []string{"judge"},
+		Errors: []int{http.StatusServiceUnavailable},
+	}, h.JudgeStatus)
 }
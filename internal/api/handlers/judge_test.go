@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/assert"
@@ -13,14 +14,17 @@ import (
 
 	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
 	"github.com/donaldgifford/server-price-tracker/pkg/judge"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
 // stubRunner is a one-shot JudgeRunner. Captures whether Run was
 // called so the disabled-worker test can assert it wasn't invoked.
 type stubRunner struct {
-	judged int
-	err    error
-	calls  int
+	judged    int
+	err       error
+	calls     int
+	status    *judge.Status
+	statusErr error
 }
 
 func (s *stubRunner) Run(_ context.Context) (int, error) {
@@ -28,6 +32,10 @@ func (s *stubRunner) Run(_ context.Context) (int, error) {
 	return s.judged, s.err
 }
 
+func (s *stubRunner) Status(_ context.Context) (*judge.Status, error) {
+	return s.status, s.statusErr
+}
+
 // TestJudgeHandler_RunsWorkerAndReturnsCount: happy path.
 func TestJudgeHandler_RunsWorkerAndReturnsCount(t *testing.T) {
 	t.Parallel()
@@ -86,4 +94,53 @@ func TestJudgeHandler_DisabledReturns503(t *testing.T) {
 
 	resp := api.Post("/api/v1/judge/run", strings.NewReader("{}"))
 	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+	resp = api.Get("/api/v1/judge/status")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
+
+// TestJudgeHandler_Status returns the worker's ledger, backlog and
+// last tick.
+func TestJudgeHandler_Status(t *testing.T) {
+	t.Parallel()
+
+	oldest := time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)
+	r := &stubRunner{status: &judge.Status{
+		Concurrency:      4,
+		DailyBudgetUSD:   10,
+		SpentTodayUSD:    2.5,
+		ReservedUSD:      0.04,
+		EstimatedCostUSD: 0.01,
+		Backlog:          &domain.JudgeBacklog{Pending: 120, OldestAt: &oldest, AgedOut: 7},
+		OldestAgeSeconds: 5400,
+		LastTick:         &domain.JudgeTickStats{Candidates: 50, Judged: 48, Failed: 2},
+	}}
+
+	_, api := humatest.New(t)
+	handlers.RegisterJudgeRoutes(api, handlers.NewJudgeHandler(r))
+
+	resp := api.Get("/api/v1/judge/status")
+	require.Equal(t, http.StatusOK, resp.Code, "body=%s", resp.Body.String())
+	body := resp.Body.String()
+	assert.Contains(t, body, `"concurrency":4`)
+	assert.Contains(t, body, `"reserved_usd":0.04`)
+	assert.Contains(t, body, `"pending":120`)
+	assert.Contains(t, body, `"aged_out_last_24h":7`)
+	assert.Contains(t, body, `"oldest_age_seconds":5400`)
+	assert.Contains(t, body, `"judged":48`)
+}
+
+// TestJudgeHandler_StatusErrorReturns500: a failed store lookup
+// surfaces as 500.
+func TestJudgeHandler_StatusErrorReturns500(t *testing.T) {
+	t.Parallel()
+
+	r := &stubRunner{statusErr: errors.New("postgres down")}
+
+	_, api := humatest.New(t)
+	handlers.RegisterJudgeRoutes(api, handlers.NewJudgeHandler(r))
+
+	resp := api.Get("/api/v1/judge/status")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, resp.Body.String(), "postgres down")
 }
@@ -24,13 +24,15 @@ func NewListingsHandler(s store.Store) *ListingsHandler {
 
 // ListListingsInput is the input for listing listings with optional filters.
 type ListListingsInput struct {
-	ComponentType string `query:"component_type" doc:"Filter by component type"       enum:"ram,drive,server,cpu,nic,other,"`
-	ProductKey    string `query:"product_key"    doc:"Filter by product key"`
-	MinScore      int    `query:"min_score"      doc:"Minimum composite score"                                               minimum:"0" maximum:"100"`
-	MaxScore      int    `query:"max_score"      doc:"Maximum composite score"                                               minimum:"0" maximum:"100"`
-	Limit         int    `query:"limit"          doc:"Number of results (default 50)"                                        minimum:"1" maximum:"1000"`
-	Offset        int    `query:"offset"         doc:"Pagination offset"                                                     minimum:"0"`
-	OrderBy       string `query:"order_by"       doc:"Sort field"                     enum:"score,price,first_seen_at,"`
+	ComponentType   string  `query:"component_type"     doc:"Filter by component type"                    enum:"ram,drive,server,cpu,nic,gpu,hba,switch,transceiver,workstation,desktop,other,"`
+	ProductKey      string  `query:"product_key"        doc:"Filter by product key"`
+	ValueClass      string  `query:"value_class"        doc:"Filter by value class (e.g. ram:ddr4:ecc_reg)"`
+	MaxPricePerUnit float64 `query:"max_price_per_unit" doc:"Maximum USD price per value unit"                                                                  minimum:"0"`
+	MinScore        int     `query:"min_score"          doc:"Minimum composite score"                                                                         minimum:"0" maximum:"100"`
+	MaxScore        int     `query:"max_score"          doc:"Maximum composite score"                                                                         minimum:"0" maximum:"100"`
+	Limit           int     `query:"limit"              doc:"Number of results (default 50)"                                                                  minimum:"1" maximum:"1000"`
+	Offset          int     `query:"offset"             doc:"Pagination offset"                                                                               minimum:"0"`
+	OrderBy         string  `query:"order_by"           doc:"Sort field"                                  enum:"score,price,price_per_unit,first_seen_at,"`
 }
 
 // ListListingsOutput is the response for listing listings.
@@ -48,9 +50,13 @@ type GetListingInput struct {
 	ID string `path:"id" doc:"Listing UUID"`
 }
 
-// GetListingOutput is the response for getting a single listing.
+// GetListingOutput is the response for getting a single listing. The
+// listing's fields are inlined alongside its price history.
 type GetListingOutput struct {
-	Body domain.Listing
+	Body struct {
+		domain.Listing
+		PriceHistory []domain.PricePoint `json:"price_history"`
+	}
 }
 
 // --- Handlers ---
@@ -74,6 +80,14 @@ func (h *ListingsHandler) ListListings(
 		q.ProductKey = &input.ProductKey
 	}
 
+	if input.ValueClass != "" {
+		q.ValueClass = &input.ValueClass
+	}
+
+	if input.MaxPricePerUnit != 0 {
+		q.MaxPricePerUnit = &input.MaxPricePerUnit
+	}
+
 	if input.MinScore != 0 {
 		q.MinScore = &input.MinScore
 	}
@@ -110,7 +124,18 @@ func (h *ListingsHandler) GetListing(
 		return nil, huma.Error404NotFound("listing not found")
 	}
 
-	return &GetListingOutput{Body: *listing}, nil
+	history, err := h.store.ListPriceHistory(ctx, listing.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("price history query failed: " + err.Error())
+	}
+	if history == nil {
+		history = []domain.PricePoint{}
+	}
+
+	resp := &GetListingOutput{}
+	resp.Body.Listing = *listing
+	resp.Body.PriceHistory = history
+	return resp, nil
 }
 
 // RegisterListingRoutes registers listing endpoints with the Huma API.
@@ -129,7 +154,7 @@ func RegisterListingRoutes(api huma.API, h *ListingsHandler) {
 		Method:      http.MethodGet,
 		Path:        "/api/v1/listings/{id}",
 		Summary:     "Get a listing by ID",
-		Description: "Returns a single listing by its UUID.",
+		Description: "Returns a single listing by its UUID, with its price history.",
 		Tags:        []string{"listings"},
 		Errors:      []int{http.StatusNotFound},
 	}, h.GetListing)
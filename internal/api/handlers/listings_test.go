@@ -95,6 +95,21 @@ func TestListingsHandler_List(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "value class sorted by price per unit",
+			path: "/api/v1/listings?value_class=ram:ddr4:ecc_reg&max_price_per_unit=1.5&order_by=price_per_unit",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					ListListings(mock.Anything, mock.MatchedBy(func(q *store.ListingQuery) bool {
+						return q.ValueClass != nil && *q.ValueClass == "ram:ddr4:ecc_reg" &&
+							q.MaxPricePerUnit != nil && *q.MaxPricePerUnit == 1.5 &&
+							q.OrderBy == "price_per_unit"
+					})).
+					Return(nil, 0, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+		},
 		{
 			name:       "invalid min_score returns 422",
 			path:       "/api/v1/listings?min_score=abc",
@@ -180,10 +195,49 @@ func TestListingsHandler_GetByID(t *testing.T) {
 						Title: "Samsung 32GB DDR4",
 					}, nil).
 					Once()
+				m.EXPECT().
+					ListPriceHistory(mock.Anything, "abc-123").
+					Return(nil, nil).
+					Once()
 			},
 			wantStatus: http.StatusOK,
 			wantBody:   `Samsung 32GB DDR4`,
 		},
+		{
+			name: "includes price history",
+			id:   "abc-123",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetListingByID(mock.Anything, "abc-123").
+					Return(&domain.Listing{ID: "abc-123", Price: 310}, nil).
+					Once()
+				m.EXPECT().
+					ListPriceHistory(mock.Anything, "abc-123").
+					Return([]domain.PricePoint{
+						{Price: 400, Currency: "USD"},
+						{Price: 310, Currency: "USD"},
+					}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"price_history":[{"price":400,`,
+		},
+		{
+			name: "price history error returns 500",
+			id:   "abc-123",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetListingByID(mock.Anything, "abc-123").
+					Return(&domain.Listing{ID: "abc-123"}, nil).
+					Once()
+				m.EXPECT().
+					ListPriceHistory(mock.Anything, "abc-123").
+					Return(nil, errors.New("db down")).
+					Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `price history query failed`,
+		},
 		{
 			name: "not found returns 404",
 			id:   "nonexistent",
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// KeyMerger is the engine surface the merge endpoint needs. The
+// production implementation is *engine.Engine, which recomputes the
+// merged baseline and re-scores its listings.
+type KeyMerger interface {
+	MergeProductKeys(ctx context.Context, from, to string) (int, error)
+}
+
+// ProductKeysHandler handles product key alias endpoints.
+type ProductKeysHandler struct {
+	store  store.Store
+	merger KeyMerger
+}
+
+// NewProductKeysHandler creates a new ProductKeysHandler.
+func NewProductKeysHandler(s store.Store, m KeyMerger) *ProductKeysHandler {
+	return &ProductKeysHandler{store: s, merger: m}
+}
+
+// ListProductKeyAliasesOutput is the response for listing aliases.
+type ListProductKeyAliasesOutput struct {
+	Body []domain.ProductKeyAlias
+}
+
+// ListAliases returns every product key alias, grouped by canonical key.
+func (h *ProductKeysHandler) ListAliases(
+	ctx context.Context,
+	_ *struct{},
+) (*ListProductKeyAliasesOutput, error) {
+	aliases, err := h.store.ListProductKeyAliases(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("listing aliases failed: " + err.Error())
+	}
+	if aliases == nil {
+		aliases = []domain.ProductKeyAlias{}
+	}
+	return &ListProductKeyAliasesOutput{Body: aliases}, nil
+}
+
+// SimilarProductKeysInput is the input for suggesting merges.
+type SimilarProductKeysInput struct {
+	MaxDistance   int    `query:"max_distance"   doc:"Largest edit distance to suggest (default 2)" minimum:"0" maximum:"5"`
+	ComponentType string `query:"component_type" doc:"Only suggest keys of this component type"`
+}
+
+// SimilarProductKeysOutput is the response for suggesting merges.
+type SimilarProductKeysOutput struct {
+	Body []domain.ProductKeySuggestion
+}
+
+// Similar suggests near-duplicate product keys to merge.
+func (h *ProductKeysHandler) Similar(
+	ctx context.Context,
+	input *SimilarProductKeysInput,
+) (*SimilarProductKeysOutput, error) {
+	counts, err := h.store.ListProductKeyCounts(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("listing product keys failed: " + err.Error())
+	}
+	if input.ComponentType != "" {
+		prefix := input.ComponentType + ":"
+		filtered := counts[:0]
+		for _, c := range counts {
+			if strings.HasPrefix(c.ProductKey, prefix) {
+				filtered = append(filtered, c)
+			}
+		}
+		counts = filtered
+	}
+
+	suggestions := extract.SimilarProductKeys(counts, input.MaxDistance)
+	if suggestions == nil {
+		suggestions = []domain.ProductKeySuggestion{}
+	}
+	return &SimilarProductKeysOutput{Body: suggestions}, nil
+}
+
+// MergeProductKeysInput is the input for merging two product keys.
+type MergeProductKeysInput struct {
+	Body struct {
+		From string `json:"from" doc:"Product key to retire; becomes an alias" minLength:"1"`
+		To   string `json:"to"   doc:"Product key to keep"                   minLength:"1"`
+	}
+}
+
+// MergeProductKeysOutput is the response for merging two product keys.
+type MergeProductKeysOutput struct {
+	Body struct {
+		From          string `json:"from"`
+		To            string `json:"to"`
+		ListingsMoved int    `json:"listings_moved" doc:"Listings re-keyed from the alias"`
+	}
+}
+
+// Merge makes one product key an alias of another and backfills its
+// listings.
+func (h *ProductKeysHandler) Merge(
+	ctx context.Context,
+	input *MergeProductKeysInput,
+) (*MergeProductKeysOutput, error) {
+	moved, err := h.merger.MergeProductKeys(ctx, input.Body.From, input.Body.To)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidKeyMerge) {
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		}
+		return nil, huma.Error500InternalServerError("merge failed: " + err.Error())
+	}
+
+	resp := &MergeProductKeysOutput{}
+	resp.Body.From = input.Body.From
+	resp.Body.To = input.Body.To
+	resp.Body.ListingsMoved = moved
+	return resp, nil
+}
+
+// RegisterProductKeyRoutes registers product key alias endpoints with
+// the Huma API.
+func RegisterProductKeyRoutes(api huma.API, h *ProductKeysHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-product-key-aliases",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/product-keys/aliases",
+		Summary:     "List product key aliases",
+		Description: "Returns every merged product key and the canonical key it resolves to.",
+		Tags:        []string{"product-keys"},
+	}, h.ListAliases)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "similar-product-keys",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/product-keys/similar",
+		Summary:     "Suggest product key merges",
+		Description: "Suggests near-duplicate product keys by edit distance. Keys whose digits differ are never suggested.",
+		Tags:        []string{"product-keys"},
+	}, h.Similar)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "merge-product-keys",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/product-keys/merge",
+		Summary:     "Merge product keys",
+		Description: "Makes `from` an alias of `to`, moves its listings, recomputes the baseline and re-scores.",
+		Tags:        []string{"product-keys"},
+		Errors:      []int{http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	}, h.Merge)
+}
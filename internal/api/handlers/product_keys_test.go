@@ -0,0 +1,197 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// fakeKeyMerger satisfies handlers.KeyMerger. Merge validation and the
+// baseline/rescore follow-up are exercised in the engine package.
+type fakeKeyMerger struct {
+	moved    int
+	err      error
+	from, to string
+}
+
+func (f *fakeKeyMerger) MergeProductKeys(_ context.Context, from, to string) (int, error) {
+	f.from, f.to = from, to
+	return f.moved, f.err
+}
+
+func TestProductKeysHandler_ListAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "returns aliases",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListProductKeyAliases(mock.Anything).Return([]domain.ProductKeyAlias{
+					{Alias: "cpu:intel:xeon:e5-2690v4", Canonical: "cpu:intel:xeon:e5-2690_v4"},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"canonical":"cpu:intel:xeon:e5-2690_v4"`,
+		},
+		{
+			name: "none returns empty array",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListProductKeyAliases(mock.Anything).Return(nil, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name: "store error returns 500",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListProductKeyAliases(mock.Anything).Return(nil, errors.New("db error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "listing aliases failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterProductKeyRoutes(api, handlers.NewProductKeysHandler(ms, &fakeKeyMerger{}))
+
+			resp := api.Get("/api/v1/product-keys/aliases")
+			require.Equal(t, tt.wantStatus, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestProductKeysHandler_Similar(t *testing.T) {
+	t.Parallel()
+
+	counts := []domain.ProductKeyCount{
+		{ProductKey: "cpu:intel:xeon:e5-2690_v4", Listings: 40},
+		{ProductKey: "cpu:intel:xeon:e5-2690v4", Listings: 3},
+		{ProductKey: "ram:ddr4:ecc_reg:32gb:2666", Listings: 50},
+		{ProductKey: "ram:ddr4:ecc-reg:32gb:2666", Listings: 2},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   []string
+		notBody    []string
+	}{
+		{
+			name:       "suggests across component types",
+			path:       "/api/v1/product-keys/similar",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`"from":"cpu:intel:xeon:e5-2690v4"`, `"from":"ram:ddr4:ecc-reg:32gb:2666"`},
+		},
+		{
+			name:       "component_type filters suggestions",
+			path:       "/api/v1/product-keys/similar?component_type=ram&max_distance=1",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`"to":"ram:ddr4:ecc_reg:32gb:2666"`, `"punctuation_only":true`},
+			notBody:    []string{"cpu:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			ms.EXPECT().ListProductKeyCounts(mock.Anything).Return(append([]domain.ProductKeyCount(nil), counts...), nil).Once()
+
+			_, api := humatest.New(t)
+			handlers.RegisterProductKeyRoutes(api, handlers.NewProductKeysHandler(ms, &fakeKeyMerger{}))
+
+			resp := api.Get(tt.path)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, resp.Body.String(), want)
+			}
+			for _, not := range tt.notBody {
+				assert.NotContains(t, resp.Body.String(), not)
+			}
+		})
+	}
+}
+
+func TestProductKeysHandler_Merge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		merger     *fakeKeyMerger
+		body       map[string]any
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "merges keys",
+			merger:     &fakeKeyMerger{moved: 3},
+			body:       map[string]any{"from": "ram:a", "to": "ram:b"},
+			wantStatus: http.StatusOK,
+			wantBody:   `"listings_moved":3`,
+		},
+		{
+			name:       "invalid merge returns 422",
+			merger:     &fakeKeyMerger{err: fmt.Errorf("%w: cycle", engine.ErrInvalidKeyMerge)},
+			body:       map[string]any{"from": "ram:a", "to": "ram:b"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "cycle",
+		},
+		{
+			name:       "missing to returns 422",
+			merger:     &fakeKeyMerger{},
+			body:       map[string]any{"from": "ram:a"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "engine error returns 500",
+			merger:     &fakeKeyMerger{err: errors.New("db down")},
+			body:       map[string]any{"from": "ram:a", "to": "ram:b"},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "merge failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, api := humatest.New(t)
+			handlers.RegisterProductKeyRoutes(api, handlers.NewProductKeysHandler(storeMocks.NewMockStore(t), tt.merger))
+
+			resp := api.Post("/api/v1/product-keys/merge", tt.body)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.wantBody)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "ram:a", tt.merger.from)
+				assert.Equal(t, "ram:b", tt.merger.to)
+			}
+		})
+	}
+}
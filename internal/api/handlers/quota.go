@@ -8,16 +8,24 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 
 	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
+// BudgetPlanSource exposes the ingestion budget planner's last plan.
+type BudgetPlanSource interface {
+	LastBudgetPlan() *domain.BudgetPlan
+}
+
 // QuotaHandler provides the eBay API quota status endpoint.
 type QuotaHandler struct {
-	rl *ebay.RateLimiter
+	rl    *ebay.RateLimiter
+	plans BudgetPlanSource
 }
 
-// NewQuotaHandler creates a new QuotaHandler.
-func NewQuotaHandler(rl *ebay.RateLimiter) *QuotaHandler {
-	return &QuotaHandler{rl: rl}
+// NewQuotaHandler creates a new QuotaHandler. plans may be nil when the
+// engine is disabled; the response then has no plan.
+func NewQuotaHandler(rl *ebay.RateLimiter, plans BudgetPlanSource) *QuotaHandler {
+	return &QuotaHandler{rl: rl, plans: plans}
 }
 
 // QuotaOutput is the response body for the quota endpoint.
@@ -27,12 +35,18 @@ type QuotaOutput struct {
 		DailyUsed  int64     `json:"daily_used"  example:"142"                     doc:"API calls used in the current 24-hour window"`
 		Remaining  int64     `json:"remaining"   example:"4858"                    doc:"API calls remaining in the current window"`
 		ResetAt    time.Time `json:"reset_at"    example:"2025-06-16T14:30:00Z"    doc:"When the current 24-hour window expires"`
+		// Plan is the last ingestion cycle's budget: planned pages
+		// against pages actually used, overall and per watch.
+		Plan *domain.BudgetPlan `json:"plan,omitempty" doc:"Last ingestion cycle's planned versus used page budget"`
 	}
 }
 
 // GetQuota returns the current eBay API quota status.
 func (h *QuotaHandler) GetQuota(_ context.Context, _ *struct{}) (*QuotaOutput, error) {
 	resp := &QuotaOutput{}
+	if h.plans != nil {
+		resp.Body.Plan = h.plans.LastBudgetPlan()
+	}
 	if h.rl == nil {
 		return resp, nil
 	}
@@ -52,7 +66,7 @@ func RegisterQuotaRoutes(api huma.API, h *QuotaHandler) {
 		Method:      http.MethodGet,
 		Path:        "/api/v1/quota",
 		Summary:     "Get eBay API quota status",
-		Description: "Returns the current daily API call usage, remaining quota, and window reset time.",
+		Description: "Returns the current daily API call usage, remaining quota, window reset time, and the last ingestion cycle's planned versus used page budget.",
 		Tags:        []string{"ebay"},
 	}, h.GetQuota)
 }
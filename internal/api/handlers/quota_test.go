@@ -11,6 +11,7 @@ import (
 
 	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
 	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
 func TestGetQuota(t *testing.T) {
@@ -65,7 +66,7 @@ func TestGetQuota(t *testing.T) {
 				}
 			}
 
-			h := handlers.NewQuotaHandler(tt.rl)
+			h := handlers.NewQuotaHandler(tt.rl, nil)
 
 			_, api := humatest.New(t)
 			handlers.RegisterQuotaRoutes(api, h)
@@ -91,7 +92,7 @@ func TestGetQuota_ResetAtValue(t *testing.T) {
 		ebay.WithRateLimiterNowFunc(func() time.Time { return now }),
 	)
 
-	h := handlers.NewQuotaHandler(rl)
+	h := handlers.NewQuotaHandler(rl, nil)
 
 	_, api := humatest.New(t)
 	handlers.RegisterQuotaRoutes(api, h)
@@ -103,3 +104,72 @@ func TestGetQuota_ResetAtValue(t *testing.T) {
 	body := resp.Body.String()
 	assert.Contains(t, body, "2025-06-16T14:30:00Z")
 }
+
+type stubPlanSource struct {
+	plan *domain.BudgetPlan
+}
+
+func (s stubPlanSource) LastBudgetPlan() *domain.BudgetPlan { return s.plan }
+
+func TestGetQuota_BudgetPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		plans handlers.BudgetPlanSource
+		check func(t *testing.T, body string)
+	}{
+		{
+			name:  "no engine omits the plan",
+			plans: nil,
+			check: func(t *testing.T, body string) {
+				t.Helper()
+				assert.NotContains(t, body, `"plan"`)
+			},
+		},
+		{
+			name:  "before the first cycle omits the plan",
+			plans: stubPlanSource{},
+			check: func(t *testing.T, body string) {
+				t.Helper()
+				assert.NotContains(t, body, `"plan"`)
+			},
+		},
+		{
+			name: "last cycle's plan",
+			plans: stubPlanSource{plan: &domain.BudgetPlan{
+				Adaptive:    true,
+				CyclesLeft:  288,
+				CycleBudget: 17,
+				CycleUsed:   9,
+				Watches: []domain.BudgetAllocation{
+					{WatchID: "w1", Name: "DDR4", Weight: 4.5, PagesPlanned: 12, PagesUsed: 8},
+					{WatchID: "w2", Name: "rails", Weight: 0.5, PagesPlanned: 5, PagesUsed: 1},
+				},
+			}},
+			check: func(t *testing.T, body string) {
+				t.Helper()
+				assert.Contains(t, body, `"cycle_budget":17`)
+				assert.Contains(t, body, `"cycle_used":9`)
+				assert.Contains(t, body, `"cycles_left":288`)
+				assert.Contains(t, body, `"pages_planned":12`)
+				assert.Contains(t, body, `"pages_used":8`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := handlers.NewQuotaHandler(ebay.NewRateLimiter(100, 10, 5000), tt.plans)
+
+			_, api := humatest.New(t)
+			handlers.RegisterQuotaRoutes(api, h)
+
+			resp := api.Get("/api/v1/quota")
+			require.Equal(t, http.StatusOK, resp.Code)
+			tt.check(t, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// SellersHandler handles seller lookup endpoints.
+type SellersHandler struct {
+	store store.Store
+}
+
+// NewSellersHandler creates a new SellersHandler.
+func NewSellersHandler(s store.Store) *SellersHandler {
+	return &SellersHandler{store: s}
+}
+
+// GetSellerInput is the input for getting a seller.
+type GetSellerInput struct {
+	Name  string `path:"name"  doc:"eBay seller username"`
+	Limit int    `query:"limit" doc:"Number of active listings to return (default 50)" minimum:"1" maximum:"500"`
+}
+
+// GetSellerOutput is the response for getting a seller. The seller's
+// fields are inlined alongside its active listings, highest score
+// first.
+type GetSellerOutput struct {
+	Body struct {
+		domain.Seller
+		Listings []domain.Listing `json:"listings"`
+	}
+}
+
+// GetSeller returns a seller's reputation history, listing and alert
+// counts, and active listings.
+func (h *SellersHandler) GetSeller(
+	ctx context.Context,
+	input *GetSellerInput,
+) (*GetSellerOutput, error) {
+	seller, err := h.store.GetSeller(ctx, input.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, huma.Error404NotFound("seller not found")
+		}
+		return nil, huma.Error500InternalServerError("seller query failed: " + err.Error())
+	}
+
+	listings, _, err := h.store.ListListings(ctx, &store.ListingQuery{
+		SellerName: &seller.Name,
+		ActiveOnly: true,
+		OrderBy:    "score",
+		Limit:      input.Limit,
+	})
+	if err != nil {
+		return nil, huma.Error500InternalServerError("listing query failed: " + err.Error())
+	}
+	if listings == nil {
+		listings = []domain.Listing{}
+	}
+
+	resp := &GetSellerOutput{}
+	resp.Body.Seller = *seller
+	resp.Body.Listings = listings
+	return resp, nil
+}
+
+// RegisterSellerRoutes registers seller endpoints with the Huma API.
+func RegisterSellerRoutes(api huma.API, h *SellersHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-seller",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/sellers/{name}",
+		Summary:     "Get a seller",
+		Description: "Returns a seller's feedback history, listing and alert counts, and active listings.",
+		Tags:        []string{"sellers"},
+		Errors:      []int{http.StatusNotFound},
+	}, h.GetSeller)
+}
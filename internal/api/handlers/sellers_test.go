@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestSellersHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	seller := &domain.Seller{
+		Name:            "server_parts_inc",
+		FeedbackScore:   5432,
+		FeedbackPct:     99.8,
+		ListingsTotal:   40,
+		ListingsActive:  12,
+		AlertsTotal:     4,
+		AlertsDismissed: 1,
+		AlertRate:       0.1,
+		DismissalRate:   0.25,
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name: "found returns seller and active listings",
+			path: "/api/v1/sellers/server_parts_inc?limit=5",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetSeller(mock.Anything, "server_parts_inc").
+					Return(seller, nil).
+					Once()
+				m.EXPECT().
+					ListListings(mock.Anything, mock.MatchedBy(func(q *store.ListingQuery) bool {
+						return q.SellerName != nil && *q.SellerName == "server_parts_inc" &&
+							q.ActiveOnly && q.Limit == 5
+					})).
+					Return([]domain.Listing{{ID: "l1", Title: "Samsung 32GB DDR4"}}, 1, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				`"name":"server_parts_inc"`,
+				`"dismissal_rate":0.25`,
+				`Samsung 32GB DDR4`,
+			},
+		},
+		{
+			name: "no active listings returns empty array",
+			path: "/api/v1/sellers/server_parts_inc",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetSeller(mock.Anything, "server_parts_inc").Return(seller, nil).Once()
+				m.EXPECT().ListListings(mock.Anything, mock.Anything).Return(nil, 0, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{`"listings":[]`},
+		},
+		{
+			name: "unknown seller returns 404",
+			path: "/api/v1/sellers/nobody",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetSeller(mock.Anything, "nobody").
+					Return(nil, fmt.Errorf("seller nobody: %w", pgx.ErrNoRows)).
+					Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "store error returns 500",
+			path: "/api/v1/sellers/server_parts_inc",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetSeller(mock.Anything, "server_parts_inc").
+					Return(nil, errors.New("db error")).
+					Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"seller query failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+			h := handlers.NewSellersHandler(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterSellerRoutes(api, h)
+
+			resp := api.Get(tt.path)
+			require.Equal(t, tt.wantStatus, resp.Code)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, resp.Body.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// ShadowReportStore aggregates shadow extraction comparisons.
+type ShadowReportStore interface {
+	GetShadowReport(ctx context.Context, q *store.ShadowReportQuery) (*domain.ShadowReport, error)
+}
+
+// ShadowHandler handles the shadow extraction comparison report.
+type ShadowHandler struct {
+	store ShadowReportStore
+}
+
+// NewShadowHandler creates a new ShadowHandler.
+func NewShadowHandler(s ShadowReportStore) *ShadowHandler {
+	return &ShadowHandler{store: s}
+}
+
+// ShadowReportInput is the input for the shadow extraction report.
+type ShadowReportInput struct {
+	Window        string `query:"window"         example:"7d" doc:"Look-back window: Go duration or whole days with a d suffix (default 30d, max 365d)"`
+	ShadowModel   string `query:"shadow_model"   doc:"Only comparisons against this shadow model"`
+	PromptVersion string `query:"prompt_version" doc:"Only comparisons with this shadow prompt version"`
+	Examples      int    `query:"examples"       doc:"Most recent disagreeing comparisons to include" default:"20" minimum:"0" maximum:"200"`
+}
+
+// ShadowReportOutput is the response for the shadow extraction report.
+type ShadowReportOutput struct {
+	Body *domain.ShadowReport
+}
+
+// Report compares shadow extractions against the live extractions they
+// repeated: agreement on component type, product key and attributes,
+// and the token, cost and latency difference.
+func (h *ShadowHandler) Report(
+	ctx context.Context,
+	input *ShadowReportInput,
+) (*ShadowReportOutput, error) {
+	window, err := ParseStatsWindow(input.Window)
+	if err != nil {
+		return nil, huma.Error422UnprocessableEntity(err.Error(), &huma.ErrorDetail{
+			Location: "query.window",
+			Message:  err.Error(),
+			Value:    input.Window,
+		})
+	}
+
+	r, err := h.store.GetShadowReport(ctx, &store.ShadowReportQuery{
+		Since:         time.Now().Add(-window),
+		ShadowModel:   input.ShadowModel,
+		PromptVersion: input.PromptVersion,
+		Examples:      input.Examples,
+	})
+	if err != nil {
+		return nil, huma.Error500InternalServerError("getting shadow report: " + err.Error())
+	}
+	fillShadowRates(r)
+	return &ShadowReportOutput{Body: r}, nil
+}
+
+// fillShadowRates derives the report's agreement rates and cost delta
+// from its counts.
+func fillShadowRates(r *domain.ShadowReport) {
+	r.Compared = r.Samples - r.ShadowErrors
+	if r.Compared > 0 {
+		r.ComponentTypeAgreement = float64(r.ComponentTypeMatches) / float64(r.Compared)
+		r.ProductKeyAgreement = float64(r.ProductKeyMatches) / float64(r.Compared)
+		r.FullAgreement = float64(r.FullMatches) / float64(r.Compared)
+	}
+	for i := range r.Fields {
+		if r.ComponentTypeMatches > 0 {
+			r.Fields[i].Rate = float64(r.Fields[i].Disagreements) / float64(r.ComponentTypeMatches)
+		}
+	}
+	for i := range r.Components {
+		if c := &r.Components[i]; c.Compared > 0 {
+			c.ProductKeyAgreement = float64(c.ProductKeyMatches) / float64(c.Compared)
+		}
+	}
+	r.CostDeltaUSD = r.ShadowCostUSD - r.PrimaryCostUSD
+	if r.PrimaryCostUSD > 0 {
+		pct := r.CostDeltaUSD / r.PrimaryCostUSD * 100
+		r.CostDeltaPct = &pct
+	}
+}
+
+// RegisterShadowRoutes registers the shadow extraction report endpoint
+// with the Huma API.
+func RegisterShadowRoutes(api huma.API, h *ShadowHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "shadow-extraction-report",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/extraction/shadow/report",
+		Summary:     "Compare shadow extractions with live extractions",
+		Description: "Aggregates the sampled live extractions repeated through the shadow backend, model or prompt set " +
+			"(llm.shadow): agreement on component type, product key and each attribute, and the token, cost and " +
+			"latency difference.",
+		Tags:   []string{"extract"},
+		Errors: []int{http.StatusUnprocessableEntity},
+	}, h.Report)
+}
@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+type fakeShadowReportStore struct {
+	report *domain.ShadowReport
+	err    error
+	got    *store.ShadowReportQuery
+}
+
+func (f *fakeShadowReportStore) GetShadowReport(
+	_ context.Context,
+	q *store.ShadowReportQuery,
+) (*domain.ShadowReport, error) {
+	f.got = q
+	return f.report, f.err
+}
+
+func TestShadowReport(t *testing.T) {
+	t.Parallel()
+
+	fs := &fakeShadowReportStore{report: &domain.ShadowReport{
+		Samples:              12,
+		ShadowErrors:         2,
+		ComponentTypeMatches: 8,
+		ProductKeyMatches:    6,
+		FullMatches:          5,
+		Fields:               []domain.ShadowFieldStat{{Field: "model", Disagreements: 2}},
+		Components: []domain.ShadowComponentStat{
+			{ComponentType: domain.ComponentRAM, Compared: 4, ComponentTypeMatches: 4, ProductKeyMatches: 3},
+		},
+		CostedSamples:  10,
+		PrimaryCostUSD: 0.02,
+		ShadowCostUSD:  0.005,
+		Examples:       []domain.ShadowExtraction{},
+	}}
+
+	_, api := humatest.New(t)
+	handlers.RegisterShadowRoutes(api, handlers.NewShadowHandler(fs))
+
+	before := time.Now()
+	resp := api.Get("/api/v1/extraction/shadow/report?window=7d&shadow_model=haiku&prompt_version=v2&examples=5")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	require.NotNil(t, fs.got)
+	assert.Equal(t, "haiku", fs.got.ShadowModel)
+	assert.Equal(t, "v2", fs.got.PromptVersion)
+	assert.Equal(t, 5, fs.got.Examples)
+	assert.WithinDuration(t, before.Add(-7*24*time.Hour), fs.got.Since, time.Minute)
+
+	var r domain.ShadowReport
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &r))
+	assert.Equal(t, 10, r.Compared)
+	assert.InDelta(t, 0.8, r.ComponentTypeAgreement, 1e-9)
+	assert.InDelta(t, 0.6, r.ProductKeyAgreement, 1e-9)
+	assert.InDelta(t, 0.5, r.FullAgreement, 1e-9)
+	assert.InDelta(t, 0.25, r.Fields[0].Rate, 1e-9)
+	assert.InDelta(t, 0.75, r.Components[0].ProductKeyAgreement, 1e-9)
+	assert.InDelta(t, -0.015, r.CostDeltaUSD, 1e-9)
+	require.NotNil(t, r.CostDeltaPct)
+	assert.InDelta(t, -75.0, *r.CostDeltaPct, 1e-9)
+}
+
+func TestShadowReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		storeErr error
+		wantCode int
+	}{
+		{name: "bad window", path: "/api/v1/extraction/shadow/report?window=soon", wantCode: http.StatusUnprocessableEntity},
+		{name: "store error", path: "/api/v1/extraction/shadow/report", storeErr: errors.New("db down"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, api := humatest.New(t)
+			handlers.RegisterShadowRoutes(api, handlers.NewShadowHandler(&fakeShadowReportStore{err: tt.storeErr}))
+
+			resp := api.Get(tt.path)
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
@@ -13,11 +16,31 @@ import (
 // WatchHandler handles Watch CRUD operations.
 type WatchHandler struct {
 	store store.Store
+	// sellerBlocklist and sellerAllowlist are the global alerts.sellers
+	// lists, which previews apply as alert evaluation does.
+	sellerBlocklist []string
+	sellerAllowlist []string
+}
+
+// WatchHandlerOption configures a WatchHandler.
+type WatchHandlerOption func(*WatchHandler)
+
+// WithGlobalSellerLists sets the alerts.sellers blocklist and allowlist
+// that apply to every watch on top of its own seller filters.
+func WithGlobalSellerLists(blocklist, allowlist []string) WatchHandlerOption {
+	return func(h *WatchHandler) {
+		h.sellerBlocklist = blocklist
+		h.sellerAllowlist = allowlist
+	}
 }
 
 // NewWatchHandler creates a new WatchHandler.
-func NewWatchHandler(s store.Store) *WatchHandler {
-	return &WatchHandler{store: s}
+func NewWatchHandler(s store.Store, opts ...WatchHandlerOption) *WatchHandler {
+	h := &WatchHandler{store: s}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // --- Input/Output types ---
@@ -52,6 +75,10 @@ type CreateWatchInput struct {
 		Filters        domain.WatchFilters  `json:"filters,omitempty" doc:"Watch filters"`
 		ScoreThreshold int                  `json:"score_threshold,omitempty" doc:"Score threshold for alerts"`
 		Enabled        bool                 `json:"enabled,omitempty" doc:"Whether the watch is enabled"`
+		PollInterval   domain.Duration      `json:"poll_interval,omitempty" example:"15m" doc:"How often to poll eBay (e.g. 5m, 2h, 1d); empty = schedule.ingestion_interval"`
+		Priority       int                  `json:"priority,omitempty" doc:"Higher-priority watches poll first when the cycle budget is short"`
+		Marketplace    string               `json:"marketplace,omitempty" example:"EBAY_GB" doc:"eBay marketplace to search; empty = ebay.marketplace"`
+		RealertDropPct float64              `json:"realert_drop_pct,omitempty" example:"15" doc:"Alert again inside the re-alert cooldown when the price drops by at least this percent; 0 = never"`
 	}
 }
 
@@ -71,6 +98,10 @@ type UpdateWatchInput struct {
 		Filters        domain.WatchFilters  `json:"filters,omitempty" doc:"Watch filters"`
 		ScoreThreshold int                  `json:"score_threshold,omitempty" doc:"Score threshold for alerts"`
 		Enabled        bool                 `json:"enabled,omitempty" doc:"Whether the watch is enabled"`
+		PollInterval   domain.Duration      `json:"poll_interval,omitempty" example:"15m" doc:"How often to poll eBay (e.g. 5m, 2h, 1d); empty = schedule.ingestion_interval"`
+		Priority       int                  `json:"priority,omitempty" doc:"Higher-priority watches poll first when the cycle budget is short"`
+		Marketplace    string               `json:"marketplace,omitempty" example:"EBAY_GB" doc:"eBay marketplace to search; empty = ebay.marketplace"`
+		RealertDropPct float64              `json:"realert_drop_pct,omitempty" example:"15" doc:"Alert again inside the re-alert cooldown when the price drops by at least this percent; 0 = never"`
 	}
 }
 
@@ -101,6 +132,141 @@ type DeleteWatchInput struct {
 
 // --- Handlers ---
 
+// validateFilters rejects a filter expression that doesn't compile. The
+// error detail carries the column so API and CLI callers can point at
+// the offending token. Fields eBay receives verbatim are checked too, so
+// a typo fails here rather than on every poll.
+func validateFilters(f *domain.WatchFilters) error {
+	if err := f.Validate(); err != nil {
+		return huma.Error422UnprocessableEntity(
+			"invalid filter expression: "+err.Error(),
+			&huma.ErrorDetail{
+				Location: "body.filters.expr",
+				Message:  err.Error(),
+				Value:    f.Expr,
+			},
+		)
+	}
+	if details := filterFieldErrors("body.filters", f); len(details) > 0 {
+		return huma.Error422UnprocessableEntity("invalid filters", details...)
+	}
+	return nil
+}
+
+// filterFieldErrors checks the filter fields pushed to the eBay Browse
+// API, and the ranges of max_risk, min_partout_ratio and
+// max_price_per_unit. loc is the location of the filters object.
+func filterFieldErrors(loc string, f *domain.WatchFilters) []error {
+	var details []error
+	for i, opt := range f.BuyingOptions {
+		if !validBuyingOption(opt) {
+			details = append(details, &huma.ErrorDetail{
+				Location: fmt.Sprintf("%s.buying_options[%d]", loc, i),
+				Message:  "buying option must be auction, buy_it_now or best_offer",
+				Value:    opt,
+			})
+		}
+	}
+	if c := f.ItemLocationCountry; c != "" && !validCountryCode(c) {
+		details = append(details, &huma.ErrorDetail{
+			Location: loc + ".item_location_country",
+			Message:  "item_location_country must be an ISO 3166 alpha-2 code like US",
+			Value:    c,
+		})
+	}
+	if r := f.MaxRisk; r != nil && (*r < 0 || *r > 100) {
+		details = append(details, &huma.ErrorDetail{
+			Location: loc + ".max_risk",
+			Message:  "max_risk must be between 0 and 100",
+			Value:    *r,
+		})
+	}
+	if r := f.MinPartOutRatio; r != nil && *r <= 0 {
+		details = append(details, &huma.ErrorDetail{
+			Location: loc + ".min_partout_ratio",
+			Message:  "min_partout_ratio must be greater than 0",
+			Value:    *r,
+		})
+	}
+	if p := f.MaxPricePerUnit; p != nil && *p <= 0 {
+		details = append(details, &huma.ErrorDetail{
+			Location: loc + ".max_price_per_unit",
+			Message:  "max_price_per_unit must be greater than 0",
+			Value:    *p,
+		})
+	}
+	return details
+}
+
+func validBuyingOption(t domain.ListingType) bool {
+	switch t {
+	case domain.ListingAuction, domain.ListingBuyItNow, domain.ListingBestOffer:
+		return true
+	}
+	return false
+}
+
+func validCountryCode(c string) bool {
+	return len(c) == 2 && c[0] >= 'A' && c[0] <= 'Z' && c[1] >= 'A' && c[1] <= 'Z'
+}
+
+// minWatchPollInterval keeps a per-watch interval from spending the
+// daily eBay quota on one search.
+const minWatchPollInterval = time.Minute
+
+// pollIntervalError describes why d isn't a usable poll interval, or
+// returns "" when it is. Zero means "use the global interval".
+func pollIntervalError(d domain.Duration) string {
+	if d != 0 && time.Duration(d) < minWatchPollInterval {
+		return "poll_interval must be 0 (use schedule.ingestion_interval) or at least 1m"
+	}
+	return ""
+}
+
+// marketplaceError describes why m isn't a marketplace watches can
+// search, or returns "" when it is. Empty means ebay.marketplace.
+func marketplaceError(m string) string {
+	if _, ok := ebay.MarketplaceCurrency(m); m != "" && !ok {
+		return "marketplace must be a supported eBay marketplace ID such as EBAY_US, EBAY_GB or EBAY_DE"
+	}
+	return ""
+}
+
+// realertDropError describes why pct isn't a usable re-alert drop
+// percentage, or returns "" when it is. Zero disables drop re-alerts.
+func realertDropError(pct float64) string {
+	if pct < 0 || pct >= 100 {
+		return "realert_drop_pct must be at least 0 (disabled) and below 100"
+	}
+	return ""
+}
+
+// validateWatch runs the checks shared by create and update.
+func validateWatch(w *domain.Watch) error {
+	if msg := pollIntervalError(w.PollInterval); msg != "" {
+		return huma.Error422UnprocessableEntity(msg, &huma.ErrorDetail{
+			Location: "body.poll_interval",
+			Message:  msg,
+			Value:    w.PollInterval.String(),
+		})
+	}
+	if msg := marketplaceError(w.Marketplace); msg != "" {
+		return huma.Error422UnprocessableEntity(msg, &huma.ErrorDetail{
+			Location: "body.marketplace",
+			Message:  msg,
+			Value:    w.Marketplace,
+		})
+	}
+	if msg := realertDropError(w.RealertDropPct); msg != "" {
+		return huma.Error422UnprocessableEntity(msg, &huma.ErrorDetail{
+			Location: "body.realert_drop_pct",
+			Message:  msg,
+			Value:    w.RealertDropPct,
+		})
+	}
+	return validateFilters(&w.Filters)
+}
+
 // ListWatches returns all watches, optionally filtered by enabled status.
 func (h *WatchHandler) ListWatches(
 	ctx context.Context,
@@ -143,6 +309,14 @@ func (h *WatchHandler) CreateWatch(
 		Filters:        input.Body.Filters,
 		ScoreThreshold: input.Body.ScoreThreshold,
 		Enabled:        input.Body.Enabled,
+		PollInterval:   input.Body.PollInterval,
+		Priority:       input.Body.Priority,
+		Marketplace:    input.Body.Marketplace,
+		RealertDropPct: input.Body.RealertDropPct,
+	}
+
+	if err := validateWatch(w); err != nil {
+		return nil, err
 	}
 
 	if err := h.store.CreateWatch(ctx, w); err != nil {
@@ -166,6 +340,14 @@ func (h *WatchHandler) UpdateWatch(
 		Filters:        input.Body.Filters,
 		ScoreThreshold: input.Body.ScoreThreshold,
 		Enabled:        input.Body.Enabled,
+		PollInterval:   input.Body.PollInterval,
+		Priority:       input.Body.Priority,
+		Marketplace:    input.Body.Marketplace,
+		RealertDropPct: input.Body.RealertDropPct,
+	}
+
+	if err := validateWatch(w); err != nil {
+		return nil, err
 	}
 
 	if err := h.store.UpdateWatch(ctx, w); err != nil {
@@ -262,4 +444,40 @@ func RegisterWatchRoutes(api huma.API, h *WatchHandler) {
 		Tags:        []string{"watches"},
 		Errors:      []int{http.StatusInternalServerError},
 	}, h.DeleteWatch)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "apply-watches",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/watches/apply",
+		Summary:     "Apply a watch manifest",
+		Description: "Creates, updates and (with prune) deletes watches so the server " +
+			"matches a manifest keyed by watch name. The whole plan is written in one " +
+			"transaction; dry_run returns the plan without writing.",
+		Tags:   []string{"watches"},
+		Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	}, h.ApplyWatches)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "preview-watch",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/watches/preview",
+		Summary:     "Preview a watch against stored listings",
+		Description: "Evaluates a new or modified watch (filters and score threshold) against " +
+			"current active listings and returns would-be alerts and near misses. " +
+			"No alerts are created.",
+		Tags:   []string{"watches"},
+		Errors: []int{http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	}, h.PreviewWatch)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-watch-stats",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/watches/{id}/stats",
+		Summary:     "Get per-watch analytics",
+		Description: "Returns polls, eBay pages used, listings ingested, alerts created, " +
+			"notified and dismissed, the median judge score and the best deal found " +
+			"for one watch over a look-back window.",
+		Tags:   []string{"watches"},
+		Errors: []int{http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+	}, h.GetWatchStats)
 }
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// ApplyWatchesInput is the input for a declarative watch apply.
+type ApplyWatchesInput struct {
+	Body struct {
+		domain.WatchManifest
+		Prune  bool `json:"prune,omitempty" doc:"Delete server watches whose name is not in the manifest"`
+		DryRun bool `json:"dry_run,omitempty" doc:"Return the plan without writing anything"`
+	}
+}
+
+// ApplyWatchesOutput is the plan that was (or, on dry run, would be) applied.
+type ApplyWatchesOutput struct {
+	Body domain.WatchApplyResult
+}
+
+// ApplyWatches converges the server's watch set onto a manifest keyed by
+// watch name. Watches are matched by name; the whole plan is written in
+// one transaction.
+func (h *WatchHandler) ApplyWatches(
+	ctx context.Context,
+	input *ApplyWatchesInput,
+) (*ApplyWatchesOutput, error) {
+	if err := validateManifest(&input.Body.WatchManifest); err != nil {
+		return nil, err
+	}
+
+	current, err := h.store.ListWatches(ctx, false)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("listing watches: " + err.Error())
+	}
+
+	result, set, err := planWatchApply(current, input.Body.Watches, input.Body.Prune)
+	if err != nil {
+		return nil, huma.Error409Conflict(err.Error())
+	}
+	result.DryRun = input.Body.DryRun
+
+	if !result.DryRun && len(set.Create)+len(set.Update)+len(set.Delete) > 0 {
+		if err := h.store.ApplyWatches(ctx, set); err != nil {
+			return nil, huma.Error500InternalServerError("applying watches: " + err.Error())
+		}
+		// Surface the IDs the transaction assigned to new watches.
+		created := make(map[string]string, len(set.Create))
+		for _, w := range set.Create {
+			created[w.Name] = w.ID
+		}
+		for i := range result.Changes {
+			if id, ok := created[result.Changes[i].Name]; ok {
+				result.Changes[i].ID = id
+			}
+		}
+	}
+
+	return &ApplyWatchesOutput{Body: *result}, nil
+}
+
+// validateManifest checks every spec up front so a manifest with one bad
+// entry is rejected as a whole, with a location per offending watch.
+func validateManifest(m *domain.WatchManifest) error {
+	var details []error
+	for _, name := range sortedSpecNames(m.Watches) {
+		spec := m.Watches[name]
+		loc := "body.watches." + name
+		if strings.TrimSpace(name) == "" {
+			details = append(details, &huma.ErrorDetail{Location: loc, Message: "watch name must not be empty"})
+		}
+		if strings.TrimSpace(spec.SearchQuery) == "" {
+			details = append(details, &huma.ErrorDetail{
+				Location: loc + ".search_query",
+				Message:  "search_query is required",
+			})
+		}
+		if msg := pollIntervalError(spec.PollInterval); msg != "" {
+			details = append(details, &huma.ErrorDetail{
+				Location: loc + ".poll_interval",
+				Message:  msg,
+				Value:    spec.PollInterval.String(),
+			})
+		}
+		if msg := marketplaceError(spec.Marketplace); msg != "" {
+			details = append(details, &huma.ErrorDetail{
+				Location: loc + ".marketplace",
+				Message:  msg,
+				Value:    spec.Marketplace,
+			})
+		}
+		if msg := realertDropError(spec.RealertDropPct); msg != "" {
+			details = append(details, &huma.ErrorDetail{
+				Location: loc + ".realert_drop_pct",
+				Message:  msg,
+				Value:    spec.RealertDropPct,
+			})
+		}
+		details = append(details, filterFieldErrors(loc+".filters", &spec.Filters)...)
+		if err := spec.Filters.Validate(); err != nil {
+			details = append(details, &huma.ErrorDetail{
+				Location: loc + ".filters.expr",
+				Message:  err.Error(),
+				Value:    spec.Filters.Expr,
+			})
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return huma.Error422UnprocessableEntity("invalid watch manifest", details...)
+}
+
+// planWatchApply diffs the current watch set against the desired specs.
+// It fails when the server holds two watches with the same name, since
+// the manifest can't say which one it means.
+func planWatchApply(
+	current []domain.Watch,
+	desired map[string]domain.WatchSpec,
+	prune bool,
+) (*domain.WatchApplyResult, *store.WatchApplySet, error) {
+	byName := make(map[string]*domain.Watch, len(current))
+	for i := range current {
+		w := &current[i]
+		if _, dup := byName[w.Name]; dup {
+			return nil, nil, fmt.Errorf(
+				"multiple watches named %q on the server; rename or delete one before applying",
+				w.Name,
+			)
+		}
+		byName[w.Name] = w
+	}
+
+	result := &domain.WatchApplyResult{Changes: []domain.WatchChange{}}
+	set := &store.WatchApplySet{}
+
+	for _, name := range sortedSpecNames(desired) {
+		spec := desired[name]
+		want := spec.Watch(name)
+		have, ok := byName[name]
+		if !ok {
+			set.Create = append(set.Create, &want)
+			result.Changes = append(result.Changes, domain.WatchChange{
+				Name:   name,
+				Action: domain.WatchActionCreate,
+			})
+			continue
+		}
+
+		want.ID = have.ID
+		diff := diffWatch(have, &want)
+		action := domain.WatchActionUnchanged
+		if len(diff) > 0 {
+			action = domain.WatchActionUpdate
+			set.Update = append(set.Update, &want)
+		}
+		result.Changes = append(result.Changes, domain.WatchChange{
+			Name:   name,
+			ID:     have.ID,
+			Action: action,
+			Diff:   diff,
+		})
+	}
+
+	if prune {
+		for i := range current {
+			if _, keep := desired[current[i].Name]; keep {
+				continue
+			}
+			set.Delete = append(set.Delete, current[i].ID)
+			result.Changes = append(result.Changes, domain.WatchChange{
+				Name:   current[i].Name,
+				ID:     current[i].ID,
+				Action: domain.WatchActionDelete,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Name < result.Changes[j].Name
+	})
+	return result, set, nil
+}
+
+// diffWatch lists the manifest-managed fields that differ between the
+// stored watch and the desired one. Filters compare by JSON encoding so
+// nil and empty maps are treated alike.
+func diffWatch(have, want *domain.Watch) []domain.WatchFieldDiff {
+	var diff []domain.WatchFieldDiff
+	add := func(field string, oldVal, newVal any) {
+		diff = append(diff, domain.WatchFieldDiff{Field: field, Old: oldVal, New: newVal})
+	}
+
+	if have.SearchQuery != want.SearchQuery {
+		add("search_query", have.SearchQuery, want.SearchQuery)
+	}
+	if have.CategoryID != want.CategoryID {
+		add("category_id", have.CategoryID, want.CategoryID)
+	}
+	if have.ComponentType != want.ComponentType {
+		add("component_type", have.ComponentType, want.ComponentType)
+	}
+	if have.ScoreThreshold != want.ScoreThreshold {
+		add("score_threshold", have.ScoreThreshold, want.ScoreThreshold)
+	}
+	if have.Enabled != want.Enabled {
+		add("enabled", have.Enabled, want.Enabled)
+	}
+	if have.PollInterval != want.PollInterval {
+		add("poll_interval", have.PollInterval, want.PollInterval)
+	}
+	if have.Priority != want.Priority {
+		add("priority", have.Priority, want.Priority)
+	}
+	if have.Marketplace != want.Marketplace {
+		add("marketplace", have.Marketplace, want.Marketplace)
+	}
+	if have.RealertDropPct != want.RealertDropPct {
+		add("realert_drop_pct", have.RealertDropPct, want.RealertDropPct)
+	}
+	if oldF, newF := filtersJSON(&have.Filters), filtersJSON(&want.Filters); oldF != newF {
+		add("filters", json.RawMessage(oldF), json.RawMessage(newF))
+	}
+	return diff
+}
+
+func filtersJSON(f *domain.WatchFilters) string {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func sortedSpecNames(specs map[string]domain.WatchSpec) []string {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
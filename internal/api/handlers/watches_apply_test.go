@@ -0,0 +1,180 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func applyExistingWatches() []domain.Watch {
+	return []domain.Watch{
+		{
+			ID: "w1", Name: "ddr4", SearchQuery: "DDR4 ECC",
+			ComponentType: domain.ComponentRAM, ScoreThreshold: 75, Enabled: true,
+		},
+		{
+			ID: "w2", Name: "r630", SearchQuery: "Dell R630",
+			ComponentType: domain.ComponentServer, ScoreThreshold: 80, Enabled: true,
+		},
+		{
+			ID: "w3", Name: "stale", SearchQuery: "old query",
+			ComponentType: domain.ComponentCPU, ScoreThreshold: 75, Enabled: true,
+		},
+	}
+}
+
+func applyManifestBody(prune, dryRun bool) map[string]any {
+	return map[string]any{
+		"prune":   prune,
+		"dry_run": dryRun,
+		"watches": map[string]any{
+			// unchanged: defaults fill threshold 75 and enabled true
+			"ddr4": map[string]any{"search_query": "DDR4 ECC", "component_type": "ram"},
+			// updated threshold
+			"r630": map[string]any{"search_query": "Dell R630", "component_type": "server", "score_threshold": 85},
+			// new
+			"x520": map[string]any{"search_query": "Intel X520", "component_type": "nic"},
+		},
+	}
+}
+
+func TestWatchHandler_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       any
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		check      func(t *testing.T, r *domain.WatchApplyResult)
+		wantBody   string
+	}{
+		{
+			name: "dry run reports plan without writing",
+			body: applyManifestBody(true, true),
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListWatches(mock.Anything, false).Return(applyExistingWatches(), nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, r *domain.WatchApplyResult) {
+				t.Helper()
+				assert.True(t, r.DryRun)
+				require.Len(t, r.Changes, 4)
+				got := map[string]domain.WatchChangeAction{}
+				for _, c := range r.Changes {
+					got[c.Name] = c.Action
+				}
+				assert.Equal(t, map[string]domain.WatchChangeAction{
+					"ddr4":  domain.WatchActionUnchanged,
+					"r630":  domain.WatchActionUpdate,
+					"stale": domain.WatchActionDelete,
+					"x520":  domain.WatchActionCreate,
+				}, got)
+				require.Len(t, r.Changes[1].Diff, 1)
+				assert.Equal(t, "score_threshold", r.Changes[1].Diff[0].Field)
+			},
+		},
+		{
+			name: "apply writes one set and returns created IDs",
+			body: applyManifestBody(false, false),
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListWatches(mock.Anything, false).Return(applyExistingWatches(), nil).Once()
+				m.EXPECT().
+					ApplyWatches(mock.Anything, mock.MatchedBy(func(s *store.WatchApplySet) bool {
+						return len(s.Create) == 1 && s.Create[0].Name == "x520" &&
+							s.Create[0].ScoreThreshold == domain.DefaultWatchScoreThreshold &&
+							s.Create[0].Enabled &&
+							len(s.Update) == 1 && s.Update[0].ID == "w2" && s.Update[0].ScoreThreshold == 85 &&
+							len(s.Delete) == 0
+					})).
+					Run(func(_ context.Context, s *store.WatchApplySet) {
+						s.Create[0].ID = "w-new"
+					}).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, r *domain.WatchApplyResult) {
+				t.Helper()
+				assert.False(t, r.DryRun)
+				require.Len(t, r.Changes, 3, "stale watch kept without prune")
+				assert.Equal(t, "x520", r.Changes[2].Name)
+				assert.Equal(t, "w-new", r.Changes[2].ID)
+			},
+		},
+		{
+			name: "duplicate server names conflict",
+			body: applyManifestBody(false, false),
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					ListWatches(mock.Anything, false).
+					Return([]domain.Watch{{ID: "a", Name: "ddr4"}, {ID: "b", Name: "ddr4"}}, nil).
+					Once()
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `multiple watches named \"ddr4\"`,
+		},
+		{
+			name: "invalid spec rejected before touching the store",
+			body: map[string]any{
+				"watches": map[string]any{
+					"bad": map[string]any{
+						"search_query":   "x",
+						"component_type": "ram",
+						"filters":        map[string]any{"expr": "price <"},
+					},
+					"empty": map[string]any{"search_query": " ", "component_type": "ram"},
+				},
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.watches.bad.filters.expr`,
+		},
+		{
+			name: "store error",
+			body: applyManifestBody(false, false),
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListWatches(mock.Anything, false).Return(applyExistingWatches(), nil).Once()
+				m.EXPECT().ApplyWatches(mock.Anything, mock.Anything).Return(errors.New("tx aborted")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "applying watches",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+			h := handlers.NewWatchHandler(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterWatchRoutes(api, h)
+
+			resp := api.Post("/api/v1/watches/apply", tt.body)
+			require.Equal(t, tt.wantStatus, resp.Code, resp.Body.String())
+			if tt.wantBody != "" {
+				assert.Contains(t, resp.Body.String(), tt.wantBody)
+			}
+			if tt.check != nil {
+				var r domain.WatchApplyResult
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &r))
+				tt.check(t, &r)
+			}
+		})
+	}
+}
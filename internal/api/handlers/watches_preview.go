@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+const (
+	// previewPageSize matches the store's listing-query hard cap.
+	previewPageSize = 500
+	// previewMaxScan bounds one preview to ten pages so a broad watch
+	// on a large listings table stays an interactive request.
+	previewMaxScan = 5000
+	// defaultPreviewLimit caps each returned list (alerts, near misses).
+	defaultPreviewLimit = 25
+)
+
+// PreviewWatchInput is the input for a watch dry-run preview. With
+// watch_id the stored watch is the starting point and any other field
+// overrides it; without it the body describes a new watch.
+type PreviewWatchInput struct {
+	Body struct {
+		WatchID        string               `json:"watch_id,omitempty" doc:"Existing watch to start from"`
+		ComponentType  domain.ComponentType `json:"component_type,omitempty" doc:"Component type (empty = all types)"`
+		Filters        *domain.WatchFilters `json:"filters,omitempty" doc:"Replaces the watch's filters when set"`
+		ScoreThreshold *int                 `json:"score_threshold,omitempty" doc:"Replaces the watch's threshold when set"`
+		Limit          int                  `json:"limit,omitempty" minimum:"0" maximum:"200" doc:"Max alerts and near misses returned (default 25)"`
+	}
+}
+
+// PreviewWatchOutput is the preview result.
+type PreviewWatchOutput struct {
+	Body domain.WatchPreview
+}
+
+// PreviewWatch evaluates a watch definition against current active
+// listings — its filters, the global seller lists and the score
+// threshold, as alert evaluation applies them — without creating
+// alerts. Checks that depend on the watch's alert history (relist
+// suppression and the re-alert cooldown) are left out.
+func (h *WatchHandler) PreviewWatch(
+	ctx context.Context,
+	input *PreviewWatchInput,
+) (*PreviewWatchOutput, error) {
+	w := &domain.Watch{ScoreThreshold: domain.DefaultWatchScoreThreshold}
+	if input.Body.WatchID != "" {
+		stored, err := h.store.GetWatch(ctx, input.Body.WatchID)
+		if err != nil {
+			return nil, huma.Error404NotFound("watch not found")
+		}
+		w = stored
+	}
+	if input.Body.ComponentType != "" {
+		w.ComponentType = input.Body.ComponentType
+	}
+	if input.Body.Filters != nil {
+		w.Filters = *input.Body.Filters
+	}
+	if input.Body.ScoreThreshold != nil {
+		w.ScoreThreshold = *input.Body.ScoreThreshold
+	}
+	if err := validateFilters(&w.Filters); err != nil {
+		return nil, err
+	}
+
+	limit := input.Body.Limit
+	if limit <= 0 {
+		limit = defaultPreviewLimit
+	}
+
+	q := &store.ListingQuery{
+		ActiveOnly: true,
+		OrderBy:    "score",
+		Limit:      previewPageSize,
+	}
+	if w.ComponentType != "" {
+		ct := string(w.ComponentType)
+		q.ComponentType = &ct
+	}
+
+	p := &domain.WatchPreview{
+		Alerts:     []domain.WatchPreviewItem{},
+		NearMisses: []domain.WatchPreviewItem{},
+		Rejections: map[string]int{},
+	}
+	for q.Offset < previewMaxScan {
+		listings, total, err := h.store.ListListings(ctx, q)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("listing listings: " + err.Error())
+		}
+		for i := range listings {
+			h.addPreviewListing(p, w, &listings[i], limit)
+		}
+		q.Offset += len(listings)
+		if len(listings) < previewPageSize || q.Offset >= total {
+			break
+		}
+		p.Truncated = q.Offset >= previewMaxScan
+	}
+
+	return &PreviewWatchOutput{Body: *p}, nil
+}
+
+// addPreviewListing evaluates one listing the way alert evaluation does
+// (score threshold, then filters and seller lists) but collects every
+// failing check so near misses — exactly one failure — can name what
+// rejected them. Listings arrive in score order, so capped lists keep
+// the best ones.
+func (h *WatchHandler) addPreviewListing(p *domain.WatchPreview, w *domain.Watch, l *domain.Listing, limit int) {
+	p.Scanned++
+
+	failures := append(w.Filters.Failures(l), h.sellerFailures(l)...)
+	if len(failures) == 0 {
+		p.Matches++
+	}
+	switch {
+	case l.Score == nil:
+		failures = append(failures, "unscored")
+	case *l.Score < w.ScoreThreshold:
+		failures = append(failures, "score_threshold")
+	}
+	for _, f := range failures {
+		p.Rejections[f]++
+	}
+
+	item := domain.WatchPreviewItem{
+		ListingID: l.ID,
+		Title:     l.Title,
+		ItemURL:   l.ItemURL,
+		UnitPrice: l.UnitPrice(),
+		Score:     l.Score,
+	}
+	switch {
+	case len(failures) == 0:
+		p.AlertCount++
+		if len(p.Alerts) < limit {
+			p.Alerts = append(p.Alerts, item)
+		}
+	case len(failures) == 1 && failures[0] != "unscored":
+		if len(p.NearMisses) < limit {
+			item.RejectedBy = failures[0]
+			p.NearMisses = append(p.NearMisses, item)
+		}
+	}
+}
+
+// sellerFailures reports the global seller lists the listing fails,
+// named after their alerts.sellers config keys.
+func (h *WatchHandler) sellerFailures(l *domain.Listing) []string {
+	var out []string
+	if domain.SellerListed(h.sellerBlocklist, l.SellerName) {
+		out = append(out, "alerts.sellers.blocklist")
+	}
+	if len(h.sellerAllowlist) > 0 && !domain.SellerListed(h.sellerAllowlist, l.SellerName) {
+		out = append(out, "alerts.sellers.allowlist")
+	}
+	return out
+}
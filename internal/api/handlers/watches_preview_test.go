@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func previewListings() []domain.Listing {
+	score := func(v int) *int { return &v }
+	return []domain.Listing{
+		// passes everything
+		{ID: "l1", Title: "32GB DDR4 RDIMM", Price: 40, Quantity: 1, Score: score(90),
+			Attributes: map[string]any{"capacity_gb": float64(32)}},
+		// near miss: only the score is short
+		{ID: "l2", Title: "32GB DDR4 RDIMM", Price: 45, Quantity: 1, Score: score(70),
+			Attributes: map[string]any{"capacity_gb": float64(32)}},
+		// near miss: only price_max fails
+		{ID: "l3", Title: "32GB DDR4 RDIMM", Price: 80, Quantity: 1, Score: score(85),
+			Attributes: map[string]any{"capacity_gb": float64(32)}},
+		// two failures: not a near miss
+		{ID: "l4", Title: "16GB DDR4 RDIMM", Price: 80, Quantity: 1, Score: score(85),
+			Attributes: map[string]any{"capacity_gb": float64(16)}},
+		// unscored but otherwise matching
+		{ID: "l5", Title: "32GB DDR4 RDIMM", Price: 30, Quantity: 1,
+			Attributes: map[string]any{"capacity_gb": float64(32)}},
+	}
+}
+
+func TestWatchHandler_Preview(t *testing.T) {
+	t.Parallel()
+
+	priceMax := 50.0
+	stored := &domain.Watch{
+		ID: "w1", Name: "ddr4", ComponentType: domain.ComponentRAM, ScoreThreshold: 95,
+		Filters: domain.WatchFilters{
+			PriceMax: &priceMax,
+			AttributeFilters: map[string]domain.AttributeFilter{
+				"capacity_gb": {Equals: float64(32)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantBody   string
+		check      func(t *testing.T, p *domain.WatchPreview)
+	}{
+		{
+			name: "existing watch with threshold override",
+			body: map[string]any{"watch_id": "w1", "score_threshold": 80},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "w1").Return(stored, nil).Once()
+				m.EXPECT().
+					ListListings(mock.Anything, mock.MatchedBy(func(q *store.ListingQuery) bool {
+						return q.ActiveOnly && q.ComponentType != nil && *q.ComponentType == "ram"
+					})).
+					Return(previewListings(), 5, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, p *domain.WatchPreview) {
+				t.Helper()
+				assert.Equal(t, 5, p.Scanned)
+				assert.Equal(t, 3, p.Matches)
+				assert.Equal(t, 1, p.AlertCount)
+				require.Len(t, p.Alerts, 1)
+				assert.Equal(t, "l1", p.Alerts[0].ListingID)
+
+				require.Len(t, p.NearMisses, 2)
+				assert.Equal(t, "l2", p.NearMisses[0].ListingID)
+				assert.Equal(t, "score_threshold", p.NearMisses[0].RejectedBy)
+				assert.Equal(t, "l3", p.NearMisses[1].ListingID)
+				assert.Equal(t, "price_max", p.NearMisses[1].RejectedBy)
+
+				assert.Equal(t, map[string]int{
+					"price_max":                     2,
+					"attribute_filters.capacity_gb": 1,
+					"score_threshold":               1,
+					"unscored":                      1,
+				}, p.Rejections)
+			},
+		},
+		{
+			name: "new watch scans every type with default threshold",
+			body: map[string]any{"filters": map[string]any{"expr": `title contains "32gb"`}},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					ListListings(mock.Anything, mock.MatchedBy(func(q *store.ListingQuery) bool {
+						return q.ComponentType == nil
+					})).
+					Return(previewListings(), 5, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, p *domain.WatchPreview) {
+				t.Helper()
+				assert.Equal(t, 4, p.Matches)
+				assert.Equal(t, 2, p.AlertCount, "l1 and l3 clear the default threshold of 75")
+			},
+		},
+		{
+			name:       "invalid expression",
+			body:       map[string]any{"filters": map[string]any{"expr": "price <"}},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "invalid filter expression",
+		},
+		{
+			name: "unknown watch",
+			body: map[string]any{"watch_id": "nope"},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "nope").Return(nil, errors.New("no rows")).Once()
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "watch not found",
+		},
+		{
+			name: "store error",
+			body: map[string]any{},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().ListListings(mock.Anything, mock.Anything).Return(nil, 0, errors.New("db down")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "listing listings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+			h := handlers.NewWatchHandler(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterWatchRoutes(api, h)
+
+			resp := api.Post("/api/v1/watches/preview", tt.body)
+			require.Equal(t, tt.wantStatus, resp.Code, resp.Body.String())
+			if tt.wantBody != "" {
+				assert.Contains(t, resp.Body.String(), tt.wantBody)
+			}
+			if tt.check != nil {
+				var p domain.WatchPreview
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &p))
+				tt.check(t, &p)
+			}
+		})
+	}
+}
+
+func TestWatchHandler_Preview_GlobalSellerLists(t *testing.T) {
+	t.Parallel()
+
+	score := 90
+	listings := []domain.Listing{
+		{ID: "ok", Price: 40, Quantity: 1, Score: &score, SellerName: "trusted"},
+		{ID: "blocked", Price: 40, Quantity: 1, Score: &score, SellerName: "Scammer"},
+		{ID: "unlisted", Price: 40, Quantity: 1, Score: &score, SellerName: "someone"},
+	}
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().ListListings(mock.Anything, mock.Anything).Return(listings, len(listings), nil).Once()
+	h := handlers.NewWatchHandler(ms, handlers.WithGlobalSellerLists(
+		[]string{"scammer"}, []string{"trusted"},
+	))
+
+	_, api := humatest.New(t)
+	handlers.RegisterWatchRoutes(api, h)
+
+	resp := api.Post("/api/v1/watches/preview", map[string]any{})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var p domain.WatchPreview
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &p))
+	assert.Equal(t, 1, p.AlertCount)
+	assert.Equal(t, 1, p.Matches)
+	assert.Equal(t, map[string]int{
+		"alerts.sellers.blocklist": 1,
+		"alerts.sellers.allowlist": 2,
+	}, p.Rejections)
+	require.Len(t, p.NearMisses, 1)
+	assert.Equal(t, "unlisted", p.NearMisses[0].ListingID)
+	assert.Equal(t, "alerts.sellers.allowlist", p.NearMisses[0].RejectedBy)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+const (
+	// DefaultWatchStatsWindow is the stats window when none is given.
+	DefaultWatchStatsWindow = 30 * 24 * time.Hour
+	// maxWatchStatsWindow bounds the window so a typo can't scan the
+	// whole alerts table.
+	maxWatchStatsWindow = 365 * 24 * time.Hour
+)
+
+// GetWatchStatsInput is the input for per-watch analytics.
+type GetWatchStatsInput struct {
+	ID     string `path:"id" doc:"Watch UUID"`
+	Window string `query:"window" example:"7d" doc:"Look-back window: Go duration or whole days with a d suffix (default 30d, max 365d)"`
+}
+
+// GetWatchStatsOutput is the response for per-watch analytics.
+type GetWatchStatsOutput struct {
+	Body domain.WatchStats
+}
+
+// GetWatchStats returns poll, listing and alert analytics for one watch
+// over the requested window.
+func (h *WatchHandler) GetWatchStats(
+	ctx context.Context,
+	input *GetWatchStatsInput,
+) (*GetWatchStatsOutput, error) {
+	window, err := ParseStatsWindow(input.Window)
+	if err != nil {
+		return nil, huma.Error422UnprocessableEntity(err.Error(), &huma.ErrorDetail{
+			Location: "query.window",
+			Message:  err.Error(),
+			Value:    input.Window,
+		})
+	}
+	if _, err := h.store.GetWatch(ctx, input.ID); err != nil {
+		return nil, huma.Error404NotFound("watch not found")
+	}
+
+	st, err := loadWatchStats(ctx, h.store, input.ID, window, time.Now())
+	if err != nil {
+		return nil, huma.Error500InternalServerError("getting watch stats: " + err.Error())
+	}
+	return &GetWatchStatsOutput{Body: *st}, nil
+}
+
+// ParseStatsWindow parses a stats look-back window. It accepts Go
+// durations ("72h") and whole days ("30d"); empty means the default.
+func ParseStatsWindow(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return DefaultWatchStatsWindow, nil
+	}
+
+	d, err := domain.ParseDuration(raw)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("invalid window %q: want a duration like 72h or 30d", raw)
+	case d <= 0:
+		return 0, fmt.Errorf("invalid window %q: must be positive", raw)
+	case d > maxWatchStatsWindow:
+		return 0, fmt.Errorf("invalid window %q: at most 365d", raw)
+	}
+	return d, nil
+}
+
+// loadWatchStats fetches the raw counts and fills in the derived rates.
+// Shared by the API endpoint and the web stats page.
+func loadWatchStats(
+	ctx context.Context,
+	s store.Store,
+	watchID string,
+	window time.Duration,
+	now time.Time,
+) (*domain.WatchStats, error) {
+	st, err := s.GetWatchStats(ctx, watchID, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	if st.Polls > 0 {
+		st.PagesPerPoll = float64(st.PagesUsed) / float64(st.Polls)
+	}
+	st.NewListingsPerDay = float64(st.ListingsIngested) / (window.Hours() / 24)
+	if st.AlertsCreated > 0 {
+		st.DismissalRate = float64(st.AlertsDismissed) / float64(st.AlertsCreated)
+	}
+	return st, nil
+}
@@ -0,0 +1,236 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/handlers"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func rawWatchStats() *domain.WatchStats {
+	median := 0.72
+	return &domain.WatchStats{
+		WatchID:          "w1",
+		Polls:            40,
+		PollErrors:       2,
+		PagesUsed:        100,
+		ListingsSeen:     2000,
+		ListingsIngested: 140,
+		AlertsCreated:    8,
+		AlertsNotified:   6,
+		AlertsDismissed:  2,
+		AlertsJudged:     5,
+		MedianJudgeScore: &median,
+		BestDeal: &domain.WatchStatsDeal{
+			AlertID: "a1", ListingID: "l1", Title: "Dell R630 2x E5-2680v4", UnitPrice: 199, Score: 93,
+		},
+	}
+}
+
+func TestParseStatsWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr string
+	}{
+		{raw: "", want: handlers.DefaultWatchStatsWindow},
+		{raw: "7d", want: 7 * 24 * time.Hour},
+		{raw: "36h", want: 36 * time.Hour},
+		{raw: "0d", wantErr: "must be positive"},
+		{raw: "400d", wantErr: "at most 365d"},
+		{raw: "a week", wantErr: "want a duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := handlers.ParseStatsWindow(tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWatchHandler_Stats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantBody   string
+		check      func(t *testing.T, st *domain.WatchStats)
+	}{
+		{
+			name: "derives rates over the window",
+			path: "/api/v1/watches/w1/stats?window=7d",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "w1").Return(&domain.Watch{ID: "w1"}, nil).Once()
+				m.EXPECT().
+					GetWatchStats(mock.Anything, "w1", mock.MatchedBy(func(since time.Time) bool {
+						age := time.Since(since)
+						return age > 7*24*time.Hour-time.Minute && age < 7*24*time.Hour+time.Minute
+					})).
+					Return(rawWatchStats(), nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, st *domain.WatchStats) {
+				t.Helper()
+				assert.InDelta(t, 2.5, st.PagesPerPoll, 1e-9)
+				assert.InDelta(t, 20.0, st.NewListingsPerDay, 1e-9)
+				assert.InDelta(t, 0.25, st.DismissalRate, 1e-9)
+				require.NotNil(t, st.BestDeal)
+				assert.Equal(t, "a1", st.BestDeal.AlertID)
+			},
+		},
+		{
+			name: "no polls or alerts leaves rates at zero",
+			path: "/api/v1/watches/w1/stats",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "w1").Return(&domain.Watch{ID: "w1"}, nil).Once()
+				m.EXPECT().
+					GetWatchStats(mock.Anything, "w1", mock.Anything).
+					Return(&domain.WatchStats{WatchID: "w1"}, nil).
+					Once()
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, st *domain.WatchStats) {
+				t.Helper()
+				assert.Zero(t, st.PagesPerPoll)
+				assert.Zero(t, st.DismissalRate)
+				assert.Nil(t, st.BestDeal)
+				assert.Nil(t, st.MedianJudgeScore)
+			},
+		},
+		{
+			name:       "invalid window",
+			path:       "/api/v1/watches/w1/stats?window=soon",
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "query.window",
+		},
+		{
+			name: "unknown watch",
+			path: "/api/v1/watches/nope/stats",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "nope").Return(nil, errors.New("no rows")).Once()
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "watch not found",
+		},
+		{
+			name: "store error",
+			path: "/api/v1/watches/w1/stats",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "w1").Return(&domain.Watch{ID: "w1"}, nil).Once()
+				m.EXPECT().GetWatchStats(mock.Anything, "w1", mock.Anything).Return(nil, errors.New("db down")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "getting watch stats",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			tt.setupMock(ms)
+			h := handlers.NewWatchHandler(ms)
+
+			_, api := humatest.New(t)
+			handlers.RegisterWatchRoutes(api, h)
+
+			resp := api.Get(tt.path)
+			require.Equal(t, tt.wantStatus, resp.Code, resp.Body.String())
+			if tt.wantBody != "" {
+				assert.Contains(t, resp.Body.String(), tt.wantBody)
+			}
+			if tt.check != nil {
+				var st domain.WatchStats
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &st))
+				tt.check(t, &st)
+			}
+		})
+	}
+}
+
+func TestWatchStatsPage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		setupMock  func(*storeMocks.MockStore)
+		wantStatus int
+		wantSubstr []string
+	}{
+		{
+			name: "renders stats and best deal",
+			path: "/watches/w1/stats?window=7d",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					GetWatch(mock.Anything, "w1").
+					Return(&domain.Watch{ID: "w1", Name: "r630"}, nil).
+					Once()
+				m.EXPECT().GetWatchStats(mock.Anything, "w1", mock.Anything).Return(rawWatchStats(), nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantSubstr: []string{"<html", "r630", "2.5 per poll", "25%", "Dell R630 2x E5-2680v4", `value="7d" selected`},
+		},
+		{
+			name: "unknown watch",
+			path: "/watches/nope/stats",
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().GetWatch(mock.Anything, "nope").Return(nil, pgx.ErrNoRows).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid window",
+			path:       "/watches/w1/stats?window=-1d",
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, s, _ := newAlertsUITestServer(t)
+			tt.setupMock(s)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, "body=%s", rec.Body.String())
+			for _, want := range tt.wantSubstr {
+				assert.Contains(t, rec.Body.String(), want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/assert"
@@ -165,6 +166,94 @@ func TestWatchHandler_Create(t *testing.T) {
 			wantStatus: http.StatusCreated,
 			wantBody:   `"DDR4 Watch"`,
 		},
+		{
+			name: "poll interval and priority",
+			body: map[string]any{
+				"name":          "RTX 3090",
+				"search_query":  "RTX 3090",
+				"poll_interval": "5m",
+				"priority":      10,
+			},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					CreateWatch(mock.Anything, mock.MatchedBy(func(w *domain.Watch) bool {
+						return w.PollInterval == domain.Duration(5*time.Minute) && w.Priority == 10
+					})).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `"poll_interval":"5m"`,
+		},
+		{
+			name: "poll interval below one minute returns 422",
+			body: map[string]any{
+				"name":          "RTX 3090",
+				"search_query":  "RTX 3090",
+				"poll_interval": "30s",
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.poll_interval`,
+		},
+		{
+			name: "marketplace",
+			body: map[string]any{
+				"name":         "UK DDR4",
+				"search_query": "DDR4 ECC",
+				"marketplace":  "EBAY_GB",
+			},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					CreateWatch(mock.Anything, mock.MatchedBy(func(w *domain.Watch) bool {
+						return w.Marketplace == "EBAY_GB"
+					})).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `"marketplace":"EBAY_GB"`,
+		},
+		{
+			name: "unknown marketplace returns 422",
+			body: map[string]any{
+				"name":         "UK DDR4",
+				"search_query": "DDR4 ECC",
+				"marketplace":  "EBAY_UK",
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.marketplace`,
+		},
+		{
+			name: "realert drop pct",
+			body: map[string]any{
+				"name":             "R740xd",
+				"search_query":     "Dell R740xd",
+				"realert_drop_pct": 15,
+			},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					CreateWatch(mock.Anything, mock.MatchedBy(func(w *domain.Watch) bool {
+						return w.RealertDropPct == 15
+					})).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `"realert_drop_pct":15`,
+		},
+		{
+			name: "realert drop pct of 100 returns 422",
+			body: map[string]any{
+				"name":             "R740xd",
+				"search_query":     "Dell R740xd",
+				"realert_drop_pct": 100,
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.realert_drop_pct`,
+		},
 		{
 			name: "missing name returns 422",
 			body: map[string]any{
@@ -183,6 +272,75 @@ func TestWatchHandler_Create(t *testing.T) {
 			wantStatus: http.StatusUnprocessableEntity,
 			wantBody:   `expected required property search_query to be present`,
 		},
+		{
+			name: "valid filter expression",
+			body: map[string]any{
+				"name":         "DDR4 Watch",
+				"search_query": "DDR4 ECC",
+				"filters": map[string]any{
+					"expr": `not title contains ["caddy", "bracket"]`,
+				},
+			},
+			setupMock: func(m *storeMocks.MockStore) {
+				m.EXPECT().
+					CreateWatch(mock.Anything, mock.MatchedBy(func(w *domain.Watch) bool {
+						return w.Filters.Expr != ""
+					})).
+					Return(nil).
+					Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `title contains`,
+		},
+		{
+			name: "invalid filter expression returns 422 with column",
+			body: map[string]any{
+				"name":         "DDR4 Watch",
+				"search_query": "DDR4 ECC",
+				"filters": map[string]any{
+					"expr": `attrs.capacity_gb >= 32 and (price < 40`,
+				},
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `column 40`,
+		},
+		{
+			name: "invalid Browse filter fields return 422",
+			body: map[string]any{
+				"name":         "DDR4 Watch",
+				"search_query": "DDR4 ECC",
+				"filters": map[string]any{
+					"buying_options":        []string{"buy_it_now", "classified"},
+					"item_location_country": "usa",
+				},
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.filters.buying_options[1]`,
+		},
+		{
+			name: "max_risk out of range returns 422",
+			body: map[string]any{
+				"name":         "DDR4 Watch",
+				"search_query": "DDR4 ECC",
+				"filters":      map[string]any{"max_risk": 120},
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.filters.max_risk`,
+		},
+		{
+			name: "negative min_partout_ratio returns 422",
+			body: map[string]any{
+				"name":         "R740 Watch",
+				"search_query": "Dell R740",
+				"filters":      map[string]any{"min_partout_ratio": -1},
+			},
+			setupMock:  func(_ *storeMocks.MockStore) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `body.filters.min_partout_ratio`,
+		},
 		{
 			name: "store error",
 			body: map[string]any{
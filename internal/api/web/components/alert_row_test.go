@@ -105,3 +105,40 @@ func TestAlertRow_JudgeEnabledRendersColumn(t *testing.T) {
 	assert.NotContains(t, disabled, `class="judge-score"`,
 		"judge-score column must be absent when JudgeEnabled is false")
 }
+
+// TestAlertRow_RiskBadge: a risk-scored listing shows its risk next to
+// the deal score; zero or missing risk renders no badge.
+func TestAlertRow_RiskBadge(t *testing.T) {
+	t.Parallel()
+
+	risk := func(v int) *int { return &v }
+	tests := []struct {
+		name  string
+		risk  *int
+		want  string
+		class string
+	}{
+		{name: "not risk scored"},
+		{name: "zero risk", risk: risk(0)},
+		{name: "medium risk", risk: risk(30), want: "risk 30", class: "risk-medium"},
+		{name: "high risk", risk: risk(70), want: "risk 70", class: "risk-high"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := domain.AlertWithListing{
+				Alert:   domain.Alert{ID: "alert-1", Score: 85, CreatedAt: time.Now()},
+				Listing: domain.Listing{Title: "test listing", RiskScore: tt.risk},
+			}
+			html := renderRow(t, &a, components.TableOptions{})
+			if tt.want == "" {
+				assert.NotContains(t, html, "risk-badge")
+				return
+			}
+			assert.Contains(t, html, tt.want)
+			assert.Contains(t, html, tt.class)
+		})
+	}
+}
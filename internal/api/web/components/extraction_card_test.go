@@ -0,0 +1,51 @@
+package components_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/web/components"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestExtractionCard_RendersFormWithCurrentExtraction(t *testing.T) {
+	t.Parallel()
+
+	l := &domain.Listing{
+		ComponentType:        domain.ComponentNIC,
+		ProductKey:           "nic:10gbe:2p:sfp+",
+		ExtractionConfidence: 0.9,
+		Attributes:           map[string]any{"model": "9300-8i"},
+	}
+	data := components.NewExtractionCardData("alert-1", l,
+		[]domain.ComponentType{domain.ComponentHBA, domain.ComponentNIC})
+
+	var buf bytes.Buffer
+	require.NoError(t, components.ExtractionCard(data).Render(context.Background(), &buf))
+	html := buf.String()
+
+	assert.Contains(t, html, "nic:10gbe:2p:sfp+")
+	assert.Contains(t, html, `hx-post="/alerts/alert-1/extraction"`)
+	assert.Contains(t, html, `<option value="nic" selected`)
+	assert.Contains(t, html, "&#34;model&#34;: &#34;9300-8i&#34;", "attributes pre-filled as indented JSON")
+	assert.NotContains(t, html, "locked against re-extraction")
+}
+
+func TestExtractionCard_HidesFormWithoutComponentTypes(t *testing.T) {
+	t.Parallel()
+
+	l := &domain.Listing{ComponentType: domain.ComponentHBA, ProductKey: "hba:lsi:9300-8i:it", ExtractionLocked: true}
+
+	var buf bytes.Buffer
+	require.NoError(t, components.ExtractionCard(components.NewExtractionCardData("alert-1", l, nil)).
+		Render(context.Background(), &buf))
+	html := buf.String()
+
+	assert.Contains(t, html, "hba:lsi:9300-8i:it")
+	assert.Contains(t, html, "locked against re-extraction")
+	assert.NotContains(t, html, "<form")
+}
@@ -4,9 +4,13 @@
 package components
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
 // prevPageURL returns the existing query string with page decremented by 1.
@@ -41,3 +45,59 @@ func money(amount float64, currency string) string {
 	}
 	return fmt.Sprintf("%s %.2f", currency, amount)
 }
+
+// priceChange is the percent change in total price from prev to cur.
+// ok is false when the two aren't comparable: different currencies, or
+// a zero starting price.
+func priceChange(prev, cur domain.PricePoint) (float64, bool) {
+	was := prev.Total()
+	if prev.Currency != cur.Currency || was == 0 {
+		return 0, false
+	}
+	return (cur.Total() - was) / was * 100, true
+}
+
+// formatChange renders a percent change with its sign, e.g. "-22.5%".
+func formatChange(pct float64) string {
+	return fmt.Sprintf("%+.1f%%", pct)
+}
+
+// feedbackTrend summarizes how a seller's feedback moved since its
+// first recorded entry, e.g. "+120 since 2026-03-01". Empty when there
+// is only one entry.
+func feedbackTrend(history []domain.SellerFeedbackPoint) string {
+	if len(history) < 2 {
+		return ""
+	}
+	first, last := history[0], history[len(history)-1]
+	trend := fmt.Sprintf("%+d", last.FeedbackScore-first.FeedbackScore)
+	if d := last.FeedbackPct - first.FeedbackPct; d != 0 {
+		trend += fmt.Sprintf(", %+.1f pts", d)
+	}
+	return trend + " since " + first.RecordedAt.Format("2006-01-02")
+}
+
+// riskSignalLabel renders a risk signal for people, e.g.
+// "low seller feedback +30".
+func riskSignalLabel(s domain.RiskSignal) string {
+	return fmt.Sprintf("%s +%d", strings.ReplaceAll(s.Name, "_", " "), s.Points)
+}
+
+// NewExtractionCardData fills the extraction panel for a listing, with
+// the attributes field pre-filled with the current attributes as
+// indented JSON.
+func NewExtractionCardData(alertID string, l *domain.Listing, types []domain.ComponentType) ExtractionCardData {
+	attrs := "{}"
+	if len(l.Attributes) > 0 {
+		if b, err := json.MarshalIndent(l.Attributes, "", "  "); err == nil {
+			attrs = string(b)
+		}
+	}
+	return ExtractionCardData{
+		AlertID:        alertID,
+		Listing:        l,
+		ComponentTypes: types,
+		Selected:       l.ComponentType,
+		Attributes:     attrs,
+	}
+}
@@ -0,0 +1,43 @@
+package components_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/web/components"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestPriceHistory_RendersChanges(t *testing.T) {
+	t.Parallel()
+
+	ship := 10.0
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	points := []domain.PricePoint{
+		{Price: 390, ShippingCost: &ship, Currency: "USD", RecordedAt: at},
+		{Price: 300, ShippingCost: &ship, Currency: "USD", RecordedAt: at.Add(48 * time.Hour)},
+		{Price: 320, ShippingCost: &ship, Currency: "USD", RecordedAt: at.Add(96 * time.Hour)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, components.PriceHistory(points).Render(context.Background(), &buf))
+	html := buf.String()
+
+	assert.Contains(t, html, "USD 400.00")
+	assert.Contains(t, html, "incl. USD 10.00 shipping")
+	assert.Contains(t, html, `<span class="down">-22.5%</span>`)
+	assert.Contains(t, html, `<span class="up">+6.5%</span>`)
+}
+
+func TestPriceHistory_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, components.PriceHistory(nil).Render(context.Background(), &buf))
+	assert.Contains(t, buf.String(), "No prices recorded yet.")
+}
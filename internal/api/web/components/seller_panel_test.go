@@ -0,0 +1,71 @@
+package components_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/api/web/components"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestSellerPanel_RendersSellerAndListings(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	seller := &domain.Seller{
+		Name:            "server_parts_inc",
+		FeedbackScore:   5552,
+		FeedbackPct:     99.8,
+		TopRated:        true,
+		FirstSeenAt:     at,
+		LastSeenAt:      at.Add(60 * 24 * time.Hour),
+		ListingsTotal:   40,
+		ListingsActive:  12,
+		AlertsTotal:     4,
+		AlertsDismissed: 1,
+		AlertRate:       0.1,
+		DismissalRate:   0.25,
+		FeedbackHistory: []domain.SellerFeedbackPoint{
+			{FeedbackScore: 5432, FeedbackPct: 99.6, RecordedAt: at},
+			{FeedbackScore: 5552, FeedbackPct: 99.8, RecordedAt: at.Add(30 * 24 * time.Hour)},
+		},
+	}
+	score := 88
+	listings := []domain.Listing{
+		{ID: "l2", Title: "Samsung 64GB DDR4", Price: 95, Currency: "USD", Score: &score, ItemURL: "https://ebay.com/itm/2"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, components.SellerPanel(seller, listings).Render(context.Background(), &buf))
+	html := buf.String()
+
+	assert.Contains(t, html, "server_parts_inc")
+	assert.Contains(t, html, "top rated")
+	assert.Contains(t, html, "+120, +0.2 pts since 2026-03-01")
+	assert.Contains(t, html, "12 active")
+	assert.Contains(t, html, "25% of alerts")
+	assert.Contains(t, html, "Samsung 64GB DDR4")
+	assert.Contains(t, html, "USD 95.00")
+}
+
+func TestSellerPanel_NilSellerRendersNothing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, components.SellerPanel(nil, nil).Render(context.Background(), &buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestSellerPanel_NoOtherListings(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	seller := &domain.Seller{Name: "server_parts_inc"}
+	require.NoError(t, components.SellerPanel(seller, nil).Render(context.Background(), &buf))
+	assert.Contains(t, buf.String(), "No other active listings.")
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -18,7 +20,9 @@ type Config struct {
 	Server        ServerConfig        `yaml:"server"`
 	Database      DatabaseConfig      `yaml:"database"`
 	Ebay          EbayConfig          `yaml:"ebay"`
+	Currency      CurrencyConfig      `yaml:"currency"`
 	LLM           LLMConfig           `yaml:"llm"`
+	Catalog       CatalogConfig       `yaml:"catalog"`
 	Scoring       ScoringConfig       `yaml:"scoring"`
 	Schedule      ScheduleConfig      `yaml:"schedule"`
 	Alerts        AlertsConfig        `yaml:"alerts"`
@@ -86,6 +90,28 @@ type RateLimitConfig struct {
 	DailyLimit int64   `yaml:"daily_limit"`
 }
 
+// CurrencyConfig defines where exchange rates for USD normalization come
+// from. RatesFile and RatesURL serve the same JSON document (see
+// currency.ParseRateTable); set at most one. With neither, only USD
+// listings get a USD price, and other currencies are left out of
+// baselines.
+type CurrencyConfig struct {
+	RatesFile       string        `yaml:"rates_file"`
+	RatesURL        string        `yaml:"rates_url"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+}
+
+// CatalogConfig controls hardware catalog enrichment. When enabled,
+// extracted attributes are filled in from the catalog (core counts,
+// TDP, release year, FP16 throughput) before the product key is built.
+// Path is an optional directory of catalog YAML files layered over the
+// catalog embedded in the binary; the catalog API serves the same
+// merged catalog whether or not enrichment is enabled.
+type CatalogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
 // LLMConfig defines LLM backend settings.
 type LLMConfig struct {
 	Backend      string             `yaml:"backend"` // ollama, anthropic, openai_compat
@@ -95,6 +121,49 @@ type LLMConfig struct {
 	UseGrammar   bool               `yaml:"use_grammar"`
 	Concurrency  int                `yaml:"concurrency"`
 	Timeout      time.Duration      `yaml:"timeout"`
+	Shadow       ShadowConfig       `yaml:"shadow"`
+}
+
+// ShadowConfig runs a sampled fraction of live extractions a second
+// time, asynchronously, through a candidate backend, model or prompt
+// set and records where the two disagree and what each cost. The
+// primary extraction is always the one stored. An empty Backend reuses
+// the primary backend settings, so a prompt set can be shadowed on its
+// own. PromptDir holds classify.tmpl and <component_type>.tmpl files
+// that replace the built-in prompts; PromptVersion labels the
+// comparisons so reports can tell candidates apart.
+type ShadowConfig struct {
+	Enabled       bool               `yaml:"enabled"`
+	Backend       string             `yaml:"backend"` // ollama, anthropic, openai_compat; empty = primary
+	Ollama        OllamaConfig       `yaml:"ollama"`
+	Anthropic     AnthropicConfig    `yaml:"anthropic"`
+	OpenAICompat  OpenAICompatConfig `yaml:"openai_compat"`
+	PromptDir     string             `yaml:"prompt_dir"`
+	PromptVersion string             `yaml:"prompt_version"`
+	SampleRate    float64            `yaml:"sample_rate"`
+	Concurrency   int                `yaml:"concurrency"`
+	Timeout       time.Duration      `yaml:"timeout"`
+}
+
+// BackendConfig returns the LLM settings the shadow extractor runs
+// with: primary's when no shadow backend is set.
+func (s *ShadowConfig) BackendConfig(primary *LLMConfig) LLMConfig {
+	if s.Backend == "" {
+		return LLMConfig{
+			Backend:      primary.Backend,
+			Ollama:       primary.Ollama,
+			Anthropic:    primary.Anthropic,
+			OpenAICompat: primary.OpenAICompat,
+			Timeout:      s.Timeout,
+		}
+	}
+	return LLMConfig{
+		Backend:      s.Backend,
+		Ollama:       s.Ollama,
+		Anthropic:    s.Anthropic,
+		OpenAICompat: s.OpenAICompat,
+		Timeout:      s.Timeout,
+	}
 }
 
 // OllamaConfig defines Ollama-specific settings.
@@ -115,13 +184,42 @@ type OpenAICompatConfig struct {
 }
 
 // ScoringConfig defines scoring weights and baseline parameters.
+//
+// ComponentWeights overrides Weights for individual component types
+// (keyed by component_type, e.g. "ram"). It is typically generated by
+// tools/score-calibrate from operator dismiss/restore labels; types
+// without an entry score with Weights.
 type ScoringConfig struct {
-	Weights            ScoringWeights `yaml:"weights"`
-	MinBaselineSamples int            `yaml:"min_baseline_samples"`
-	BaselineWindowDays int            `yaml:"baseline_window_days"`
+	Weights            ScoringWeights            `yaml:"weights"`
+	ComponentWeights   map[string]ScoringWeights `yaml:"component_weights"`
+	MinBaselineSamples int                       `yaml:"min_baseline_samples"`
+	BaselineWindowDays int                       `yaml:"baseline_window_days"`
+	Relists            RelistConfig              `yaml:"relists"`
+	PartOut            PartOutConfig             `yaml:"partout"`
+}
+
+// PartOutConfig controls the server part-out estimator. When enabled,
+// scoring a server prices the CPUs and RAM its extraction names from
+// their own baselines and uses the parts value to price ratio as a
+// price signal and for the min_partout_ratio watch filter.
+type PartOutConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// RelistConfig controls relist and duplicate detection. After
+// extraction, a listing joins the group of an earlier listing from the
+// same seller with the same product key and normalized title whose
+// price with shipping is within PriceTolerancePct. Baselines count a
+// group once, and a watch does not alert again on a listing whose group
+// already alerted it unless the price dropped by realert_drop_pct.
+type RelistConfig struct {
+	Enabled           bool    `yaml:"enabled"`
+	PriceTolerancePct float64 `yaml:"price_tolerance_pct"`
 }
 
 // ScoringWeights defines the relative weight of each scoring factor.
+// Value (price per unit against the value-class baseline) is optional
+// and defaults to zero.
 type ScoringWeights struct {
 	Price     float64 `yaml:"price"`
 	Seller    float64 `yaml:"seller"`
@@ -129,16 +227,31 @@ type ScoringWeights struct {
 	Quantity  float64 `yaml:"quantity"`
 	Quality   float64 `yaml:"quality"`
 	Time      float64 `yaml:"time"`
+	Value     float64 `yaml:"value"`
+}
+
+// Sum returns the total of all factor weights.
+func (w ScoringWeights) Sum() float64 {
+	return w.Price + w.Seller + w.Condition + w.Quantity + w.Quality + w.Time + w.Value
 }
 
 // ScheduleConfig defines cron intervals.
+//
+// IngestionInterval is the poll interval for watches that don't set
+// their own poll_interval. PollTick is how often the scheduler checks
+// for due watches, so it bounds how fast any one watch can be polled.
 type ScheduleConfig struct {
 	IngestionInterval    time.Duration `yaml:"ingestion_interval"`
+	PollTick             time.Duration `yaml:"poll_tick"`
 	BaselineInterval     time.Duration `yaml:"baseline_interval"`
 	StaggerOffset        time.Duration `yaml:"stagger_offset"`
 	ReExtractionInterval time.Duration `yaml:"re_extraction_interval"`
 }
 
+// defaultPollTick caps the default scheduler tick; shorter ingestion
+// intervals tick at the interval itself.
+const defaultPollTick = 5 * time.Minute
+
 // NotificationsConfig defines notification targets.
 type NotificationsConfig struct {
 	Discord DiscordConfig `yaml:"discord"`
@@ -170,7 +283,64 @@ type WebhookConfig struct {
 type AlertsConfig struct {
 	// ReAlertsCooldown suppresses re-alerts on the same (watch, listing) within
 	// this window. Default: 24h. Set to 0 to disable the cooldown entirely.
+	// A watch's realert_drop_pct lets a large enough price drop through.
 	ReAlertsCooldown time.Duration `yaml:"re_alerts_cooldown"`
+
+	// PriceCheckInterval is how often a listing alerted to a watch with
+	// realert_drop_pct has its price re-read with getItem, from the page
+	// budget an ingestion cycle leaves unused. Default: 6h.
+	PriceCheckInterval time.Duration `yaml:"price_check_interval"`
+
+	// Auctions controls ending-soon tracking of auction listings.
+	Auctions AuctionTrackingConfig `yaml:"auctions"`
+
+	// Sellers holds the seller lists applied to every watch, on top of
+	// each watch's own seller_blocklist and seller_allowlist.
+	Sellers SellerListsConfig `yaml:"sellers"`
+
+	// JudgeGate holds alerts back until the LLM judge has scored them.
+	JudgeGate JudgeGateConfig `yaml:"judge_gate"`
+}
+
+// JudgeGateConfig gates alert delivery on judge verdicts. While enabled,
+// a pending alert with no verdict waits up to MaxWait for one and is
+// then sent unjudged. An alert whose verdict is below MinScore is either
+// suppressed ("suppress") or folded into one summary embed per tick
+// ("summary") instead of getting its own notification. Requires
+// observability.judge.enabled.
+type JudgeGateConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	MaxWait  time.Duration `yaml:"max_wait"`
+	MinScore float64       `yaml:"min_score"`
+	Action   string        `yaml:"action"`
+}
+
+// Judge gate actions for alerts below the verdict floor.
+const (
+	JudgeGateActionSuppress = "suppress"
+	JudgeGateActionSummary  = "summary"
+)
+
+// SellerListsConfig is the global seller blocklist and allowlist. A
+// blocked seller never alerts; a non-empty allowlist limits alerts to
+// its sellers. Names compare case-insensitively.
+type SellerListsConfig struct {
+	Blocklist []string `yaml:"blocklist"`
+	Allowlist []string `yaml:"allowlist"`
+}
+
+// AuctionTrackingConfig controls the auction tracking job. Every
+// CheckInterval it refreshes the current bid of alerted or watched
+// auctions ending within RefreshWindow (one eBay getItem call each),
+// re-scores them, and sends a one-time reminder per watch once an
+// auction is within ReminderLead of its end. Outside ReminderLead an
+// auction is refreshed at most once per RefreshInterval.
+type AuctionTrackingConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	CheckInterval   time.Duration `yaml:"check_interval"`
+	RefreshWindow   time.Duration `yaml:"refresh_window"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	ReminderLead    time.Duration `yaml:"reminder_lead"`
 }
 
 // LoggingConfig defines logging settings.
@@ -218,6 +388,11 @@ type LangfuseConfig struct {
 	// server-side rate table. Operators only need entries for in-house
 	// or private models that Langfuse can't price (e.g., Ollama).
 	ModelCosts map[string]langfuse.ModelCost `yaml:"model_costs"`
+
+	// CorrectionsDatasetID is the Langfuse dataset operator extraction
+	// corrections are added to. Empty skips the upload; corrections are
+	// still stored and exportable to the golden file.
+	CorrectionsDatasetID string `yaml:"corrections_dataset_id"`
 }
 
 // JudgeConfig controls the async LLM-as-judge worker (IMPL-0019 Phase 5).
@@ -233,6 +408,11 @@ type JudgeConfig struct {
 	Lookback       time.Duration `yaml:"lookback"`
 	BatchSize      int           `yaml:"batch_size"`
 	DailyBudgetUSD float64       `yaml:"daily_budget_usd"`
+	// Concurrency caps the judge calls in flight per tick; 1 judges
+	// sequentially. EstimatedCostUSD is reserved against the daily
+	// budget before each call so concurrent calls can't overshoot it.
+	Concurrency      int     `yaml:"concurrency"`
+	EstimatedCostUSD float64 `yaml:"estimated_cost_usd"`
 }
 
 // Load reads and parses a YAML config file, performing environment variable
@@ -264,6 +444,7 @@ func applyDefaults(cfg *Config) {
 	applyServerDefaults(&cfg.Server)
 	applyDatabaseDefaults(&cfg.Database)
 	applyEbayDefaults(&cfg.Ebay)
+	applyCurrencyDefaults(&cfg.Currency)
 	applyLLMDefaults(&cfg.LLM)
 	applyScoringDefaults(&cfg.Scoring)
 	applyScheduleDefaults(&cfg.Schedule)
@@ -285,6 +466,12 @@ func applyEbayDefaults(e *EbayConfig) {
 	applyRateLimitDefaults(&e.RateLimit)
 }
 
+func applyCurrencyDefaults(c *CurrencyConfig) {
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = 6 * time.Hour
+	}
+}
+
 func applyRateLimitDefaults(r *RateLimitConfig) {
 	if r.PerSecond == 0 {
 		r.PerSecond = 5.0
@@ -334,6 +521,15 @@ func applyLLMDefaults(l *LLMConfig) {
 	if l.Timeout == 0 {
 		l.Timeout = 30 * time.Second
 	}
+	if l.Shadow.SampleRate == 0 {
+		l.Shadow.SampleRate = 0.05
+	}
+	if l.Shadow.Concurrency == 0 {
+		l.Shadow.Concurrency = 2
+	}
+	if l.Shadow.Timeout == 0 {
+		l.Shadow.Timeout = l.Timeout
+	}
 }
 
 func applyScoringDefaults(s *ScoringConfig) {
@@ -343,12 +539,18 @@ func applyScoringDefaults(s *ScoringConfig) {
 	if s.BaselineWindowDays == 0 {
 		s.BaselineWindowDays = 90
 	}
+	if s.Relists.PriceTolerancePct == 0 {
+		s.Relists.PriceTolerancePct = 10
+	}
 }
 
 func applyScheduleDefaults(s *ScheduleConfig) {
 	if s.IngestionInterval == 0 {
 		s.IngestionInterval = 15 * time.Minute
 	}
+	if s.PollTick == 0 {
+		s.PollTick = min(s.IngestionInterval, defaultPollTick)
+	}
 	if s.BaselineInterval == 0 {
 		s.BaselineInterval = 6 * time.Hour
 	}
@@ -361,6 +563,30 @@ func applyAlertsDefaults(a *AlertsConfig) {
 	if a.ReAlertsCooldown == 0 {
 		a.ReAlertsCooldown = 24 * time.Hour
 	}
+	if a.PriceCheckInterval == 0 {
+		a.PriceCheckInterval = 6 * time.Hour
+	}
+	if a.Auctions.CheckInterval == 0 {
+		a.Auctions.CheckInterval = 5 * time.Minute
+	}
+	if a.Auctions.RefreshWindow == 0 {
+		a.Auctions.RefreshWindow = time.Hour
+	}
+	if a.Auctions.RefreshInterval == 0 {
+		a.Auctions.RefreshInterval = 20 * time.Minute
+	}
+	if a.Auctions.ReminderLead == 0 {
+		a.Auctions.ReminderLead = 15 * time.Minute
+	}
+	if a.JudgeGate.MaxWait == 0 {
+		a.JudgeGate.MaxWait = 45 * time.Minute
+	}
+	if a.JudgeGate.MinScore == 0 {
+		a.JudgeGate.MinScore = 0.3
+	}
+	if a.JudgeGate.Action == "" {
+		a.JudgeGate.Action = JudgeGateActionSummary
+	}
 }
 
 func applyLoggingDefaults(l *LoggingConfig) {
@@ -409,6 +635,12 @@ func applyJudgeDefaults(j *JudgeConfig) {
 	if j.DailyBudgetUSD == 0 {
 		j.DailyBudgetUSD = 10.0
 	}
+	if j.Concurrency == 0 {
+		j.Concurrency = 1
+	}
+	if j.EstimatedCostUSD == 0 {
+		j.EstimatedCostUSD = 0.01
+	}
 }
 
 func validate(cfg *Config) error {
@@ -424,38 +656,118 @@ func validate(cfg *Config) error {
 		errs = append(errs, fmt.Errorf("database.user is required"))
 	}
 
-	switch cfg.LLM.Backend {
+	errs = append(errs, validateLLMBackend("llm", &cfg.LLM)...)
+	if sh := &cfg.LLM.Shadow; sh.Enabled {
+		shadow := sh.BackendConfig(&cfg.LLM)
+		errs = append(errs, validateLLMBackend("llm.shadow", &shadow)...)
+		if sh.SampleRate <= 0 || sh.SampleRate > 1 {
+			errs = append(errs, fmt.Errorf("llm.shadow.sample_rate must be in (0, 1] (got %g)", sh.SampleRate))
+		}
+	}
+
+	if cfg.Currency.RatesFile != "" && cfg.Currency.RatesURL != "" {
+		errs = append(errs, fmt.Errorf("currency.rates_file and currency.rates_url are mutually exclusive"))
+	}
+
+	if a := cfg.Alerts.Auctions; a.Enabled && a.ReminderLead > a.RefreshWindow {
+		errs = append(errs, fmt.Errorf(
+			"alerts.auctions.reminder_lead (%s) must not exceed alerts.auctions.refresh_window (%s)",
+			a.ReminderLead, a.RefreshWindow,
+		))
+	}
+
+	errs = append(errs, validateJudgeGate(cfg)...)
+
+	for _, name := range cfg.Alerts.Sellers.Blocklist {
+		if slices.ContainsFunc(cfg.Alerts.Sellers.Allowlist, func(a string) bool {
+			return strings.EqualFold(a, name)
+		}) {
+			errs = append(errs, fmt.Errorf(
+				"alerts.sellers: %q is in both blocklist and allowlist", name,
+			))
+		}
+	}
+
+	errs = append(errs, validateScoring(&cfg.Scoring)...)
+
+	return errors.Join(errs...)
+}
+
+// validateJudgeGate checks alerts.judge_gate when it is enabled. The
+// gate needs the judge running, or every alert would sit out max_wait.
+func validateJudgeGate(cfg *Config) []error {
+	g := &cfg.Alerts.JudgeGate
+	if !g.Enabled {
+		return nil
+	}
+	var errs []error
+	if !cfg.Observability.Judge.Enabled {
+		errs = append(errs, fmt.Errorf("alerts.judge_gate requires observability.judge.enabled"))
+	}
+	if g.MinScore < 0 || g.MinScore > 1 {
+		errs = append(errs, fmt.Errorf("alerts.judge_gate.min_score must be in [0, 1] (got %g)", g.MinScore))
+	}
+	if g.MaxWait < 0 {
+		errs = append(errs, fmt.Errorf("alerts.judge_gate.max_wait must not be negative (got %s)", g.MaxWait))
+	}
+	if g.Action != JudgeGateActionSuppress && g.Action != JudgeGateActionSummary {
+		errs = append(errs, fmt.Errorf(
+			"alerts.judge_gate.action must be one of: suppress, summary (got %q)", g.Action,
+		))
+	}
+	return errs
+}
+
+// validateLLMBackend checks that l names a known backend and carries
+// the settings it needs. path prefixes the field names in errors.
+func validateLLMBackend(path string, l *LLMConfig) []error {
+	switch l.Backend {
 	case "ollama":
-		if cfg.LLM.Ollama.Endpoint == "" {
-			errs = append(
-				errs,
-				fmt.Errorf("llm.ollama.endpoint is required when backend is ollama"),
-			)
+		if l.Ollama.Endpoint == "" {
+			return []error{fmt.Errorf("%s.ollama.endpoint is required when backend is ollama", path)}
 		}
 	case "anthropic":
 		// API key comes from env, model must be set.
-		if cfg.LLM.Anthropic.Model == "" {
-			errs = append(
-				errs,
-				fmt.Errorf("llm.anthropic.model is required when backend is anthropic"),
-			)
+		if l.Anthropic.Model == "" {
+			return []error{fmt.Errorf("%s.anthropic.model is required when backend is anthropic", path)}
 		}
 	case "openai_compat":
-		if cfg.LLM.OpenAICompat.Endpoint == "" {
-			errs = append(
-				errs,
-				fmt.Errorf("llm.openai_compat.endpoint is required when backend is openai_compat"),
-			)
+		if l.OpenAICompat.Endpoint == "" {
+			return []error{fmt.Errorf("%s.openai_compat.endpoint is required when backend is openai_compat", path)}
 		}
 	default:
-		errs = append(
-			errs,
-			fmt.Errorf(
-				"llm.backend must be one of: ollama, anthropic, openai_compat (got %q)",
-				cfg.LLM.Backend,
-			),
-		)
+		return []error{fmt.Errorf(
+			"%s.backend must be one of: ollama, anthropic, openai_compat (got %q)", path, l.Backend,
+		)}
 	}
+	return nil
+}
 
-	return errors.Join(errs...)
+// validateScoring rejects weight blocks that can't produce a 0-100
+// composite. An all-zero block is "unset" and skipped — the engine
+// falls back to the built-in defaults.
+func validateScoring(s *ScoringConfig) []error {
+	var errs []error
+	check := func(path string, w ScoringWeights) {
+		if w == (ScoringWeights{}) {
+			return
+		}
+		if w.Price < 0 || w.Seller < 0 || w.Condition < 0 ||
+			w.Quantity < 0 || w.Quality < 0 || w.Time < 0 || w.Value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not contain negative weights", path))
+		}
+		if sum := w.Sum(); sum < 0.99 || sum > 1.01 {
+			errs = append(errs, fmt.Errorf("%s must sum to 1.0 (got %.2f)", path, sum))
+		}
+	}
+	check("scoring.weights", s.Weights)
+	for ct, w := range s.ComponentWeights {
+		check("scoring.component_weights."+ct, w)
+	}
+	if pct := s.Relists.PriceTolerancePct; pct < 0 || pct >= 100 {
+		errs = append(errs, fmt.Errorf(
+			"scoring.relists.price_tolerance_pct must be in [0, 100) (got %g)", pct,
+		))
+	}
+	return errs
 }
@@ -66,6 +66,7 @@ llm:
 				assert.Equal(t, 10, cfg.Scoring.MinBaselineSamples)
 				assert.Equal(t, 90, cfg.Scoring.BaselineWindowDays)
 				assert.Equal(t, 15*time.Minute, cfg.Schedule.IngestionInterval)
+				assert.Equal(t, 5*time.Minute, cfg.Schedule.PollTick)
 				assert.Equal(t, 6*time.Hour, cfg.Schedule.BaselineInterval)
 				assert.Equal(t, 30*time.Second, cfg.Schedule.StaggerOffset)
 				assert.Equal(t, "info", cfg.Logging.Level)
@@ -74,6 +75,15 @@ llm:
 				assert.Equal(t, 5.0, cfg.Ebay.RateLimit.PerSecond)
 				assert.Equal(t, 10, cfg.Ebay.RateLimit.Burst)
 				assert.Equal(t, int64(5000), cfg.Ebay.RateLimit.DailyLimit)
+				assert.Equal(t, 6*time.Hour, cfg.Currency.RefreshInterval)
+				assert.Equal(t, 6*time.Hour, cfg.Alerts.PriceCheckInterval)
+				assert.False(t, cfg.Alerts.Auctions.Enabled)
+				assert.Equal(t, 5*time.Minute, cfg.Alerts.Auctions.CheckInterval)
+				assert.Equal(t, time.Hour, cfg.Alerts.Auctions.RefreshWindow)
+				assert.Equal(t, 20*time.Minute, cfg.Alerts.Auctions.RefreshInterval)
+				assert.Equal(t, 15*time.Minute, cfg.Alerts.Auctions.ReminderLead)
+				assert.False(t, cfg.Scoring.Relists.Enabled)
+				assert.InDelta(t, 10.0, cfg.Scoring.Relists.PriceTolerancePct, 0.001)
 				// Observability defaults: all subtrees disabled,
 				// safe values populated for when operator opts in.
 				assert.False(t, cfg.Observability.Otel.Enabled)
@@ -325,6 +335,303 @@ logging:
 				assert.Equal(t, "json", cfg.Logging.Format)
 			},
 		},
+		{
+			name: "component weight overrides parsed",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+scoring:
+  component_weights:
+    ram:
+      price: 0.55
+      seller: 0.15
+      condition: 0.10
+      quantity: 0.10
+      quality: 0.05
+      time: 0.05
+`,
+			checkFunc: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				require.Contains(t, cfg.Scoring.ComponentWeights, "ram")
+				assert.Equal(t, 0.55, cfg.Scoring.ComponentWeights["ram"].Price)
+			},
+		},
+		{
+			name: "value weight counts toward the sum",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+scoring:
+  weights:
+    price: 0.30
+    seller: 0.20
+    condition: 0.15
+    quantity: 0.10
+    quality: 0.10
+    time: 0.05
+    value: 0.10
+`,
+			checkFunc: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				assert.Equal(t, 0.10, cfg.Scoring.Weights.Value)
+			},
+		},
+		{
+			name: "scoring weights must sum to one",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+scoring:
+  weights:
+    price: 0.80
+    seller: 0.80
+`,
+			wantErr: "scoring.weights must sum to 1.0",
+		},
+		{
+			name: "negative component weight rejected",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+scoring:
+  component_weights:
+    gpu:
+      price: 1.2
+      seller: -0.2
+`,
+			wantErr: "scoring.component_weights.gpu must not contain negative weights",
+		},
+		{
+			name: "relist price tolerance out of range",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+scoring:
+  relists:
+    enabled: true
+    price_tolerance_pct: 150
+`,
+			wantErr: "scoring.relists.price_tolerance_pct must be in [0, 100)",
+		},
+		{
+			name: "currency rate sources are mutually exclusive",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+currency:
+  rates_file: /etc/spt/rates.json
+  rates_url: https://open.er-api.com/v6/latest/USD
+`,
+			wantErr: "currency.rates_file and currency.rates_url are mutually exclusive",
+		},
+		{
+			name: "auction reminder lead beyond refresh window",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+alerts:
+  auctions:
+    enabled: true
+    refresh_window: 30m
+    reminder_lead: 45m
+`,
+			wantErr: "alerts.auctions.reminder_lead (45m0s) must not exceed alerts.auctions.refresh_window (30m0s)",
+		},
+		{
+			name: "seller in both global lists",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+alerts:
+  sellers:
+    blocklist: [junk_dealer]
+    allowlist: [Junk_Dealer, server_parts_inc]
+`,
+			wantErr: `alerts.sellers: "junk_dealer" is in both blocklist and allowlist`,
+		},
+		{
+			name: "shadow defaults to the primary backend",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+    model: mistral
+  timeout: 45s
+  shadow:
+    enabled: true
+    prompt_dir: /etc/spt/prompts-v2
+`,
+			checkFunc: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				sh := cfg.LLM.Shadow
+				assert.InDelta(t, 0.05, sh.SampleRate, 0)
+				assert.Equal(t, 2, sh.Concurrency)
+				assert.Equal(t, 45*time.Second, sh.Timeout)
+				backend := sh.BackendConfig(&cfg.LLM)
+				assert.Equal(t, "ollama", backend.Backend)
+				assert.Equal(t, "mistral", backend.Ollama.Model)
+			},
+		},
+		{
+			name: "shadow backend validated",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+  shadow:
+    enabled: true
+    backend: openai_compat
+    sample_rate: 1.5
+`,
+			wantErr: "llm.shadow.openai_compat.endpoint is required when backend is openai_compat",
+		},
+		{
+			name: "shadow sample rate out of range",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+  shadow:
+    enabled: true
+    backend: anthropic
+    anthropic:
+      model: claude-haiku-4-20250514
+    sample_rate: 1.5
+`,
+			wantErr: "llm.shadow.sample_rate must be in (0, 1] (got 1.5)",
+		},
+		{
+			name: "judge concurrency and reservation",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+observability:
+  judge:
+    enabled: true
+    concurrency: 4
+`,
+			checkFunc: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				j := cfg.Observability.Judge
+				assert.Equal(t, 4, j.Concurrency)
+				assert.InDelta(t, 0.01, j.EstimatedCostUSD, 0)
+				assert.InDelta(t, 10.0, j.DailyBudgetUSD, 0)
+			},
+		},
+		{
+			name: "judge gate defaults",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+observability:
+  judge:
+    enabled: true
+alerts:
+  judge_gate:
+    enabled: true
+`,
+			checkFunc: func(t *testing.T, cfg *Config) {
+				t.Helper()
+				g := cfg.Alerts.JudgeGate
+				assert.Equal(t, 45*time.Minute, g.MaxWait)
+				assert.InDelta(t, 0.3, g.MinScore, 0)
+				assert.Equal(t, JudgeGateActionSummary, g.Action)
+			},
+		},
+		{
+			name: "judge gate without judge",
+			yaml: `
+database:
+  host: localhost
+  name: testdb
+  user: testuser
+llm:
+  backend: ollama
+  ollama:
+    endpoint: http://localhost:11434
+alerts:
+  judge_gate:
+    enabled: true
+    min_score: 1.5
+    action: drop
+`,
+			wantErr: "alerts.judge_gate requires observability.judge.enabled",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,167 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+)
+
+const defaultRefreshInterval = 6 * time.Hour
+
+// Source loads a raw rate document for ParseRateTable.
+type Source interface {
+	Fetch(ctx context.Context) ([]byte, error)
+}
+
+// FileSource reads the rate document from a local file, e.g. one
+// mounted from a ConfigMap.
+type FileSource string
+
+// Fetch implements Source.
+func (f FileSource) Fetch(context.Context) ([]byte, error) {
+	data, err := os.ReadFile(string(f)) //nolint:gosec // path from trusted config
+	if err != nil {
+		return nil, fmt.Errorf("reading rate file: %w", err)
+	}
+	return data, nil
+}
+
+// HTTPSource fetches the rate document with a GET request.
+type HTTPSource struct {
+	URL    string
+	Client *http.Client // nil = 30s timeout client
+}
+
+// Fetch implements Source.
+func (h *HTTPSource) Fetch(ctx context.Context) ([]byte, error) {
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating rate request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading rate response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate source returned status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// Provider is a Converter backed by a cached RateTable that it reloads
+// from a Source. A failed reload keeps the previous table, so a flaky
+// rate API degrades to slightly stale rates rather than no conversion.
+type Provider struct {
+	source  Source
+	refresh time.Duration
+	log     *slog.Logger
+
+	mu       sync.RWMutex
+	table    *RateTable
+	loadedAt time.Time
+}
+
+// ProviderOption configures a Provider.
+type ProviderOption func(*Provider)
+
+// WithRefreshInterval sets how often Start reloads the table.
+func WithRefreshInterval(d time.Duration) ProviderOption {
+	return func(p *Provider) {
+		if d > 0 {
+			p.refresh = d
+		}
+	}
+}
+
+// WithProviderLogger sets the logger for reload failures.
+func WithProviderLogger(l *slog.Logger) ProviderOption {
+	return func(p *Provider) {
+		p.log = l
+	}
+}
+
+// NewProvider returns a Provider for source. Until the first successful
+// Refresh it converts USD only.
+func NewProvider(source Source, opts ...ProviderOption) *Provider {
+	p := &Provider{
+		source:  source,
+		refresh: defaultRefreshInterval,
+		log:     slog.Default(),
+		table:   &RateTable{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Refresh loads the table from the source and swaps it in.
+func (p *Provider) Refresh(ctx context.Context) error {
+	data, err := p.source.Fetch(ctx)
+	if err == nil {
+		var t *RateTable
+		if t, err = ParseRateTable(data); err == nil {
+			p.mu.Lock()
+			p.table = t
+			p.loadedAt = time.Now()
+			p.mu.Unlock()
+			metrics.CurrencyRatesLastRefreshTimestamp.Set(float64(time.Now().Unix()))
+			return nil
+		}
+	}
+	metrics.CurrencyRatesRefreshFailuresTotal.Inc()
+	return err
+}
+
+// Start reloads the table every refresh interval until ctx is done.
+func (p *Provider) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.Refresh(ctx); err != nil {
+					p.log.Warn("exchange rate refresh failed, keeping previous rates", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// ToUSD implements Converter using the current table.
+func (p *Provider) ToUSD(amount float64, currency string) (float64, bool) {
+	p.mu.RLock()
+	t := p.table
+	p.mu.RUnlock()
+	return t.ToUSD(amount, currency)
+}
+
+// Table returns the current table and when it was loaded; the time is
+// zero before the first successful Refresh. The table must not be
+// modified.
+func (p *Provider) Table() (*RateTable, time.Time) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.table, p.loadedAt
+}
@@ -0,0 +1,74 @@
+package currency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/currency"
+)
+
+func TestProvider_FileSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rates": {"GBP": 0.5}}`), 0o600))
+
+	p := currency.NewProvider(currency.FileSource(path))
+
+	// Before the first refresh only USD converts.
+	_, ok := p.ToUSD(10, "GBP")
+	assert.False(t, ok)
+	_, loadedAt := p.Table()
+	assert.True(t, loadedAt.IsZero())
+
+	require.NoError(t, p.Refresh(context.Background()))
+	usd, ok := p.ToUSD(10, "GBP")
+	require.True(t, ok)
+	assert.InDelta(t, 20, usd, 1e-9)
+
+	_, loadedAt = p.Table()
+	assert.False(t, loadedAt.IsZero())
+}
+
+func TestProvider_FailedRefreshKeepsRates(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"base": "USD", "rates": {"EUR": 0.8}}`))
+	}))
+	defer srv.Close()
+
+	p := currency.NewProvider(&currency.HTTPSource{URL: srv.URL})
+	require.NoError(t, p.Refresh(context.Background()))
+
+	fail.Store(true)
+	err := p.Refresh(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 502")
+
+	usd, ok := p.ToUSD(80, "EUR")
+	require.True(t, ok)
+	assert.InDelta(t, 100, usd, 1e-9)
+}
+
+func TestProvider_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	p := currency.NewProvider(currency.FileSource(filepath.Join(t.TempDir(), "missing.json")))
+	err := p.Refresh(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading rate file")
+}
@@ -0,0 +1,104 @@
+// Package currency converts listing prices to USD using a cached table
+// of exchange rates loaded from a file or an HTTP source.
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// USD is the currency every price is normalized to.
+const USD = "USD"
+
+// Converter converts amounts to USD.
+type Converter interface {
+	// ToUSD converts amount, priced in currency, to USD. ok is false
+	// when there is no rate for currency.
+	ToUSD(amount float64, currency string) (usd float64, ok bool)
+}
+
+// RateTable holds exchange rates as units of each currency per one USD,
+// the way most rate APIs publish them ({"EUR": 0.92} means $1 = €0.92).
+type RateTable struct {
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt time.Time          `json:"updated_at,omitzero"`
+}
+
+// ToUSD implements Converter. USD always converts, even with an empty
+// table. An empty currency is taken as USD, which is what every listing
+// was assumed to be priced in before marketplaces were configurable.
+func (t *RateTable) ToUSD(amount float64, currency string) (float64, bool) {
+	currency = strings.ToUpper(currency)
+	if currency == USD || currency == "" {
+		return amount, true
+	}
+	rate, ok := t.Rates[currency]
+	if !ok {
+		return 0, false
+	}
+	return amount / rate, true
+}
+
+// rateDocument is the JSON accepted by ParseRateTable. base_code and
+// time_last_update_unix match open.er-api.com responses.
+type rateDocument struct {
+	Base        string             `json:"base"`
+	BaseCode    string             `json:"base_code"`
+	Rates       map[string]float64 `json:"rates"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	UpdatedUnix int64              `json:"time_last_update_unix"`
+}
+
+// ParseRateTable parses a JSON rate document:
+//
+//	{"base": "USD", "rates": {"EUR": 0.92, "GBP": 0.79}}
+//
+// base defaults to USD. A table quoted against another base is rebased
+// to USD, which then needs a USD rate.
+func ParseRateTable(data []byte) (*RateTable, error) {
+	var doc rateDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing rate table: %w", err)
+	}
+	if len(doc.Rates) == 0 {
+		return nil, errors.New("rate table has no rates")
+	}
+
+	base := strings.ToUpper(doc.Base)
+	if base == "" {
+		base = strings.ToUpper(doc.BaseCode)
+	}
+	if base == "" {
+		base = USD
+	}
+
+	rates := make(map[string]float64, len(doc.Rates)+1)
+	for code, rate := range doc.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate table has a non-positive rate for %s", code)
+		}
+		rates[strings.ToUpper(code)] = rate
+	}
+	rates[base] = 1
+
+	// Rebase so every rate is per one USD.
+	if base != USD {
+		usd, ok := rates[USD]
+		if !ok {
+			return nil, fmt.Errorf("rate table is based on %s and has no USD rate", base)
+		}
+		for code, rate := range rates {
+			rates[code] = rate / usd
+		}
+	}
+	delete(rates, USD)
+
+	t := &RateTable{Rates: rates, UpdatedAt: doc.UpdatedAt}
+	if t.UpdatedAt.IsZero() && doc.UpdatedUnix > 0 {
+		t.UpdatedAt = time.Unix(doc.UpdatedUnix, 0).UTC()
+	}
+	return t, nil
+}
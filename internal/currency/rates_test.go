@@ -0,0 +1,111 @@
+package currency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/currency"
+)
+
+func TestParseRateTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      string
+		want      map[string]float64
+		wantTime  time.Time
+		wantErr   bool
+		errSubstr string
+	}{
+		{
+			name: "USD base",
+			data: `{"base": "USD", "rates": {"EUR": 0.8, "gbp": 0.5}}`,
+			want: map[string]float64{"EUR": 0.8, "GBP": 0.5},
+		},
+		{
+			name: "base defaults to USD",
+			data: `{"rates": {"EUR": 0.8, "USD": 1}}`,
+			want: map[string]float64{"EUR": 0.8},
+		},
+		{
+			name:     "open.er-api.com shape",
+			data:     `{"base_code": "USD", "time_last_update_unix": 1700000000, "rates": {"CAD": 1.25}}`,
+			want:     map[string]float64{"CAD": 1.25},
+			wantTime: time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name: "EUR base is rebased to USD",
+			data: `{"base": "EUR", "rates": {"USD": 1.25, "GBP": 0.5}}`,
+			want: map[string]float64{"EUR": 0.8, "GBP": 0.4},
+		},
+		{
+			name:      "non-USD base without a USD rate",
+			data:      `{"base": "EUR", "rates": {"GBP": 0.5}}`,
+			wantErr:   true,
+			errSubstr: "no USD rate",
+		},
+		{
+			name:      "no rates",
+			data:      `{"base": "USD", "rates": {}}`,
+			wantErr:   true,
+			errSubstr: "no rates",
+		},
+		{
+			name:      "zero rate",
+			data:      `{"rates": {"EUR": 0}}`,
+			wantErr:   true,
+			errSubstr: "non-positive",
+		},
+		{
+			name:      "malformed JSON",
+			data:      `{`,
+			wantErr:   true,
+			errSubstr: "parsing rate table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := currency.ParseRateTable([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got.Rates, len(tt.want))
+			for code, rate := range tt.want {
+				assert.InDeltaf(t, rate, got.Rates[code], 1e-9, "rate for %s", code)
+			}
+			assert.Equal(t, tt.wantTime, got.UpdatedAt)
+		})
+	}
+}
+
+func TestRateTable_ToUSD(t *testing.T) {
+	t.Parallel()
+
+	table := &currency.RateTable{Rates: map[string]float64{"EUR": 0.8}}
+
+	usd, ok := table.ToUSD(100, "EUR")
+	require.True(t, ok)
+	assert.InDelta(t, 125, usd, 1e-9)
+
+	usd, ok = table.ToUSD(100, "usd")
+	require.True(t, ok)
+	assert.InDelta(t, 100, usd, 1e-9)
+
+	_, ok = table.ToUSD(100, "JPY")
+	assert.False(t, ok)
+
+	// An empty table still converts USD.
+	usd, ok = (&currency.RateTable{}).ToUSD(42, "USD")
+	require.True(t, ok)
+	assert.InDelta(t, 42, usd, 1e-9)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
@@ -23,6 +24,7 @@ const (
 type BrowseClient struct {
 	tokens      TokenProvider
 	browseURL   string
+	itemURL     string
 	marketplace string
 	client      *http.Client
 	rateLimiter *RateLimiter
@@ -38,6 +40,14 @@ func WithBrowseURL(u string) BrowseOption {
 	}
 }
 
+// WithItemURL overrides the getItem endpoint. By default it is derived
+// from the Browse URL: .../item_summary/search becomes .../item.
+func WithItemURL(u string) BrowseOption {
+	return func(c *BrowseClient) {
+		c.itemURL = u
+	}
+}
+
 // WithMarketplace overrides the default marketplace.
 func WithMarketplace(m string) BrowseOption {
 	return func(c *BrowseClient) {
@@ -53,7 +63,8 @@ func WithBrowseHTTPClient(hc *http.Client) BrowseOption {
 }
 
 // WithRateLimiter injects a rate limiter that controls per-second and daily
-// API call limits. When set, every Search() call goes through Wait() first.
+// API call limits. When set, every Search() and GetItem() call goes through
+// Wait() first.
 func WithRateLimiter(r *RateLimiter) BrowseOption {
 	return func(c *BrowseClient) {
 		c.rateLimiter = r
@@ -71,6 +82,13 @@ func NewBrowseClient(tokens TokenProvider, opts ...BrowseOption) *BrowseClient {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.itemURL == "" {
+		base, ok := strings.CutSuffix(c.browseURL, "/item_summary/search")
+		if !ok {
+			base = strings.TrimSuffix(c.browseURL, "/")
+		}
+		c.itemURL = base + "/item"
+	}
 	return c
 }
 
@@ -87,6 +105,47 @@ func (c *BrowseClient) Search(
 	ctx context.Context,
 	req SearchRequest,
 ) (*SearchResponse, error) {
+	body, err := c.get(ctx, c.buildSearchURL(req), c.marketplaceFor(req), "search")
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp browseAPIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parsing search response: %w", err)
+	}
+
+	return &SearchResponse{
+		Items:   apiResp.ItemSummaries,
+		Total:   apiResp.Total,
+		Offset:  apiResp.Offset,
+		Limit:   apiResp.Limit,
+		HasMore: apiResp.Next != "",
+	}, nil
+}
+
+// GetItem implements EbayClient.GetItem by querying the Browse API
+// getItem endpoint.
+func (c *BrowseClient) GetItem(
+	ctx context.Context,
+	itemID, marketplace string,
+) (*ItemSummary, error) {
+	u := strings.TrimSuffix(c.itemURL, "/") + "/" + url.PathEscape(itemID)
+	body, err := c.get(ctx, u, c.marketplaceFor(SearchRequest{Marketplace: marketplace}), "item")
+	if err != nil {
+		return nil, err
+	}
+
+	var item ItemSummary
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("parsing item response: %w", err)
+	}
+	return &item, nil
+}
+
+// get performs one rate-limited, authenticated Browse API GET and
+// returns the body of a 200 response. what names the request in errors.
+func (c *BrowseClient) get(ctx context.Context, u, marketplace, what string) ([]byte, error) {
 	if c.rateLimiter != nil {
 		if err := c.rateLimiter.Wait(ctx); err != nil {
 			if errors.Is(err, ErrDailyLimitReached) {
@@ -102,23 +161,18 @@ func (c *BrowseClient) Search(
 		return nil, fmt.Errorf("getting auth token: %w", err)
 	}
 
-	u := c.buildSearchURL(req)
-
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("creating HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set(
-		"X-EBAY-C-MARKETPLACE-ID",
-		c.marketplace,
-	)
+	httpReq.Header.Set("X-EBAY-C-MARKETPLACE-ID", marketplace)
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.client.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("executing search request: %w", err)
+		return nil, fmt.Errorf("executing %s request: %w", what, err)
 	}
 	defer resp.Body.Close()
 
@@ -134,19 +188,16 @@ func (c *BrowseClient) Search(
 			string(body),
 		)
 	}
+	return body, nil
+}
 
-	var apiResp browseAPIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("parsing search response: %w", err)
+// marketplaceFor returns the marketplace to search: the request's, or
+// the client's default when the request doesn't set one.
+func (c *BrowseClient) marketplaceFor(req SearchRequest) string {
+	if req.Marketplace != "" {
+		return req.Marketplace
 	}
-
-	return &SearchResponse{
-		Items:   apiResp.ItemSummaries,
-		Total:   apiResp.Total,
-		Offset:  apiResp.Offset,
-		Limit:   apiResp.Limit,
-		HasMore: apiResp.Next != "",
-	}, nil
+	return c.marketplace
 }
 
 func (c *BrowseClient) buildSearchURL(req SearchRequest) string {
@@ -172,6 +223,9 @@ func (c *BrowseClient) buildSearchURL(req SearchRequest) string {
 	}
 
 	for k, v := range req.Filters {
+		if k == "filter" {
+			v = withPriceCurrency(v, c.marketplaceFor(req))
+		}
 		params.Set(k, v)
 	}
 
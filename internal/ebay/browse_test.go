@@ -52,6 +52,16 @@ func TestBrowseClient_Search(t *testing.T) {
 			wantItems: 2,
 			wantMore:  true,
 		},
+		{
+			name: "request marketplace overrides the client default",
+			req:  ebay.SearchRequest{Query: "DDR4", Marketplace: "EBAY_DE"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "EBAY_DE", r.Header.Get("X-EBAY-C-MARKETPLACE-ID"))
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"itemSummaries": [], "total": 0, "offset": 0, "limit": 50}`))
+			},
+		},
 		{
 			name: "empty results",
 			req:  ebay.SearchRequest{Query: "nonexistent item xyz"},
@@ -279,6 +289,37 @@ func TestBrowseClient_Search_QueryParams(t *testing.T) {
 				"offset": "20",
 			},
 		},
+		{
+			name: "price filter gets the marketplace currency",
+			req: ebay.SearchRequest{
+				Query:   "test",
+				Filters: map[string]string{"filter": "price:[..150],conditionIds:{3000}"},
+			},
+			wantQuery: map[string]string{
+				"filter": "price:[..150],conditionIds:{3000},priceCurrency:USD",
+			},
+		},
+		{
+			name: "explicit price currency is kept",
+			req: ebay.SearchRequest{
+				Query:   "test",
+				Filters: map[string]string{"filter": "price:[..150],priceCurrency:EUR"},
+			},
+			wantQuery: map[string]string{
+				"filter": "price:[..150],priceCurrency:EUR",
+			},
+		},
+		{
+			name: "request marketplace picks the price currency",
+			req: ebay.SearchRequest{
+				Query:       "test",
+				Filters:     map[string]string{"filter": "price:[..150]"},
+				Marketplace: "EBAY_GB",
+			},
+			wantQuery: map[string]string{
+				"filter": "price:[..150],priceCurrency:GBP",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -318,3 +359,61 @@ func TestBrowseClient_Search_QueryParams(t *testing.T) {
 		})
 	}
 }
+
+func TestBrowseClient_GetItem(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The item endpoint is derived from the configured search URL.
+		assert.Equal(t, "/buy/browse/v1/item/v1|123|0", r.URL.Path)
+		assert.Equal(t, "EBAY_GB", r.Header.Get("X-EBAY-C-MARKETPLACE-ID"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"itemId": "v1|123|0",
+			"title": "Dell R740xd",
+			"buyingOptions": ["AUCTION"],
+			"currentBidPrice": {"value": "310.00", "currency": "GBP"},
+			"bidCount": 7,
+			"itemEndDate": "2026-05-01T18:00:00.000Z"
+		}`))
+	}))
+	defer srv.Close()
+
+	mockTokens := mocks.NewMockTokenProvider(t)
+	mockTokens.EXPECT().
+		Token(mock.Anything).
+		Return("test-token", nil)
+
+	client := ebay.NewBrowseClient(
+		mockTokens,
+		ebay.WithBrowseURL(srv.URL+"/buy/browse/v1/item_summary/search"),
+	)
+
+	item, err := client.GetItem(context.Background(), "v1|123|0", "EBAY_GB")
+	require.NoError(t, err)
+	assert.Equal(t, "Dell R740xd", item.Title)
+	assert.Equal(t, 7, item.BidCount)
+	require.NotNil(t, item.CurrentBidPrice)
+	assert.Equal(t, "310.00", item.CurrentBidPrice.Value)
+}
+
+func TestBrowseClient_GetItem_NotFound(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"errorId":11001}]}`))
+	}))
+	defer srv.Close()
+
+	mockTokens := mocks.NewMockTokenProvider(t)
+	mockTokens.EXPECT().
+		Token(mock.Anything).
+		Return("test-token", nil)
+
+	client := ebay.NewBrowseClient(mockTokens, ebay.WithBrowseURL(srv.URL))
+	_, err := client.GetItem(context.Background(), "v1|123|0", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
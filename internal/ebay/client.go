@@ -8,12 +8,13 @@ import (
 
 // SearchRequest defines the parameters for an eBay search.
 type SearchRequest struct {
-	Query      string
-	CategoryID string
-	Limit      int
-	Offset     int
-	Sort       string // "newlyListed"
-	Filters    map[string]string
+	Query       string
+	CategoryID  string
+	Limit       int
+	Offset      int
+	Sort        string // "newlyListed"
+	Filters     map[string]string
+	Marketplace string // "" = the client's marketplace, e.g. "EBAY_GB"
 }
 
 // SearchResponse holds the results of an eBay search.
@@ -28,6 +29,9 @@ type SearchResponse struct {
 // EbayClient defines the interface for interacting with the eBay API.
 type EbayClient interface {
 	Search(ctx context.Context, req SearchRequest) (*SearchResponse, error)
+	// GetItem fetches one item's current state by its Browse API item
+	// ID. marketplace "" means the client's marketplace.
+	GetItem(ctx context.Context, itemID, marketplace string) (*ItemSummary, error)
 }
 
 // TokenProvider defines the interface for obtaining OAuth2 tokens.
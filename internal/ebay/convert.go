@@ -13,12 +13,14 @@ import (
 func ToListings(items []ItemSummary) []domain.Listing {
 	listings := make([]domain.Listing, 0, len(items))
 	for i := range items {
-		listings = append(listings, toListing(&items[i]))
+		listings = append(listings, ToListing(&items[i]))
 	}
 	return listings
 }
 
-func toListing(item *ItemSummary) domain.Listing {
+// ToListing converts one eBay item into a domain listing. An auction's
+// price is its current bid when eBay reports one.
+func ToListing(item *ItemSummary) domain.Listing {
 	l := domain.Listing{
 		EbayID:      item.ItemID,
 		Title:       item.Title,
@@ -33,11 +35,25 @@ func toListing(item *ItemSummary) domain.Listing {
 		l.Price = p
 	}
 
+	// Current bid (auctions only).
+	l.BidCount = item.BidCount
+	if bid := item.CurrentBidPrice; bid != nil && l.ListingType == domain.ListingAuction {
+		if p, err := strconv.ParseFloat(bid.Value, 64); err == nil {
+			l.Price = p
+			l.Currency = bid.Currency
+		}
+	}
+
 	// Image
 	if item.Image != nil && item.Image.ImageURL != "" {
 		l.ImageURL = item.Image.ImageURL
 	}
 
+	// Location
+	if item.ItemLocation != nil {
+		l.ItemCountry = item.ItemLocation.Country
+	}
+
 	// Seller
 	if item.Seller != nil {
 		l.SellerName = item.Seller.Username
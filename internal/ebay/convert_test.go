@@ -34,6 +34,7 @@ func TestToListings(t *testing.T) {
 					Title:             "Samsung 32GB DDR4 ECC REG",
 					ItemURL:           "https://www.ebay.com/itm/123456",
 					ImageURL:          "https://i.ebayimg.com/images/123.jpg",
+					ItemCountry:       "US",
 					Price:             45.99,
 					Currency:          "USD",
 					ShippingCost:      floatPtr(5.99),
@@ -94,6 +95,32 @@ func TestToListings(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "auction current bid replaces price",
+			items: []ebay.ItemSummary{
+				{
+					ItemID:          "v1|112|0",
+					Title:           "Auction Item",
+					Price:           ebay.ItemPrice{Value: "1.00", Currency: "USD"},
+					CurrentBidPrice: &ebay.ItemPrice{Value: "42.50", Currency: "USD"},
+					BidCount:        6,
+					ItemWebURL:      "https://www.ebay.com/itm/112",
+					BuyingOptions:   []string{"AUCTION"},
+				},
+			},
+			want: []domain.Listing{
+				{
+					EbayID:      "v1|112|0",
+					Title:       "Auction Item",
+					ItemURL:     "https://www.ebay.com/itm/112",
+					Price:       42.50,
+					Currency:    "USD",
+					ListingType: domain.ListingAuction,
+					BidCount:    6,
+					Quantity:    1,
+				},
+			},
+		},
 		{
 			name: "best offer listing type",
 			items: []ebay.ItemSummary{
@@ -229,6 +256,10 @@ func completeItem() ebay.ItemSummary {
 		Price:      ebay.ItemPrice{Value: "45.99", Currency: "USD"},
 		ItemWebURL: "https://www.ebay.com/itm/123456",
 		Image:      &ebay.ItemImage{ImageURL: "https://i.ebayimg.com/images/123.jpg"},
+		ItemLocation: &ebay.ItemLocation{
+			Country:    "US",
+			PostalCode: "941**",
+		},
 		Seller: &ebay.ItemSeller{
 			Username:           "server_parts_co",
 			FeedbackScore:      5432,
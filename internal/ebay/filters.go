@@ -0,0 +1,173 @@
+package ebay
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// SortNewlyListed orders search results newest first. The paginator's
+// stop-at-known-listing check relies on it.
+const SortNewlyListed = "newlyListed"
+
+// browseConditionIDs maps each normalized condition to the Browse API
+// condition IDs whose condition text normalizes to it. ConditionUnknown
+// has no entry: any ID can end up there.
+var browseConditionIDs = map[domain.Condition][]string{
+	domain.ConditionNew:         {"1000"},
+	domain.ConditionLikeNew:     {"1500", "2000", "2010"},
+	domain.ConditionUsedWorking: {"2020", "2030", "2500", "3000"},
+	domain.ConditionForParts:    {"7000"},
+}
+
+// browseBuyingOptions maps listing types to Browse buyingOptions values.
+// A best-offer listing is also FIXED_PRICE on eBay, so buy_it_now
+// returns both and Match tells them apart.
+var browseBuyingOptions = map[domain.ListingType]string{
+	domain.ListingAuction:   "AUCTION",
+	domain.ListingBuyItNow:  "FIXED_PRICE",
+	domain.ListingBestOffer: "BEST_OFFER",
+}
+
+// marketplaceCurrencies is the listing currency of each eBay marketplace,
+// which the Browse API requires alongside a price filter.
+var marketplaceCurrencies = map[string]string{
+	"EBAY_US": "USD",
+	"EBAY_CA": "CAD",
+	"EBAY_GB": "GBP",
+	"EBAY_AU": "AUD",
+	"EBAY_AT": "EUR",
+	"EBAY_BE": "EUR",
+	"EBAY_DE": "EUR",
+	"EBAY_ES": "EUR",
+	"EBAY_FR": "EUR",
+	"EBAY_IE": "EUR",
+	"EBAY_IT": "EUR",
+	"EBAY_NL": "EUR",
+	"EBAY_CH": "CHF",
+	"EBAY_PL": "PLN",
+	"EBAY_HK": "HKD",
+	"EBAY_SG": "SGD",
+}
+
+// MarketplaceCurrency returns the currency code listings on marketplace
+// are priced in.
+func MarketplaceCurrency(marketplace string) (string, bool) {
+	c, ok := marketplaceCurrencies[marketplace]
+	return c, ok
+}
+
+// CurrencyCountries returns the countries of the marketplaces that price
+// listings in currency, e.g. the eurozone marketplaces for EUR.
+func CurrencyCountries(currency string) []string {
+	var countries []string
+	for m, c := range marketplaceCurrencies {
+		if c == currency {
+			countries = append(countries, strings.TrimPrefix(m, "EBAY_"))
+		}
+	}
+	slices.Sort(countries)
+	return countries
+}
+
+// BrowseFilter translates the parts of a watch's filters the Browse API
+// can apply into a filter= value, so eBay doesn't return listings Match
+// would reject anyway. Every clause is looser than or equal to the local
+// check, which still runs on each listing:
+//
+//   - price_max becomes price:[..max]. Unit price is price plus
+//     shipping (listings are ingested with quantity 1), so an item over
+//     max on price alone can't match. price_min is not pushed: shipping
+//     can lift a cheaper item over it.
+//   - conditions become conditionIds:{…}, unless they include unknown,
+//     which any condition ID can normalize to.
+//   - buying_options become buyingOptions:{…}.
+//   - item_location_country becomes itemLocationCountry:XX.
+//   - seller_allowlist becomes sellers:{…} and seller_blocklist
+//     becomes excludeSellers:{…}.
+//
+// BrowseClient adds the priceCurrency a price clause needs. Returns ""
+// when nothing can be pushed.
+func BrowseFilter(f *domain.WatchFilters) string {
+	var clauses []string
+
+	if f.PriceMax != nil {
+		clauses = append(clauses, "price:[.."+strconv.FormatFloat(*f.PriceMax, 'f', -1, 64)+"]")
+	}
+
+	if ids := conditionIDs(f.Conditions); len(ids) > 0 {
+		clauses = append(clauses, "conditionIds:{"+strings.Join(ids, "|")+"}")
+	}
+
+	if opts := buyingOptions(f.BuyingOptions); len(opts) > 0 {
+		clauses = append(clauses, "buyingOptions:{"+strings.Join(opts, "|")+"}")
+	}
+
+	if f.ItemLocationCountry != "" {
+		clauses = append(clauses, "itemLocationCountry:"+f.ItemLocationCountry)
+	}
+
+	if len(f.SellerAllowlist) > 0 {
+		clauses = append(clauses, "sellers:{"+strings.Join(f.SellerAllowlist, "|")+"}")
+	}
+
+	if len(f.SellerBlocklist) > 0 {
+		clauses = append(clauses, "excludeSellers:{"+strings.Join(f.SellerBlocklist, "|")+"}")
+	}
+
+	return strings.Join(clauses, ",")
+}
+
+// conditionIDs returns the sorted condition IDs for conds, or nil when
+// any of them can't be expressed as IDs.
+func conditionIDs(conds []domain.Condition) []string {
+	var ids []string
+	for _, c := range conds {
+		cids, ok := browseConditionIDs[c]
+		if !ok {
+			return nil
+		}
+		ids = append(ids, cids...)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// buyingOptions returns the sorted Browse buying options for types, or
+// nil when any of them is not a known listing type.
+func buyingOptions(types []domain.ListingType) []string {
+	var opts []string
+	for _, t := range types {
+		opt, ok := browseBuyingOptions[t]
+		if !ok {
+			return nil
+		}
+		opts = append(opts, opt)
+	}
+	slices.Sort(opts)
+	return slices.Compact(opts)
+}
+
+// withPriceCurrency appends priceCurrency to a filter= value that has a
+// price clause but no currency.
+func withPriceCurrency(filter, marketplace string) string {
+	if !hasFilterClause(filter, "price") || hasFilterClause(filter, "priceCurrency") {
+		return filter
+	}
+	currency, ok := MarketplaceCurrency(marketplace)
+	if !ok {
+		return filter
+	}
+	return filter + ",priceCurrency:" + currency
+}
+
+func hasFilterClause(filter, name string) bool {
+	for clause := range strings.SplitSeq(filter, ",") {
+		if strings.HasPrefix(strings.TrimSpace(clause), name+":") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package ebay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestBrowseFilter(t *testing.T) {
+	t.Parallel()
+
+	priceMax := 149.99
+	priceMin := 50.0
+
+	tests := []struct {
+		name    string
+		filters domain.WatchFilters
+		want    string
+	}{
+		{name: "no filters", want: ""},
+		{
+			name:    "price max only; price min stays local",
+			filters: domain.WatchFilters{PriceMax: &priceMax, PriceMin: &priceMin},
+			want:    "price:[..149.99]",
+		},
+		{
+			name: "conditions map to sorted condition IDs",
+			filters: domain.WatchFilters{Conditions: []domain.Condition{
+				domain.ConditionUsedWorking, domain.ConditionNew,
+			}},
+			want: "conditionIds:{1000|2020|2030|2500|3000}",
+		},
+		{
+			name: "unknown condition is not pushed",
+			filters: domain.WatchFilters{Conditions: []domain.Condition{
+				domain.ConditionNew, domain.ConditionUnknown,
+			}},
+			want: "",
+		},
+		{
+			name: "buying options",
+			filters: domain.WatchFilters{BuyingOptions: []domain.ListingType{
+				domain.ListingBuyItNow, domain.ListingAuction,
+			}},
+			want: "buyingOptions:{AUCTION|FIXED_PRICE}",
+		},
+		{
+			name:    "item location",
+			filters: domain.WatchFilters{ItemLocationCountry: "US"},
+			want:    "itemLocationCountry:US",
+		},
+		{
+			name: "seller lists",
+			filters: domain.WatchFilters{
+				SellerAllowlist: []string{"server_parts_inc", "techrecycler"},
+				SellerBlocklist: []string{"junk_dealer"},
+			},
+			want: "sellers:{server_parts_inc|techrecycler},excludeSellers:{junk_dealer}",
+		},
+		{
+			name: "everything together",
+			filters: domain.WatchFilters{
+				PriceMax:            &priceMax,
+				Conditions:          []domain.Condition{domain.ConditionForParts},
+				BuyingOptions:       []domain.ListingType{domain.ListingBestOffer},
+				ItemLocationCountry: "DE",
+				SellerTopRatedOnly:  true,
+			},
+			want: "price:[..149.99],conditionIds:{7000},buyingOptions:{BEST_OFFER},itemLocationCountry:DE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ebay.BrowseFilter(&tt.filters))
+		})
+	}
+}
+
+func TestMarketplaceCurrency(t *testing.T) {
+	t.Parallel()
+
+	c, ok := ebay.MarketplaceCurrency("EBAY_GB")
+	assert.True(t, ok)
+	assert.Equal(t, "GBP", c)
+
+	_, ok = ebay.MarketplaceCurrency("EBAY_XX")
+	assert.False(t, ok)
+}
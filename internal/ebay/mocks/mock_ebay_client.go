@@ -23,6 +23,66 @@ func (_m *MockEbayClient) EXPECT() *MockEbayClient_Expecter {
 	return &MockEbayClient_Expecter{mock: &_m.Mock}
 }
 
+// GetItem provides a mock function with given fields: ctx, itemID, marketplace
+func (_m *MockEbayClient) GetItem(ctx context.Context, itemID string, marketplace string) (*ebay.ItemSummary, error) {
+	ret := _m.Called(ctx, itemID, marketplace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *ebay.ItemSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ebay.ItemSummary, error)); ok {
+		return rf(ctx, itemID, marketplace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ebay.ItemSummary); ok {
+		r0 = rf(ctx, itemID, marketplace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ebay.ItemSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, itemID, marketplace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEbayClient_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockEbayClient_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemID string
+//   - marketplace string
+func (_e *MockEbayClient_Expecter) GetItem(ctx interface{}, itemID interface{}, marketplace interface{}) *MockEbayClient_GetItem_Call {
+	return &MockEbayClient_GetItem_Call{Call: _e.mock.On("GetItem", ctx, itemID, marketplace)}
+}
+
+func (_c *MockEbayClient_GetItem_Call) Run(run func(ctx context.Context, itemID string, marketplace string)) *MockEbayClient_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockEbayClient_GetItem_Call) Return(_a0 *ebay.ItemSummary, _a1 error) *MockEbayClient_GetItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEbayClient_GetItem_Call) RunAndReturn(run func(context.Context, string, string) (*ebay.ItemSummary, error)) *MockEbayClient_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function with given fields: ctx, req
 func (_m *MockEbayClient) Search(ctx context.Context, req ebay.SearchRequest) (*ebay.SearchResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -70,12 +70,27 @@ func NewPaginator(
 	return p
 }
 
+// MaxPages returns the configured per-search page cap.
+func (p *Paginator) MaxPages() int {
+	return p.maxPages
+}
+
 // PaginateResult holds the result of a paginated search.
 type PaginateResult struct {
 	NewListings []domain.Listing
-	TotalSeen   int
-	PagesUsed   int
-	StoppedAt   string // "known_listing", "max_pages", "no_more_results"
+	// KnownListings are the already-stored listings on the page where
+	// pagination stopped, so callers can refresh their prices without
+	// another API call.
+	KnownListings []KnownListing
+	TotalSeen     int
+	PagesUsed     int
+	StoppedAt     string // "known_listing", "max_pages", "no_more_results"
+}
+
+// KnownListing is a stored listing as this search returned it.
+type KnownListing struct {
+	Seen   domain.Listing
+	Stored *domain.Listing
 }
 
 // Paginate fetches listings for a search query, stopping when:
@@ -83,6 +98,10 @@ type PaginateResult struct {
 // - Max pages reached
 // - No more results from eBay
 // isFirstRun caps at defaultFirstRunPages pages for initial watch polls.
+//
+// The rest of the page holding the first known listing is still checked,
+// so every stored listing on it lands in KnownListings; items there that
+// aren't stored are not returned as new.
 func (p *Paginator) Paginate(
 	ctx context.Context,
 	req SearchRequest,
@@ -92,6 +111,21 @@ func (p *Paginator) Paginate(
 	if isFirstRun && maxPages > defaultFirstRunPages {
 		maxPages = defaultFirstRunPages
 	}
+	return p.PaginateLimit(ctx, req, maxPages)
+}
+
+// PaginateLimit is Paginate with an explicit page cap, used by the
+// ingestion budget planner. Caps above the configured max pages are
+// lowered to it; caps below one are raised to one.
+//
+// A failed search still returns the partial result alongside the error;
+// its PagesUsed counts the failed request, since it spent quota too.
+func (p *Paginator) PaginateLimit(
+	ctx context.Context,
+	req SearchRequest,
+	maxPages int,
+) (*PaginateResult, error) {
+	maxPages = min(max(maxPages, 1), p.maxPages)
 
 	req.Limit = p.pageSize
 
@@ -101,12 +135,11 @@ func (p *Paginator) Paginate(
 		req.Offset = page * p.pageSize
 
 		resp, err := p.client.Search(ctx, req)
+		result.PagesUsed++
 		if err != nil {
-			return nil, fmt.Errorf("searching page %d: %w", page, err)
+			return result, fmt.Errorf("searching page %d: %w", page, err)
 		}
 
-		result.PagesUsed++
-
 		if len(resp.Items) == 0 {
 			result.StoppedAt = "no_more_results"
 			return result, nil
@@ -134,10 +167,16 @@ func (p *Paginator) Paginate(
 
 			if existing != nil {
 				foundKnown = true
-				break
+				result.KnownListings = append(result.KnownListings, KnownListing{
+					Seen:   listings[i],
+					Stored: existing,
+				})
+				continue
 			}
 
-			result.NewListings = append(result.NewListings, listings[i])
+			if !foundKnown {
+				result.NewListings = append(result.NewListings, listings[i])
+			}
 		}
 
 		if foundKnown {
@@ -24,6 +24,7 @@ func TestPaginator_Paginate(t *testing.T) {
 		maxPages    int
 		setupMocks  func(*ebayMocks.MockEbayClient, *storeMocks.MockStore)
 		wantNew     int
+		wantKnown   int
 		wantPages   int
 		wantStopped string
 		wantErr     bool
@@ -56,6 +57,12 @@ func TestPaginator_Paginate(t *testing.T) {
 								Price:      ebay.ItemPrice{Value: "30.00", Currency: "USD"},
 								ItemWebURL: "https://ebay.com/3",
 							},
+							{
+								ItemID:     "known-2",
+								Title:      "Known Item 2",
+								Price:      ebay.ItemPrice{Value: "40.00", Currency: "USD"},
+								ItemWebURL: "https://ebay.com/4",
+							},
 						},
 						HasMore: true,
 					}, nil).Once()
@@ -65,8 +72,16 @@ func TestPaginator_Paginate(t *testing.T) {
 					GetListing(mock.Anything, "known-1").
 					Return(&domain.Listing{EbayID: "known-1"}, nil).
 					Once()
+				// The rest of the page is still checked for known listings;
+				// unstored items after the first known one aren't new.
+				ms.EXPECT().GetListing(mock.Anything, "new-2").Return(nil, nil).Once()
+				ms.EXPECT().
+					GetListing(mock.Anything, "known-2").
+					Return(&domain.Listing{EbayID: "known-2"}, nil).
+					Once()
 			},
 			wantNew:     1,
+			wantKnown:   2,
 			wantPages:   1,
 			wantStopped: "known_listing",
 		},
@@ -206,7 +221,37 @@ func TestPaginator_Paginate(t *testing.T) {
 					Search(mock.Anything, mock.Anything).
 					Return(nil, errors.New("connection refused")).Once()
 			},
-			wantErr: true,
+			wantPages: 1,
+			wantErr:   true,
+		},
+		{
+			name: "eBay client error on a later page",
+			setupMocks: func(
+				ec *ebayMocks.MockEbayClient,
+				ms *storeMocks.MockStore,
+			) {
+				ec.EXPECT().
+					Search(mock.Anything, mock.Anything).
+					Return(&ebay.SearchResponse{
+						Items: []ebay.ItemSummary{
+							{
+								ItemID:     "item",
+								Title:      "Item",
+								Price:      ebay.ItemPrice{Value: "10.00", Currency: "USD"},
+								ItemWebURL: "https://ebay.com/1",
+							},
+						},
+						HasMore: true,
+					}, nil).Once()
+				ec.EXPECT().
+					Search(mock.Anything, mock.Anything).
+					Return(nil, errors.New("rate limited")).Once()
+				ms.EXPECT().
+					GetListing(mock.Anything, "item").
+					Return(nil, nil).Once()
+			},
+			wantPages: 2,
+			wantErr:   true,
 		},
 		{
 			name: "store GetListing error continues processing",
@@ -278,14 +323,69 @@ func TestPaginator_Paginate(t *testing.T) {
 
 			if tt.wantErr {
 				require.Error(t, err)
+				require.NotNil(t, result)
+				assert.Equal(t, tt.wantPages, result.PagesUsed, "failed requests still count")
 				return
 			}
 
 			require.NoError(t, err)
 			require.NotNil(t, result)
 			assert.Len(t, result.NewListings, tt.wantNew)
+			assert.Len(t, result.KnownListings, tt.wantKnown)
 			assert.Equal(t, tt.wantPages, result.PagesUsed)
 			assert.Equal(t, tt.wantStopped, result.StoppedAt)
 		})
 	}
 }
+
+func TestPaginator_PaginateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantPages int
+	}{
+		{name: "limit below max pages", limit: 2, wantPages: 2},
+		{name: "limit above max pages is clamped", limit: 50, wantPages: 4},
+		{name: "zero limit still fetches one page", limit: 0, wantPages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := ebayMocks.NewMockEbayClient(t)
+			mockStore := storeMocks.NewMockStore(t)
+
+			mockClient.EXPECT().
+				Search(mock.Anything, mock.Anything).
+				Return(&ebay.SearchResponse{
+					Items: []ebay.ItemSummary{
+						{
+							ItemID:     "item",
+							Title:      "Item",
+							Price:      ebay.ItemPrice{Value: "10.00", Currency: "USD"},
+							ItemWebURL: "https://ebay.com/1",
+						},
+					},
+					HasMore: true,
+				}, nil).Times(tt.wantPages)
+			mockStore.EXPECT().
+				GetListing(mock.Anything, mock.Anything).
+				Return(nil, nil)
+
+			paginator := ebay.NewPaginator(mockClient, mockStore, ebay.WithMaxPages(4))
+			assert.Equal(t, 4, paginator.MaxPages())
+
+			result, err := paginator.PaginateLimit(
+				context.Background(),
+				ebay.SearchRequest{Query: "test"},
+				tt.limit,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPages, result.PagesUsed)
+			assert.Equal(t, "max_pages", result.StoppedAt)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package ebay
 
-// ItemSummary represents a single item from the eBay Browse API search response.
+// ItemSummary represents a single item from the eBay Browse API search
+// response. getItem responses carry the same fields and decode into it
+// too.
 type ItemSummary struct {
 	ItemID          string           `json:"itemId"`
 	Title           string           `json:"title"`
@@ -14,6 +16,9 @@ type ItemSummary struct {
 	ShippingOptions []ShippingOption `json:"shippingOptions,omitempty"`
 	ItemEndDate     string           `json:"itemEndDate,omitempty"`
 	Categories      []ItemCategory   `json:"categories,omitempty"`
+	CurrentBidPrice *ItemPrice       `json:"currentBidPrice,omitempty"`
+	BidCount        int              `json:"bidCount,omitempty"`
+	ItemLocation    *ItemLocation    `json:"itemLocation,omitempty"`
 
 	TopRatedBuyingExperience bool `json:"topRatedBuyingExperience"`
 }
@@ -29,6 +34,12 @@ type ItemImage struct {
 	ImageURL string `json:"imageUrl"`
 }
 
+// ItemLocation is where the item ships from.
+type ItemLocation struct {
+	Country    string `json:"country"`
+	PostalCode string `json:"postalCode,omitempty"`
+}
+
 // ItemSeller holds eBay seller information.
 type ItemSeller struct {
 	Username           string `json:"username"`
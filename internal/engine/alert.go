@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/donaldgifford/server-price-tracker/internal/config"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	"github.com/donaldgifford/server-price-tracker/internal/notify"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
@@ -19,10 +20,12 @@ const batchThreshold = 5
 // AlertProcessingConfig controls how ProcessAlerts surfaces pending
 // alerts. SummaryOnly collapses every tick into one Discord embed —
 // see DESIGN-0010 / IMPL-0015 Phase 6. AlertsURLBase, when non-empty,
-// is used as the dashboard hyperlink in the summary embed.
+// is used as the dashboard hyperlink in the summary embed. JudgeGate,
+// when enabled, holds alerts for a judge verdict before they go out.
 type AlertProcessingConfig struct {
 	SummaryOnly   bool
 	AlertsURLBase string
+	JudgeGate     config.JudgeGateConfig
 }
 
 // ProcessAlerts sends notifications for pending alerts, then marks them as notified.
@@ -35,6 +38,12 @@ type AlertProcessingConfig struct {
 // into a single Discord embed regardless of count or watch grouping.
 // On success every pending alert is marked notified — operators triage
 // via the /alerts page from there. On failure no alerts are marked.
+//
+// Judge gate (cfg.JudgeGate.Enabled): before either mode, alerts the
+// judge hasn't scored yet stay pending until they are older than
+// MaxWait, and alerts scored below MinScore are suppressed or sent as
+// a separate summary embed (see applyJudgeGate). The verdict and its
+// reason ride along on per-alert notifications.
 func ProcessAlerts(
 	ctx context.Context,
 	s store.Store,
@@ -50,6 +59,18 @@ func ProcessAlerts(
 		return nil
 	}
 
+	var verdicts map[string]*domain.JudgeScore
+	if cfg.JudgeGate.Enabled {
+		g := applyJudgeGate(ctx, s, pending, cfg.JudgeGate, time.Now())
+		if err := releaseBelowFloor(ctx, s, n, g, cfg.JudgeGate.Action, cfg.AlertsURLBase); err != nil {
+			slog.Default().Warn("judge gate: releasing below-floor alerts failed", "error", err)
+		}
+		pending, verdicts = g.ready, g.verdicts
+		if len(pending) == 0 {
+			return nil
+		}
+	}
+
 	if cfg.SummaryOnly {
 		return processSummary(ctx, s, n, pending, cfg.AlertsURLBase)
 	}
@@ -63,7 +84,7 @@ func ProcessAlerts(
 			continue // watch may have been deleted
 		}
 
-		if err := sendAlerts(ctx, s, n, watch, alerts); err != nil {
+		if err := sendAlerts(ctx, s, n, watch, alerts, verdicts); err != nil {
 			metrics.NotificationFailuresTotal.Inc()
 			metrics.NotificationLastFailureTimestamp.Set(float64(time.Now().Unix()))
 			continue
@@ -83,6 +104,21 @@ func processSummary(
 	n notify.Notifier,
 	pending []domain.Alert,
 	alertsURLBase string,
+) error {
+	return sendSummary(ctx, s, n, pending, alertsURLBase, nil)
+}
+
+// sendSummary is processSummary for any pool of alerts. Non-nil
+// reasons marks the pool as judge-gated alerts below the verdict
+// floor: the embed says so and each attempt records reasons[id] as
+// its suppression reason.
+func sendSummary(
+	ctx context.Context,
+	s store.Store,
+	n notify.Notifier,
+	pending []domain.Alert,
+	alertsURLBase string,
+	reasons map[string]string,
 ) error {
 	listings := make(map[string]*domain.Listing, len(pending))
 	for i := range pending {
@@ -94,6 +130,10 @@ func processSummary(
 	}
 
 	payload := BuildSummaryPayload(pending, listings, alertsURLBase)
+	if reasons != nil {
+		payload.WatchName = "Below judge floor"
+		payload.ListingTitle = fmt.Sprintf("%d alerts below the judge floor (top score %d)", len(pending), payload.Score)
+	}
 	sendErr := n.SendAlert(ctx, payload)
 
 	errText := ""
@@ -102,7 +142,13 @@ func processSummary(
 	}
 	ids := make([]string, 0, len(pending))
 	for i := range pending {
-		recordAttempt(ctx, s, pending[i].ID, sendErr == nil, errText)
+		// A failed below-floor summary is an ordinary failed send; only a
+		// delivered one records the judge's reason.
+		if reasons != nil && sendErr == nil {
+			recordSuppressedAttempt(ctx, s, pending[i].ID, true, "", reasons[pending[i].ID])
+		} else {
+			recordAttempt(ctx, s, pending[i].ID, sendErr == nil, errText)
+		}
 		ids = append(ids, pending[i].ID)
 	}
 
@@ -191,13 +237,14 @@ func sendAlerts(
 	n notify.Notifier,
 	watch *domain.Watch,
 	alerts []domain.Alert,
+	verdicts map[string]*domain.JudgeScore,
 ) error {
 	if len(alerts) >= batchThreshold {
-		return sendBatch(ctx, s, n, watch, alerts)
+		return sendBatch(ctx, s, n, watch, alerts, verdicts)
 	}
 
 	for i := range alerts {
-		if err := sendSingle(ctx, s, n, watch, &alerts[i]); err != nil {
+		if err := sendSingle(ctx, s, n, watch, &alerts[i], verdicts[alerts[i].ID]); err != nil {
 			return err
 		}
 	}
@@ -211,6 +258,7 @@ func sendSingle(
 	n notify.Notifier,
 	watch *domain.Watch,
 	alert *domain.Alert,
+	verdict *domain.JudgeScore,
 ) error {
 	// Idempotency: skip if already successfully notified (prevents re-send after timeout).
 	already, err := s.HasSuccessfulNotification(ctx, alert.ID)
@@ -226,7 +274,7 @@ func sendSingle(
 		return fmt.Errorf("getting listing %s: %w", alert.ListingID, err)
 	}
 
-	payload := buildAlertPayload(watch, listing, alert.Score)
+	payload := withVerdict(buildAlertPayload(watch, listing, alert.Score), verdict)
 	sendErr := n.SendAlert(ctx, payload)
 
 	// Record the attempt regardless of outcome.
@@ -257,6 +305,7 @@ func sendBatch(
 	n notify.Notifier,
 	watch *domain.Watch,
 	alerts []domain.Alert,
+	verdicts map[string]*domain.JudgeScore,
 ) error {
 	payloads := make([]notify.AlertPayload, 0, len(alerts))
 	toSend := make([]domain.Alert, 0, len(alerts))
@@ -271,7 +320,7 @@ func sendBatch(
 		if err != nil {
 			continue // listing may have been removed
 		}
-		payloads = append(payloads, *buildAlertPayload(watch, listing, alerts[i].Score))
+		payloads = append(payloads, *withVerdict(buildAlertPayload(watch, listing, alerts[i].Score), verdicts[alerts[i].ID]))
 		toSend = append(toSend, alerts[i])
 	}
 
@@ -342,11 +391,40 @@ func buildAlertPayload(
 		ListingTitle:  listing.Title,
 		EbayURL:       listing.ItemURL,
 		ImageURL:      listing.ImageURL,
-		Price:         fmt.Sprintf("$%.2f", listing.Price),
-		UnitPrice:     fmt.Sprintf("$%.2f", listing.UnitPrice()),
+		Price:         alertPrice(listing),
+		UnitPrice:     alertUnitPrice(listing),
 		Score:         score,
 		Seller:        fmt.Sprintf("%s (%d)", listing.SellerName, listing.SellerFeedback),
 		Condition:     string(listing.ConditionNorm),
 		ComponentType: string(listing.ComponentType),
+		Risk:          listing.RiskScore,
+		RiskSignals:   riskSignalNames(listing),
+	}
+}
+
+func riskSignalNames(l *domain.Listing) []string {
+	var names []string
+	for _, s := range l.RiskSignalList() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// alertPrice is the listing's price as listed, with the USD equivalent
+// alongside when it was listed in another currency.
+func alertPrice(l *domain.Listing) string {
+	price := domain.FormatPrice(l.Price, l.Currency)
+	if l.PriceUSD != nil && l.Currency != "" && l.Currency != "USD" {
+		price += " (" + domain.FormatPrice(*l.PriceUSD, "USD") + ")"
+	}
+	return price
+}
+
+// alertUnitPrice is the unit price in USD, the currency the score was
+// computed in, or in the listing's currency when it has no USD price.
+func alertUnitPrice(l *domain.Listing) string {
+	if usd, ok := l.UnitPriceUSD(); ok {
+		return domain.FormatPrice(usd, "USD")
 	}
+	return domain.FormatPrice(l.UnitPrice(), l.Currency)
 }
@@ -699,6 +699,195 @@ func TestEvaluateAlert_CooldownActive_AllowsAfterWindow(t *testing.T) {
 	eng.evaluateAlert(context.Background(), watch, listing)
 }
 
+func TestEvaluateAlert_CooldownActive_PriceDrop(t *testing.T) {
+	t.Parallel()
+
+	ship := 10.0
+	tests := []struct {
+		name       string
+		dropPct    float64
+		alerted    *domain.PricePoint
+		alertedErr error
+		wantAlert  bool
+	}{
+		{
+			name:      "drop at threshold re-alerts",
+			dropPct:   20,
+			alerted:   &domain.PricePoint{Price: 390, ShippingCost: &ship, Currency: "USD"},
+			wantAlert: true,
+		},
+		{
+			name:    "drop below threshold stays in cooldown",
+			dropPct: 25,
+			alerted: &domain.PricePoint{Price: 390, ShippingCost: &ship, Currency: "USD"},
+		},
+		{
+			name:    "no alerted price stays in cooldown",
+			dropPct: 20,
+		},
+		{
+			name:       "store error stays in cooldown",
+			dropPct:    20,
+			alertedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			me := ebayMocks.NewMockEbayClient(t)
+			mx := extractMocks.NewMockExtractor(t)
+			mn := notifyMocks.NewMockNotifier(t)
+
+			// $400 with shipping at the last alert, $320 now: a 20% drop.
+			score := 85
+			listing := &domain.Listing{
+				ID: "l1", Score: &score, Price: 310, ShippingCost: &ship, Currency: "USD",
+			}
+			watch := testWatch()
+			watch.RealertDropPct = tt.dropPct
+
+			ms.EXPECT().
+				HasRecentAlert(mock.Anything, "w1", "l1", 24*time.Hour).
+				Return(true, nil).Once()
+			ms.EXPECT().
+				GetAlertedPrice(mock.Anything, "w1", "l1").
+				Return(tt.alerted, tt.alertedErr).Once()
+			if tt.wantAlert {
+				ms.EXPECT().CreateAlert(mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			eng := newTestEngine(ms, me, mx, mn)
+			eng.alertsConfig = config.AlertsConfig{
+				ReAlertsCooldown: 24 * time.Hour,
+			}
+			eng.evaluateAlert(context.Background(), watch, listing)
+		})
+	}
+}
+
+func TestEvaluateAlert_RelistOfAlertedListing(t *testing.T) {
+	t.Parallel()
+
+	ship := 10.0
+	tests := []struct {
+		name       string
+		groupID    string
+		dropPct    float64
+		grouped    bool
+		groupedErr error
+		alerted    *domain.PricePoint
+		wantAlert  bool
+	}{
+		{name: "ungrouped listing alerts", wantAlert: true},
+		{name: "group not alerted", groupID: "l0", wantAlert: true},
+		{name: "relist of alerted listing suppressed", groupID: "l0", grouped: true},
+		{
+			name:      "relist at a lower price re-alerts",
+			groupID:   "l0",
+			dropPct:   20,
+			grouped:   true,
+			alerted:   &domain.PricePoint{Price: 390, ShippingCost: &ship, Currency: "USD"},
+			wantAlert: true,
+		},
+		{name: "store error skips", groupID: "l0", groupedErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			me := ebayMocks.NewMockEbayClient(t)
+			mx := extractMocks.NewMockExtractor(t)
+			mn := notifyMocks.NewMockNotifier(t)
+
+			score := 85
+			listing := &domain.Listing{
+				ID: "l1", ListingGroupID: tt.groupID, Score: &score,
+				Price: 310, ShippingCost: &ship, Currency: "USD",
+			}
+			watch := testWatch()
+			watch.RealertDropPct = tt.dropPct
+
+			if tt.groupID != "" {
+				ms.EXPECT().
+					HasGroupAlert(mock.Anything, "w1", "l1").
+					Return(tt.grouped, tt.groupedErr).Once()
+			}
+			if tt.alerted != nil {
+				ms.EXPECT().
+					GetAlertedPrice(mock.Anything, "w1", "l1").
+					Return(tt.alerted, nil).Once()
+			}
+			if tt.wantAlert {
+				ms.EXPECT().CreateAlert(mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			eng := newTestEngine(ms, me, mx, mn)
+			eng.evaluateAlert(context.Background(), watch, listing)
+		})
+	}
+}
+
+func TestPriceDropPct(t *testing.T) {
+	t.Parallel()
+
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		from    domain.PricePoint
+		listing domain.Listing
+		want    float64
+		wantOK  bool
+	}{
+		{
+			name:    "same currency includes shipping",
+			from:    domain.PricePoint{Price: 90, ShippingCost: f(10), Currency: "USD"},
+			listing: domain.Listing{Price: 70, ShippingCost: f(5), Currency: "USD"},
+			want:    25,
+			wantOK:  true,
+		},
+		{
+			name:    "price rise is negative",
+			from:    domain.PricePoint{Price: 100, Currency: "GBP"},
+			listing: domain.Listing{Price: 110, Currency: "GBP"},
+			want:    -10,
+			wantOK:  true,
+		},
+		{
+			name:    "different currencies compare in USD",
+			from:    domain.PricePoint{Price: 80, Currency: "GBP", PriceUSD: f(100)},
+			listing: domain.Listing{Price: 90, Currency: "EUR", PriceUSD: f(90)},
+			want:    10,
+			wantOK:  true,
+		},
+		{
+			name:    "different currencies without USD",
+			from:    domain.PricePoint{Price: 80, Currency: "GBP"},
+			listing: domain.Listing{Price: 90, Currency: "EUR", PriceUSD: f(90)},
+		},
+		{
+			name:    "zero starting price",
+			from:    domain.PricePoint{Currency: "USD"},
+			listing: domain.Listing{Price: 90, Currency: "USD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := priceDropPct(&tt.from, &tt.listing)
+			require.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
 // === IMPL-0015 Phase 6: summary mode ===
 
 func TestBuildSummaryPayload(t *testing.T) {
@@ -877,3 +1066,47 @@ func TestProcessAlerts_SummaryMode_SendFailure(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "sending summary alert")
 }
+
+func TestBuildAlertPayload_Prices(t *testing.T) {
+	t.Parallel()
+
+	watch := &domain.Watch{Name: "DDR4"}
+	priceUSD, shipUSD, ship := 50.0, 5.0, 4.0
+	tests := []struct {
+		name          string
+		listing       domain.Listing
+		wantPrice     string
+		wantUnitPrice string
+	}{
+		{
+			name:          "USD",
+			listing:       domain.Listing{Price: 45.99, Currency: "USD", Quantity: 1},
+			wantPrice:     "$45.99",
+			wantUnitPrice: "$45.99",
+		},
+		{
+			name: "converted from GBP",
+			listing: domain.Listing{
+				Price: 40, Currency: "GBP", ShippingCost: &ship, Quantity: 1,
+				PriceUSD: &priceUSD, ShippingCostUSD: &shipUSD,
+			},
+			wantPrice:     "40.00 GBP ($50.00)",
+			wantUnitPrice: "$55.00",
+		},
+		{
+			name:          "no rate",
+			listing:       domain.Listing{Price: 5000, Currency: "JPY", Quantity: 1},
+			wantPrice:     "5000.00 JPY",
+			wantUnitPrice: "5000.00 JPY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := buildAlertPayload(watch, &tt.listing, 90)
+			assert.Equal(t, tt.wantPrice, p.Price)
+			assert.Equal(t, tt.wantUnitPrice, p.UnitPrice)
+		})
+	}
+}
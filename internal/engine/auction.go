@@ -0,0 +1,218 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	"github.com/donaldgifford/server-price-tracker/internal/notify"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// RunAuctionTracking refreshes the current bid on tracked auctions
+// ending within the configured refresh window, re-scores them, and sends
+// an ending-soon reminder to each qualifying watch once the auction is
+// inside the reminder lead. An auction is refreshed on every run inside
+// the reminder lead and otherwise once per refresh interval, soonest
+// ending first, up to the run's share of the eBay quota; the calls are
+// charged to the next ingestion cycle's budget. Refresh failures are
+// logged and counted; the listing keeps its stored price and is still
+// considered for a reminder.
+func (eng *Engine) RunAuctionTracking(ctx context.Context) error {
+	cfg := eng.alertsConfig.Auctions
+	now := time.Now()
+
+	auctions, err := eng.store.ListTrackedAuctions(ctx, now.Add(cfg.RefreshWindow))
+	if err != nil {
+		return fmt.Errorf("listing tracked auctions: %w", err)
+	}
+	eng.pruneAuctionRefreshes(auctions)
+	if len(auctions) == 0 {
+		return nil
+	}
+
+	watches, err := eng.store.ListWatches(ctx, true)
+	if err != nil {
+		return fmt.Errorf("listing watches: %w", err)
+	}
+
+	budget := eng.itemCallCap(cfg.CheckInterval, now)
+	var calls, deferred int
+	defer func() {
+		eng.chargeItemCalls(calls)
+		if deferred > 0 {
+			eng.log.Warn("auction refresh budget exhausted",
+				"refreshed", calls,
+				"deferred", deferred,
+			)
+		}
+	}()
+
+	var errs []error
+	for i := range auctions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		l := &auctions[i]
+		switch {
+		case !eng.auctionRefreshDue(l, now):
+		case calls >= budget:
+			deferred++
+		default:
+			calls++
+			eng.markAuctionRefreshed(l.ID, now)
+			if err := eng.refreshAuction(ctx, l, watches); err != nil {
+				metrics.AuctionRefreshFailuresTotal.Inc()
+				eng.log.Warn("auction refresh failed", "ebay_id", l.EbayID, "error", err)
+				if errors.Is(err, ebay.ErrDailyLimitReached) {
+					// Refused before it was made; stop refreshing this run.
+					calls--
+					budget = calls
+				}
+			}
+		}
+		if err := eng.remindAuction(ctx, l, watches, now); err != nil {
+			errs = append(errs, fmt.Errorf("reminding %s: %w", l.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// auctionRefreshDue reports whether l should be refreshed this run:
+// always inside the reminder lead, otherwise when it hasn't been
+// refreshed within the refresh interval.
+func (eng *Engine) auctionRefreshDue(l *domain.Listing, now time.Time) bool {
+	cfg := eng.alertsConfig.Auctions
+	if l.AuctionEndAt != nil && l.AuctionEndAt.Sub(now) <= cfg.ReminderLead {
+		return true
+	}
+	eng.auctionMu.Lock()
+	last, ok := eng.auctionRefreshed[l.ID]
+	eng.auctionMu.Unlock()
+	return !ok || now.Sub(last) >= cfg.RefreshInterval
+}
+
+func (eng *Engine) markAuctionRefreshed(id string, now time.Time) {
+	eng.auctionMu.Lock()
+	defer eng.auctionMu.Unlock()
+	if eng.auctionRefreshed == nil {
+		eng.auctionRefreshed = make(map[string]time.Time)
+	}
+	eng.auctionRefreshed[id] = now
+}
+
+// pruneAuctionRefreshes forgets auctions that are no longer tracked.
+func (eng *Engine) pruneAuctionRefreshes(tracked []domain.Listing) {
+	keep := make(map[string]bool, len(tracked))
+	for i := range tracked {
+		keep[tracked[i].ID] = true
+	}
+	eng.auctionMu.Lock()
+	defer eng.auctionMu.Unlock()
+	for id := range eng.auctionRefreshed {
+		if !keep[id] {
+			delete(eng.auctionRefreshed, id)
+		}
+	}
+}
+
+// refreshAuction fetches the item from eBay, merges the current bid,
+// bid count and end time into the stored listing, and re-scores it.
+// The upsert records a price history row when the bid moved.
+func (eng *Engine) refreshAuction(
+	ctx context.Context,
+	l *domain.Listing,
+	watches []domain.Watch,
+) error {
+	item, err := eng.ebay.GetItem(ctx, l.EbayID, itemMarketplace(l, watches))
+	if err != nil {
+		return err
+	}
+
+	fresh := ebay.ToListing(item)
+	eng.mergeSeenPrice(l, &fresh)
+
+	if err := eng.store.UpsertListing(ctx, l); err != nil {
+		return fmt.Errorf("upserting listing: %w", err)
+	}
+	if err := eng.scoreListing(ctx, l); err != nil {
+		return fmt.Errorf("scoring listing: %w", err)
+	}
+	eng.evaluateAlertsForListing(ctx, l)
+	return nil
+}
+
+// itemMarketplace picks the marketplace of the first watch whose
+// marketplace lists in the listing's currency, so getItem returns the
+// same localized price the search did. Empty means the client default.
+func itemMarketplace(l *domain.Listing, watches []domain.Watch) string {
+	for i := range watches {
+		m := watches[i].Marketplace
+		if c, ok := ebay.MarketplaceCurrency(m); m != "" && ok && c == l.Currency {
+			return m
+		}
+	}
+	return ""
+}
+
+// remindAuction sends one ending-soon reminder per qualifying watch once
+// the auction is within the reminder lead. A watch qualifies under the
+// same rules as a deal alert, minus the re-alert cooldown; the
+// auction_reminders table keeps it to one reminder per pair.
+func (eng *Engine) remindAuction(
+	ctx context.Context,
+	l *domain.Listing,
+	watches []domain.Watch,
+	now time.Time,
+) error {
+	if l.AuctionEndAt == nil || l.Score == nil {
+		return nil
+	}
+	left := l.AuctionEndAt.Sub(now)
+	if left <= 0 || left > eng.alertsConfig.Auctions.ReminderLead {
+		return nil
+	}
+
+	var errs []error
+	for i := range watches {
+		w := &watches[i]
+		if w.ComponentType != l.ComponentType || *l.Score < w.ScoreThreshold ||
+			!w.Filters.Match(l) || !eng.sellerAllowed(l) {
+			continue
+		}
+
+		sent, err := eng.store.HasAuctionReminder(ctx, w.ID, l.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if sent {
+			continue
+		}
+
+		if err := eng.notifier.SendAlert(ctx, buildReminderPayload(w, l)); err != nil {
+			errs = append(errs, fmt.Errorf("sending reminder for watch %s: %w", w.ID, err))
+			continue
+		}
+		metrics.AuctionRemindersSentTotal.Inc()
+
+		if err := eng.store.RecordAuctionReminder(ctx, w.ID, l.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// buildReminderPayload is the deal alert payload switched to the
+// ending-soon variant.
+func buildReminderPayload(w *domain.Watch, l *domain.Listing) *notify.AlertPayload {
+	p := buildAlertPayload(w, l, *l.Score)
+	p.EndsAt = l.AuctionEndAt
+	p.BidCount = l.BidCount
+	return p
+}
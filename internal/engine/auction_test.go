@@ -0,0 +1,282 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	"github.com/donaldgifford/server-price-tracker/internal/notify"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func testAuction(endsIn time.Duration) domain.Listing {
+	end := time.Now().Add(endsIn)
+	score := 80
+	return domain.Listing{
+		ID:            "l1",
+		EbayID:        "v1|123|0",
+		Title:         "Samsung 32GB DDR4",
+		Price:         40,
+		Currency:      "USD",
+		ListingType:   domain.ListingAuction,
+		BidCount:      2,
+		ComponentType: domain.ComponentRAM,
+		Quantity:      1,
+		Score:         &score,
+		AuctionEndAt:  &end,
+	}
+}
+
+func newAuctionTestEngine(
+	t *testing.T,
+	ms *storeMocks.MockStore,
+	me *ebayMocks.MockEbayClient,
+	mn *notifyMocks.MockNotifier,
+) *Engine {
+	eng := newTestEngine(ms, me, extractMocks.NewMockExtractor(t), mn)
+	eng.alertsConfig = config.AlertsConfig{
+		ReAlertsCooldown: 24 * time.Hour,
+		Auctions: config.AuctionTrackingConfig{
+			Enabled:         true,
+			CheckInterval:   5 * time.Minute,
+			RefreshWindow:   time.Hour,
+			RefreshInterval: 20 * time.Minute,
+			ReminderLead:    15 * time.Minute,
+		},
+	}
+	return eng
+}
+
+func TestRunAuctionTracking_RefreshesAndReminds(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newAuctionTestEngine(t, ms, me, mn)
+
+	auction := testAuction(10 * time.Minute)
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return([]domain.Listing{auction}, nil).
+		Once()
+	ms.EXPECT().
+		ListWatches(mock.Anything, true).
+		Return([]domain.Watch{*testWatch()}, nil)
+	me.EXPECT().
+		GetItem(mock.Anything, auction.EbayID, "").
+		Return(&ebay.ItemSummary{
+			ItemID:          auction.EbayID,
+			Price:           ebay.ItemPrice{Value: "40.00", Currency: "USD"},
+			CurrentBidPrice: &ebay.ItemPrice{Value: "52.50", Currency: "USD"},
+			BidCount:        5,
+			BuyingOptions:   []string{"AUCTION"},
+		}, nil).
+		Once()
+	ms.EXPECT().
+		UpsertListing(mock.Anything, mock.MatchedBy(func(l *domain.Listing) bool {
+			return l.Price == 52.50 && l.BidCount == 5 && l.AuctionEndAt != nil
+		})).
+		Return(nil).
+		Once()
+	ms.EXPECT().
+		HasRecentAlert(mock.Anything, "w1", "l1", mock.Anything).
+		Return(true, nil).
+		Once()
+	ms.EXPECT().
+		HasAuctionReminder(mock.Anything, "w1", "l1").
+		Return(false, nil).
+		Once()
+	mn.EXPECT().
+		SendAlert(mock.Anything, mock.MatchedBy(func(p *notify.AlertPayload) bool {
+			return p.EndsAt != nil && p.BidCount == 5 && p.Price == "$52.50"
+		})).
+		Return(nil).
+		Once()
+	ms.EXPECT().
+		RecordAuctionReminder(mock.Anything, "w1", "l1").
+		Return(nil).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+}
+
+func TestRunAuctionTracking_AlreadyReminded(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newAuctionTestEngine(t, ms, me, mn)
+
+	auction := testAuction(5 * time.Minute)
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return([]domain.Listing{auction}, nil).
+		Once()
+	ms.EXPECT().
+		ListWatches(mock.Anything, true).
+		Return([]domain.Watch{*testWatch()}, nil).
+		Once()
+	me.EXPECT().
+		GetItem(mock.Anything, auction.EbayID, "").
+		Return(nil, errors.New("ebay down")).
+		Once()
+	ms.EXPECT().
+		HasAuctionReminder(mock.Anything, "w1", "l1").
+		Return(true, nil).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+	mn.AssertNotCalled(t, "SendAlert", mock.Anything, mock.Anything)
+}
+
+func TestRunAuctionTracking_OutsideReminderLead(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newAuctionTestEngine(t, ms, me, mn)
+
+	auction := testAuction(45 * time.Minute)
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return([]domain.Listing{auction}, nil).
+		Once()
+	ms.EXPECT().
+		ListWatches(mock.Anything, true).
+		Return([]domain.Watch{*testWatch()}, nil).
+		Once()
+	me.EXPECT().
+		GetItem(mock.Anything, auction.EbayID, "").
+		Return(nil, errors.New("ebay down")).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+}
+
+func TestRunAuctionTracking_RefreshSchedule(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newAuctionTestEngine(t, ms, me, mn)
+
+	later := testAuction(45 * time.Minute)
+	ending := testAuction(10 * time.Minute)
+	ending.ID, ending.EbayID = "l2", "v1|456|0"
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return([]domain.Listing{ending, later}, nil).
+		Twice()
+	ms.EXPECT().
+		ListWatches(mock.Anything, true).
+		Return(nil, nil).
+		Twice()
+	// The auction inside the reminder lead is refreshed on both runs; the
+	// later one only on the first, until the refresh interval passes.
+	me.EXPECT().
+		GetItem(mock.Anything, ending.EbayID, "").
+		Return(nil, errors.New("ebay down")).
+		Twice()
+	me.EXPECT().
+		GetItem(mock.Anything, later.EbayID, "").
+		Return(nil, errors.New("ebay down")).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+}
+
+func TestRunAuctionTracking_ChargesItemCalls(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newAuctionTestEngine(t, ms, me, mn)
+
+	// One call left today: a single refresh this run.
+	rl := ebay.NewRateLimiter(100, 10, 1000)
+	rl.Sync(999, 1000, time.Now().Add(24*time.Hour))
+	eng.rateLimiter = rl
+
+	first := testAuction(40 * time.Minute)
+	second := testAuction(50 * time.Minute)
+	second.ID, second.EbayID = "l2", "v1|456|0"
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return([]domain.Listing{first, second}, nil).
+		Once()
+	ms.EXPECT().
+		ListWatches(mock.Anything, true).
+		Return(nil, nil).
+		Once()
+	me.EXPECT().
+		GetItem(mock.Anything, first.EbayID, "").
+		Return(nil, errors.New("ebay down")).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+
+	// The next ingestion cycle pays for the call.
+	plan := eng.planCycle(context.Background(), nil, time.Now())
+	assert.Equal(t, 1, plan.ItemCalls)
+	assert.Equal(t, defaultMaxCallsPerCycle-1, plan.CycleBudget)
+	assert.Zero(t, eng.planCycle(context.Background(), nil, time.Now()).ItemCalls)
+}
+
+func TestRunAuctionTracking_NoneTracked(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	eng := newAuctionTestEngine(t, ms, ebayMocks.NewMockEbayClient(t), notifyMocks.NewMockNotifier(t))
+
+	ms.EXPECT().
+		ListTrackedAuctions(mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	require.NoError(t, eng.RunAuctionTracking(context.Background()))
+}
+
+func TestItemMarketplace(t *testing.T) {
+	t.Parallel()
+
+	watches := []domain.Watch{
+		{Marketplace: ""},
+		{Marketplace: "EBAY_GB"},
+		{Marketplace: "EBAY_DE"},
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		want     string
+	}{
+		{name: "GBP picks EBAY_GB", currency: "GBP", want: "EBAY_GB"},
+		{name: "EUR picks EBAY_DE", currency: "EUR", want: "EBAY_DE"},
+		{name: "no match uses client default", currency: "JPY", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := &domain.Listing{Currency: tt.currency}
+			assert.Equal(t, tt.want, itemMarketplace(l, watches))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"time"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// budgetYieldLookback is how much watch_polls history the planner reads
+// to weight watches.
+const budgetYieldLookback = 24 * time.Hour
+
+// LastBudgetPlan returns the plan of the most recent ingestion cycle, or
+// nil before the first cycle. The returned plan is not modified again.
+func (eng *Engine) LastBudgetPlan() *domain.BudgetPlan {
+	eng.budgetMu.Lock()
+	defer eng.budgetMu.Unlock()
+	return eng.lastPlan
+}
+
+func (eng *Engine) setBudgetPlan(plan *domain.BudgetPlan) {
+	eng.budgetMu.Lock()
+	eng.lastPlan = plan
+	eng.budgetMu.Unlock()
+}
+
+// chargeItemCalls records getItem calls made outside ingestion. The
+// next ingestion cycle pays for them out of its page budget.
+func (eng *Engine) chargeItemCalls(n int) {
+	eng.budgetMu.Lock()
+	eng.itemCalls += n
+	eng.budgetMu.Unlock()
+}
+
+func (eng *Engine) takeItemCalls() int {
+	eng.budgetMu.Lock()
+	defer eng.budgetMu.Unlock()
+	n := eng.itemCalls
+	eng.itemCalls = 0
+	return n
+}
+
+// itemCallCap is how many getItem calls one run of a refresh job that
+// fires every interval may make: with a rate limiter, its share of the
+// remaining daily quota over the runs left before the reset, rounded
+// up; always capped at max_calls_per_cycle.
+func (eng *Engine) itemCallCap(interval time.Duration, now time.Time) int {
+	if eng.rateLimiter == nil || interval <= 0 {
+		return eng.maxCallsPerCycle
+	}
+	remaining := eng.rateLimiter.Remaining()
+	runs := int64(cyclesUntil(eng.rateLimiter.ResetAt().Sub(now), interval))
+	return int(min((remaining+runs-1)/runs, int64(eng.maxCallsPerCycle)))
+}
+
+// planCycle sizes this cycle's page budget and weights the watches to
+// poll, in poll order.
+//
+// With a rate limiter and a poll tick the budget is the remaining daily
+// quota spread evenly over the ticks left before it resets, capped at
+// max_calls_per_cycle, so the quota lasts the whole day instead of
+// running dry by mid-afternoon. Without them it is max_calls_per_cycle.
+// getItem calls made since the last cycle come out of the budget.
+func (eng *Engine) planCycle(
+	ctx context.Context,
+	watches []domain.Watch,
+	now time.Time,
+) *domain.BudgetPlan {
+	plan := &domain.BudgetPlan{
+		PlannedAt:   now,
+		CycleBudget: eng.maxCallsPerCycle,
+		Watches:     make([]domain.BudgetAllocation, len(watches)),
+	}
+
+	plan.ItemCalls = eng.takeItemCalls()
+	if eng.rateLimiter != nil && eng.pollTick > 0 {
+		plan.Adaptive = true
+		plan.QuotaRemaining = eng.rateLimiter.Remaining()
+		plan.ResetAt = eng.rateLimiter.ResetAt()
+		plan.CyclesLeft = cyclesUntil(plan.ResetAt.Sub(now), eng.pollTick)
+		// The item calls already left the remaining quota; share it out
+		// as if they hadn't so this cycle alone pays for them.
+		plan.CycleBudget = eng.cycleShare(plan.QuotaRemaining+int64(plan.ItemCalls), plan.CyclesLeft)
+	}
+	plan.CycleBudget = max(plan.CycleBudget-plan.ItemCalls, 0)
+
+	yields := make(map[string]domain.WatchYield)
+	if len(watches) > 0 {
+		ys, err := eng.store.ListWatchYields(ctx, now.Add(-budgetYieldLookback))
+		if err != nil {
+			// Equal weights are a fine fallback; don't skip the cycle.
+			eng.log.Warn("failed to load watch yields, weighting watches equally", "error", err)
+		}
+		for _, y := range ys {
+			yields[y.WatchID] = y
+		}
+	}
+
+	for i := range watches {
+		plan.Watches[i] = domain.BudgetAllocation{
+			WatchID: watches[i].ID,
+			Name:    watches[i].Name,
+			Weight:  yieldWeight(yields[watches[i].ID]),
+		}
+	}
+	return plan
+}
+
+// cycleShare returns remaining/cyclesLeft whole pages. The fractional
+// part carries over to the next cycle, so a quota smaller than the
+// number of cycles left still gets spent a page at a time rather than
+// never.
+func (eng *Engine) cycleShare(remaining int64, cyclesLeft int) int {
+	eng.budgetMu.Lock()
+	defer eng.budgetMu.Unlock()
+
+	share := float64(remaining)/float64(cyclesLeft) + eng.budgetCarry
+	if share >= float64(eng.maxCallsPerCycle) {
+		eng.budgetCarry = 0
+		return eng.maxCallsPerCycle
+	}
+	// The epsilon keeps summed thirds and tenths from losing a page.
+	budget := int(share + 1e-9)
+	eng.budgetCarry = max(share-float64(budget), 0)
+	return budget
+}
+
+// cyclesUntil counts the poll ticks left in d, counting the current one.
+func cyclesUntil(d, tick time.Duration) int {
+	if d <= 0 {
+		return 1
+	}
+	return int((d + tick - 1) / tick)
+}
+
+// yieldWeight is a watch's smoothed new listings per poll, scaled up by
+// the share of its polls that hit the page cap: those watches left new
+// listings unread. Watches with no history weigh 1.
+func yieldWeight(y domain.WatchYield) float64 {
+	perPoll := float64(y.NewListings+1) / float64(y.Polls+1)
+	if y.Polls == 0 {
+		return perPoll
+	}
+	return perPoll * (1 + float64(y.MaxPagesStops)/float64(y.Polls))
+}
+
+// pageCap is the page limit for allocs[i] given left pages in the
+// cycle: its weighted share among the watches not yet polled, keeping
+// one page back for each of them when the budget allows. Always at
+// least one page. Pages a watch doesn't use (it reached a known
+// listing) stay in left and flow to the watches after it.
+func pageCap(allocs []domain.BudgetAllocation, i, left int) int {
+	var total float64
+	for _, a := range allocs[i:] {
+		total += a.Weight
+	}
+	share := left
+	if total > 0 {
+		share = int(math.Round(float64(left) * allocs[i].Weight / total))
+	}
+	if rest := len(allocs) - i - 1; left > rest {
+		share = min(share, left-rest)
+	}
+	return max(share, 1)
+}
@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestCycleShare_CarriesFraction(t *testing.T) {
+	t.Parallel()
+
+	eng := &Engine{maxCallsPerCycle: 50}
+	// 1000 pages over 288 five-minute ticks is ~3.47 a cycle.
+	got := []int{
+		eng.cycleShare(1000, 288),
+		eng.cycleShare(1000, 288),
+		eng.cycleShare(1000, 288),
+	}
+	assert.Equal(t, []int{3, 3, 4}, got)
+
+	// Less quota than cycles: a page every few cycles, not never.
+	eng = &Engine{maxCallsPerCycle: 50}
+	var spent int
+	for range 10 {
+		spent += eng.cycleShare(3, 10)
+	}
+	assert.Equal(t, 3, spent)
+
+	// Plenty of quota is still capped by max_calls_per_cycle.
+	eng = &Engine{maxCallsPerCycle: 50}
+	assert.Equal(t, 50, eng.cycleShare(10000, 2))
+}
+
+func TestCyclesUntil(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 288, cyclesUntil(24*time.Hour, 5*time.Minute))
+	assert.Equal(t, 2, cyclesUntil(6*time.Minute, 5*time.Minute))
+	assert.Equal(t, 1, cyclesUntil(0, 5*time.Minute))
+	assert.Equal(t, 1, cyclesUntil(-time.Hour, 5*time.Minute))
+}
+
+func TestYieldWeight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		yield domain.WatchYield
+		want  float64
+	}{
+		{name: "no history", want: 1},
+		{
+			name:  "caught up every poll",
+			yield: domain.WatchYield{Polls: 9, NewListings: 19},
+			want:  2,
+		},
+		{
+			name:  "nothing new",
+			yield: domain.WatchYield{Polls: 9},
+			want:  0.1,
+		},
+		{
+			name:  "half the polls hit the page cap",
+			yield: domain.WatchYield{Polls: 4, NewListings: 39, MaxPagesStops: 2},
+			want:  12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, yieldWeight(tt.yield), 1e-9)
+		})
+	}
+}
+
+func TestPageCap(t *testing.T) {
+	t.Parallel()
+
+	allocs := func(weights ...float64) []domain.BudgetAllocation {
+		out := make([]domain.BudgetAllocation, len(weights))
+		for i, w := range weights {
+			out[i].Weight = w
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		allocs []domain.BudgetAllocation
+		i      int
+		left   int
+		want   int
+	}{
+		{name: "equal weights split evenly", allocs: allocs(1, 1), left: 10, want: 5},
+		{name: "weighted share", allocs: allocs(3, 1), left: 8, want: 6},
+		{name: "keeps a page for each later watch", allocs: allocs(100, 1, 1), left: 10, want: 8},
+		{name: "last watch takes what is left", allocs: allocs(100, 1), i: 1, left: 7, want: 7},
+		{name: "low weight still gets a page", allocs: allocs(0.01, 100), left: 10, want: 1},
+		{name: "budget smaller than watches", allocs: allocs(1, 1, 1), left: 2, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, pageCap(tt.allocs, tt.i, tt.left))
+		})
+	}
+}
+
+func TestRunIngestion_AdaptiveBudgetFollowsYield(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	ms.EXPECT().GetSystemState(mock.Anything).Return(&domain.SystemState{}, nil).Maybe()
+	ms.EXPECT().UpdateWatchLastPolled(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	ms.EXPECT().InsertWatchPoll(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// 1000 calls left over the next 24h at a 5m tick: 3 pages this cycle.
+	rl := ebay.NewRateLimiter(100, 10, 1000)
+	rl.Sync(0, 1000, time.Now().Add(24*time.Hour))
+
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithRateLimiter(rl),
+		WithPollSchedule(15*time.Minute, 5*time.Minute),
+		WithPaginator(ebay.NewPaginator(me, ms, ebay.WithMaxPages(10))),
+	)
+
+	watches := []domain.Watch{
+		{ID: "w1", Name: "Busy", SearchQuery: "DDR4", Enabled: true},
+		{ID: "w2", Name: "Quiet", SearchQuery: "rails", Enabled: true},
+	}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	// w1 keeps hitting the page cap with lots of new listings.
+	ms.EXPECT().ListWatchYields(mock.Anything, mock.Anything).Return([]domain.WatchYield{
+		{WatchID: "w1", Polls: 10, PagesUsed: 100, NewListings: 900, MaxPagesStops: 10},
+	}, nil).Once()
+
+	// Every page is full of unseen listings, so each watch uses its cap.
+	me.EXPECT().
+		Search(mock.Anything, mock.Anything).
+		Return(&ebay.SearchResponse{
+			Items:   []ebay.ItemSummary{{ItemID: "new", Price: ebay.ItemPrice{Value: "10.00", Currency: "USD"}}},
+			HasMore: true,
+		}, nil).
+		Times(3)
+	ms.EXPECT().GetListing(mock.Anything, "new").Return(nil, nil).Times(3)
+	ms.EXPECT().UpsertListing(mock.Anything, mock.Anything).Return(nil).Times(3)
+	ms.EXPECT().EnqueueExtraction(mock.Anything, mock.Anything, 0).Return(nil).Times(3)
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, eng.RunScheduledIngestion(context.Background()))
+
+	plan := eng.LastBudgetPlan()
+	require.NotNil(t, plan)
+	assert.True(t, plan.Adaptive)
+	assert.Equal(t, 288, plan.CyclesLeft)
+	assert.Equal(t, 3, plan.CycleBudget)
+	assert.Equal(t, 3, plan.CycleUsed)
+	require.Len(t, plan.Watches, 2)
+	assert.Equal(t, "w1", plan.Watches[0].WatchID)
+	assert.Equal(t, 2, plan.Watches[0].PagesPlanned)
+	assert.Equal(t, 2, plan.Watches[0].PagesUsed)
+	assert.Equal(t, 1, plan.Watches[1].PagesPlanned)
+	assert.Equal(t, 1, plan.Watches[1].PagesUsed)
+	assert.Greater(t, plan.Watches[0].Weight, plan.Watches[1].Weight)
+}
+
+func TestRunIngestion_NoQuotaDefersEveryWatch(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+
+	rl := ebay.NewRateLimiter(100, 10, 1000)
+	rl.Sync(1000, 1000, time.Now().Add(time.Hour))
+
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithRateLimiter(rl),
+		WithPollSchedule(15*time.Minute, 5*time.Minute),
+	)
+
+	watches := []domain.Watch{{ID: "w1", Name: "Watch 1", SearchQuery: "DDR4", Enabled: true}}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	// No Search expectation: an exhausted quota polls nothing.
+	require.NoError(t, eng.RunScheduledIngestion(context.Background()))
+
+	plan := eng.LastBudgetPlan()
+	require.NotNil(t, plan)
+	assert.Equal(t, 0, plan.CycleBudget)
+	assert.Equal(t, 1, plan.Deferred)
+	assert.True(t, plan.Watches[0].Deferred)
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	"github.com/donaldgifford/server-price-tracker/internal/regression"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// ErrInvalidCorrection is returned by CorrectExtraction when the
+// corrected extraction names an unknown component type or fails
+// extraction validation.
+var ErrInvalidCorrection = errors.New("invalid extraction correction")
+
+// correctionSource is the Langfuse dataset item metadata source of
+// operator corrections.
+const correctionSource = "operator_correction"
+
+// WithCorrectionsDataset adds each operator extraction correction to
+// the Langfuse dataset datasetID as a labelled example. An empty ID or
+// nil client disables the upload.
+func WithCorrectionsDataset(lf langfuse.Client, datasetID string) EngineOption {
+	return func(e *Engine) {
+		e.langfuse = lf
+		e.correctionsDataset = datasetID
+	}
+}
+
+// CorrectExtraction replaces a listing's extraction with an operator's
+// correction. The attributes are normalized and validated like an LLM
+// extraction and enriched from the catalog; the product key is rebuilt
+// and resolved through aliases. The listing is stored at full
+// confidence, locked against re-extraction, and re-scored. The
+// correction is recorded as a labelled example and, when a corrections
+// dataset is configured, added to it in Langfuse. Scoring and upload
+// failures are logged; the correction itself stands.
+func (eng *Engine) CorrectExtraction(
+	ctx context.Context,
+	listingID string,
+	componentType domain.ComponentType,
+	attrs map[string]any,
+	note string,
+) (*domain.ExtractionCorrection, error) {
+	ct, ok := extract.ParseComponentType(string(componentType))
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown component type %q", ErrInvalidCorrection, componentType)
+	}
+
+	listing, err := eng.store.GetListingByID(ctx, listingID)
+	if err != nil {
+		return nil, fmt.Errorf("getting listing %s: %w", listingID, err)
+	}
+
+	if attrs == nil {
+		attrs = make(map[string]any)
+	}
+	if _, ok := attrs["condition"]; !ok {
+		attrs["condition"] = string(listing.ConditionNorm)
+	}
+	attrs["confidence"] = 1.0
+	extract.NormalizeExtraction(ct, listing.Title, attrs)
+	if err := extract.ValidateExtraction(ct, attrs); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCorrection, err)
+	}
+	eng.enrichAttributes(ct, attrs)
+
+	c := &domain.ExtractionCorrection{
+		ListingID:             listing.ID,
+		Title:                 listing.Title,
+		PreviousComponentType: listing.ComponentType,
+		PreviousAttributes:    listing.Attributes,
+		PreviousProductKey:    listing.ProductKey,
+		ComponentType:         ct,
+		Attributes:            attrs,
+		ProductKey:            eng.resolveProductKey(ctx, extract.ProductKey(string(ct), attrs)),
+		Note:                  strings.TrimSpace(note),
+	}
+	if c.PreviousAttributes == nil {
+		c.PreviousAttributes = map[string]any{}
+	}
+	if err := eng.store.CorrectListingExtraction(ctx, c); err != nil {
+		return nil, fmt.Errorf("storing correction for %s: %w", listingID, err)
+	}
+	metrics.ExtractionCorrectionsTotal.WithLabelValues(string(ct)).Inc()
+	eng.log.Info("extraction corrected",
+		"listing", listing.ID,
+		"from", c.PreviousProductKey,
+		"to", c.ProductKey,
+	)
+
+	listing.ComponentType = ct
+	listing.Attributes = attrs
+	listing.ProductKey = c.ProductKey
+	listing.ExtractionConfidence = 1.0
+	listing.ExtractionLocked = true
+	if err := eng.scoreListing(ctx, listing); err != nil {
+		eng.log.Error("re-scoring corrected listing failed", "listing", listing.ID, "error", err)
+	}
+
+	eng.uploadCorrection(ctx, c)
+	return c, nil
+}
+
+// uploadCorrection adds the correction to the Langfuse corrections
+// dataset when one is configured.
+func (eng *Engine) uploadCorrection(ctx context.Context, c *domain.ExtractionCorrection) {
+	if eng.langfuse == nil || eng.correctionsDataset == "" {
+		return
+	}
+	item := regression.FromCorrection(c)
+	if err := eng.langfuse.CreateDatasetItem(
+		ctx, eng.correctionsDataset, regression.DatasetItem(&item, correctionSource),
+	); err != nil {
+		eng.log.Warn("uploading correction to langfuse dataset failed",
+			"listing", c.ListingID, "dataset", eng.correctionsDataset, "error", err,
+		)
+	}
+}
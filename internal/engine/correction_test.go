@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	langfuseMocks "github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestCorrectExtraction_StoresLocksAndRescores(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	lf := langfuseMocks.NewMockClient(t)
+
+	listing := testListing("nic:unknown:1p:unknown")
+	listing.Title = "LSI SAS9300-8i 12Gb/s HBA IT Mode"
+	listing.ComponentType = domain.ComponentNIC
+	listing.Attributes = map[string]any{"model": "9300-8i"}
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(listing, nil).Once()
+	ms.EXPECT().ResolveProductKey(mock.Anything, "hba:lsi:9300-8i:it").Return("hba:lsi:9300-8i:it", nil).Once()
+	var stored *domain.ExtractionCorrection
+	ms.EXPECT().CorrectListingExtraction(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *domain.ExtractionCorrection) { stored = c }).
+		Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, "hba:lsi:9300-8i:it").Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	lf.EXPECT().CreateDatasetItem(mock.Anything, "corrections",
+		mock.MatchedBy(func(item *langfuse.DatasetItem) bool {
+			return item.ExpectedOutput["product_key"] == "hba:lsi:9300-8i:it" &&
+				item.Metadata["source"] == "operator_correction"
+		}),
+	).Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	WithCorrectionsDataset(lf, "corrections")(eng)
+
+	c, err := eng.CorrectExtraction(context.Background(), "listing-1", domain.ComponentHBA,
+		map[string]any{"manufacturer": "Broadcom", "model": "SAS9300-8i", "mode": "it"}, "  classified as nic ")
+	require.NoError(t, err)
+
+	require.Same(t, c, stored)
+	assert.Equal(t, "hba:lsi:9300-8i:it", c.ProductKey)
+	assert.Equal(t, domain.ComponentNIC, c.PreviousComponentType)
+	assert.Equal(t, "nic:unknown:1p:unknown", c.PreviousProductKey)
+	assert.Equal(t, "classified as nic", c.Note)
+	assert.Equal(t, "used_working", c.Attributes["condition"], "condition defaults to the listing's")
+	assert.InDelta(t, 1.0, c.Attributes["confidence"], 0.0001)
+	assert.Equal(t, "lsi", c.Attributes["manufacturer"], "normalized like an LLM extraction")
+
+	assert.True(t, listing.ExtractionLocked)
+	assert.Equal(t, "hba:lsi:9300-8i:it", listing.ProductKey)
+	assert.NotNil(t, listing.Score)
+}
+
+func TestCorrectExtraction_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		ct        domain.ComponentType
+		attrs     map[string]any
+		setupMock func(*storeMocks.MockStore)
+		wantErr   error
+	}{
+		{
+			name:    "unknown component type",
+			ct:      "tape",
+			wantErr: ErrInvalidCorrection,
+		},
+		{
+			name:  "missing listing",
+			ct:    domain.ComponentHBA,
+			attrs: map[string]any{},
+			setupMock: func(ms *storeMocks.MockStore) {
+				ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(nil, pgx.ErrNoRows).Once()
+			},
+			wantErr: pgx.ErrNoRows,
+		},
+		{
+			name:  "attributes fail validation",
+			ct:    domain.ComponentRAM,
+			attrs: map[string]any{"generation": "DDR4"},
+			setupMock: func(ms *storeMocks.MockStore) {
+				ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(testListing(""), nil).Once()
+			},
+			wantErr: ErrInvalidCorrection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			if tt.setupMock != nil {
+				tt.setupMock(ms)
+			}
+
+			eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+			_, err := eng.CorrectExtraction(context.Background(), "listing-1", tt.ct, tt.attrs, "")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCorrectExtraction_UploadFailureKeepsCorrection(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	lf := langfuseMocks.NewMockClient(t)
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(testListing(""), nil).Once()
+	ms.EXPECT().ResolveProductKey(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, key string) (string, error) { return key, nil },
+	).Once()
+	ms.EXPECT().CorrectListingExtraction(mock.Anything, mock.Anything).Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, mock.Anything).Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().UpdateScore(mock.Anything, "listing-1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().UpdateValueMetric(mock.Anything, "listing-1", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+	ms.EXPECT().GetValueBaseline(mock.Anything, mock.Anything).Return(nil, pgx.ErrNoRows).Maybe()
+	lf.EXPECT().CreateDatasetItem(mock.Anything, "corrections", mock.Anything).
+		Return(errors.New("langfuse down")).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	WithCorrectionsDataset(lf, "corrections")(eng)
+
+	c, err := eng.CorrectExtraction(context.Background(), "listing-1", domain.ComponentRAM,
+		map[string]any{"capacity_gb": 32, "generation": "DDR4", "speed_mhz": 2666}, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ComponentRAM, c.ComponentType)
+}
+
+func TestProcessExtractionJob_SkipsLockedListing(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mx := extractMocks.NewMockExtractor(t)
+
+	listing := testListing("hba:lsi:9300-8i:it")
+	listing.ExtractionLocked = true
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(listing, nil).Once()
+	ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-l", "").Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+	eng.processExtractionJob(context.Background(), "worker-0",
+		&domain.ExtractionJob{ID: "job-l", ListingID: "listing-1"})
+
+	mx.AssertNotCalled(t, "ClassifyAndExtract", mock.Anything, mock.Anything, mock.Anything)
+}
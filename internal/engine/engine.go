@@ -5,16 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/currency"
 	"github.com/donaldgifford/server-price-tracker/internal/ebay"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	"github.com/donaldgifford/server-price-tracker/internal/notify"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
 	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
@@ -34,9 +41,35 @@ type Engine struct {
 	maxCallsPerCycle   int
 	baselineWindowDays int
 	staggerOffset      time.Duration
-	alertsConfig       config.AlertsConfig
-	alertProcessing    AlertProcessingConfig
-	workerCount        int
+	// defaultPollInterval applies to watches without their own
+	// poll_interval; pollTick is how often the scheduler checks for
+	// due watches. Zero for both makes every watch always due.
+	defaultPollInterval time.Duration
+	pollTick            time.Duration
+	alertsConfig        config.AlertsConfig
+	relists             config.RelistConfig
+	partOut             config.PartOutConfig
+	catalog             *catalog.Catalog
+	alertProcessing     AlertProcessingConfig
+	workerCount         int
+	weights             score.WeightSet
+	converter           currency.Converter
+	langfuse            langfuse.Client
+	correctionsDataset  string
+
+	// budgetMu guards the budget planner's carried fraction of a page
+	// and the last cycle's plan (see budget.go).
+	budgetMu    sync.Mutex
+	budgetCarry float64
+	lastPlan    *domain.BudgetPlan
+	// itemCalls counts getItem calls made since the last ingestion
+	// cycle; the next cycle's budget pays for them.
+	itemCalls int
+
+	// auctionMu guards when each tracked auction was last refreshed
+	// (see auction.go).
+	auctionMu        sync.Mutex
+	auctionRefreshed map[string]time.Time
 }
 
 // NewEngine creates a new Engine with injected dependencies.
@@ -56,6 +89,8 @@ func NewEngine(
 		maxCallsPerCycle:   defaultMaxCallsPerCycle,
 		baselineWindowDays: 90,
 		staggerOffset:      30 * time.Second,
+		weights:            score.WeightSet{Default: score.DefaultWeights()},
+		converter:          &currency.RateTable{},
 	}
 	for _, opt := range opts {
 		opt(eng)
@@ -87,6 +122,15 @@ func WithStaggerOffset(d time.Duration) EngineOption {
 	}
 }
 
+// WithPollSchedule sets the poll interval for watches that don't set
+// their own, and the scheduler tick used to decide when a watch is due.
+func WithPollSchedule(defaultInterval, tick time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.defaultPollInterval = defaultInterval
+		e.pollTick = tick
+	}
+}
+
 // WithPaginator sets the paginator for multi-page eBay searches.
 func WithPaginator(p *ebay.Paginator) EngineOption {
 	return func(e *Engine) {
@@ -122,15 +166,56 @@ func WithAlertsConfig(cfg config.AlertsConfig) EngineOption {
 	}
 }
 
+// WithRelistDetection sets the relist and duplicate grouping config.
+// Grouping is off unless cfg.Enabled.
+func WithRelistDetection(cfg config.RelistConfig) EngineOption {
+	return func(e *Engine) {
+		e.relists = cfg
+	}
+}
+
+// WithPartOutEstimates sets the server part-out estimator config.
+// Estimates are off unless cfg.Enabled.
+func WithPartOutEstimates(cfg config.PartOutConfig) EngineOption {
+	return func(e *Engine) {
+		e.partOut = cfg
+	}
+}
+
+// WithCatalog enriches extracted attributes from the hardware catalog
+// before the product key is built. A nil catalog disables enrichment.
+func WithCatalog(c *catalog.Catalog) EngineOption {
+	return func(e *Engine) {
+		e.catalog = c
+	}
+}
+
 // WithAlertProcessing sets the alert processing config used by
-// ProcessAlerts (SummaryOnly + AlertsURLBase). Default zero value is
-// the per-watch chunked path with no dashboard hyperlink.
+// ProcessAlerts (SummaryOnly, AlertsURLBase, JudgeGate). Default zero
+// value is the per-watch chunked path with no dashboard hyperlink and
+// no judge gate.
 func WithAlertProcessing(cfg AlertProcessingConfig) EngineOption {
 	return func(e *Engine) {
 		e.alertProcessing = cfg
 	}
 }
 
+// WithScoringWeights sets the factor weights used when scoring,
+// optionally overridden per component type (see WeightsFromConfig).
+func WithScoringWeights(ws score.WeightSet) EngineOption {
+	return func(e *Engine) {
+		e.weights = ws
+	}
+}
+
+// WithCurrencyConverter sets the converter used to store USD prices
+// next to each listing's own. The default converts USD only.
+func WithCurrencyConverter(c currency.Converter) EngineOption {
+	return func(e *Engine) {
+		e.converter = c
+	}
+}
+
 // WithWorkerCount sets the number of extraction worker goroutines.
 func WithWorkerCount(n int) EngineOption {
 	return func(e *Engine) {
@@ -198,6 +283,12 @@ func (eng *Engine) processExtractionJob(
 		eng.completeJob(ctx, workerID, job.ID, err.Error())
 		return
 	}
+	// An operator correction is authoritative; re-ingestion still
+	// enqueues the listing, so drop the job without calling the LLM.
+	if listing.ExtractionLocked {
+		eng.completeJob(ctx, workerID, job.ID, "")
+		return
+	}
 
 	extractStart := time.Now()
 	ct, attrs, extractErr := eng.extractor.ClassifyAndExtract(ctx, listing.Title, nil)
@@ -212,7 +303,9 @@ func (eng *Engine) processExtractionJob(
 		return
 	}
 
-	productKey := extract.ProductKey(string(ct), attrs)
+	metrics.ExtractionsByComponentTotal.WithLabelValues(string(ct)).Inc()
+	eng.enrichAttributes(ct, attrs)
+	productKey := eng.resolveProductKey(ctx, extract.ProductKey(string(ct), attrs))
 	if updateErr := eng.store.UpdateListingExtraction(
 		ctx, listing.ID, string(ct), attrs, 0.9, productKey,
 	); updateErr != nil {
@@ -225,8 +318,10 @@ func (eng *Engine) processExtractionJob(
 
 	listing.ProductKey = productKey
 	listing.ComponentType = ct
+	listing.Attributes = attrs
+	eng.groupListing(ctx, listing)
 
-	if scoreErr := ScoreListing(ctx, eng.store, listing); scoreErr != nil {
+	if scoreErr := eng.scoreListing(ctx, listing); scoreErr != nil {
 		eng.log.Error("scoring failed",
 			"worker", workerID, "listing", listing.EbayID, "error", scoreErr,
 		)
@@ -239,6 +334,38 @@ func (eng *Engine) processExtractionJob(
 	eng.completeJob(ctx, workerID, job.ID, "")
 }
 
+// enrichAttributes fills attributes the title left out from the
+// hardware catalog when one is configured. Component types the catalog
+// has no entries for are skipped without a lookup.
+func (eng *Engine) enrichAttributes(ct domain.ComponentType, attrs map[string]any) {
+	if eng.catalog == nil || !eng.catalog.Covers(string(ct)) {
+		return
+	}
+	result := "miss"
+	if _, ok := eng.catalog.Enrich(string(ct), attrs); ok {
+		result = "hit"
+	}
+	metrics.CatalogEnrichmentsTotal.WithLabelValues(string(ct), result).Inc()
+}
+
+// groupListing assigns the listing to its relist group when relist
+// detection is enabled. Failures are logged; the listing is then
+// treated as ungrouped.
+func (eng *Engine) groupListing(ctx context.Context, listing *domain.Listing) {
+	if !eng.relists.Enabled {
+		return
+	}
+	groupID, err := eng.store.AssignListingGroup(ctx, listing.ID, eng.relists.PriceTolerancePct)
+	if err != nil {
+		eng.log.Error("assigning listing group failed", "listing", listing.ID, "error", err)
+		return
+	}
+	if groupID != listing.ID && groupID != listing.ListingGroupID {
+		metrics.ListingsRelistedTotal.Inc()
+	}
+	listing.ListingGroupID = groupID
+}
+
 // RescoreAll re-scores every active listing and evaluates alerts after each
 // successful score. Use this from the operator-facing /api/v1/rescore path
 // (and from RunBaselineRefresh) so a manual rescore can backfill alerts on
@@ -258,7 +385,7 @@ func (eng *Engine) RescoreAll(ctx context.Context) (int, error) {
 			break
 		}
 		for i := range batch {
-			if err := ScoreListing(ctx, eng.store, &batch[i]); err != nil {
+			if err := eng.scoreListing(ctx, &batch[i]); err != nil {
 				errs = append(errs, fmt.Errorf("scoring %s: %w", batch[i].ID, err))
 				continue
 			}
@@ -271,6 +398,16 @@ func (eng *Engine) RescoreAll(ctx context.Context) (int, error) {
 	return total, errors.Join(errs...)
 }
 
+// scoreListing scores with the weights configured for the listing's
+// component type, refreshing a server's part-out estimate first so the
+// score sees current part baselines.
+func (eng *Engine) scoreListing(ctx context.Context, listing *domain.Listing) error {
+	eng.estimatePartOut(ctx, listing)
+	return ScoreListingWithWeights(
+		ctx, eng.store, listing, eng.weights.For(string(listing.ComponentType)),
+	)
+}
+
 // evaluateAlertsForListing checks every enabled watch whose component_type
 // matches the listing and calls evaluateAlert for each. This is how alerts
 // get created for newly-scored listings — the per-watch ingestion loop
@@ -304,37 +441,61 @@ func (eng *Engine) completeJob(ctx context.Context, workerID, jobID, errText str
 	}
 }
 
-// RunIngestion executes the full ingestion pipeline for all enabled watches.
+// RunIngestion polls every enabled watch now, regardless of when each
+// was last polled. It backs the manual /api/v1/ingest trigger; the
+// scheduler uses RunScheduledIngestion.
 func (eng *Engine) RunIngestion(ctx context.Context) error {
+	return eng.runIngestion(ctx, false)
+}
+
+// RunScheduledIngestion polls only the enabled watches that are due
+// under their poll interval (see watchDue).
+func (eng *Engine) RunScheduledIngestion(ctx context.Context) error {
+	return eng.runIngestion(ctx, true)
+}
+
+func (eng *Engine) runIngestion(ctx context.Context, dueOnly bool) error {
 	start := time.Now()
 	defer func() {
 		metrics.IngestionDuration.Observe(time.Since(start).Seconds())
 	}()
 
-	watches, err := eng.store.ListWatches(ctx, true)
+	enabled, err := eng.store.ListWatches(ctx, true)
 	if err != nil {
 		return fmt.Errorf("listing watches: %w", err)
 	}
+	watches := eng.pollOrder(enabled, start, dueOnly)
 
+	plan := eng.planCycle(ctx, watches, start)
+	budget := plan.CycleBudget
 	var totalPages int
+	defer func() {
+		plan.CycleUsed = totalPages
+		metrics.IngestionCycleBudgetPages.Set(float64(budget))
+		metrics.IngestionCyclePagesUsed.Set(float64(totalPages))
+		eng.setBudgetPlan(plan)
+	}()
 
 	for i := range watches {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
-		if totalPages >= eng.maxCallsPerCycle {
-			eng.log.Warn("cycle budget exhausted",
-				"total_pages", totalPages,
-				"max_calls_per_cycle", eng.maxCallsPerCycle,
-			)
+		if totalPages >= budget {
+			eng.deferWatches(plan, i, totalPages)
 			break
 		}
 
 		w := &watches[i]
-		eng.log.Info("processing watch", "name", w.Name, "id", w.ID)
+		limit := pageCap(plan.Watches, i, budget-totalPages)
+		if eng.paginator != nil {
+			limit = min(limit, eng.paginator.MaxPages())
+		}
+		plan.Watches[i].PagesPlanned = limit
+		eng.log.Info("processing watch", "name", w.Name, "id", w.ID, "page_limit", limit)
 
-		pagesUsed, processErr := eng.processWatch(ctx, w)
+		pagesUsed, processErr := eng.processWatch(ctx, w, limit)
+		plan.Watches[i].PagesUsed = pagesUsed
 		totalPages += pagesUsed
 
 		// Record last poll time regardless of processing outcome.
@@ -367,13 +528,21 @@ func (eng *Engine) RunIngestion(ctx context.Context) error {
 		}
 	}
 
-	// Always process alerts, even if budget/daily limit was hit.
+	// Pages the watches left unused re-check alerted listings' prices.
+	plan.PriceChecks = eng.checkAlertedPrices(ctx, enabled, budget-totalPages)
+	totalPages += plan.PriceChecks
+
+	// Always process alerts, even if budget/daily limit was hit or no
+	// watch was due — extraction workers create alerts between polls.
 	if err := ProcessAlerts(ctx, eng.store, eng.notifier, eng.alertProcessing); err != nil {
 		eng.log.Error("alert processing failed", "error", err)
 	}
 
-	// Sync quota metrics from eBay Analytics API after ingestion.
-	eng.SyncQuota(ctx)
+	// Sync quota metrics from eBay Analytics API after ingestion. A tick
+	// that polled nothing spent no quota, so skip the extra API call.
+	if totalPages > 0 || !dueOnly {
+		eng.SyncQuota(ctx)
+	}
 
 	// Sync system state gauges after ingestion.
 	eng.SyncStateMetrics(ctx)
@@ -381,22 +550,123 @@ func (eng *Engine) RunIngestion(ctx context.Context) error {
 	return nil
 }
 
-func (eng *Engine) processWatch(ctx context.Context, w *domain.Watch) (int, error) {
+// deferWatches marks plan's watches from i on as deferred once the
+// cycle budget is spent. They keep their old last_polled_at, so they
+// sort ahead of their priority peers next cycle.
+func (eng *Engine) deferWatches(plan *domain.BudgetPlan, i, totalPages int) {
+	deferred := len(plan.Watches) - i
+	for j := i; j < len(plan.Watches); j++ {
+		plan.Watches[j].Deferred = true
+	}
+	plan.Deferred = deferred
+	metrics.IngestionWatchesDeferredTotal.Add(float64(deferred))
+	eng.log.Warn("cycle budget exhausted",
+		"total_pages", totalPages,
+		"cycle_budget", plan.CycleBudget,
+		"deferred_watches", deferred,
+	)
+}
+
+// pollOrder returns the watches to poll this cycle, in order: highest
+// priority first, then most overdue relative to its own interval, then
+// never-polled watches ahead of everything at the same priority. With
+// dueOnly, watches that aren't due yet are dropped.
+//
+// Ordering by overdue ratio is what rotates fairly when the cycle
+// budget runs out: a watch skipped this cycle is more overdue next
+// cycle and moves ahead of the peers that were polled.
+func (eng *Engine) pollOrder(watches []domain.Watch, now time.Time, dueOnly bool) []domain.Watch {
+	overdue := make(map[string]float64, len(watches))
+	out := make([]domain.Watch, 0, len(watches))
+	for i := range watches {
+		w := &watches[i]
+		if dueOnly && !eng.watchDue(w, now) {
+			continue
+		}
+		overdue[w.ID] = overdueRatio(w, now, w.EffectivePollInterval(eng.defaultPollInterval))
+		out = append(out, *w)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Priority != out[j].Priority {
+			return out[i].Priority > out[j].Priority
+		}
+		return overdue[out[i].ID] > overdue[out[j].ID]
+	})
+	return out
+}
+
+// watchDue reports whether w should be polled on a tick at now. A watch
+// is due once its interval has elapsed, less half a poll tick: polls
+// finish a little after the tick that started them, and without the
+// slack a watch whose interval equals the tick would slip to every
+// other tick.
+func (eng *Engine) watchDue(w *domain.Watch, now time.Time) bool {
+	if w.LastPolledAt == nil {
+		return true
+	}
+	interval := w.EffectivePollInterval(eng.defaultPollInterval)
+	return now.Sub(*w.LastPolledAt) >= interval-eng.pollTick/2
+}
+
+// overdueRatio is elapsed time since the last poll over the watch's
+// interval; never-polled watches sort first.
+func overdueRatio(w *domain.Watch, now time.Time, interval time.Duration) float64 {
+	if w.LastPolledAt == nil || interval <= 0 {
+		return math.Inf(1)
+	}
+	return float64(now.Sub(*w.LastPolledAt)) / float64(interval)
+}
+
+// processWatch polls one watch, fetching at most maxPages pages when the
+// paginator is configured.
+func (eng *Engine) processWatch(
+	ctx context.Context,
+	w *domain.Watch,
+	maxPages int,
+) (pagesUsed int, err error) {
 	req := ebay.SearchRequest{
-		Query:      w.SearchQuery,
-		CategoryID: w.CategoryID,
+		Query:       w.SearchQuery,
+		CategoryID:  w.CategoryID,
+		Sort:        ebay.SortNewlyListed,
+		Marketplace: w.Marketplace,
+	}
+	// Let eBay drop what Match would reject anyway; Match still runs.
+	if filter := ebay.BrowseFilter(&w.Filters); filter != "" {
+		req.Filters = map[string]string{"filter": filter}
 	}
 
-	var listings []domain.Listing
-	pagesUsed := 1
+	var (
+		listings []domain.Listing
+		known    []ebay.KnownListing
+	)
+	pagesUsed = 1
+
+	// Every poll, failed or not, leaves a watch_polls row for the
+	// per-watch stats endpoint.
+	poll := &domain.WatchPoll{WatchID: w.ID, PolledAt: time.Now()}
+	defer func() {
+		poll.PagesUsed = pagesUsed
+		if err != nil {
+			errText := err.Error()
+			poll.ErrorText = &errText
+		}
+		if insertErr := eng.store.InsertWatchPoll(ctx, poll); insertErr != nil {
+			eng.log.Warn("failed to record watch poll", "watch", w.Name, "error", insertErr)
+		}
+	}()
 
 	if eng.paginator != nil {
-		result, err := eng.paginator.Paginate(ctx, req, false)
+		result, err := eng.paginator.PaginateLimit(ctx, req, maxPages)
 		if err != nil {
-			return 0, fmt.Errorf("paginating eBay: %w", err)
+			return result.PagesUsed, fmt.Errorf("paginating eBay: %w", err)
 		}
 		listings = result.NewListings
+		known = result.KnownListings
 		pagesUsed = result.PagesUsed
+		poll.TotalSeen = result.TotalSeen
+		poll.NewListings = len(result.NewListings)
+		poll.StoppedAt = result.StoppedAt
 		eng.log.Info("paginated search complete",
 			"watch", w.Name,
 			"pages_used", result.PagesUsed,
@@ -407,23 +677,82 @@ func (eng *Engine) processWatch(ctx context.Context, w *domain.Watch) (int, erro
 	} else {
 		resp, err := eng.ebay.Search(ctx, req)
 		if err != nil {
-			return 0, fmt.Errorf("searching eBay: %w", err)
+			return pagesUsed, fmt.Errorf("searching eBay: %w", err)
 		}
 		listings = ebay.ToListings(resp.Items)
+		// Without the paginator there is no known-listing check, so
+		// every returned item counts as both seen and new.
+		poll.TotalSeen = len(listings)
+		poll.NewListings = len(listings)
 	}
 
 	for i := range listings {
 		eng.processListing(ctx, w, &listings[i])
 	}
+	for i := range known {
+		eng.refreshKnownListing(ctx, &known[i])
+	}
 
 	return pagesUsed, nil
 }
 
+// refreshKnownListing merges the price a search just returned for an
+// already-stored listing into the stored row. When it moved, the upsert
+// records a price history row, the listing is re-scored, and every
+// matching watch's alert is evaluated with the new score, so a drop of
+// at least a watch's realert_drop_pct re-alerts inside the cooldown.
+// Other watches are evaluated too because only the first poll to see
+// the new price gets here.
+func (eng *Engine) refreshKnownListing(ctx context.Context, k *ebay.KnownListing) {
+	l := k.Stored
+	if !priceMoved(l, &k.Seen) {
+		return
+	}
+	eng.mergeSeenPrice(l, &k.Seen)
+	if err := eng.store.UpsertListing(ctx, l); err != nil {
+		eng.log.Error("refreshing known listing failed", "ebay_id", l.EbayID, "error", err)
+		return
+	}
+	metrics.ListingsPriceRefreshedTotal.Inc()
+	if err := eng.scoreListing(ctx, l); err != nil {
+		eng.log.Error("re-scoring refreshed listing failed", "listing", l.ID, "error", err)
+		return
+	}
+	eng.evaluateAlertsForListing(ctx, l)
+}
+
+// priceMoved reports whether seen differs from stored in price,
+// shipping or currency, the fields a price history row tracks.
+func priceMoved(stored, seen *domain.Listing) bool {
+	if stored.Price != seen.Price || stored.Currency != seen.Currency {
+		return true
+	}
+	if (stored.ShippingCost == nil) != (seen.ShippingCost == nil) {
+		return true
+	}
+	return stored.ShippingCost != nil && *stored.ShippingCost != *seen.ShippingCost
+}
+
+// mergeSeenPrice copies the price, bid count and end time eBay just
+// returned onto the stored listing l and re-derives its USD prices.
+func (eng *Engine) mergeSeenPrice(l, seen *domain.Listing) {
+	l.Price = seen.Price
+	l.Currency = seen.Currency
+	l.ShippingCost = seen.ShippingCost
+	l.BidCount = seen.BidCount
+	if seen.AuctionEndAt != nil {
+		l.AuctionEndAt = seen.AuctionEndAt
+	}
+	l.PriceUSD, l.ShippingCostUSD = nil, nil
+	eng.normalizePrices(l)
+}
+
 func (eng *Engine) processListing(
 	ctx context.Context,
 	w *domain.Watch,
 	listing *domain.Listing,
 ) {
+	eng.normalizePrices(listing)
 	if err := eng.store.UpsertListing(ctx, listing); err != nil {
 		eng.log.Error("upsert failed", "ebay_id", listing.EbayID, "error", err)
 		return
@@ -436,10 +765,29 @@ func (eng *Engine) processListing(
 		eng.log.Error("enqueue extraction failed", "listing", listing.EbayID, "error", err)
 	}
 
-	// Evaluate alert for listings that already have a score (e.g., re-ingested).
+	// New listings have no score yet, so this is a no-op until the
+	// extraction worker scores them and evaluates every watch; known
+	// listings are re-evaluated in refreshKnownListing.
 	eng.evaluateAlert(ctx, w, listing)
 }
 
+// normalizePrices fills the listing's USD prices from its own. They
+// stay nil when the currency has no rate; baselines skip the listing
+// and it scores a neutral price factor.
+func (eng *Engine) normalizePrices(l *domain.Listing) {
+	price, ok := eng.converter.ToUSD(l.Price, l.Currency)
+	if !ok {
+		metrics.CurrencyUnconvertedTotal.WithLabelValues(l.Currency).Inc()
+		eng.log.Debug("no exchange rate for listing currency", "ebay_id", l.EbayID, "currency", l.Currency)
+		return
+	}
+	l.PriceUSD = &price
+	if l.ShippingCost != nil {
+		shipping, _ := eng.converter.ToUSD(*l.ShippingCost, l.Currency)
+		l.ShippingCostUSD = &shipping
+	}
+}
+
 func (eng *Engine) evaluateAlert(
 	ctx context.Context,
 	w *domain.Watch,
@@ -454,10 +802,27 @@ func (eng *Engine) evaluateAlert(
 		return
 	}
 
-	if !w.Filters.Match(listing) {
+	if !w.Filters.Match(listing) || !eng.sellerAllowed(listing) {
 		return
 	}
 
+	// Skip relists and duplicates of a listing this watch already
+	// alerted on, unless the price has dropped since.
+	if listing.ListingGroupID != "" {
+		grouped, err := eng.store.HasGroupAlert(ctx, w.ID, listing.ID)
+		if err != nil {
+			eng.log.Error("checking group alert failed", "listing", listing.ID, "error", err)
+			return
+		}
+		if grouped && !eng.priceDropped(ctx, w, listing) {
+			eng.log.Debug("skipping alert: relist of an alerted listing",
+				"watch", w.Name, "listing", listing.ID, "group", listing.ListingGroupID,
+			)
+			metrics.AlertsRelistSuppressedTotal.Inc()
+			return
+		}
+	}
+
 	// Skip listings notified within the cooldown window. A cooldown of 0
 	// disables the check (escape hatch for "alert every cycle" workflows).
 	if eng.alertsConfig.ReAlertsCooldown > 0 {
@@ -468,7 +833,7 @@ func (eng *Engine) evaluateAlert(
 			eng.log.Error("checking recent alert failed", "listing", listing.ID, "error", err)
 			return
 		}
-		if recent {
+		if recent && !eng.priceDropped(ctx, w, listing) {
 			eng.log.Debug("skipping alert: within cooldown window",
 				"watch", w.Name, "listing", listing.ID,
 			)
@@ -490,6 +855,74 @@ func (eng *Engine) evaluateAlert(
 	metrics.AlertsCreatedTotal.WithLabelValues(string(listing.ComponentType)).Inc()
 }
 
+// sellerAllowed applies the global alerts.sellers lists. Per-watch
+// lists are part of the watch's filters.
+func (eng *Engine) sellerAllowed(l *domain.Listing) bool {
+	lists := eng.alertsConfig.Sellers
+	if domain.SellerListed(lists.Blocklist, l.SellerName) {
+		eng.log.Debug("skipping alert: seller blocklisted", "listing", l.ID, "seller", l.SellerName)
+		return false
+	}
+	return len(lists.Allowlist) == 0 || domain.SellerListed(lists.Allowlist, l.SellerName)
+}
+
+// priceDropped reports whether listing's price has dropped by at least
+// the watch's RealertDropPct since the last notified alert, which lets
+// the alert through the re-alert cooldown.
+func (eng *Engine) priceDropped(
+	ctx context.Context,
+	w *domain.Watch,
+	listing *domain.Listing,
+) bool {
+	if w.RealertDropPct <= 0 {
+		return false
+	}
+	alerted, err := eng.store.GetAlertedPrice(ctx, w.ID, listing.ID)
+	if err != nil {
+		eng.log.Error("getting alerted price failed", "listing", listing.ID, "error", err)
+		return false
+	}
+	if alerted == nil {
+		return false
+	}
+	drop, ok := priceDropPct(alerted, listing)
+	if !ok || drop < w.RealertDropPct {
+		return false
+	}
+	eng.log.Info("re-alerting inside cooldown on price drop",
+		"watch", w.Name, "listing", listing.ID, "drop_pct", drop,
+	)
+	metrics.AlertsPriceDropTotal.Inc()
+	return true
+}
+
+// priceDropPct is the percentage listing's price with shipping has
+// fallen since from; negative when it rose. Prices in different
+// currencies are compared in USD, and ok is false when either side has
+// no USD price.
+func priceDropPct(from *domain.PricePoint, listing *domain.Listing) (float64, bool) {
+	was, now := from.Total(), listing.Price
+	if listing.ShippingCost != nil {
+		now += *listing.ShippingCost
+	}
+	if from.Currency != listing.Currency {
+		if from.PriceUSD == nil || listing.PriceUSD == nil {
+			return 0, false
+		}
+		was, now = *from.PriceUSD, *listing.PriceUSD
+		if from.ShippingCostUSD != nil {
+			was += *from.ShippingCostUSD
+		}
+		if listing.ShippingCostUSD != nil {
+			now += *listing.ShippingCostUSD
+		}
+	}
+	if was <= 0 {
+		return 0, false
+	}
+	return (was - now) / was * 100, true
+}
+
 // traceIDFromContext returns a *string trace ID extracted from the
 // active span on ctx, or nil when there's no valid span. The Alert
 // struct field is *string so a nil parent span produces a NULL trace_id
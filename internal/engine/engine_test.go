@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,11 +20,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/currency"
 	"github.com/donaldgifford/server-price-tracker/internal/ebay"
 	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
 	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
 	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
@@ -33,12 +37,14 @@ func quietLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
 
-// expectCountMethods sets up Maybe expectations for the GetSystemState call and
-// UpdateWatchLastPolled so that tests exercising RunIngestion (which calls
-// SyncStateMetrics) don't fail on unexpected calls.
+// expectCountMethods sets up Maybe expectations for the GetSystemState call,
+// UpdateWatchLastPolled and InsertWatchPoll so that tests exercising
+// RunIngestion (which calls SyncStateMetrics) don't fail on unexpected calls.
 func expectCountMethods(ms *storeMocks.MockStore) {
 	ms.EXPECT().GetSystemState(mock.Anything).Return(&domain.SystemState{}, nil).Maybe()
 	ms.EXPECT().UpdateWatchLastPolled(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	ms.EXPECT().InsertWatchPoll(mock.Anything, mock.Anything).Return(nil).Maybe()
+	ms.EXPECT().ListWatchYields(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 }
 
 func newTestEngine(
@@ -131,6 +137,7 @@ func TestRunIngestion_TwoWatchesThreeListingsEach(t *testing.T) {
 			Search(mock.Anything, ebay.SearchRequest{
 				Query:      w.SearchQuery,
 				CategoryID: w.CategoryID,
+				Sort:       ebay.SortNewlyListed,
 			}).
 			Return(&ebay.SearchResponse{Items: items}, nil).
 			Once()
@@ -208,13 +215,13 @@ func TestRunIngestion_EbayErrorForOneWatch(t *testing.T) {
 
 	// First watch: eBay error.
 	me.EXPECT().
-		Search(mock.Anything, ebay.SearchRequest{Query: "fail"}).
+		Search(mock.Anything, ebay.SearchRequest{Query: "fail", Sort: ebay.SortNewlyListed}).
 		Return(nil, errors.New("eBay 503")).
 		Once()
 
 	// Second watch: success with 1 item.
 	me.EXPECT().
-		Search(mock.Anything, ebay.SearchRequest{Query: "ok"}).
+		Search(mock.Anything, ebay.SearchRequest{Query: "ok", Sort: ebay.SortNewlyListed}).
 		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{
 			{
 				ItemID: "ok-1",
@@ -233,6 +240,157 @@ func TestRunIngestion_EbayErrorForOneWatch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunIngestion_PushesFiltersToBrowse(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := newTestEngine(ms, me, mx, mn)
+
+	priceMax := 120.0
+	watches := []domain.Watch{{
+		ID: "w1", Name: "Cheap used DDR4", SearchQuery: "DDR4 ECC", Enabled: true,
+		Filters: domain.WatchFilters{
+			PriceMax:      &priceMax,
+			Conditions:    []domain.Condition{domain.ConditionUsedWorking},
+			BuyingOptions: []domain.ListingType{domain.ListingBuyItNow},
+		},
+	}}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{
+			Query: "DDR4 ECC",
+			Sort:  ebay.SortNewlyListed,
+			Filters: map[string]string{
+				"filter": "price:[..120],conditionIds:{2020|2030|2500|3000},buyingOptions:{FIXED_PRICE}",
+			},
+		}).
+		Return(&ebay.SearchResponse{}, nil).
+		Once()
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, eng.RunIngestion(context.Background()))
+}
+
+func TestRunIngestion_WatchMarketplaceNormalizesToUSD(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithCurrencyConverter(&currency.RateTable{Rates: map[string]float64{"GBP": 0.8}}),
+	)
+
+	watches := []domain.Watch{{
+		ID: "w1", Name: "UK DDR4", SearchQuery: "DDR4", Enabled: true, Marketplace: "EBAY_GB",
+	}}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "DDR4", Sort: ebay.SortNewlyListed, Marketplace: "EBAY_GB"}).
+		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{
+			{
+				ItemID: "gb-1",
+				Price:  ebay.ItemPrice{Value: "40.00", Currency: "GBP"},
+				ShippingOptions: []ebay.ShippingOption{
+					{ShippingCost: &ebay.ItemPrice{Value: "4.00", Currency: "GBP"}},
+				},
+			},
+			{ItemID: "jp-1", Price: ebay.ItemPrice{Value: "5000", Currency: "JPY"}},
+		}}, nil).
+		Once()
+
+	var upserted []domain.Listing
+	ms.EXPECT().UpsertListing(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, l *domain.Listing) { upserted = append(upserted, *l) }).
+		Return(nil).
+		Times(2)
+	ms.EXPECT().EnqueueExtraction(mock.Anything, mock.Anything, 0).Return(nil).Times(2)
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, eng.RunIngestion(context.Background()))
+
+	require.Len(t, upserted, 2)
+	gb := upserted[0]
+	assert.InDelta(t, 40, gb.Price, 1e-9)
+	require.NotNil(t, gb.PriceUSD)
+	assert.InDelta(t, 50, *gb.PriceUSD, 1e-9)
+	require.NotNil(t, gb.ShippingCostUSD)
+	assert.InDelta(t, 5, *gb.ShippingCostUSD, 1e-9)
+
+	// No JPY rate: stored without a USD price.
+	assert.Nil(t, upserted[1].PriceUSD)
+}
+
+func TestRunIngestion_RecordsWatchPolls(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	// Built by hand: newTestEngine's Maybe InsertWatchPoll would swallow
+	// the calls this test captures.
+	ms.EXPECT().GetSystemState(mock.Anything).Return(&domain.SystemState{}, nil).Maybe()
+	ms.EXPECT().UpdateWatchLastPolled(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	ms.EXPECT().ListWatchYields(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	eng := NewEngine(ms, me, mx, mn, WithLogger(quietLogger()), WithStaggerOffset(0))
+
+	watches := []domain.Watch{
+		{ID: "w1", Name: "Failing Watch", SearchQuery: "fail", ScoreThreshold: 80, Enabled: true},
+		{ID: "w2", Name: "OK Watch", SearchQuery: "ok", ScoreThreshold: 80, Enabled: true},
+	}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "fail", Sort: ebay.SortNewlyListed}).
+		Return(nil, errors.New("eBay 503")).
+		Once()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "ok", Sort: ebay.SortNewlyListed}).
+		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{
+			{ItemID: "ok-1", Title: "OK Item", Price: ebay.ItemPrice{Value: "25.00", Currency: "USD"}},
+			{ItemID: "ok-2", Title: "OK Item 2", Price: ebay.ItemPrice{Value: "30.00", Currency: "USD"}},
+		}}, nil).
+		Once()
+	ms.EXPECT().UpsertListing(mock.Anything, mock.Anything).Return(nil).Times(2)
+	ms.EXPECT().EnqueueExtraction(mock.Anything, mock.Anything, 0).Return(nil).Times(2)
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	var (
+		mu    sync.Mutex
+		polls = map[string]domain.WatchPoll{}
+	)
+	ms.EXPECT().
+		InsertWatchPoll(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, p *domain.WatchPoll) {
+			mu.Lock()
+			defer mu.Unlock()
+			polls[p.WatchID] = *p
+		}).
+		Return(nil).
+		Times(2)
+
+	require.NoError(t, eng.RunIngestion(context.Background()))
+
+	failed := polls["w1"]
+	require.NotNil(t, failed.ErrorText)
+	assert.Contains(t, *failed.ErrorText, "eBay 503")
+
+	assert.Equal(t, 1, failed.PagesUsed, "the failed search still spent a call")
+
+	ok := polls["w2"]
+	assert.Nil(t, ok.ErrorText)
+	assert.Equal(t, 1, ok.PagesUsed)
+	assert.Equal(t, 2, ok.TotalSeen)
+	assert.Equal(t, 2, ok.NewListings)
+}
+
 func TestRunIngestion_ListWatchesError(t *testing.T) {
 	t.Parallel()
 
@@ -453,6 +611,52 @@ func TestEvaluateAlert_FiltersDontMatch(t *testing.T) {
 	eng.evaluateAlert(context.Background(), watch, listing)
 }
 
+func TestEvaluateAlert_GlobalSellerLists(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		lists     config.SellerListsConfig
+		wantAlert bool
+	}{
+		{name: "no lists", wantAlert: true},
+		{
+			name:  "blocklisted seller",
+			lists: config.SellerListsConfig{Blocklist: []string{"JUNK_DEALER"}},
+		},
+		{
+			name:  "seller missing from allowlist",
+			lists: config.SellerListsConfig{Allowlist: []string{"server_parts_inc"}},
+		},
+		{
+			name:      "allowlisted seller",
+			lists:     config.SellerListsConfig{Allowlist: []string{"junk_dealer"}},
+			wantAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t),
+				extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+			eng.alertsConfig = config.AlertsConfig{Sellers: tt.lists}
+
+			score := 90
+			listing := &domain.Listing{ID: "l1", Score: &score, SellerName: "junk_dealer"}
+			watch := &domain.Watch{ID: "w1", ScoreThreshold: 80}
+
+			if tt.wantAlert {
+				ms.EXPECT().CreateAlert(mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			eng.evaluateAlert(context.Background(), watch, listing)
+		})
+	}
+}
+
 func TestEvaluateAlert_CreateAlertError(t *testing.T) {
 	t.Parallel()
 
@@ -577,7 +781,7 @@ func TestEngineRescoreAll_FiresAlerts(t *testing.T) {
 	ms.EXPECT().ListListingsCursor(mock.Anything, "l1", 200).Return(nil, nil).Once()
 
 	ms.EXPECT().GetBaseline(mock.Anything, "ram:ddr4:32gb").Return(nil, pgx.ErrNoRows).Once()
-	ms.EXPECT().UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything).Return(nil).Once()
+	ms.EXPECT().UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 	// Watch lookup for alert eval — threshold 0 so any computed score
 	// triggers the alert path (this test verifies plumbing, not scoring math).
@@ -860,7 +1064,7 @@ func TestRunIngestion_DailyLimitHit(t *testing.T) {
 
 	// First watch: Search returns ErrDailyLimitReached.
 	me.EXPECT().
-		Search(mock.Anything, ebay.SearchRequest{Query: "DDR4"}).
+		Search(mock.Anything, ebay.SearchRequest{Query: "DDR4", Sort: ebay.SortNewlyListed}).
 		Return(nil, fmt.Errorf("rate limit: %w", ebay.ErrDailyLimitReached)).
 		Once()
 
@@ -894,7 +1098,7 @@ func TestRunIngestion_CycleBudgetExhausted(t *testing.T) {
 
 	// Only first watch should be processed (maxCallsPerCycle=1).
 	me.EXPECT().
-		Search(mock.Anything, ebay.SearchRequest{Query: "DDR4"}).
+		Search(mock.Anything, ebay.SearchRequest{Query: "DDR4", Sort: ebay.SortNewlyListed}).
 		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{
 			{ItemID: "item1", Title: "Item 1", Price: ebay.ItemPrice{Value: "10", Currency: "USD"}},
 		}}, nil).
@@ -910,6 +1114,113 @@ func TestRunIngestion_CycleBudgetExhausted(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunIngestion_BudgetRotatesToMostOverdue(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithMaxCallsPerCycle(1),
+		WithPollSchedule(15*time.Minute, 5*time.Minute),
+	)
+
+	// w2 was starved last cycle, so it goes first even though w1 comes
+	// first in the list.
+	recent := time.Now().Add(-20 * time.Minute)
+	starved := time.Now().Add(-50 * time.Minute)
+	watches := []domain.Watch{
+		{ID: "w1", Name: "Watch 1", SearchQuery: "DDR4", Enabled: true, LastPolledAt: &recent},
+		{ID: "w2", Name: "Watch 2", SearchQuery: "SSD", Enabled: true, LastPolledAt: &starved},
+	}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Once()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "SSD", Sort: ebay.SortNewlyListed}).
+		Return(&ebay.SearchResponse{}, nil).
+		Once()
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, eng.RunScheduledIngestion(context.Background()))
+}
+
+func TestRunScheduledIngestion_PollsOnlyDueWatches(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithPollSchedule(15*time.Minute, 5*time.Minute),
+	)
+
+	tenMinAgo := time.Now().Add(-10 * time.Minute)
+	watches := []domain.Watch{
+		// Default 15m interval, polled 10m ago: not due.
+		{ID: "w1", Name: "Slow", SearchQuery: "rails", Enabled: true, LastPolledAt: &tenMinAgo},
+		// Own 5m interval: due.
+		{
+			ID: "w2", Name: "Hot", SearchQuery: "RTX 3090", Enabled: true, LastPolledAt: &tenMinAgo,
+			PollInterval: domain.Duration(5 * time.Minute),
+		},
+	}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(watches, nil).Twice()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "RTX 3090", Sort: ebay.SortNewlyListed}).
+		Return(&ebay.SearchResponse{}, nil).
+		Twice()
+	me.EXPECT().
+		Search(mock.Anything, ebay.SearchRequest{Query: "rails", Sort: ebay.SortNewlyListed}).
+		Return(&ebay.SearchResponse{}, nil).
+		Once()
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Twice()
+
+	require.NoError(t, eng.RunScheduledIngestion(context.Background()))
+	// A manual run ignores poll intervals.
+	require.NoError(t, eng.RunIngestion(context.Background()))
+}
+
+func TestPollOrder(t *testing.T) {
+	t.Parallel()
+
+	eng := NewEngine(nil, nil, nil, nil, WithPollSchedule(time.Hour, 10*time.Minute))
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	watches := []domain.Watch{
+		{ID: "low-overdue", Priority: -1, LastPolledAt: ago(5 * time.Hour)},
+		{ID: "fresh", LastPolledAt: ago(10 * time.Minute)},
+		{ID: "almost", LastPolledAt: ago(56 * time.Minute)}, // within half a tick
+		{ID: "overdue-2x", LastPolledAt: ago(2 * time.Hour)},
+		{ID: "never"},
+		{ID: "hot", Priority: 10, LastPolledAt: ago(6 * time.Minute), PollInterval: domain.Duration(5 * time.Minute)},
+	}
+
+	ids := func(ws []domain.Watch) []string {
+		out := make([]string, len(ws))
+		for i := range ws {
+			out[i] = ws[i].ID
+		}
+		return out
+	}
+
+	due := eng.pollOrder(watches, now, true)
+	assert.Equal(t, []string{"hot", "never", "overdue-2x", "almost", "low-overdue"}, ids(due))
+
+	all := eng.pollOrder(watches, now, false)
+	assert.Equal(t, []string{"hot", "never", "overdue-2x", "almost", "fresh", "low-overdue"}, ids(all))
+}
+
 func TestRunIngestion_WithPaginator(t *testing.T) {
 	t.Parallel()
 
@@ -961,6 +1272,117 @@ func TestRunIngestion_WithPaginator(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestProcessWatch_PaginatorErrorCountsPagesSpent(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithPaginator(ebay.NewPaginator(me, ms, ebay.WithPaginatorLogger(quietLogger()))),
+	)
+
+	me.EXPECT().
+		Search(mock.Anything, mock.MatchedBy(func(req ebay.SearchRequest) bool { return req.Offset == 0 })).
+		Return(&ebay.SearchResponse{
+			Items:   []ebay.ItemSummary{{ItemID: "item1", Title: "DDR4 32GB", Price: ebay.ItemPrice{Value: "45", Currency: "USD"}}},
+			HasMore: true,
+		}, nil).
+		Once()
+	me.EXPECT().
+		Search(mock.Anything, mock.MatchedBy(func(req ebay.SearchRequest) bool { return req.Offset > 0 })).
+		Return(nil, errors.New("eBay 503")).
+		Once()
+	ms.EXPECT().GetListing(mock.Anything, "item1").Return(nil, nil).Once()
+
+	var poll domain.WatchPoll
+	ms.EXPECT().
+		InsertWatchPoll(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, p *domain.WatchPoll) { poll = *p }).
+		Return(nil).
+		Once()
+
+	w := &domain.Watch{ID: "w1", Name: "Watch 1", SearchQuery: "DDR4"}
+	pages, err := eng.processWatch(context.Background(), w, 5)
+	require.Error(t, err)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, 2, poll.PagesUsed)
+	require.NotNil(t, poll.ErrorText)
+}
+
+func TestProcessWatch_KnownListingPriceDropReAlerts(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithPaginator(ebay.NewPaginator(me, ms, ebay.WithPaginatorLogger(quietLogger()))),
+		WithAlertsConfig(config.AlertsConfig{ReAlertsCooldown: 24 * time.Hour}),
+	)
+
+	// A BIN listing alerted at $390 + $10 shipping is back on the first
+	// page at $310 + $10: a 20% drop. Its neighbour hasn't moved.
+	ship := 10.0
+	score := 85
+	stored := func(id, ebayID string, price float64) *domain.Listing {
+		return &domain.Listing{
+			ID: id, EbayID: ebayID, Title: "Samsung 32GB DDR4 ECC", Price: price, ShippingCost: &ship,
+			Currency: "USD", ListingType: domain.ListingBuyItNow, ComponentType: domain.ComponentRAM,
+			ProductKey: "ram:ddr4:32gb", Score: &score,
+		}
+	}
+	item := func(ebayID, price string) ebay.ItemSummary {
+		return ebay.ItemSummary{
+			ItemID: ebayID, Title: "Samsung 32GB DDR4 ECC",
+			Price:           ebay.ItemPrice{Value: price, Currency: "USD"},
+			ShippingOptions: []ebay.ShippingOption{{ShippingCost: &ebay.ItemPrice{Value: "10.00", Currency: "USD"}}},
+		}
+	}
+	me.EXPECT().
+		Search(mock.Anything, mock.Anything).
+		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{item("bin-1", "310.00"), item("bin-2", "200.00")}, HasMore: true}, nil).
+		Once()
+	ms.EXPECT().GetListing(mock.Anything, "bin-1").Return(stored("l1", "bin-1", 390), nil).Once()
+	ms.EXPECT().GetListing(mock.Anything, "bin-2").Return(stored("l2", "bin-2", 200), nil).Once()
+
+	w := &domain.Watch{
+		ID: "w1", Name: "DDR4", SearchQuery: "DDR4", ComponentType: domain.ComponentRAM,
+		ScoreThreshold: 1, RealertDropPct: 20,
+	}
+
+	// Only the moved listing is upserted (recording price history),
+	// re-scored and evaluated with its stored score.
+	ms.EXPECT().
+		UpsertListing(mock.Anything, mock.MatchedBy(func(l *domain.Listing) bool {
+			return l.ID == "l1" && l.Price == 310 && l.PriceUSD != nil && *l.PriceUSD == 310
+		})).
+		Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, "ram:ddr4:32gb").Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "l1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().ListWatches(mock.Anything, true).Return([]domain.Watch{*w}, nil).Once()
+	ms.EXPECT().HasRecentAlert(mock.Anything, "w1", "l1", 24*time.Hour).Return(true, nil).Once()
+	ms.EXPECT().
+		GetAlertedPrice(mock.Anything, "w1", "l1").
+		Return(&domain.PricePoint{Price: 390, ShippingCost: &ship, Currency: "USD"}, nil).Once()
+	ms.EXPECT().
+		CreateAlert(mock.Anything, mock.MatchedBy(func(a *domain.Alert) bool {
+			return a.WatchID == "w1" && a.ListingID == "l1"
+		})).
+		Return(nil).Once()
+
+	pages, err := eng.processWatch(context.Background(), w, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pages)
+}
+
 // analyticsResponse is a valid eBay Analytics API response for testing.
 const analyticsResponse = `{
 	"rateLimits": [{
@@ -1330,6 +1752,9 @@ func TestProcessExtractionJob_UpdateExtractionFails(t *testing.T) {
 	mx.EXPECT().
 		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
 		Return(domain.ComponentRAM, map[string]any{"speed_mhz": 2666}, nil).Once()
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, k string) (string, error) { return k, nil }).Once()
 	ms.EXPECT().
 		UpdateListingExtraction(mock.Anything, "listing-ue", "ram", mock.Anything, 0.9, mock.AnythingOfType("string")).
 		Return(errors.New("db write error")).Once()
@@ -1341,6 +1766,154 @@ func TestProcessExtractionJob_UpdateExtractionFails(t *testing.T) {
 	eng.processExtractionJob(context.Background(), "worker-0", job)
 }
 
+func TestProcessExtractionJob_AssignsListingGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		relists config.RelistConfig
+	}{
+		{name: "disabled skips grouping"},
+		{name: "enabled groups listing", relists: config.RelistConfig{Enabled: true, PriceTolerancePct: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			mx := extractMocks.NewMockExtractor(t)
+
+			job := &domain.ExtractionJob{ID: "job-g", ListingID: "listing-g"}
+			listing := testListing("")
+			listing.ID = "listing-g"
+
+			ms.EXPECT().GetListingByID(mock.Anything, "listing-g").Return(listing, nil).Once()
+			mx.EXPECT().
+				ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
+				Return(domain.ComponentRAM, map[string]any{"capacity_gb": 32}, nil).Once()
+			ms.EXPECT().
+				ResolveProductKey(mock.Anything, mock.AnythingOfType("string")).
+				RunAndReturn(func(_ context.Context, k string) (string, error) { return k, nil }).Once()
+			ms.EXPECT().
+				UpdateListingExtraction(mock.Anything, "listing-g", "ram", mock.Anything, 0.9, mock.AnythingOfType("string")).
+				Return(nil).Once()
+			if tt.relists.Enabled {
+				ms.EXPECT().
+					AssignListingGroup(mock.Anything, "listing-g", 10.0).
+					Return("listing-first", nil).Once()
+			}
+			ms.EXPECT().
+				GetBaseline(mock.Anything, mock.AnythingOfType("string")).
+				Return(nil, pgx.ErrNoRows).Once()
+			ms.EXPECT().
+				UpdateScore(mock.Anything, "listing-g", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).Once()
+			ms.EXPECT().
+				UpdateValueMetric(mock.Anything, "listing-g", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).Once()
+			ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+			ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-g", "").Return(nil).Once()
+
+			eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+			eng.relists = tt.relists
+			eng.processExtractionJob(context.Background(), "worker-0", job)
+
+			if tt.relists.Enabled {
+				assert.Equal(t, "listing-first", listing.ListingGroupID)
+			} else {
+				assert.Empty(t, listing.ListingGroupID)
+			}
+		})
+	}
+}
+
+func TestProcessExtractionJob_StoresExtractedValueMetric(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mx := extractMocks.NewMockExtractor(t)
+
+	job := &domain.ExtractionJob{ID: "job-v", ListingID: "listing-v"}
+	listing := testListing("")
+	listing.ID = "listing-v"
+	listing.Price = 48
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-v").Return(listing, nil).Once()
+	mx.EXPECT().
+		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
+		Return(domain.ComponentRAM, map[string]any{
+			"generation": "DDR4", "ecc": true, "registered": true, "capacity_gb": 32, "speed_mhz": 2666,
+		}, nil).Once()
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, k string) (string, error) { return k, nil }).Once()
+	ms.EXPECT().
+		UpdateListingExtraction(mock.Anything, "listing-v", "ram", mock.Anything, 0.9, mock.AnythingOfType("string")).
+		Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, mock.AnythingOfType("string")).Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-v", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().
+		UpdateValueMetric(mock.Anything, "listing-v", "ram:ddr4:ecc_reg", "gb",
+			mock.MatchedBy(func(p *float64) bool { return p != nil && *p == 1.5 })).
+		Return(nil).Once()
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+	ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-v", "").Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+	eng.processExtractionJob(context.Background(), "worker-0", job)
+
+	assert.Equal(t, "ram:ddr4:ecc_reg", listing.ValueClass)
+	require.NotNil(t, listing.PricePerUnit)
+	assert.InDelta(t, 1.5, *listing.PricePerUnit, 1e-9)
+}
+
+func TestProcessExtractionJob_EnrichesFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	hw, err := catalog.Default()
+	require.NoError(t, err)
+
+	ms := storeMocks.NewMockStore(t)
+	mx := extractMocks.NewMockExtractor(t)
+
+	job := &domain.ExtractionJob{ID: "job-c", ListingID: "listing-c"}
+	listing := testListing("")
+	listing.ID = "listing-c"
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-c").Return(listing, nil).Once()
+	mx.EXPECT().
+		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
+		Return(domain.ComponentGPU, map[string]any{"manufacturer": "NVIDIA", "model": "L4"}, nil).Once()
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, "gpu:nvidia:l-series:l4:24gb").
+		Return("gpu:nvidia:l-series:l4:24gb", nil).Once()
+	ms.EXPECT().
+		UpdateListingExtraction(mock.Anything, "listing-c", "gpu",
+			mock.MatchedBy(func(attrs map[string]any) bool {
+				return attrs["catalog_id"] == "gpu:nvidia:l-series:l4" && attrs["release_year"] == 2023
+			}),
+			0.9, "gpu:nvidia:l-series:l4:24gb").
+		Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, "gpu:nvidia:l-series:l4:24gb").Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-c", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().
+		UpdateValueMetric(mock.Anything, "listing-c", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+	ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-c", "").Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+	eng.catalog = hw
+	eng.processExtractionJob(context.Background(), "worker-0", job)
+
+	assert.Equal(t, "gpu:nvidia:l-series:l4:24gb", listing.ProductKey)
+}
+
 func TestProcessExtractionJob_GetListingFails(t *testing.T) {
 	t.Parallel()
 
@@ -1410,6 +1983,9 @@ func TestStartExtractionWorkers_ProcessesJob(t *testing.T) {
 		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
 		Return(domain.ComponentRAM, map[string]any{"speed_mhz": 2666}, nil).Once()
 
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, k string) (string, error) { return k, nil }).Once()
 	ms.EXPECT().
 		UpdateListingExtraction(mock.Anything, "listing-1", "ram", mock.Anything, 0.9, mock.AnythingOfType("string")).
 		Return(nil).Once()
@@ -1418,7 +1994,7 @@ func TestStartExtractionWorkers_ProcessesJob(t *testing.T) {
 		GetBaseline(mock.Anything, mock.AnythingOfType("string")).
 		Return(nil, pgx.ErrNoRows).Once()
 	ms.EXPECT().
-		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).Once()
 
 	// Post-score alert evaluation queries enabled watches; return none so
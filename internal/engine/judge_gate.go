@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	"github.com/donaldgifford/server-price-tracker/internal/notify"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// gatedAlerts is the pending pool split by the judge gate.
+type gatedAlerts struct {
+	// ready go out as usual; their verdicts (if any) are in verdicts.
+	ready    []domain.Alert
+	verdicts map[string]*domain.JudgeScore
+	// belowFloor scored under the gate's MinScore; reasons holds the
+	// suppression reason recorded for each.
+	belowFloor []domain.Alert
+	reasons    map[string]string
+	held       int
+}
+
+// applyJudgeGate splits pending by judge verdict, read for the whole
+// pool in one query. An alert without a verdict is held (left pending
+// for a later tick) until it is older than gate.MaxWait, then released
+// unjudged. A verdict lookup error is treated as no verdict so a flaky
+// read delays alerts, never drops them.
+func applyJudgeGate(
+	ctx context.Context,
+	s store.Store,
+	pending []domain.Alert,
+	gate config.JudgeGateConfig,
+	now time.Time,
+) *gatedAlerts {
+	g := &gatedAlerts{
+		verdicts: make(map[string]*domain.JudgeScore),
+		reasons:  make(map[string]string),
+	}
+	ids := make([]string, len(pending))
+	for i := range pending {
+		ids[i] = pending[i].ID
+	}
+	verdicts, err := s.GetJudgeScores(ctx, ids)
+	if err != nil {
+		slog.Default().Warn("judge gate: reading verdicts failed", "alerts", len(ids), "error", err)
+		verdicts = nil
+	}
+	for i := range pending {
+		a := pending[i]
+		verdict := verdicts[a.ID]
+		switch {
+		case verdict == nil && now.Sub(a.CreatedAt) < gate.MaxWait:
+			g.held++
+		case verdict == nil:
+			metrics.AlertsJudgeGateTotal.WithLabelValues("timed_out").Inc()
+			g.ready = append(g.ready, a)
+		case verdict.Score < gate.MinScore:
+			g.belowFloor = append(g.belowFloor, a)
+			g.reasons[a.ID] = fmt.Sprintf(
+				"judge score %.2f below min_score %.2f: %s", verdict.Score, gate.MinScore, verdict.Reason,
+			)
+		default:
+			metrics.AlertsJudgeGateTotal.WithLabelValues("passed").Inc()
+			g.verdicts[a.ID] = verdict
+			g.ready = append(g.ready, a)
+		}
+	}
+	metrics.AlertsJudgeGateHeld.Set(float64(g.held))
+	return g
+}
+
+// releaseBelowFloor handles the alerts the judge scored under the
+// floor: with action "summary" they share one summary embed, otherwise
+// they are suppressed. Either way they leave the pending pool and their
+// notification_attempts rows record why, so a suppressed alert also
+// counts as alerted for the re-alert cooldown.
+func releaseBelowFloor(
+	ctx context.Context,
+	s store.Store,
+	n notify.Notifier,
+	g *gatedAlerts,
+	action string,
+	alertsURLBase string,
+) error {
+	if len(g.belowFloor) == 0 {
+		return nil
+	}
+	if action == config.JudgeGateActionSummary {
+		if err := sendSummary(ctx, s, n, g.belowFloor, alertsURLBase, g.reasons); err != nil {
+			return err
+		}
+		metrics.AlertsJudgeGateTotal.WithLabelValues("summarized").Add(float64(len(g.belowFloor)))
+		return nil
+	}
+
+	ids := make([]string, 0, len(g.belowFloor))
+	for i := range g.belowFloor {
+		id := g.belowFloor[i].ID
+		recordSuppressedAttempt(ctx, s, id, false, "", g.reasons[id])
+		ids = append(ids, id)
+	}
+	metrics.AlertsJudgeGateTotal.WithLabelValues("suppressed").Add(float64(len(ids)))
+	if err := s.MarkAlertsNotified(ctx, ids); err != nil {
+		return fmt.Errorf("marking suppressed alerts: %w", err)
+	}
+	return nil
+}
+
+// recordSuppressedAttempt is recordAttempt for judge-gated alerts.
+func recordSuppressedAttempt(
+	ctx context.Context,
+	s store.Store,
+	alertID string,
+	succeeded bool,
+	errText, reason string,
+) {
+	if err := s.InsertSuppressedNotificationAttempt(ctx, alertID, succeeded, errText, reason); err != nil {
+		slog.Default().Warn("failed to record suppressed notification attempt",
+			"alert_id", alertID, "error", err,
+		)
+	}
+}
+
+// withVerdict copies the judge's verdict onto p when there is one.
+func withVerdict(p *notify.AlertPayload, verdict *domain.JudgeScore) *notify.AlertPayload {
+	if verdict != nil {
+		p.JudgeScore = &verdict.Score
+		p.JudgeReason = verdict.Reason
+	}
+	return p
+}
@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/notify"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func judgeGateConfig(action string) AlertProcessingConfig {
+	return AlertProcessingConfig{JudgeGate: config.JudgeGateConfig{
+		Enabled:  true,
+		MaxWait:  30 * time.Minute,
+		MinScore: 0.3,
+		Action:   action,
+	}}
+}
+
+func TestProcessAlerts_JudgeGate_HoldsUnjudged(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now().Add(-5 * time.Minute)},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().GetJudgeScores(mock.Anything, []string{"a1"}).Return(map[string]*domain.JudgeScore{}, nil).Once()
+	// No send, no attempt, no mark: the alert stays pending.
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSummary))
+	require.NoError(t, err)
+}
+
+func TestProcessAlerts_JudgeGate_SendsUnjudgedAfterMaxWait(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now().Add(-time.Hour)},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().GetJudgeScores(mock.Anything, []string{"a1"}).Return(nil, errors.New("db down")).Once()
+	ms.EXPECT().GetWatch(mock.Anything, "w1").Return(testWatch(), nil).Once()
+	ms.EXPECT().HasSuccessfulNotification(mock.Anything, "a1").Return(false, nil).Once()
+	ms.EXPECT().GetListingByID(mock.Anything, "l1").Return(testListingForAlert("l1"), nil).Once()
+	mn.EXPECT().
+		SendAlert(mock.Anything, mock.MatchedBy(func(p *notify.AlertPayload) bool {
+			return p.JudgeScore == nil && p.JudgeReason == ""
+		})).
+		Return(nil).Once()
+	ms.EXPECT().InsertNotificationAttempt(mock.Anything, "a1", true, 0, "").Return(nil).Once()
+	ms.EXPECT().MarkAlertNotified(mock.Anything, "a1").Return(nil).Once()
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSummary))
+	require.NoError(t, err)
+}
+
+func TestProcessAlerts_JudgeGate_VerdictInPayload(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now()},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().
+		GetJudgeScores(mock.Anything, []string{"a1"}).
+		Return(map[string]*domain.JudgeScore{"a1": {AlertID: "a1", Score: 0.82, Reason: "well under p25"}}, nil).Once()
+	ms.EXPECT().GetWatch(mock.Anything, "w1").Return(testWatch(), nil).Once()
+	ms.EXPECT().HasSuccessfulNotification(mock.Anything, "a1").Return(false, nil).Once()
+	ms.EXPECT().GetListingByID(mock.Anything, "l1").Return(testListingForAlert("l1"), nil).Once()
+	mn.EXPECT().
+		SendAlert(mock.Anything, mock.MatchedBy(func(p *notify.AlertPayload) bool {
+			return p.JudgeScore != nil && *p.JudgeScore == 0.82 && p.JudgeReason == "well under p25"
+		})).
+		Return(nil).Once()
+	ms.EXPECT().InsertNotificationAttempt(mock.Anything, "a1", true, 0, "").Return(nil).Once()
+	ms.EXPECT().MarkAlertNotified(mock.Anything, "a1").Return(nil).Once()
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSummary))
+	require.NoError(t, err)
+}
+
+func TestProcessAlerts_JudgeGate_SuppressesBelowFloor(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now()},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().
+		GetJudgeScores(mock.Anything, []string{"a1"}).
+		Return(map[string]*domain.JudgeScore{"a1": {AlertID: "a1", Score: 0.12, Reason: "bare board, no CPU"}}, nil).Once()
+	ms.EXPECT().
+		InsertSuppressedNotificationAttempt(mock.Anything, "a1", false, "",
+			"judge score 0.12 below min_score 0.30: bare board, no CPU").
+		Return(nil).Once()
+	ms.EXPECT().MarkAlertsNotified(mock.Anything, []string{"a1"}).Return(nil).Once()
+	// SendAlert MUST NOT be called.
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSuppress))
+	require.NoError(t, err)
+}
+
+func TestProcessAlerts_JudgeGate_SummarizesBelowFloor(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now()},
+		{ID: "a2", WatchID: "w1", ListingID: "l2", Score: 90, CreatedAt: time.Now()},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().
+		GetJudgeScores(mock.Anything, []string{"a1", "a2"}).
+		Return(map[string]*domain.JudgeScore{
+			"a1": {AlertID: "a1", Score: 0.2, Reason: "lot of mixed parts"},
+			"a2": {AlertID: "a2", Score: 0.9, Reason: "deal"},
+		}, nil).Once()
+
+	// a1 goes out in the below-floor summary.
+	ms.EXPECT().
+		GetListingByID(mock.Anything, "l1").
+		Return(&domain.Listing{ID: "l1", ComponentType: domain.ComponentRAM}, nil).Once()
+	mn.EXPECT().
+		SendAlert(mock.Anything, mock.MatchedBy(func(p *notify.AlertPayload) bool {
+			return p.WatchName == "Below judge floor"
+		})).
+		Return(nil).Once()
+	ms.EXPECT().
+		InsertSuppressedNotificationAttempt(mock.Anything, "a1", true, "",
+			"judge score 0.20 below min_score 0.30: lot of mixed parts").
+		Return(nil).Once()
+	ms.EXPECT().MarkAlertsNotified(mock.Anything, []string{"a1"}).Return(nil).Once()
+
+	// a2 goes out on its own.
+	ms.EXPECT().GetWatch(mock.Anything, "w1").Return(testWatch(), nil).Once()
+	ms.EXPECT().HasSuccessfulNotification(mock.Anything, "a2").Return(false, nil).Once()
+	ms.EXPECT().GetListingByID(mock.Anything, "l2").Return(testListingForAlert("l2"), nil).Once()
+	mn.EXPECT().
+		SendAlert(mock.Anything, mock.MatchedBy(func(p *notify.AlertPayload) bool {
+			return p.WatchName == testWatch().Name
+		})).
+		Return(nil).Once()
+	ms.EXPECT().InsertNotificationAttempt(mock.Anything, "a2", true, 0, "").Return(nil).Once()
+	ms.EXPECT().MarkAlertNotified(mock.Anything, "a2").Return(nil).Once()
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSummary))
+	require.NoError(t, err)
+}
+
+func TestProcessAlerts_JudgeGate_FailedSummaryRecordsFailure(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mn := notifyMocks.NewMockNotifier(t)
+
+	alerts := []domain.Alert{
+		{ID: "a1", WatchID: "w1", ListingID: "l1", Score: 85, CreatedAt: time.Now()},
+	}
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(alerts, nil).Once()
+	ms.EXPECT().
+		GetJudgeScores(mock.Anything, []string{"a1"}).
+		Return(map[string]*domain.JudgeScore{"a1": {AlertID: "a1", Score: 0.2, Reason: "lot of mixed parts"}}, nil).Once()
+	ms.EXPECT().
+		GetListingByID(mock.Anything, "l1").
+		Return(&domain.Listing{ID: "l1", ComponentType: domain.ComponentRAM}, nil).Once()
+	mn.EXPECT().SendAlert(mock.Anything, mock.Anything).Return(errors.New("webhook 500")).Once()
+	// Recorded as a plain failed attempt, without a suppression reason,
+	// and left pending for the next tick.
+	ms.EXPECT().InsertNotificationAttempt(mock.Anything, "a1", false, 0, "webhook 500").Return(nil).Once()
+
+	err := ProcessAlerts(context.Background(), ms, mn, judgeGateConfig(config.JudgeGateActionSummary))
+	require.NoError(t, err)
+}
+
+func TestApplyJudgeGate_Floor(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	now := time.Now()
+	alerts := []domain.Alert{
+		{ID: "at-floor", CreatedAt: now},
+		{ID: "below", CreatedAt: now},
+		{ID: "held", CreatedAt: now},
+	}
+	// One query for the whole pool.
+	ms.EXPECT().
+		GetJudgeScores(mock.Anything, []string{"at-floor", "below", "held"}).
+		Return(map[string]*domain.JudgeScore{
+			"at-floor": {Score: 0.3},
+			"below":    {Score: 0.29},
+		}, nil).Once()
+
+	g := applyJudgeGate(context.Background(), ms, alerts, judgeGateConfig("").JudgeGate, now)
+
+	require.Len(t, g.ready, 1)
+	assert.Equal(t, "at-floor", g.ready[0].ID)
+	require.Len(t, g.belowFloor, 1)
+	assert.Equal(t, "below", g.belowFloor[0].ID)
+	assert.Equal(t, 1, g.held)
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// PartOutValue prices the CPUs and RAM named by a server's extracted
+// attributes from their baselines, in USD. Each part is priced at the
+// lowest P50 among the baselines its key pattern matches that have
+// enough samples, so an unknown RAM generation or speed values the
+// sticks as the cheapest kind that fits. A part naming an exact key is
+// resolved through the product key aliases first, since a merge drops
+// the alias's baseline. Parts without a usable baseline are left out;
+// ok is false when none could be priced.
+func PartOutValue(
+	ctx context.Context,
+	s store.Store,
+	attrs map[string]any,
+) (value float64, ok bool, err error) {
+	for _, part := range extract.ServerParts(attrs) {
+		pattern := part.KeyPattern
+		if part.Key != "" {
+			canonical, err := s.ResolveProductKey(ctx, part.Key)
+			if err != nil {
+				return 0, false, fmt.Errorf("resolving %s product key: %w", part.ComponentType, err)
+			}
+			pattern = extract.KeyPatternFor(canonical)
+		}
+		baselines, err := s.ListBaselinesMatching(ctx, pattern)
+		if err != nil {
+			return 0, false, fmt.Errorf("listing %s baselines: %w", part.ComponentType, err)
+		}
+		unit, priced := lowestP50(baselines)
+		if !priced {
+			continue
+		}
+		value += unit * float64(part.Quantity)
+		ok = true
+	}
+	return value, ok, nil
+}
+
+func lowestP50(baselines []domain.PriceBaseline) (float64, bool) {
+	lowest, found := 0.0, false
+	for i := range baselines {
+		b := &baselines[i]
+		if b.SampleCount < score.MinBaselineSamples || b.P50 <= 0 {
+			continue
+		}
+		if !found || b.P50 < lowest {
+			lowest, found = b.P50, true
+		}
+	}
+	return lowest, found
+}
+
+// estimatePartOut refreshes a server's part-out value and ratio when
+// the estimator is enabled. Failures are logged and leave the previous
+// estimate in place; a server whose parts can no longer be priced has
+// its estimate cleared.
+func (eng *Engine) estimatePartOut(ctx context.Context, listing *domain.Listing) {
+	if !eng.partOut.Enabled || listing.ComponentType != domain.ComponentServer {
+		return
+	}
+
+	value, ok, err := PartOutValue(ctx, eng.store, listing.Attributes)
+	if err != nil {
+		eng.log.Error("estimating part-out value failed", "listing", listing.ID, "error", err)
+		return
+	}
+
+	var valuePtr, ratioPtr *float64
+	if unitPrice, priced := listing.UnitPriceUSD(); ok && priced && unitPrice > 0 {
+		v := math.Round(value*100) / 100
+		r := math.Round(value/unitPrice*1000) / 1000
+		valuePtr, ratioPtr = &v, &r
+	}
+
+	if err := eng.store.UpdatePartOut(ctx, listing.ID, valuePtr, ratioPtr); err != nil {
+		eng.log.Error("storing part-out estimate failed", "listing", listing.ID, "error", err)
+		return
+	}
+	if ratioPtr != nil {
+		metrics.PartOutRatioDistribution.Observe(*ratioPtr)
+	}
+	listing.PartOutValue = valuePtr
+	listing.PartOutRatio = ratioPtr
+}
@@ -0,0 +1,245 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func testServerListing() *domain.Listing {
+	l := testListing("server:dell:r740xd:sff:unknown")
+	l.ComponentType = domain.ComponentServer
+	l.Price = 500
+	l.Attributes = map[string]any{
+		"cpu_model":       "Xeon Gold 6248R",
+		"cpu_count":       2,
+		"ram_total_gb":    256,
+		"ram_stick_count": 8,
+	}
+	return l
+}
+
+func TestPartOutValue(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, "cpu:intel:xeon:6248r").
+		Return("cpu:intel:xeon:6248r", nil).
+		Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, "cpu:intel:xeon:6248r").
+		Return([]domain.PriceBaseline{{ProductKey: "cpu:intel:xeon:6248r", P50: 150, SampleCount: 25}}, nil).
+		Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, `ram:%:ecc\_reg:32gb:%`).
+		Return([]domain.PriceBaseline{
+			{ProductKey: "ram:ddr4:ecc_reg:32gb:2933", P50: 40, SampleCount: 30},
+			{ProductKey: "ram:ddr4:ecc_reg:32gb:2666", P50: 30, SampleCount: 30},
+			{ProductKey: "ram:ddr4:ecc_reg:32gb:3200", P50: 10, SampleCount: 3},
+		}, nil).
+		Once()
+
+	value, ok, err := PartOutValue(context.Background(), ms, testServerListing().Attributes)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	// 2 CPUs at 150 plus 8 sticks at the cheapest well-sampled 30.
+	assert.InDelta(t, 540.0, value, 0.001)
+}
+
+func TestPartOutValue_NoBaselines(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, "cpu:intel:xeon:6248r").
+		Return("cpu:intel:xeon:6248r", nil).
+		Once()
+	ms.EXPECT().ListBaselinesMatching(mock.Anything, mock.Anything).Return(nil, nil).Twice()
+
+	_, ok, err := PartOutValue(context.Background(), ms, testServerListing().Attributes)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestPartOutValue_ResolvesMergedCPUKey(t *testing.T) {
+	t.Parallel()
+
+	// The server's CPU key was merged into another; its own baseline
+	// went with the merge.
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, "cpu:intel:xeon:6248r").
+		Return("cpu:intel:xeon:gold_6248r", nil).
+		Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, `cpu:intel:xeon:gold\_6248r`).
+		Return([]domain.PriceBaseline{{ProductKey: "cpu:intel:xeon:gold_6248r", P50: 150, SampleCount: 25}}, nil).
+		Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, `ram:%:ecc\_reg:32gb:%`).
+		Return(nil, nil).
+		Once()
+
+	value, ok, err := PartOutValue(context.Background(), ms, testServerListing().Attributes)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, 300.0, value, 0.001)
+}
+
+func TestEstimatePartOut(t *testing.T) {
+	t.Parallel()
+
+	ratio := 1.2
+
+	tests := []struct {
+		name      string
+		cfg       config.PartOutConfig
+		listing   func() *domain.Listing
+		setupMock func(ms *storeMocks.MockStore)
+		wantRatio *float64
+	}{
+		{
+			name:    "disabled skips",
+			listing: testServerListing,
+		},
+		{
+			name: "non-server skips",
+			cfg:  config.PartOutConfig{Enabled: true},
+			listing: func() *domain.Listing {
+				return testListing("ram:ddr4:ecc_reg:32gb:2666")
+			},
+		},
+		{
+			name:    "stores value and ratio",
+			cfg:     config.PartOutConfig{Enabled: true},
+			listing: testServerListing,
+			setupMock: func(ms *storeMocks.MockStore) {
+				ms.EXPECT().
+					ResolveProductKey(mock.Anything, "cpu:intel:xeon:6248r").
+					Return("cpu:intel:xeon:6248r", nil).
+					Once()
+				ms.EXPECT().
+					ListBaselinesMatching(mock.Anything, "cpu:intel:xeon:6248r").
+					Return([]domain.PriceBaseline{{P50: 300, SampleCount: 20}}, nil).
+					Once()
+				ms.EXPECT().
+					ListBaselinesMatching(mock.Anything, mock.Anything).
+					Return(nil, nil).
+					Once()
+				ms.EXPECT().
+					UpdatePartOut(mock.Anything, "listing-1",
+						mock.MatchedBy(func(v *float64) bool { return v != nil && *v == 600 }),
+						mock.MatchedBy(func(r *float64) bool { return r != nil && *r == 1.2 }),
+					).
+					Return(nil).
+					Once()
+			},
+			wantRatio: &ratio,
+		},
+		{
+			name:    "store error keeps estimate unset",
+			cfg:     config.PartOutConfig{Enabled: true},
+			listing: testServerListing,
+			setupMock: func(ms *storeMocks.MockStore) {
+				ms.EXPECT().
+					ResolveProductKey(mock.Anything, "cpu:intel:xeon:6248r").
+					Return("cpu:intel:xeon:6248r", nil).
+					Once()
+				ms.EXPECT().
+					ListBaselinesMatching(mock.Anything, mock.Anything).
+					Return(nil, errors.New("db down")).
+					Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			if tt.setupMock != nil {
+				tt.setupMock(ms)
+			}
+			eng := newTestEngine(
+				ms,
+				ebayMocks.NewMockEbayClient(t),
+				extractMocks.NewMockExtractor(t),
+				notifyMocks.NewMockNotifier(t),
+			)
+			eng.partOut = tt.cfg
+
+			l := tt.listing()
+			eng.estimatePartOut(context.Background(), l)
+			assert.Equal(t, tt.wantRatio, l.PartOutRatio)
+		})
+	}
+}
+
+func TestProcessExtractionJob_EstimatesPartOutFromExtractedAttributes(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mx := extractMocks.NewMockExtractor(t)
+
+	// A freshly ingested listing has neither a component type nor
+	// attributes until the extraction worker fills them in.
+	job := &domain.ExtractionJob{ID: "job-p", ListingID: "listing-1"}
+	listing := testServerListing()
+	listing.ComponentType = ""
+	listing.Attributes = nil
+	extracted := testServerListing().Attributes
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-1").Return(listing, nil).Once()
+	mx.EXPECT().
+		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
+		Return(domain.ComponentServer, extracted, nil).Once()
+	// Once for the server's own key, once for its CPU's.
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, mock.AnythingOfType("string")).
+		RunAndReturn(func(_ context.Context, k string) (string, error) { return k, nil }).Twice()
+	ms.EXPECT().
+		UpdateListingExtraction(mock.Anything, "listing-1", "server", mock.Anything, 0.9, mock.AnythingOfType("string")).
+		Return(nil).Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, "cpu:intel:xeon:6248r").
+		Return([]domain.PriceBaseline{{P50: 300, SampleCount: 20}}, nil).
+		Once()
+	ms.EXPECT().
+		ListBaselinesMatching(mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+	ms.EXPECT().
+		UpdatePartOut(mock.Anything, "listing-1",
+			mock.MatchedBy(func(v *float64) bool { return v != nil && *v == 600 }),
+			mock.MatchedBy(func(r *float64) bool { return r != nil && *r == 1.2 }),
+		).
+		Return(nil).
+		Once()
+	ms.EXPECT().GetBaseline(mock.Anything, mock.AnythingOfType("string")).Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+	ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-p", "").Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+	eng.partOut = config.PartOutConfig{Enabled: true}
+	eng.processExtractionJob(context.Background(), "worker-0", job)
+
+	require.NotNil(t, listing.PartOutRatio)
+	assert.InDelta(t, 1.2, *listing.PartOutRatio, 0.001)
+}
@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// checkAlertedPrices re-reads, with one getItem call each, the price of
+// up to limit listings alerted to a watch that re-alerts on price drops,
+// least recently checked first. A poll only sees a stored listing's
+// price again on the page where pagination stopped, so a drop on one
+// further down the results would otherwise go unnoticed. A moved price
+// is handled as a search sighting would be (see refreshKnownListing).
+// Returns the number of getItem calls made.
+func (eng *Engine) checkAlertedPrices(ctx context.Context, watches []domain.Watch, limit int) int {
+	interval := eng.alertsConfig.PriceCheckInterval
+	if interval <= 0 || limit <= 0 {
+		return 0
+	}
+
+	listings, err := eng.store.ListPriceCheckListings(ctx, time.Now().Add(-interval), limit)
+	if err != nil {
+		eng.log.Warn("listing alerted listings for price checks failed", "error", err)
+		return 0
+	}
+
+	var calls int
+	for i := range listings {
+		if ctx.Err() != nil {
+			break
+		}
+		l := &listings[i]
+		item, err := eng.ebay.GetItem(ctx, l.EbayID, itemMarketplace(l, watches))
+		if errors.Is(err, ebay.ErrDailyLimitReached) {
+			break
+		}
+		calls++
+		if err != nil {
+			eng.log.Warn("price check failed", "ebay_id", l.EbayID, "error", err)
+		} else {
+			metrics.ListingsPriceCheckedTotal.Inc()
+			eng.refreshKnownListing(ctx, &ebay.KnownListing{Seen: ebay.ToListing(item), Stored: l})
+		}
+		// Marked on failure too, so a listing eBay no longer serves
+		// doesn't take a call every cycle.
+		if err := eng.store.MarkPriceChecked(ctx, l.ID); err != nil {
+			eng.log.Warn("marking listing price checked failed", "listing", l.ID, "error", err)
+		}
+	}
+	return calls
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestRunIngestion_PriceCheckReAlertsBeyondFirstPage(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	me := ebayMocks.NewMockEbayClient(t)
+	mx := extractMocks.NewMockExtractor(t)
+	mn := notifyMocks.NewMockNotifier(t)
+	expectCountMethods(ms)
+	eng := NewEngine(ms, me, mx, mn,
+		WithLogger(quietLogger()),
+		WithStaggerOffset(0),
+		WithMaxCallsPerCycle(5),
+		WithPaginator(ebay.NewPaginator(me, ms, ebay.WithPaginatorLogger(quietLogger()))),
+		WithAlertsConfig(config.AlertsConfig{ReAlertsCooldown: 24 * time.Hour, PriceCheckInterval: 6 * time.Hour}),
+	)
+
+	ship := 10.0
+	score := 85
+	stored := func(id, ebayID string, price float64) *domain.Listing {
+		return &domain.Listing{
+			ID: id, EbayID: ebayID, Title: "Samsung 32GB DDR4 ECC", Price: price, ShippingCost: &ship,
+			Currency: "USD", ListingType: domain.ListingBuyItNow, ComponentType: domain.ComponentRAM,
+			ProductKey: "ram:ddr4:32gb", Score: &score,
+		}
+	}
+	item := func(ebayID, price string) ebay.ItemSummary {
+		return ebay.ItemSummary{
+			ItemID: ebayID, Title: "Samsung 32GB DDR4 ECC",
+			Price:           ebay.ItemPrice{Value: price, Currency: "USD"},
+			ShippingOptions: []ebay.ShippingOption{{ShippingCost: &ebay.ItemPrice{Value: "10.00", Currency: "USD"}}},
+		}
+	}
+
+	w := domain.Watch{
+		ID: "w1", Name: "DDR4", SearchQuery: "DDR4", ComponentType: domain.ComponentRAM, Enabled: true,
+		ScoreThreshold: 1, RealertDropPct: 20,
+	}
+	ms.EXPECT().ListWatches(mock.Anything, true).Return([]domain.Watch{w}, nil).Twice()
+
+	// The first page stops at an unchanged known listing, so the poll
+	// never reaches l9, alerted at $390 + $10 and now $310 + $10.
+	me.EXPECT().
+		Search(mock.Anything, mock.Anything).
+		Return(&ebay.SearchResponse{Items: []ebay.ItemSummary{item("bin-1", "200.00")}, HasMore: true}, nil).
+		Once()
+	ms.EXPECT().GetListing(mock.Anything, "bin-1").Return(stored("l1", "bin-1", 200), nil).Once()
+
+	// The four pages the poll left over go to price checks.
+	ms.EXPECT().
+		ListPriceCheckListings(mock.Anything, mock.Anything, 4).
+		Return([]domain.Listing{*stored("l9", "bin-9", 390)}, nil).
+		Once()
+	reduced := item("bin-9", "310.00")
+	me.EXPECT().GetItem(mock.Anything, "bin-9", "").Return(&reduced, nil).Once()
+	ms.EXPECT().
+		UpsertListing(mock.Anything, mock.MatchedBy(func(l *domain.Listing) bool {
+			return l.ID == "l9" && l.Price == 310
+		})).
+		Return(nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, "ram:ddr4:32gb").Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "l9", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().HasRecentAlert(mock.Anything, "w1", "l9", 24*time.Hour).Return(true, nil).Once()
+	ms.EXPECT().
+		GetAlertedPrice(mock.Anything, "w1", "l9").
+		Return(&domain.PricePoint{Price: 390, ShippingCost: &ship, Currency: "USD"}, nil).Once()
+	ms.EXPECT().
+		CreateAlert(mock.Anything, mock.MatchedBy(func(a *domain.Alert) bool {
+			return a.WatchID == "w1" && a.ListingID == "l9"
+		})).
+		Return(nil).Once()
+	ms.EXPECT().MarkPriceChecked(mock.Anything, "l9").Return(nil).Once()
+	ms.EXPECT().ListPendingAlerts(mock.Anything).Return(nil, nil).Once()
+
+	require.NoError(t, eng.RunIngestion(context.Background()))
+
+	plan := eng.LastBudgetPlan()
+	require.NotNil(t, plan)
+	assert.Equal(t, 1, plan.PriceChecks)
+	assert.Equal(t, 2, plan.CycleUsed)
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+)
+
+// ErrInvalidKeyMerge is returned by MergeProductKeys for merges that
+// would make no sense: empty keys, a key merged into itself, keys of
+// different component types, or a merge that would form an alias
+// cycle.
+var ErrInvalidKeyMerge = errors.New("invalid product key merge")
+
+// MergeProductKeys makes from an alias of to. Listings under from (and
+// any keys already aliased to it) move to to's canonical key, to's
+// baseline is recomputed over the combined listings, and they are
+// re-scored against it and checked for alerts as the extraction worker
+// would. Returns the number of listings moved.
+func (eng *Engine) MergeProductKeys(ctx context.Context, from, to string) (int, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" {
+		return 0, fmt.Errorf("%w: from and to are required", ErrInvalidKeyMerge)
+	}
+	if from == to {
+		return 0, fmt.Errorf("%w: cannot merge %s into itself", ErrInvalidKeyMerge, from)
+	}
+	if componentOf(from) != componentOf(to) {
+		return 0, fmt.Errorf("%w: %s and %s are different component types", ErrInvalidKeyMerge, from, to)
+	}
+
+	canonical, err := eng.store.ResolveProductKey(ctx, to)
+	if err != nil {
+		return 0, fmt.Errorf("resolving %s: %w", to, err)
+	}
+	if canonical == from {
+		return 0, fmt.Errorf("%w: %s is already an alias of %s", ErrInvalidKeyMerge, to, from)
+	}
+
+	moved, err := eng.store.MergeProductKeys(ctx, from, canonical)
+	if err != nil {
+		return 0, fmt.Errorf("merging %s into %s: %w", from, canonical, err)
+	}
+	eng.log.Info("product keys merged", "from", from, "to", canonical, "listings_moved", moved)
+
+	if err := eng.store.RecomputeBaseline(ctx, canonical, eng.baselineWindowDays); err != nil {
+		return moved, fmt.Errorf("recomputing baseline for %s: %w", canonical, err)
+	}
+	if _, err := eng.rescoreProductKey(ctx, canonical); err != nil {
+		return moved, fmt.Errorf("re-scoring %s: %w", canonical, err)
+	}
+	return moved, nil
+}
+
+// rescoreProductKey re-scores every active listing under productKey
+// and evaluates alerts after each successful score, as RescoreAll does.
+// Pages are ordered by first_seen_at, which re-scoring doesn't change.
+func (eng *Engine) rescoreProductKey(ctx context.Context, productKey string) (int, error) {
+	const batchSize = 200
+	total := 0
+	var errs []error
+
+	for offset := 0; ; offset += batchSize {
+		batch, _, err := eng.store.ListListings(ctx, &store.ListingQuery{
+			ProductKey: &productKey,
+			ActiveOnly: true,
+			OrderBy:    "first_seen_at",
+			Limit:      batchSize,
+			Offset:     offset,
+		})
+		if err != nil {
+			return total, fmt.Errorf("listing by product key: %w", err)
+		}
+		for i := range batch {
+			if err := eng.scoreListing(ctx, &batch[i]); err != nil {
+				errs = append(errs, fmt.Errorf("scoring %s: %w", batch[i].ID, err))
+				continue
+			}
+			total++
+			eng.evaluateAlertsForListing(ctx, &batch[i])
+		}
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	return total, errors.Join(errs...)
+}
+
+// resolveProductKey maps an extracted product key onto its canonical
+// key. Lookup failures are logged and keep the extracted key, so a
+// transient error files the listing under its alias until it is merged
+// again.
+func (eng *Engine) resolveProductKey(ctx context.Context, productKey string) string {
+	canonical, err := eng.store.ResolveProductKey(ctx, productKey)
+	if err != nil {
+		eng.log.Error("resolving product key failed", "product_key", productKey, "error", err)
+		return productKey
+	}
+	return canonical
+}
+
+func componentOf(productKey string) string {
+	ct, _, _ := strings.Cut(productKey, ":")
+	return ct
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ebayMocks "github.com/donaldgifford/server-price-tracker/internal/ebay/mocks"
+	notifyMocks "github.com/donaldgifford/server-price-tracker/internal/notify/mocks"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestMergeProductKeys(t *testing.T) {
+	t.Parallel()
+
+	const (
+		from = "cpu:intel:xeon:e5-2690v4"
+		to   = "cpu:intel:xeon:e5-2690_v4"
+	)
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().ResolveProductKey(mock.Anything, to).Return(to, nil).Once()
+	ms.EXPECT().MergeProductKeys(mock.Anything, from, to).Return(3, nil).Once()
+	ms.EXPECT().RecomputeBaseline(mock.Anything, to, 90).Return(nil).Once()
+	ms.EXPECT().ListListings(mock.Anything, mock.Anything).Return(nil, 0, nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	moved, err := eng.MergeProductKeys(context.Background(), from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+}
+
+func TestMergeProductKeys_RescoresWithConfiguredWeights(t *testing.T) {
+	t.Parallel()
+
+	const key = "ram:ddr4:ecc_reg:32gb:2666"
+	weights := score.Weights{Seller: 1}
+	listing := testListing(key)
+	listing.ComponentType = domain.ComponentRAM
+	want := score.Score(buildListingData(listing), nil, weights).Total
+	require.NotEqual(t, score.Score(buildListingData(listing), nil, score.DefaultWeights()).Total, want)
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().ResolveProductKey(mock.Anything, key).Return(key, nil).Once()
+	ms.EXPECT().MergeProductKeys(mock.Anything, "ram:ddr4:ecc-reg:32gb:2666", key).Return(1, nil).Once()
+	ms.EXPECT().RecomputeBaseline(mock.Anything, key, 90).Return(nil).Once()
+	ms.EXPECT().
+		ListListings(mock.Anything, mock.MatchedBy(func(q *store.ListingQuery) bool {
+			return q.ProductKey != nil && *q.ProductKey == key && q.Offset == 0
+		})).
+		Return([]domain.Listing{*listing}, 1, nil).Once()
+	ms.EXPECT().GetBaseline(mock.Anything, key).Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", want, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	// Merged listings are checked for alerts like freshly scored ones.
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	eng.weights = score.WeightSet{Components: map[string]score.Weights{"ram": weights}}
+	_, err := eng.MergeProductKeys(context.Background(), "ram:ddr4:ecc-reg:32gb:2666", key)
+	require.NoError(t, err)
+}
+
+func TestMergeProductKeys_MergesIntoCanonical(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().ResolveProductKey(mock.Anything, "ram:ddr4:ecc_rge:32gb:2666").
+		Return("ram:ddr4:ecc_reg:32gb:2666", nil).Once()
+	ms.EXPECT().MergeProductKeys(mock.Anything, "ram:ddr4:ecc-reg:32gb:2666", "ram:ddr4:ecc_reg:32gb:2666").
+		Return(1, nil).Once()
+	ms.EXPECT().RecomputeBaseline(mock.Anything, "ram:ddr4:ecc_reg:32gb:2666", 90).Return(nil).Once()
+	ms.EXPECT().ListListings(mock.Anything, mock.Anything).Return(nil, 0, nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	_, err := eng.MergeProductKeys(context.Background(), "ram:ddr4:ecc-reg:32gb:2666", "ram:ddr4:ecc_rge:32gb:2666")
+	require.NoError(t, err)
+}
+
+func TestMergeProductKeys_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		from, to  string
+		canonical string
+	}{
+		{name: "empty from", to: "ram:ddr4"},
+		{name: "different component types", from: "ram:ddr4", to: "cpu:intel"},
+		{name: "same key", from: "ram:ddr4", to: "ram:ddr4"},
+		{name: "alias cycle", from: "ram:ddr4", to: "ram:ddr-4", canonical: "ram:ddr4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ms := storeMocks.NewMockStore(t)
+			if tt.canonical != "" {
+				ms.EXPECT().ResolveProductKey(mock.Anything, tt.to).Return(tt.canonical, nil).Once()
+			}
+
+			eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+			_, err := eng.MergeProductKeys(context.Background(), tt.from, tt.to)
+			require.ErrorIs(t, err, ErrInvalidKeyMerge)
+		})
+	}
+}
+
+func TestMergeProductKeys_StoreError(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	ms.EXPECT().ResolveProductKey(mock.Anything, "ram:b").Return("ram:b", nil).Once()
+	ms.EXPECT().MergeProductKeys(mock.Anything, "ram:a", "ram:b").Return(0, errors.New("db down")).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), extractMocks.NewMockExtractor(t), notifyMocks.NewMockNotifier(t))
+	_, err := eng.MergeProductKeys(context.Background(), "ram:a", "ram:b")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidKeyMerge)
+}
+
+func TestProcessExtractionJob_ResolvesProductKeyAlias(t *testing.T) {
+	t.Parallel()
+
+	ms := storeMocks.NewMockStore(t)
+	mx := extractMocks.NewMockExtractor(t)
+
+	job := &domain.ExtractionJob{ID: "job-a", ListingID: "listing-a"}
+	listing := testListing("")
+	listing.ID = "listing-a"
+
+	ms.EXPECT().GetListingByID(mock.Anything, "listing-a").Return(listing, nil).Once()
+	mx.EXPECT().
+		ClassifyAndExtract(mock.Anything, listing.Title, mock.Anything).
+		Return(domain.ComponentRAM, map[string]any{"capacity_gb": 32}, nil).Once()
+	ms.EXPECT().
+		ResolveProductKey(mock.Anything, "ram:unknown:unknown:32gb:0").
+		Return("ram:ddr4:ecc_reg:32gb:2666", nil).Once()
+	ms.EXPECT().
+		UpdateListingExtraction(mock.Anything, "listing-a", "ram", mock.Anything, 0.9, "ram:ddr4:ecc_reg:32gb:2666").
+		Return(nil).Once()
+	ms.EXPECT().
+		GetBaseline(mock.Anything, "ram:ddr4:ecc_reg:32gb:2666").
+		Return(nil, pgx.ErrNoRows).Once()
+	ms.EXPECT().
+		UpdateScore(mock.Anything, "listing-a", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().
+		UpdateValueMetric(mock.Anything, "listing-a", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	ms.EXPECT().ListWatches(mock.Anything, true).Return(nil, nil).Once()
+	ms.EXPECT().CompleteExtractionJob(mock.Anything, "job-a", "").Return(nil).Once()
+
+	eng := newTestEngine(ms, ebayMocks.NewMockEbayClient(t), mx, notifyMocks.NewMockNotifier(t))
+	eng.processExtractionJob(context.Background(), "worker-0", job)
+
+	assert.Equal(t, "ram:ddr4:ecc_reg:32gb:2666", listing.ProductKey)
+}
@@ -34,10 +34,13 @@ type Scheduler struct {
 }
 
 // NewScheduler creates a new Scheduler that runs engine tasks on a schedule.
+// Ingestion runs every pollTick and polls only the watches that are due
+// (see Engine.RunScheduledIngestion); configure the per-watch default
+// interval on the engine with WithPollSchedule.
 func NewScheduler(
 	eng *Engine,
 	s store.Store,
-	ingestionInterval time.Duration,
+	pollTick time.Duration,
 	baselineInterval time.Duration,
 	reExtractionInterval time.Duration,
 	log *slog.Logger,
@@ -58,7 +61,7 @@ func NewScheduler(
 		reExtractionInterval: reExtractionInterval,
 	}
 
-	if _, err = c.AddFunc("@every "+ingestionInterval.String(), sched.runIngestion); err != nil {
+	if _, err = c.AddFunc("@every "+pollTick.String(), sched.runIngestion); err != nil {
 		return nil, err
 	}
 
@@ -186,7 +189,7 @@ func (s *Scheduler) runIngestion() {
 	defer span.End()
 
 	s.log.Info("scheduled ingestion starting")
-	if err := s.runJob(ctx, "ingestion", 30*time.Minute, s.engine.RunIngestion); err != nil {
+	if err := s.runJob(ctx, "ingestion", 30*time.Minute, s.engine.RunScheduledIngestion); err != nil {
 		recordRunErr(span, err)
 		s.log.Error("scheduled ingestion failed", "error", err)
 		return
@@ -255,3 +258,20 @@ func (s *Scheduler) AddJudge(interval time.Duration, runFn func(context.Context)
 	_, err := s.cron.AddFunc("@every "+interval.String(), tick)
 	return err
 }
+
+// AddAuctionTracking registers the auction tracking job (see
+// Engine.RunAuctionTracking) to run every interval. Opt-in like
+// AddJudge so alerts.auctions.enabled = false registers nothing.
+func (s *Scheduler) AddAuctionTracking(interval time.Duration) error {
+	tick := func() {
+		ctx, span := withSpan(context.Background(), "engine.auction_tracking")
+		defer span.End()
+
+		if err := s.runJob(ctx, "auction_tracking", 10*time.Minute, s.engine.RunAuctionTracking); err != nil {
+			recordRunErr(span, err)
+			s.log.Error("scheduled auction tracking failed", "error", err)
+		}
+	}
+	_, err := s.cron.AddFunc("@every "+interval.String(), tick)
+	return err
+}
@@ -7,22 +7,65 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	"github.com/donaldgifford/server-price-tracker/internal/store"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
 	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
-// ScoreListing computes and persists the deal score for a single listing.
-// Returns nil if the listing has no product key (cannot be scored).
+// WeightsFromConfig converts the scoring config into the per-component
+// weight set the engine scores with. Unset (all-zero) blocks fall back
+// to score.DefaultWeights via WeightSet.For.
+func WeightsFromConfig(cfg config.ScoringConfig) score.WeightSet {
+	ws := score.WeightSet{Default: scorerWeights(cfg.Weights)}
+	if len(cfg.ComponentWeights) > 0 {
+		ws.Components = make(map[string]score.Weights, len(cfg.ComponentWeights))
+		for ct, w := range cfg.ComponentWeights {
+			ws.Components[ct] = scorerWeights(w)
+		}
+	}
+	return ws
+}
+
+func scorerWeights(w config.ScoringWeights) score.Weights {
+	return score.Weights{
+		Price:     w.Price,
+		Seller:    w.Seller,
+		Condition: w.Condition,
+		Quantity:  w.Quantity,
+		Quality:   w.Quality,
+		Time:      w.Time,
+		Value:     w.Value,
+	}
+}
+
+// ScoreListing computes and persists the deal score for a single listing
+// using score.DefaultWeights. Returns nil if the listing has no product
+// key (cannot be scored).
 func ScoreListing(
 	ctx context.Context,
 	s store.Store,
 	listing *domain.Listing,
+) error {
+	return ScoreListingWithWeights(ctx, s, listing, score.DefaultWeights())
+}
+
+// ScoreListingWithWeights is ScoreListing with caller-supplied factor
+// weights — the Engine resolves them per component type from config.
+func ScoreListingWithWeights(
+	ctx context.Context,
+	s store.Store,
+	listing *domain.Listing,
+	weights score.Weights,
 ) error {
 	if listing.ProductKey == "" {
 		return nil
@@ -35,7 +78,10 @@ func ScoreListing(
 
 	data := buildListingData(listing)
 	var scorerBaseline *score.Baseline
-	if baseline != nil {
+	// Baselines are in USD. A listing whose currency had no rate can't
+	// be compared against one, so it scores a neutral price factor, the
+	// same as a cold start.
+	if _, ok := listing.UnitPriceUSD(); baseline != nil && ok {
 		scorerBaseline = &score.Baseline{
 			P10:         baseline.P10,
 			P25:         baseline.P25,
@@ -46,7 +92,30 @@ func ScoreListing(
 		}
 	}
 
-	breakdown := score.Score(data, scorerBaseline, score.DefaultWeights())
+	metric, pricePerUnit := valueMetric(listing)
+	if pricePerUnit != nil {
+		data.PricePerUnit = *pricePerUnit
+		// The value baseline only matters when the factor is weighted.
+		if weights.Value > 0 {
+			vb, err := s.GetValueBaseline(ctx, metric.Class)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("getting value baseline for %s: %w", metric.Class, err)
+			}
+			if vb != nil {
+				data.ValueBaseline = &score.Baseline{
+					P10:         vb.P10,
+					P25:         vb.P25,
+					P50:         vb.P50,
+					P75:         vb.P75,
+					P90:         vb.P90,
+					SampleCount: vb.SampleCount,
+				}
+			}
+		}
+	}
+
+	breakdown := score.Score(data, scorerBaseline, weights)
+	risk := score.Risk(buildRiskData(listing), scorerBaseline)
 
 	if scorerBaseline != nil && scorerBaseline.SampleCount >= score.MinBaselineSamples {
 		metrics.ScoringWithBaselineTotal.Inc()
@@ -59,17 +128,34 @@ func ScoreListing(
 		return fmt.Errorf("marshaling breakdown: %w", err)
 	}
 
+	riskJSON, err := json.Marshal(risk.Signals)
+	if err != nil {
+		return fmt.Errorf("marshaling risk signals: %w", err)
+	}
+
 	metrics.ScoringDistribution.Observe(float64(breakdown.Total))
+	metrics.RiskDistribution.Observe(float64(risk.Total))
 
-	if err := s.UpdateScore(ctx, listing.ID, breakdown.Total, breakdownJSON); err != nil {
+	if err := s.UpdateScore(
+		ctx, listing.ID, breakdown.Total, breakdownJSON, risk.Total, riskJSON,
+	); err != nil {
 		return err
 	}
 
-	// Mirror the persisted score onto the in-memory listing so callers
+	if metric.Class != listing.ValueClass || !equalFloatPtr(pricePerUnit, listing.PricePerUnit) {
+		if err := s.UpdateValueMetric(ctx, listing.ID, metric.Class, metric.Unit, pricePerUnit); err != nil {
+			return err
+		}
+		listing.ValueClass, listing.ValueUnit, listing.PricePerUnit = metric.Class, metric.Unit, pricePerUnit
+	}
+
+	// Mirror the persisted scores onto the in-memory listing so callers
 	// (notably the extraction worker's post-score alert evaluator) can
-	// read it without re-fetching.
-	total := breakdown.Total
+	// read them without re-fetching.
+	total, riskTotal := breakdown.Total, risk.Total
 	listing.Score = &total
+	listing.RiskScore = &riskTotal
+	listing.RiskSignals = riskJSON
 	return nil
 }
 
@@ -145,10 +231,51 @@ func scoreAll(ctx context.Context, s store.Store, listings []domain.Listing) (in
 	return scored, errors.Join(errs...)
 }
 
+// valueMetric returns the listing's value metric and USD price per
+// unit, rounded to four decimals. Both are zero when the listing has
+// no metric or no USD price.
+func valueMetric(l *domain.Listing) (extract.ValueMetric, *float64) {
+	metric, ok := extract.ComputeValueMetric(string(l.ComponentType), l.Attributes)
+	unitPrice, priced := l.UnitPriceUSD()
+	if !ok || !priced {
+		return extract.ValueMetric{}, nil
+	}
+	ppu := math.Round(unitPrice/metric.Units*1e4) / 1e4
+	return metric, &ppu
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// buildRiskData maps a listing onto the scorer's risk inputs. The
+// location is foreign when the item ships from outside every country
+// whose marketplace prices in the listing's currency.
+func buildRiskData(l *domain.Listing) *score.RiskData {
+	unitPrice, _ := l.UnitPriceUSD()
+	countries := ebay.CurrencyCountries(l.Currency)
+	return &score.RiskData{
+		UnitPrice:      unitPrice,
+		SellerFeedback: l.SellerFeedback,
+		Title:          l.Title,
+		HasImages:      l.ImageURL != "",
+		ForeignLocation: l.ItemCountry != "" && len(countries) > 0 &&
+			!slices.Contains(countries, l.ItemCountry),
+	}
+}
+
 func buildListingData(l *domain.Listing) *score.ListingData {
 	isAuction := l.ListingType == domain.ListingAuction
+	unitPrice, _ := l.UnitPriceUSD()
+	var partOutRatio float64
+	if l.PartOutRatio != nil {
+		partOutRatio = *l.PartOutRatio
+	}
 	return &score.ListingData{
-		UnitPrice:         l.UnitPrice(),
+		UnitPrice:         unitPrice,
 		SellerFeedback:    l.SellerFeedback,
 		SellerFeedbackPct: l.SellerFeedbackPct,
 		SellerTopRated:    l.SellerTopRated,
@@ -159,5 +286,6 @@ func buildListingData(l *domain.Listing) *score.ListingData {
 		IsAuction:         isAuction,
 		AuctionEndingSoon: isAuction && l.AuctionEndAt != nil && time.Until(*l.AuctionEndAt) < 4*time.Hour,
 		IsNewListing:      time.Since(l.FirstSeenAt) < 24*time.Hour,
+		PartOutRatio:      partOutRatio,
 	}
 }
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
 	storeMocks "github.com/donaldgifford/server-price-tracker/internal/store/mocks"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
@@ -63,7 +65,7 @@ func TestScoreListing(t *testing.T) {
 					}, nil).
 					Once()
 				m.EXPECT().
-					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 					Return(nil).
 					Once()
 			},
@@ -77,7 +79,7 @@ func TestScoreListing(t *testing.T) {
 					Return(nil, pgx.ErrNoRows).
 					Once()
 				m.EXPECT().
-					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 					Return(nil).
 					Once()
 			},
@@ -110,7 +112,7 @@ func TestScoreListing(t *testing.T) {
 					Return(nil, pgx.ErrNoRows).
 					Once()
 				m.EXPECT().
-					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+					UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 					Return(errors.New("write failed")).
 					Once()
 			},
@@ -137,6 +139,132 @@ func TestScoreListing(t *testing.T) {
 	}
 }
 
+func TestScoreListing_UnconvertedPriceIsNeutral(t *testing.T) {
+	t.Parallel()
+
+	l := testListing("ram:ddr4:ecc_reg:32gb:2666")
+	l.Currency = "JPY"
+
+	mockStore := storeMocks.NewMockStore(t)
+	mockStore.EXPECT().
+		GetBaseline(mock.Anything, l.ProductKey).
+		Return(&domain.PriceBaseline{SampleCount: 50, P10: 20, P25: 35, P50: 50, P75: 65, P90: 80}, nil).
+		Once()
+	mockStore.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"),
+			mock.MatchedBy(func(b []byte) bool {
+				var breakdown score.Breakdown
+				return json.Unmarshal(b, &breakdown) == nil && breakdown.Price == 50
+			}), mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	require.NoError(t, ScoreListing(context.Background(), mockStore, l))
+}
+
+func TestScoreListing_PersistsRisk(t *testing.T) {
+	t.Parallel()
+
+	// Shipped from China on EBAY_US by a brand-new seller, no image.
+	l := testListing("ram:ddr4:ecc_reg:32gb:2666")
+	l.ImageURL = ""
+	l.SellerFeedback = 3
+	l.ItemCountry = "CN"
+	usd := 45.99
+	l.PriceUSD = &usd
+
+	mockStore := storeMocks.NewMockStore(t)
+	mockStore.EXPECT().
+		GetBaseline(mock.Anything, l.ProductKey).
+		Return(&domain.PriceBaseline{SampleCount: 50, P10: 40, P25: 45, P50: 50, P75: 65, P90: 80}, nil).
+		Once()
+	mockStore.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything,
+			70, mock.MatchedBy(func(b []byte) bool {
+				var signals []score.RiskSignal
+				return json.Unmarshal(b, &signals) == nil && len(signals) == 3
+			})).
+		Return(nil).
+		Once()
+
+	require.NoError(t, ScoreListing(context.Background(), mockStore, l))
+	require.NotNil(t, l.RiskScore)
+	assert.Equal(t, 70, *l.RiskScore)
+}
+
+func TestScoreListingWithWeights_ValueMetric(t *testing.T) {
+	t.Parallel()
+
+	l := testListing("ram:ddr4:ecc_reg:32gb:2666")
+	l.Price = 48
+	l.ComponentType = domain.ComponentRAM
+	l.Attributes = map[string]any{
+		"generation": "DDR4", "ecc": true, "registered": true, "capacity_gb": 32, "speed_mhz": 2666,
+	}
+
+	weights := score.DefaultWeights()
+	weights.Price -= 0.2
+	weights.Value = 0.2
+
+	mockStore := storeMocks.NewMockStore(t)
+	mockStore.EXPECT().GetBaseline(mock.Anything, l.ProductKey).Return(nil, pgx.ErrNoRows).Once()
+	mockStore.EXPECT().
+		GetValueBaseline(mock.Anything, "ram:ddr4:ecc_reg").
+		Return(&domain.ValueBaseline{SampleCount: 40, P10: 1.5, P25: 2, P50: 2.5, P75: 3, P90: 4}, nil).
+		Once()
+	mockStore.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"),
+			mock.MatchedBy(func(b []byte) bool {
+				var bd score.Breakdown
+				return json.Unmarshal(b, &bd) == nil && bd.Value == 100
+			}), mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	mockStore.EXPECT().
+		UpdateValueMetric(mock.Anything, "listing-1", "ram:ddr4:ecc_reg", "gb",
+			mock.MatchedBy(func(p *float64) bool { return p != nil && *p == 1.5 })).
+		Return(nil).
+		Once()
+
+	require.NoError(t, ScoreListingWithWeights(context.Background(), mockStore, l, weights))
+	require.NotNil(t, l.PricePerUnit)
+	assert.InDelta(t, 1.5, *l.PricePerUnit, 1e-9)
+
+	// Rescoring an unchanged listing doesn't rewrite the metric.
+	mockStore.EXPECT().GetBaseline(mock.Anything, l.ProductKey).Return(nil, pgx.ErrNoRows).Once()
+	mockStore.EXPECT().GetValueBaseline(mock.Anything, "ram:ddr4:ecc_reg").Return(nil, pgx.ErrNoRows).Once()
+	mockStore.EXPECT().
+		UpdateScore(mock.Anything, "listing-1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+	require.NoError(t, ScoreListingWithWeights(context.Background(), mockStore, l, weights))
+}
+
+func TestBuildRiskData_ForeignLocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		currency string
+		country  string
+		want     bool
+	}{
+		{name: "domestic US", currency: "USD", country: "US"},
+		{name: "foreign on US", currency: "USD", country: "CN", want: true},
+		{name: "eurozone neighbour", currency: "EUR", country: "FR"},
+		{name: "unknown location", currency: "USD"},
+		{name: "currency without marketplace", currency: "JPY", country: "CN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := &domain.Listing{Currency: tt.currency, ItemCountry: tt.country}
+			assert.Equal(t, tt.want, buildRiskData(l).ForeignLocation)
+		})
+	}
+}
+
 func TestRescoreListings(t *testing.T) {
 	t.Parallel()
 
@@ -160,7 +288,7 @@ func TestRescoreListings(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -169,7 +297,7 @@ func TestRescoreListings(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l2", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l2", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -203,7 +331,7 @@ func TestRescoreByProductKey(t *testing.T) {
 			Return(nil, pgx.ErrNoRows).
 			Once()
 		mockStore.EXPECT().
-			UpdateScore(mock.Anything, l.ID, mock.AnythingOfType("int"), mock.Anything).
+			UpdateScore(mock.Anything, l.ID, mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Once()
 	}
@@ -233,7 +361,7 @@ func TestScoreAll_ContinuesOnError(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -249,7 +377,7 @@ func TestScoreAll_ContinuesOnError(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l3", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l3", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -320,6 +448,24 @@ func TestBuildListingData_IsNewListing(t *testing.T) {
 	assert.False(t, data.AuctionEndingSoon)
 }
 
+func TestBuildListingData_UsesUSDPrice(t *testing.T) {
+	t.Parallel()
+
+	priceUSD, shippingUSD := 125.0, 12.5
+	l := &domain.Listing{
+		Price:           100,
+		Currency:        "EUR",
+		PriceUSD:        &priceUSD,
+		ShippingCostUSD: &shippingUSD,
+		Quantity:        1,
+	}
+	assert.InDelta(t, 137.5, buildListingData(l).UnitPrice, 0.001)
+
+	// No rate for the currency: no USD price to compare with baselines.
+	l = &domain.Listing{Price: 100, Currency: "JPY", Quantity: 1}
+	assert.Zero(t, buildListingData(l).UnitPrice)
+}
+
 func TestRescoreAll(t *testing.T) {
 	t.Parallel()
 
@@ -349,7 +495,7 @@ func TestRescoreAll(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -358,7 +504,7 @@ func TestRescoreAll(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "l2", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "l2", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -413,7 +559,7 @@ func TestRescoreAll_CursorPagination(t *testing.T) {
 	for _, id := range []string{"aaa", "bbb", "ccc", "ddd", "eee", "fff"} {
 		i := id
 		mockStore.EXPECT().
-			UpdateScore(mock.Anything, i, mock.AnythingOfType("int"), mock.Anything).
+			UpdateScore(mock.Anything, i, mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).Once()
 	}
 
@@ -469,7 +615,7 @@ func TestScoreListing_IncrementsWarmBaselineCounter(t *testing.T) {
 		}, nil).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -489,7 +635,7 @@ func TestScoreListing_IncrementsColdStartCounter(t *testing.T) {
 		Return(nil, pgx.ErrNoRows).
 		Once()
 	mockStore.EXPECT().
-		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything).
+		UpdateScore(mock.Anything, "listing-1", mock.AnythingOfType("int"), mock.Anything, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -1,5 +1,7 @@
 package metrics
 
+import domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+
 // JudgeRecorder adapts the package-level Prometheus vecs to the
 // judge.MetricsRecorder interface so the judge worker can stay free of
 // Prometheus imports. The package-level vecs are the source of truth;
@@ -30,3 +32,23 @@ func (JudgeRecorder) RecordCost(model string, costUSD float64) {
 func (JudgeRecorder) RecordBudgetExhausted() {
 	JudgeBudgetExhaustedTotal.Inc()
 }
+
+// RecordTick sets the per-tick catch-up gauges and observes the tick
+// duration. The backlog gauges keep their previous values when the
+// tick couldn't read the backlog.
+func (JudgeRecorder) RecordTick(t *domain.JudgeTickStats) {
+	JudgeTickAlerts.WithLabelValues("candidates").Set(float64(t.Candidates))
+	JudgeTickAlerts.WithLabelValues("judged").Set(float64(t.Judged))
+	JudgeTickAlerts.WithLabelValues("failed").Set(float64(t.Failed))
+	JudgeTickDuration.Observe(t.DurationSeconds)
+	if t.Backlog == nil {
+		return
+	}
+	JudgeBacklogAlerts.Set(float64(t.Backlog.Pending))
+	JudgeAgedOutAlerts.Set(float64(t.Backlog.AgedOut))
+	age := 0.0
+	if t.Backlog.OldestAt != nil {
+		age = t.StartedAt.Sub(*t.Backlog.OldestAt).Seconds()
+	}
+	JudgeBacklogOldestAgeSeconds.Set(age)
+}
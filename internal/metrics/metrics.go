@@ -61,6 +61,24 @@ var (
 		Help:      "Duration of ingestion cycles in seconds.",
 		Buckets:   prometheus.DefBuckets,
 	})
+
+	IngestionWatchesDeferredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ingestion_watches_deferred_total",
+		Help:      "Total number of due watches left for a later cycle because the cycle page budget ran out.",
+	})
+
+	IngestionCycleBudgetPages = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ingestion_cycle_budget_pages",
+		Help:      "eBay pages the budget planner allotted to the last ingestion cycle.",
+	})
+
+	IngestionCyclePagesUsed = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ingestion_cycle_pages_used",
+		Help:      "eBay pages the last ingestion cycle actually used.",
+	})
 )
 
 // Extraction metrics.
@@ -77,6 +95,32 @@ var (
 		Name:      "extraction_failures_total",
 		Help:      "Total number of extraction failures.",
 	})
+
+	// ExtractionsByComponentTotal counts successful extractions by the
+	// component type the listing was classified as. Shows how much
+	// traffic a component type takes from "other" when it is added.
+	ExtractionsByComponentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "extractions_by_component_total",
+		Help:      "Total successful extractions by classified component type.",
+	}, []string{"component_type"})
+
+	// ExtractionCorrectionsTotal counts operator extraction corrections
+	// by the corrected component type.
+	ExtractionCorrectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "extraction_corrections_total",
+		Help:      "Total operator extraction corrections by corrected component type.",
+	}, []string{"component_type"})
+
+	// ShadowExtractionsTotal counts sampled shadow extractions by
+	// result: agree, disagree, error (the shadow extraction failed) or
+	// dropped (every shadow slot was busy).
+	ShadowExtractionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shadow_extractions_total",
+		Help:      "Total sampled shadow extractions by result.",
+	}, []string{"result"})
 )
 
 // LLM token metrics.
@@ -126,6 +170,20 @@ var (
 		Buckets:   prometheus.LinearBuckets(0, 10, 11), // 0, 10, 20, ..., 100
 	})
 
+	RiskDistribution = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "risk_distribution",
+		Help:      "Distribution of computed listing risk scores.",
+		Buckets:   prometheus.LinearBuckets(0, 10, 11), // 0, 10, 20, ..., 100
+	})
+
+	PartOutRatioDistribution = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "partout_ratio_distribution",
+		Help:      "Distribution of server part-out value to price ratios.",
+		Buckets:   []float64{0.25, 0.5, 0.75, 1, 1.25, 1.5, 2, 3},
+	})
+
 	ScoringWithBaselineTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "scoring_with_baseline_total",
@@ -202,6 +260,27 @@ var (
 	})
 )
 
+// Currency conversion metrics.
+var (
+	CurrencyRatesLastRefreshTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "currency_rates_last_refresh_timestamp",
+		Help:      "Unix epoch of the last successful exchange rate table load.",
+	})
+
+	CurrencyRatesRefreshFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "currency_rates_refresh_failures_total",
+		Help:      "Exchange rate table loads that failed; the previous table stays in use.",
+	})
+
+	CurrencyUnconvertedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "currency_unconverted_total",
+		Help:      "Listings ingested without a USD price because their currency had no rate.",
+	}, []string{"currency"})
+)
+
 // Alert metrics.
 var (
 	AlertsFiredTotal = promauto.NewCounter(prometheus.CounterOpts{
@@ -230,6 +309,71 @@ var (
 			"delivery so it reflects engine decisions, not Discord outcomes.",
 	}, []string{"component_type"})
 
+	// AlertsPriceDropTotal counts alerts let through the re-alert
+	// cooldown because the listing's price dropped by at least the
+	// watch's realert_drop_pct since the last notification.
+	AlertsPriceDropTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_price_drop_total",
+		Help:      "Alerts created inside the re-alert cooldown because of a price drop.",
+	})
+
+	// AlertsRelistSuppressedTotal counts alerts skipped because another
+	// listing in the same relist group already alerted the watch.
+	AlertsRelistSuppressedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_relist_suppressed_total",
+		Help:      "Alerts skipped because the listing relists one already alerted.",
+	})
+
+	// ListingsPriceRefreshedTotal counts known listings whose price a
+	// watch poll saw move, which re-scores them and can re-alert.
+	ListingsPriceRefreshedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "listings_price_refreshed_total",
+		Help:      "Known listings whose price changed when seen again by a watch poll.",
+	})
+
+	// ListingsPriceCheckedTotal counts alerted listings whose price was
+	// re-read with getItem because no search reached them.
+	ListingsPriceCheckedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "listings_price_checked_total",
+		Help:      "Alerted listings whose price was re-read with getItem.",
+	})
+
+	// ListingsRelistedTotal counts listings grouped with an earlier
+	// listing as a relist or duplicate.
+	ListingsRelistedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "listings_relisted_total",
+		Help:      "Total listings grouped with an earlier relist or duplicate.",
+	})
+
+	// CatalogEnrichmentsTotal counts catalog lookups after extraction by
+	// whether the extracted model was found in the hardware catalog.
+	CatalogEnrichmentsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "catalog_enrichments_total",
+		Help:      "Catalog lookups after extraction, labeled by component type and result (hit/miss).",
+	}, []string{"component_type", "result"})
+
+	// AuctionRemindersSentTotal counts ending-soon reminders delivered
+	// for tracked auctions.
+	AuctionRemindersSentTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auction_reminders_sent_total",
+		Help:      "Total ending-soon reminders sent for tracked auctions.",
+	})
+
+	// AuctionRefreshFailuresTotal counts tracked auctions whose current
+	// bid could not be refreshed from eBay.
+	AuctionRefreshFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auction_refresh_failures_total",
+		Help:      "Total tracked auction refreshes that failed.",
+	})
+
 	NotificationFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "notification_failures_total",
@@ -474,4 +618,51 @@ var (
 		Name:      "judge_budget_exhausted_total",
 		Help:      "Number of judge ticks that hit the configured daily USD budget cap.",
 	})
+
+	JudgeBacklogAlerts = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "judge_backlog_alerts",
+		Help:      "Un-judged alerts inside the judge lookback window at the start of the last tick.",
+	})
+
+	JudgeBacklogOldestAgeSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "judge_backlog_oldest_age_seconds",
+		Help:      "Age of the oldest un-judged alert inside the lookback window at the start of the last tick.",
+	})
+
+	JudgeAgedOutAlerts = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "judge_aged_out_alerts",
+		Help:      "Alerts that left the judge lookback window unjudged in the 24h before it, as of the last tick.",
+	})
+
+	JudgeTickAlerts = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "judge_tick_alerts",
+		Help:      "Alerts in the last judge tick by outcome (candidates, judged, failed).",
+	}, []string{"outcome"})
+
+	JudgeTickDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "judge_tick_duration_seconds",
+		Help:      "Duration of judge worker ticks.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800},
+	})
+
+	// AlertsJudgeGateTotal counts pending alerts released by the judge
+	// gate, by outcome: passed (verdict at or above the floor),
+	// timed_out (sent unjudged after max_wait), suppressed or
+	// summarized (verdict below the floor).
+	AlertsJudgeGateTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_judge_gate_total",
+		Help:      "Pending alerts released by the judge gate, by outcome (passed, timed_out, suppressed, summarized).",
+	}, []string{"outcome"})
+
+	AlertsJudgeGateHeld = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "alerts_judge_gate_held",
+		Help:      "Pending alerts the judge gate held back for a verdict in the last alert tick.",
+	})
 )
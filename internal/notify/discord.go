@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/donaldgifford/server-price-tracker/internal/metrics"
@@ -148,6 +149,9 @@ func buildEmbed(alert *AlertPayload) discordEmbed {
 	if len(alert.SummaryFields) > 0 {
 		return buildSummaryEmbed(alert)
 	}
+	if alert.EndsAt != nil {
+		return buildEndingSoonEmbed(alert)
+	}
 
 	embed := discordEmbed{
 		Title: fmt.Sprintf("Deal Alert: %s", alert.ListingTitle),
@@ -162,6 +166,8 @@ func buildEmbed(alert *AlertPayload) discordEmbed {
 			{Name: "Type", Value: alert.ComponentType, Inline: true},
 		},
 	}
+	embed.Fields = appendRiskField(embed.Fields, alert)
+	embed.Fields = appendJudgeField(embed.Fields, alert)
 
 	if alert.ImageURL != "" {
 		embed.Thumbnail = &discordThumbnail{URL: alert.ImageURL}
@@ -170,6 +176,38 @@ func buildEmbed(alert *AlertPayload) discordEmbed {
 	return embed
 }
 
+// maxFieldValueLen is Discord's limit on an embed field value.
+const maxFieldValueLen = 1024
+
+// appendJudgeField adds a Judge row with the verdict and its reason
+// when the alert was judged before it was sent.
+func appendJudgeField(fields []discordEmbedField, alert *AlertPayload) []discordEmbedField {
+	if alert.JudgeScore == nil {
+		return fields
+	}
+	value := fmt.Sprintf("%.2f", *alert.JudgeScore)
+	if alert.JudgeReason != "" {
+		value += " — " + alert.JudgeReason
+	}
+	if r := []rune(value); len(r) > maxFieldValueLen {
+		value = string(r[:maxFieldValueLen-1]) + "…"
+	}
+	return append(fields, discordEmbedField{Name: "Judge", Value: value})
+}
+
+// appendRiskField adds a Risk row when the listing has a non-zero risk
+// score, listing the signals behind it.
+func appendRiskField(fields []discordEmbedField, alert *AlertPayload) []discordEmbedField {
+	if alert.Risk == nil || *alert.Risk == 0 {
+		return fields
+	}
+	value := fmt.Sprintf("%d/100", *alert.Risk)
+	if len(alert.RiskSignals) > 0 {
+		value += " (" + strings.ReplaceAll(strings.Join(alert.RiskSignals, ", "), "_", " ") + ")"
+	}
+	return append(fields, discordEmbedField{Name: "Risk", Value: value})
+}
+
 // buildSummaryEmbed renders a Phase 6 summary payload as one embed:
 // Title + each SummaryField as an inline row + optional dashboard URL.
 // No price/seller/condition rows since those don't apply across a pool
@@ -189,6 +227,32 @@ func buildSummaryEmbed(alert *AlertPayload) discordEmbed {
 	}
 }
 
+// buildEndingSoonEmbed renders an auction reminder. The end time uses
+// Discord's relative timestamp markup so readers see "in 12 minutes"
+// in their own clock.
+func buildEndingSoonEmbed(alert *AlertPayload) discordEmbed {
+	embed := discordEmbed{
+		Title: fmt.Sprintf("Ending Soon: %s", alert.ListingTitle),
+		URL:   alert.EbayURL,
+		Color: scoreColor(alert.Score),
+		Fields: []discordEmbedField{
+			{Name: "Score", Value: fmt.Sprintf("%d/100", alert.Score), Inline: true},
+			{Name: "Current Bid", Value: alert.Price, Inline: true},
+			{Name: "Bids", Value: strconv.Itoa(alert.BidCount), Inline: true},
+			{Name: "Ends", Value: fmt.Sprintf("<t:%d:R>", alert.EndsAt.Unix()), Inline: true},
+			{Name: "Seller", Value: alert.Seller, Inline: true},
+			{Name: "Type", Value: alert.ComponentType, Inline: true},
+		},
+	}
+	embed.Fields = appendRiskField(embed.Fields, alert)
+
+	if alert.ImageURL != "" {
+		embed.Thumbnail = &discordThumbnail{URL: alert.ImageURL}
+	}
+
+	return embed
+}
+
 func scoreColor(score int) int {
 	switch {
 	case score >= 90:
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -158,6 +159,129 @@ func TestDiscordNotifier_SendAlert_NoImage(t *testing.T) {
 	assert.Nil(t, received.Embeds[0].Thumbnail)
 }
 
+func TestDiscordNotifier_SendAlert_EndingSoon(t *testing.T) {
+	t.Parallel()
+
+	var received discordWebhookPayload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewDecoder(r.Body).Decode(&received)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	endsAt := time.Unix(1_800_000_000, 0)
+	alert := testAlert(88)
+	alert.EndsAt = &endsAt
+	alert.BidCount = 7
+
+	d := NewDiscordNotifier(srv.URL)
+	err := d.SendAlert(context.Background(), &alert)
+	require.NoError(t, err)
+
+	require.Len(t, received.Embeds, 1)
+	embed := received.Embeds[0]
+	assert.Equal(t, "Ending Soon: "+alert.ListingTitle, embed.Title)
+
+	fieldMap := make(map[string]string)
+	for _, f := range embed.Fields {
+		fieldMap[f.Name] = f.Value
+	}
+	assert.Equal(t, alert.Price, fieldMap["Current Bid"])
+	assert.Equal(t, "7", fieldMap["Bids"])
+	assert.Equal(t, "<t:1800000000:R>", fieldMap["Ends"])
+	assert.NotContains(t, fieldMap, "Unit Price")
+}
+
+func TestBuildEmbed_Risk(t *testing.T) {
+	t.Parallel()
+
+	risk := func(v int) *int { return &v }
+	tests := []struct {
+		name    string
+		risk    *int
+		signals []string
+		want    string
+	}{
+		{name: "not risk scored"},
+		{name: "zero risk", risk: risk(0)},
+		{
+			name:    "risk with signals",
+			risk:    risk(45),
+			signals: []string{"low_seller_feedback", "no_image"},
+			want:    "45/100 (low seller feedback, no image)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alert := testAlert(88)
+			alert.Risk = tt.risk
+			alert.RiskSignals = tt.signals
+
+			fieldMap := make(map[string]string)
+			for _, f := range buildEmbed(&alert).Fields {
+				fieldMap[f.Name] = f.Value
+			}
+			if tt.want == "" {
+				assert.NotContains(t, fieldMap, "Risk")
+				return
+			}
+			assert.Equal(t, tt.want, fieldMap["Risk"])
+		})
+	}
+}
+
+func TestBuildEmbed_Judge(t *testing.T) {
+	t.Parallel()
+
+	verdict := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name   string
+		score  *float64
+		reason string
+		want   string
+	}{
+		{name: "not judged"},
+		{name: "verdict without reason", score: verdict(0.4), want: "0.40"},
+		{
+			name:   "verdict with reason",
+			score:  verdict(0.82),
+			reason: "well under p25 for a 32GB RDIMM",
+			want:   "0.82 — well under p25 for a 32GB RDIMM",
+		},
+		{
+			name:   "long reason is truncated",
+			score:  verdict(0.5),
+			reason: strings.Repeat("x", 2000),
+			want:   "0.50 — " + strings.Repeat("x", 1016) + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alert := testAlert(88)
+			alert.JudgeScore = tt.score
+			alert.JudgeReason = tt.reason
+
+			fieldMap := make(map[string]string)
+			for _, f := range buildEmbed(&alert).Fields {
+				fieldMap[f.Name] = f.Value
+			}
+			if tt.want == "" {
+				assert.NotContains(t, fieldMap, "Judge")
+				return
+			}
+			assert.Equal(t, tt.want, fieldMap["Judge"])
+		})
+	}
+}
+
 func TestDiscordNotifier_SendBatchAlert(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ package notify
 
 import (
 	"context"
+	"time"
 
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
@@ -15,6 +16,16 @@ import (
 // a labeled row, no per-listing Price/Seller/Condition table. The
 // notifier infers summary mode from this field's presence — callers
 // don't need to flip a separate flag.
+//
+// EndsAt, when non-nil, switches the embed into the auction ending-soon
+// shape: Price carries the current bid and BidCount the number of bids.
+//
+// Risk is the listing's risk score, nil when it hasn't been computed;
+// RiskSignals names the warning signs behind it.
+//
+// JudgeScore is the LLM judge's 0.0-1.0 verdict on the alert, nil when
+// it was sent before one existed; JudgeReason is the judge's one-line
+// rationale.
 type AlertPayload struct {
 	WatchName     string
 	ListingTitle  string
@@ -29,6 +40,12 @@ type AlertPayload struct {
 	Condition     string
 	ComponentType string
 	SummaryFields []SummaryField
+	EndsAt        *time.Time
+	BidCount      int
+	Risk          *int
+	RiskSignals   []string
+	JudgeScore    *float64
+	JudgeReason   string
 }
 
 // SummaryField is one labeled count in a summary embed (e.g.,
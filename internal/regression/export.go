@@ -0,0 +1,88 @@
+package regression
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// FromCorrection converts an operator extraction correction into a
+// golden dataset item. Listings don't keep their item specifics, so
+// the item has none; the corrected attributes become the expected
+// attributes.
+func FromCorrection(c *domain.ExtractionCorrection) Item {
+	return Item{
+		Title:              c.Title,
+		ItemSpecifics:      map[string]string{},
+		ExpectedComponent:  c.ComponentType,
+		ExpectedProductKey: c.ProductKey,
+		ExpectedAttributes: c.Attributes,
+	}
+}
+
+// DatasetItem converts a golden item to a Langfuse dataset item keyed
+// by TitleHash, so uploads and regression-runner DatasetRun items
+// align. source is recorded in the item metadata.
+func DatasetItem(g *Item, source string) *langfuse.DatasetItem {
+	expected := map[string]any{
+		"component_type": string(g.ExpectedComponent),
+		"product_key":    g.ExpectedProductKey,
+	}
+	if len(g.ExpectedAttributes) > 0 {
+		expected["attributes"] = g.ExpectedAttributes
+	}
+	return &langfuse.DatasetItem{
+		ID: TitleHash(g.Title),
+		Input: map[string]any{
+			"title":          g.Title,
+			"item_specifics": g.ItemSpecifics,
+		},
+		ExpectedOutput: expected,
+		Metadata: map[string]string{
+			"source": source,
+		},
+	}
+}
+
+// MergeItems adds items to a dataset. An added item replaces an
+// existing item with the same title hash in place, so a correction
+// relabels a golden row instead of duplicating it; new items are
+// appended in order. Returns the merged dataset and how many items
+// were appended.
+func MergeItems(existing, added []Item) ([]Item, int) {
+	out := slices.Clone(existing)
+	index := make(map[string]int, len(out))
+	for i := range out {
+		index[TitleHash(out[i].Title)] = i
+	}
+
+	var appended int
+	for i := range added {
+		h := TitleHash(added[i].Title)
+		if j, ok := index[h]; ok {
+			out[j] = added[i]
+			continue
+		}
+		index[h] = len(out)
+		out = append(out, added[i])
+		appended++
+	}
+	return out, appended
+}
+
+// WriteDataset writes items to path in the indented JSON layout
+// LoadDataset reads.
+func WriteDataset(path string, items []Item) error {
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding dataset: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing dataset: %w", err)
+	}
+	return nil
+}
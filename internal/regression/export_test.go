@@ -0,0 +1,87 @@
+package regression_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/internal/regression"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestDatasetItem_PopulatesIDAndShape(t *testing.T) {
+	t.Parallel()
+
+	g := &regression.Item{
+		Title:              "Dell R740xd",
+		ItemSpecifics:      map[string]string{"Brand": "Dell"},
+		ExpectedComponent:  domain.ComponentServer,
+		ExpectedProductKey: "server:dell:r740xd:sff:configured",
+	}
+
+	item := regression.DatasetItem(g, "golden_classifications.json")
+	assert.Equal(t, regression.TitleHash("Dell R740xd"), item.ID)
+	assert.Equal(t, "Dell R740xd", item.Input["title"])
+	assert.Equal(t, "server", item.ExpectedOutput["component_type"])
+	assert.Equal(t, "server:dell:r740xd:sff:configured", item.ExpectedOutput["product_key"])
+	assert.NotContains(t, item.ExpectedOutput, "attributes")
+	assert.Equal(t, "golden_classifications.json", item.Metadata["source"])
+}
+
+func TestDatasetItem_FromCorrectionCarriesAttributes(t *testing.T) {
+	t.Parallel()
+
+	g := regression.FromCorrection(&domain.ExtractionCorrection{
+		Title:         "LSI 9300-8i HBA",
+		ComponentType: domain.ComponentHBA,
+		Attributes:    map[string]any{"manufacturer": "lsi", "model": "9300-8i", "mode": "IT"},
+		ProductKey:    "hba:lsi:9300-8i:it",
+	})
+	item := regression.DatasetItem(&g, "operator_correction")
+
+	assert.Equal(t, "hba", item.ExpectedOutput["component_type"])
+	assert.Equal(t, "hba:lsi:9300-8i:it", item.ExpectedOutput["product_key"])
+	assert.Equal(t, g.ExpectedAttributes, item.ExpectedOutput["attributes"])
+	assert.Equal(t, "operator_correction", item.Metadata["source"])
+	assert.NotNil(t, g.ItemSpecifics, "serialises as {} not null")
+}
+
+func TestMergeItems(t *testing.T) {
+	t.Parallel()
+
+	existing := []regression.Item{
+		{Title: "a", ExpectedComponent: domain.ComponentRAM},
+		{Title: "b", ExpectedComponent: domain.ComponentCPU},
+	}
+	added := []regression.Item{
+		{Title: "b", ExpectedComponent: domain.ComponentGPU},
+		{Title: "c", ExpectedComponent: domain.ComponentNIC},
+	}
+
+	merged, appended := regression.MergeItems(existing, added)
+	assert.Equal(t, 1, appended)
+	require.Len(t, merged, 3)
+	assert.Equal(t, domain.ComponentGPU, merged[1].ExpectedComponent, "replaced in place")
+	assert.Equal(t, "c", merged[2].Title)
+	assert.Equal(t, domain.ComponentCPU, existing[1].ExpectedComponent, "input untouched")
+}
+
+func TestWriteDataset_RoundTrips(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "golden.json")
+	items := []regression.Item{{
+		Title:              "32GB DDR4 RDIMM",
+		ItemSpecifics:      map[string]string{},
+		ExpectedComponent:  domain.ComponentRAM,
+		ExpectedProductKey: "ram:ddr4:ecc_reg:32gb:2666",
+		ExpectedAttributes: map[string]any{"capacity_gb": float64(32)},
+	}}
+
+	require.NoError(t, regression.WriteDataset(path, items))
+	got, err := regression.LoadDataset(path)
+	require.NoError(t, err)
+	assert.Equal(t, items, got)
+}
@@ -31,6 +31,10 @@ type Item struct {
 	ItemSpecifics      map[string]string    `json:"item_specifics"`
 	ExpectedComponent  domain.ComponentType `json:"expected_component"`
 	ExpectedProductKey string               `json:"expected_product_key,omitempty"`
+	// ExpectedAttributes is the full labelled extraction, present on
+	// items exported from operator corrections and on hand-labelled
+	// items of types with an extraction schema.
+	ExpectedAttributes map[string]any `json:"expected_attributes,omitempty"`
 }
 
 // TitleHash returns the deterministic dataset-item ID derived from a
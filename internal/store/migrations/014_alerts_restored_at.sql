@@ -0,0 +1,15 @@
+-- Migration 014: Add restored_at to alerts so operator restores are
+-- durable labels.
+--
+-- Restoring an alert today only clears dismissed_at, which makes a
+-- restored alert indistinguishable from one the operator never looked
+-- at. The offline weight calibration (tools/score-calibrate) needs the
+-- explicit "operator kept this" signal alongside dismissals, so
+-- RestoreAlerts now stamps restored_at. A later dismissal wins — the
+-- label query checks dismissed_at first.
+--
+-- Nullable, no backfill: historical restores are unrecoverable and
+-- simply fall through to the judge verdict (if any) when labelled.
+
+ALTER TABLE alerts
+    ADD COLUMN IF NOT EXISTS restored_at TIMESTAMPTZ NULL;
@@ -0,0 +1,23 @@
+-- 015_watch_polls.sql
+--
+-- Per-poll record for each watch, written by Engine.processWatch from
+-- the paginator result. Feeds GET /api/v1/watches/{id}/stats so an
+-- operator can judge whether a watch is worth its eBay quota: pages
+-- spent per poll against new listings found and alerts produced.
+--
+-- Rows cascade with the watch. error_text is NULL on a successful poll.
+CREATE TABLE IF NOT EXISTS watch_polls (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    watch_id      UUID NOT NULL REFERENCES watches(id) ON DELETE CASCADE,
+    polled_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    pages_used    INTEGER NOT NULL DEFAULT 0,
+    total_seen    INTEGER NOT NULL DEFAULT 0,
+    new_listings  INTEGER NOT NULL DEFAULT 0,
+    stopped_at    TEXT NOT NULL DEFAULT '',
+    error_text    TEXT NULL
+);
+
+-- Stats window scan: one watch, most recent polls first.
+CREATE INDEX IF NOT EXISTS idx_watch_polls_watch_polled_at
+    ON watch_polls (watch_id, polled_at DESC);
+
@@ -0,0 +1,45 @@
+-- Migration 018: Listing price history and price-drop re-alerts.
+--
+-- UpsertListing used to overwrite price in place, so a Buy It Now
+-- listing going from $400 to $310 over a week left no trace. The
+-- upsert now appends a listing_price_history row when a listing is
+-- first seen and whenever its price or shipping changes.
+--
+-- watches.realert_drop_pct lets a watch alert again on a listing
+-- inside alerts.re_alerts_cooldown when its price (with shipping) has
+-- dropped by at least that percentage since the last notified alert.
+-- 0 keeps the old behavior: the cooldown always applies.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS listing_price_history (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    listing_id        UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    price             NUMERIC(10,2) NOT NULL,
+    shipping_cost     NUMERIC(10,2),
+    currency          TEXT NOT NULL DEFAULT 'USD',
+    price_usd         NUMERIC(10,2),
+    shipping_cost_usd NUMERIC(10,2),
+    recorded_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- History reads: one listing, in time order.
+CREATE INDEX IF NOT EXISTS idx_listing_price_history_listing_recorded_at
+    ON listing_price_history (listing_id, recorded_at);
+
+-- Seed every existing listing with its current price so the first
+-- change after this migration has something to compare against.
+INSERT INTO listing_price_history (
+    listing_id, price, shipping_cost, currency, price_usd, shipping_cost_usd, recorded_at
+)
+SELECT id, price, shipping_cost, currency, price_usd, shipping_cost_usd, first_seen_at
+FROM listings l
+WHERE NOT EXISTS (
+    SELECT 1 FROM listing_price_history h WHERE h.listing_id = l.id
+);
+
+ALTER TABLE watches
+    ADD COLUMN IF NOT EXISTS realert_drop_pct NUMERIC(5,2) NOT NULL DEFAULT 0
+        CHECK (realert_drop_pct >= 0 AND realert_drop_pct < 100);
+
+COMMIT;
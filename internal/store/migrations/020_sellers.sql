@@ -0,0 +1,57 @@
+-- Migration 020: Seller intelligence.
+--
+-- Seller data was only ever flattened onto each listing, so there was
+-- no way to see a seller's feedback trend or how its listings fared.
+-- sellers keeps the latest feedback per seller name and
+-- seller_feedback_history appends a row when a seller is first seen and
+-- whenever its feedback score or percentage changes. Both are written
+-- by the listing upsert. Listing and alert counts are computed on read
+-- from listings and alerts, so they need no backfill beyond the seed.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sellers (
+    name           TEXT PRIMARY KEY,
+    feedback_score INTEGER NOT NULL DEFAULT 0,
+    feedback_pct   NUMERIC(5,2) NOT NULL DEFAULT 0,
+    top_rated      BOOLEAN NOT NULL DEFAULT false,
+    first_seen_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS seller_feedback_history (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    seller_name    TEXT NOT NULL REFERENCES sellers(name) ON DELETE CASCADE,
+    feedback_score INTEGER NOT NULL,
+    feedback_pct   NUMERIC(5,2) NOT NULL,
+    recorded_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_seller_feedback_history_seller_recorded_at
+    ON seller_feedback_history (seller_name, recorded_at);
+
+-- Seller pages and the alert detail panel list a seller's listings.
+CREATE INDEX IF NOT EXISTS idx_listings_seller_name ON listings (seller_name);
+
+-- Seed one seller per name from its most recently updated listing.
+INSERT INTO sellers (name, feedback_score, feedback_pct, top_rated, first_seen_at, last_seen_at)
+SELECT DISTINCT ON (seller_name)
+    seller_name,
+    COALESCE(seller_feedback_score, 0),
+    COALESCE(seller_feedback_pct, 0),
+    COALESCE(seller_top_rated, false),
+    MIN(first_seen_at) OVER (PARTITION BY seller_name),
+    updated_at
+FROM listings
+WHERE seller_name IS NOT NULL AND seller_name <> ''
+ORDER BY seller_name, updated_at DESC
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO seller_feedback_history (seller_name, feedback_score, feedback_pct, recorded_at)
+SELECT name, feedback_score, feedback_pct, last_seen_at
+FROM sellers s
+WHERE NOT EXISTS (
+    SELECT 1 FROM seller_feedback_history h WHERE h.seller_name = s.name
+);
+
+COMMIT;
@@ -0,0 +1,80 @@
+-- Migration 024: Price-per-unit value metrics.
+--
+-- Baselines compare a listing only against its exact product key, so
+-- nothing could answer "cheapest $/GB of DDR4 RDIMM whatever the stick
+-- size". Scoring now stores the listing's USD unit price per GB (RAM),
+-- TB (drives), core (CPUs) or GB of VRAM (GPUs) with its value class,
+-- the product key minus the segments that only change how many units
+-- there are (e.g. ram:ddr4:ecc_reg). value_baselines holds the price
+-- per unit percentiles of each class and feeds the optional value
+-- scoring factor. Existing listings get a value metric on their next
+-- rescore.
+
+BEGIN;
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS value_class TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS value_unit TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS price_per_unit NUMERIC(12,4);
+
+CREATE INDEX IF NOT EXISTS idx_listings_value_class_ppu
+    ON listings (value_class, price_per_unit)
+    WHERE price_per_unit IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS value_baselines (
+    value_class     TEXT PRIMARY KEY,
+    value_unit      TEXT NOT NULL,
+    sample_count    INTEGER NOT NULL,
+    p10             NUMERIC(12,4),
+    p25             NUMERIC(12,4),
+    p50             NUMERIC(12,4),
+    p75             NUMERIC(12,4),
+    p90             NUMERIC(12,4),
+    mean            NUMERIC(12,4),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Same sample rules as recompute_baseline: active listings updated in
+-- the window, no for-parts listings, each relist group counted once.
+CREATE OR REPLACE FUNCTION recompute_value_baselines(p_window_days INTEGER DEFAULT 90)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO value_baselines (value_class, value_unit, sample_count, p10, p25, p50, p75, p90, mean, updated_at)
+    SELECT
+        value_class,
+        min(value_unit),
+        count(*),
+        percentile_cont(0.10) WITHIN GROUP (ORDER BY price_per_unit),
+        percentile_cont(0.25) WITHIN GROUP (ORDER BY price_per_unit),
+        percentile_cont(0.50) WITHIN GROUP (ORDER BY price_per_unit),
+        percentile_cont(0.75) WITHIN GROUP (ORDER BY price_per_unit),
+        percentile_cont(0.90) WITHIN GROUP (ORDER BY price_per_unit),
+        avg(price_per_unit),
+        now()
+    FROM (
+        SELECT DISTINCT ON (COALESCE(listing_group_id, id))
+            value_class, value_unit, price_per_unit
+        FROM listings
+        WHERE value_class != ''
+          AND price_per_unit IS NOT NULL
+          AND active = true
+          AND updated_at >= now() - (p_window_days || ' days')::interval
+          AND condition_norm != 'for_parts'
+        ORDER BY COALESCE(listing_group_id, id), updated_at DESC
+    ) sub
+    GROUP BY value_class
+    HAVING count(*) >= 5
+    ON CONFLICT (value_class) DO UPDATE SET
+        value_unit = EXCLUDED.value_unit,
+        sample_count = EXCLUDED.sample_count,
+        p10 = EXCLUDED.p10,
+        p25 = EXCLUDED.p25,
+        p50 = EXCLUDED.p50,
+        p75 = EXCLUDED.p75,
+        p90 = EXCLUDED.p90,
+        mean = EXCLUDED.mean,
+        updated_at = now();
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
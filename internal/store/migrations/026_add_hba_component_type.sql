@@ -0,0 +1,21 @@
+-- Migration 026: Allow 'hba' as a component_type value on watches and
+-- listings.
+--
+-- RAID controllers and HBAs (PERC H730, LSI 9300-8i, IT-mode cards) get
+-- their own ComponentType in the Go domain layer instead of landing in
+-- 'other'. Extend both CHECK constraints so an hba watch can be created
+-- and an hba listing classified. Mirror migration 011 shape exactly.
+
+ALTER TABLE watches
+    DROP CONSTRAINT watches_component_type_check;
+
+ALTER TABLE watches
+    ADD CONSTRAINT watches_component_type_check
+    CHECK (component_type IN ('ram', 'drive', 'server', 'cpu', 'nic', 'gpu', 'hba', 'workstation', 'desktop', 'other'));
+
+ALTER TABLE listings
+    DROP CONSTRAINT listings_component_type_check;
+
+ALTER TABLE listings
+    ADD CONSTRAINT listings_component_type_check
+    CHECK (component_type IN ('ram', 'drive', 'server', 'cpu', 'nic', 'gpu', 'hba', 'workstation', 'desktop', 'other'));
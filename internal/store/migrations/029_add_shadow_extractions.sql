@@ -0,0 +1,43 @@
+-- Migration 029: Shadow extraction comparisons.
+--
+-- With llm.shadow enabled, a sampled fraction of live extractions runs
+-- a second time, asynchronously, through a candidate backend, model or
+-- prompt set. Each comparison is kept here: what both sides produced,
+-- the attributes they disagreed on, and tokens, cost and latency per
+-- side. GET /api/v1/extraction/shadow/report aggregates the rows so a
+-- cheaper model can be judged on real traffic before switching.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS shadow_extractions (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title                  TEXT NOT NULL,
+    primary_backend        TEXT NOT NULL,
+    primary_model          TEXT NOT NULL DEFAULT '',
+    shadow_backend         TEXT NOT NULL,
+    shadow_model           TEXT NOT NULL DEFAULT '',
+    prompt_version         TEXT NOT NULL DEFAULT '',
+    primary_component_type TEXT NOT NULL,
+    shadow_component_type  TEXT NOT NULL DEFAULT '',
+    primary_product_key    TEXT NOT NULL DEFAULT '',
+    shadow_product_key     TEXT NOT NULL DEFAULT '',
+    primary_attributes     JSONB NOT NULL DEFAULT '{}',
+    shadow_attributes      JSONB NOT NULL DEFAULT '{}',
+    -- Attribute-level disagreements: [{"field", "primary", "shadow"}].
+    -- Only diffed when both sides chose the same component type.
+    disagreements          JSONB NOT NULL DEFAULT '[]',
+    shadow_error           TEXT NOT NULL DEFAULT '',
+    primary_tokens         INTEGER NOT NULL DEFAULT 0,
+    shadow_tokens          INTEGER NOT NULL DEFAULT 0,
+    -- NULL when the model has no entry in observability.langfuse.model_costs.
+    primary_cost_usd       DOUBLE PRECISION,
+    shadow_cost_usd        DOUBLE PRECISION,
+    primary_latency_ms     INTEGER NOT NULL DEFAULT 0,
+    shadow_latency_ms      INTEGER NOT NULL DEFAULT 0,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_extractions_created
+    ON shadow_extractions (created_at DESC);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Migration 030: Judge-gated alert delivery.
+--
+-- With alerts.judge_gate enabled, an alert whose judge verdict falls
+-- below the configured floor is either suppressed outright or folded
+-- into a summary embed instead of getting its own notification. Either
+-- way a notification_attempts row records why, so the alert detail
+-- page shows the verdict that held it back.
+
+BEGIN;
+
+ALTER TABLE notification_attempts ADD COLUMN suppression_reason TEXT;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Migration 031: Price checks for alerted listings.
+--
+-- Ingestion only sees a stored listing's price again on the page where
+-- pagination stopped. Listings with an alert from a watch that sets
+-- realert_drop_pct are re-read with getItem instead; price_checked_at
+-- records the last check so the least recently checked go first.
+
+BEGIN;
+
+ALTER TABLE listings ADD COLUMN price_checked_at TIMESTAMPTZ;
+
+COMMIT;
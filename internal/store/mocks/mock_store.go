@@ -87,6 +87,111 @@ func (_c *MockStore_AcquireSchedulerLock_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ApplyWatches provides a mock function with given fields: ctx, set
+func (_m *MockStore) ApplyWatches(ctx context.Context, set *store.WatchApplySet) error {
+	ret := _m.Called(ctx, set)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyWatches")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *store.WatchApplySet) error); ok {
+		r0 = rf(ctx, set)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_ApplyWatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyWatches'
+type MockStore_ApplyWatches_Call struct {
+	*mock.Call
+}
+
+// ApplyWatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - set *store.WatchApplySet
+func (_e *MockStore_Expecter) ApplyWatches(ctx interface{}, set interface{}) *MockStore_ApplyWatches_Call {
+	return &MockStore_ApplyWatches_Call{Call: _e.mock.On("ApplyWatches", ctx, set)}
+}
+
+func (_c *MockStore_ApplyWatches_Call) Run(run func(ctx context.Context, set *store.WatchApplySet)) *MockStore_ApplyWatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*store.WatchApplySet))
+	})
+	return _c
+}
+
+func (_c *MockStore_ApplyWatches_Call) Return(_a0 error) *MockStore_ApplyWatches_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_ApplyWatches_Call) RunAndReturn(run func(context.Context, *store.WatchApplySet) error) *MockStore_ApplyWatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignListingGroup provides a mock function with given fields: ctx, listingID, priceTolerancePct
+func (_m *MockStore) AssignListingGroup(ctx context.Context, listingID string, priceTolerancePct float64) (string, error) {
+	ret := _m.Called(ctx, listingID, priceTolerancePct)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignListingGroup")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) (string, error)); ok {
+		return rf(ctx, listingID, priceTolerancePct)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) string); ok {
+		r0 = rf(ctx, listingID, priceTolerancePct)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64) error); ok {
+		r1 = rf(ctx, listingID, priceTolerancePct)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_AssignListingGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignListingGroup'
+type MockStore_AssignListingGroup_Call struct {
+	*mock.Call
+}
+
+// AssignListingGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listingID string
+//   - priceTolerancePct float64
+func (_e *MockStore_Expecter) AssignListingGroup(ctx interface{}, listingID interface{}, priceTolerancePct interface{}) *MockStore_AssignListingGroup_Call {
+	return &MockStore_AssignListingGroup_Call{Call: _e.mock.On("AssignListingGroup", ctx, listingID, priceTolerancePct)}
+}
+
+func (_c *MockStore_AssignListingGroup_Call) Run(run func(ctx context.Context, listingID string, priceTolerancePct float64)) *MockStore_AssignListingGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MockStore_AssignListingGroup_Call) Return(_a0 string, _a1 error) *MockStore_AssignListingGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_AssignListingGroup_Call) RunAndReturn(run func(context.Context, string, float64) (string, error)) *MockStore_AssignListingGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CompleteExtractionJob provides a mock function with given fields: ctx, id, errText
 func (_m *MockStore) CompleteExtractionJob(ctx context.Context, id string, errText string) error {
 	ret := _m.Called(ctx, id, errText)
@@ -185,6 +290,53 @@ func (_c *MockStore_CompleteJobRun_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CorrectListingExtraction provides a mock function with given fields: ctx, c
+func (_m *MockStore) CorrectListingExtraction(ctx context.Context, c *domain.ExtractionCorrection) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CorrectListingExtraction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ExtractionCorrection) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_CorrectListingExtraction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CorrectListingExtraction'
+type MockStore_CorrectListingExtraction_Call struct {
+	*mock.Call
+}
+
+// CorrectListingExtraction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c *domain.ExtractionCorrection
+func (_e *MockStore_Expecter) CorrectListingExtraction(ctx interface{}, c interface{}) *MockStore_CorrectListingExtraction_Call {
+	return &MockStore_CorrectListingExtraction_Call{Call: _e.mock.On("CorrectListingExtraction", ctx, c)}
+}
+
+func (_c *MockStore_CorrectListingExtraction_Call) Run(run func(ctx context.Context, c *domain.ExtractionCorrection)) *MockStore_CorrectListingExtraction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ExtractionCorrection))
+	})
+	return _c
+}
+
+func (_c *MockStore_CorrectListingExtraction_Call) Return(_a0 error) *MockStore_CorrectListingExtraction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_CorrectListingExtraction_Call) RunAndReturn(run func(context.Context, *domain.ExtractionCorrection) error) *MockStore_CorrectListingExtraction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountPendingExtractionJobs provides a mock function with given fields: ctx
 func (_m *MockStore) CountPendingExtractionJobs(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -615,6 +767,66 @@ func (_c *MockStore_GetAlertDetail_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetAlertedPrice provides a mock function with given fields: ctx, watchID, listingID
+func (_m *MockStore) GetAlertedPrice(ctx context.Context, watchID string, listingID string) (*domain.PricePoint, error) {
+	ret := _m.Called(ctx, watchID, listingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlertedPrice")
+	}
+
+	var r0 *domain.PricePoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.PricePoint, error)); ok {
+		return rf(ctx, watchID, listingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.PricePoint); ok {
+		r0 = rf(ctx, watchID, listingID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PricePoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, watchID, listingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetAlertedPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlertedPrice'
+type MockStore_GetAlertedPrice_Call struct {
+	*mock.Call
+}
+
+// GetAlertedPrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - watchID string
+//   - listingID string
+func (_e *MockStore_Expecter) GetAlertedPrice(ctx interface{}, watchID interface{}, listingID interface{}) *MockStore_GetAlertedPrice_Call {
+	return &MockStore_GetAlertedPrice_Call{Call: _e.mock.On("GetAlertedPrice", ctx, watchID, listingID)}
+}
+
+func (_c *MockStore_GetAlertedPrice_Call) Run(run func(ctx context.Context, watchID string, listingID string)) *MockStore_GetAlertedPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetAlertedPrice_Call) Return(_a0 *domain.PricePoint, _a1 error) *MockStore_GetAlertedPrice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetAlertedPrice_Call) RunAndReturn(run func(context.Context, string, string) (*domain.PricePoint, error)) *MockStore_GetAlertedPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBaseline provides a mock function with given fields: ctx, productKey
 func (_m *MockStore) GetBaseline(ctx context.Context, productKey string) (*domain.PriceBaseline, error) {
 	ret := _m.Called(ctx, productKey)
@@ -674,6 +886,65 @@ func (_c *MockStore_GetBaseline_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// GetJudgeBacklog provides a mock function with given fields: ctx, lookback
+func (_m *MockStore) GetJudgeBacklog(ctx context.Context, lookback time.Duration) (*domain.JudgeBacklog, error) {
+	ret := _m.Called(ctx, lookback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJudgeBacklog")
+	}
+
+	var r0 *domain.JudgeBacklog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (*domain.JudgeBacklog, error)); ok {
+		return rf(ctx, lookback)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) *domain.JudgeBacklog); ok {
+		r0 = rf(ctx, lookback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.JudgeBacklog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, lookback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetJudgeBacklog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJudgeBacklog'
+type MockStore_GetJudgeBacklog_Call struct {
+	*mock.Call
+}
+
+// GetJudgeBacklog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lookback time.Duration
+func (_e *MockStore_Expecter) GetJudgeBacklog(ctx interface{}, lookback interface{}) *MockStore_GetJudgeBacklog_Call {
+	return &MockStore_GetJudgeBacklog_Call{Call: _e.mock.On("GetJudgeBacklog", ctx, lookback)}
+}
+
+func (_c *MockStore_GetJudgeBacklog_Call) Run(run func(ctx context.Context, lookback time.Duration)) *MockStore_GetJudgeBacklog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetJudgeBacklog_Call) Return(_a0 *domain.JudgeBacklog, _a1 error) *MockStore_GetJudgeBacklog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetJudgeBacklog_Call) RunAndReturn(run func(context.Context, time.Duration) (*domain.JudgeBacklog, error)) *MockStore_GetJudgeBacklog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetJudgeScore provides a mock function with given fields: ctx, alertID
 func (_m *MockStore) GetJudgeScore(ctx context.Context, alertID string) (*domain.JudgeScore, error) {
 	ret := _m.Called(ctx, alertID)
@@ -733,6 +1004,65 @@ func (_c *MockStore_GetJudgeScore_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// GetJudgeScores provides a mock function with given fields: ctx, alertIDs
+func (_m *MockStore) GetJudgeScores(ctx context.Context, alertIDs []string) (map[string]*domain.JudgeScore, error) {
+	ret := _m.Called(ctx, alertIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJudgeScores")
+	}
+
+	var r0 map[string]*domain.JudgeScore
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]*domain.JudgeScore, error)); ok {
+		return rf(ctx, alertIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]*domain.JudgeScore); ok {
+		r0 = rf(ctx, alertIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*domain.JudgeScore)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, alertIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_GetJudgeScores_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJudgeScores'
+type MockStore_GetJudgeScores_Call struct {
+	*mock.Call
+}
+
+// GetJudgeScores is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alertIDs []string
+func (_e *MockStore_Expecter) GetJudgeScores(ctx interface{}, alertIDs interface{}) *MockStore_GetJudgeScores_Call {
+	return &MockStore_GetJudgeScores_Call{Call: _e.mock.On("GetJudgeScores", ctx, alertIDs)}
+}
+
+func (_c *MockStore_GetJudgeScores_Call) Run(run func(ctx context.Context, alertIDs []string)) *MockStore_GetJudgeScores_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockStore_GetJudgeScores_Call) Return(_a0 map[string]*domain.JudgeScore, _a1 error) *MockStore_GetJudgeScores_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_GetJudgeScores_Call) RunAndReturn(run func(context.Context, []string) (map[string]*domain.JudgeScore, error)) *MockStore_GetJudgeScores_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetListing provides a mock function with given fields: ctx, ebayID
 func (_m *MockStore) GetListing(ctx context.Context, ebayID string) (*domain.Listing, error) {
 	ret := _m.Called(ctx, ebayID)
@@ -851,29 +1181,29 @@ func (_c *MockStore_GetListingByID_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetSystemState provides a mock function with given fields: ctx
-func (_m *MockStore) GetSystemState(ctx context.Context) (*domain.SystemState, error) {
-	ret := _m.Called(ctx)
+// GetSeller provides a mock function with given fields: ctx, name
+func (_m *MockStore) GetSeller(ctx context.Context, name string) (*domain.Seller, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSystemState")
+		panic("no return value specified for GetSeller")
 	}
 
-	var r0 *domain.SystemState
+	var r0 *domain.Seller
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*domain.SystemState, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Seller, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *domain.SystemState); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Seller); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.SystemState)
+			r0 = ret.Get(0).(*domain.Seller)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -881,57 +1211,58 @@ func (_m *MockStore) GetSystemState(ctx context.Context) (*domain.SystemState, e
 	return r0, r1
 }
 
-// MockStore_GetSystemState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSystemState'
-type MockStore_GetSystemState_Call struct {
+// MockStore_GetSeller_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSeller'
+type MockStore_GetSeller_Call struct {
 	*mock.Call
 }
 
-// GetSystemState is a helper method to define mock.On call
+// GetSeller is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) GetSystemState(ctx interface{}) *MockStore_GetSystemState_Call {
-	return &MockStore_GetSystemState_Call{Call: _e.mock.On("GetSystemState", ctx)}
+//   - name string
+func (_e *MockStore_Expecter) GetSeller(ctx interface{}, name interface{}) *MockStore_GetSeller_Call {
+	return &MockStore_GetSeller_Call{Call: _e.mock.On("GetSeller", ctx, name)}
 }
 
-func (_c *MockStore_GetSystemState_Call) Run(run func(ctx context.Context)) *MockStore_GetSystemState_Call {
+func (_c *MockStore_GetSeller_Call) Run(run func(ctx context.Context, name string)) *MockStore_GetSeller_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetSystemState_Call) Return(_a0 *domain.SystemState, _a1 error) *MockStore_GetSystemState_Call {
+func (_c *MockStore_GetSeller_Call) Return(_a0 *domain.Seller, _a1 error) *MockStore_GetSeller_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetSystemState_Call) RunAndReturn(run func(context.Context) (*domain.SystemState, error)) *MockStore_GetSystemState_Call {
+func (_c *MockStore_GetSeller_Call) RunAndReturn(run func(context.Context, string) (*domain.Seller, error)) *MockStore_GetSeller_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWatch provides a mock function with given fields: ctx, id
-func (_m *MockStore) GetWatch(ctx context.Context, id string) (*domain.Watch, error) {
-	ret := _m.Called(ctx, id)
+// GetShadowReport provides a mock function with given fields: ctx, q
+func (_m *MockStore) GetShadowReport(ctx context.Context, q *store.ShadowReportQuery) (*domain.ShadowReport, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWatch")
+		panic("no return value specified for GetShadowReport")
 	}
 
-	var r0 *domain.Watch
+	var r0 *domain.ShadowReport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Watch, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ShadowReportQuery) (*domain.ShadowReport, error)); ok {
+		return rf(ctx, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Watch); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ShadowReportQuery) *domain.ShadowReport); ok {
+		r0 = rf(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Watch)
+			r0 = ret.Get(0).(*domain.ShadowReport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, *store.ShadowReportQuery) error); ok {
+		r1 = rf(ctx, q)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -939,56 +1270,58 @@ func (_m *MockStore) GetWatch(ctx context.Context, id string) (*domain.Watch, er
 	return r0, r1
 }
 
-// MockStore_GetWatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWatch'
-type MockStore_GetWatch_Call struct {
+// MockStore_GetShadowReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShadowReport'
+type MockStore_GetShadowReport_Call struct {
 	*mock.Call
 }
 
-// GetWatch is a helper method to define mock.On call
+// GetShadowReport is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockStore_Expecter) GetWatch(ctx interface{}, id interface{}) *MockStore_GetWatch_Call {
-	return &MockStore_GetWatch_Call{Call: _e.mock.On("GetWatch", ctx, id)}
+//   - q *store.ShadowReportQuery
+func (_e *MockStore_Expecter) GetShadowReport(ctx interface{}, q interface{}) *MockStore_GetShadowReport_Call {
+	return &MockStore_GetShadowReport_Call{Call: _e.mock.On("GetShadowReport", ctx, q)}
 }
 
-func (_c *MockStore_GetWatch_Call) Run(run func(ctx context.Context, id string)) *MockStore_GetWatch_Call {
+func (_c *MockStore_GetShadowReport_Call) Run(run func(ctx context.Context, q *store.ShadowReportQuery)) *MockStore_GetShadowReport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(*store.ShadowReportQuery))
 	})
 	return _c
 }
 
-func (_c *MockStore_GetWatch_Call) Return(_a0 *domain.Watch, _a1 error) *MockStore_GetWatch_Call {
+func (_c *MockStore_GetShadowReport_Call) Return(_a0 *domain.ShadowReport, _a1 error) *MockStore_GetShadowReport_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_GetWatch_Call) RunAndReturn(run func(context.Context, string) (*domain.Watch, error)) *MockStore_GetWatch_Call {
+func (_c *MockStore_GetShadowReport_Call) RunAndReturn(run func(context.Context, *store.ShadowReportQuery) (*domain.ShadowReport, error)) *MockStore_GetShadowReport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasRecentAlert provides a mock function with given fields: ctx, watchID, listingID, cooldown
-func (_m *MockStore) HasRecentAlert(ctx context.Context, watchID string, listingID string, cooldown time.Duration) (bool, error) {
-	ret := _m.Called(ctx, watchID, listingID, cooldown)
+// GetSystemState provides a mock function with given fields: ctx
+func (_m *MockStore) GetSystemState(ctx context.Context) (*domain.SystemState, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasRecentAlert")
+		panic("no return value specified for GetSystemState")
 	}
 
-	var r0 bool
+	var r0 *domain.SystemState
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (bool, error)); ok {
-		return rf(ctx, watchID, listingID, cooldown)
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.SystemState, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
-		r0 = rf(ctx, watchID, listingID, cooldown)
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.SystemState); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SystemState)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
-		r1 = rf(ctx, watchID, listingID, cooldown)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -996,58 +1329,57 @@ func (_m *MockStore) HasRecentAlert(ctx context.Context, watchID string, listing
 	return r0, r1
 }
 
-// MockStore_HasRecentAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasRecentAlert'
-type MockStore_HasRecentAlert_Call struct {
+// MockStore_GetSystemState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSystemState'
+type MockStore_GetSystemState_Call struct {
 	*mock.Call
 }
 
-// HasRecentAlert is a helper method to define mock.On call
+// GetSystemState is a helper method to define mock.On call
 //   - ctx context.Context
-//   - watchID string
-//   - listingID string
-//   - cooldown time.Duration
-func (_e *MockStore_Expecter) HasRecentAlert(ctx interface{}, watchID interface{}, listingID interface{}, cooldown interface{}) *MockStore_HasRecentAlert_Call {
-	return &MockStore_HasRecentAlert_Call{Call: _e.mock.On("HasRecentAlert", ctx, watchID, listingID, cooldown)}
+func (_e *MockStore_Expecter) GetSystemState(ctx interface{}) *MockStore_GetSystemState_Call {
+	return &MockStore_GetSystemState_Call{Call: _e.mock.On("GetSystemState", ctx)}
 }
 
-func (_c *MockStore_HasRecentAlert_Call) Run(run func(ctx context.Context, watchID string, listingID string, cooldown time.Duration)) *MockStore_HasRecentAlert_Call {
+func (_c *MockStore_GetSystemState_Call) Run(run func(ctx context.Context)) *MockStore_GetSystemState_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_HasRecentAlert_Call) Return(_a0 bool, _a1 error) *MockStore_HasRecentAlert_Call {
+func (_c *MockStore_GetSystemState_Call) Return(_a0 *domain.SystemState, _a1 error) *MockStore_GetSystemState_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_HasRecentAlert_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (bool, error)) *MockStore_HasRecentAlert_Call {
+func (_c *MockStore_GetSystemState_Call) RunAndReturn(run func(context.Context) (*domain.SystemState, error)) *MockStore_GetSystemState_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasSuccessfulNotification provides a mock function with given fields: ctx, alertID
-func (_m *MockStore) HasSuccessfulNotification(ctx context.Context, alertID string) (bool, error) {
-	ret := _m.Called(ctx, alertID)
+// GetValueBaseline provides a mock function with given fields: ctx, valueClass
+func (_m *MockStore) GetValueBaseline(ctx context.Context, valueClass string) (*domain.ValueBaseline, error) {
+	ret := _m.Called(ctx, valueClass)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasSuccessfulNotification")
+		panic("no return value specified for GetValueBaseline")
 	}
 
-	var r0 bool
+	var r0 *domain.ValueBaseline
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return rf(ctx, alertID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ValueBaseline, error)); ok {
+		return rf(ctx, valueClass)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, alertID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ValueBaseline); ok {
+		r0 = rf(ctx, valueClass)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ValueBaseline)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, alertID)
+		r1 = rf(ctx, valueClass)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1055,56 +1387,58 @@ func (_m *MockStore) HasSuccessfulNotification(ctx context.Context, alertID stri
 	return r0, r1
 }
 
-// MockStore_HasSuccessfulNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasSuccessfulNotification'
-type MockStore_HasSuccessfulNotification_Call struct {
+// MockStore_GetValueBaseline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetValueBaseline'
+type MockStore_GetValueBaseline_Call struct {
 	*mock.Call
 }
 
-// HasSuccessfulNotification is a helper method to define mock.On call
+// GetValueBaseline is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alertID string
-func (_e *MockStore_Expecter) HasSuccessfulNotification(ctx interface{}, alertID interface{}) *MockStore_HasSuccessfulNotification_Call {
-	return &MockStore_HasSuccessfulNotification_Call{Call: _e.mock.On("HasSuccessfulNotification", ctx, alertID)}
+//   - valueClass string
+func (_e *MockStore_Expecter) GetValueBaseline(ctx interface{}, valueClass interface{}) *MockStore_GetValueBaseline_Call {
+	return &MockStore_GetValueBaseline_Call{Call: _e.mock.On("GetValueBaseline", ctx, valueClass)}
 }
 
-func (_c *MockStore_HasSuccessfulNotification_Call) Run(run func(ctx context.Context, alertID string)) *MockStore_HasSuccessfulNotification_Call {
+func (_c *MockStore_GetValueBaseline_Call) Run(run func(ctx context.Context, valueClass string)) *MockStore_GetValueBaseline_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_HasSuccessfulNotification_Call) Return(_a0 bool, _a1 error) *MockStore_HasSuccessfulNotification_Call {
+func (_c *MockStore_GetValueBaseline_Call) Return(_a0 *domain.ValueBaseline, _a1 error) *MockStore_GetValueBaseline_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_HasSuccessfulNotification_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockStore_HasSuccessfulNotification_Call {
+func (_c *MockStore_GetValueBaseline_Call) RunAndReturn(run func(context.Context, string) (*domain.ValueBaseline, error)) *MockStore_GetValueBaseline_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertJobRun provides a mock function with given fields: ctx, jobName
-func (_m *MockStore) InsertJobRun(ctx context.Context, jobName string) (string, error) {
-	ret := _m.Called(ctx, jobName)
+// GetWatch provides a mock function with given fields: ctx, id
+func (_m *MockStore) GetWatch(ctx context.Context, id string) (*domain.Watch, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertJobRun")
+		panic("no return value specified for GetWatch")
 	}
 
-	var r0 string
+	var r0 *domain.Watch
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, jobName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Watch, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, jobName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Watch); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Watch)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, jobName)
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1112,155 +1446,174 @@ func (_m *MockStore) InsertJobRun(ctx context.Context, jobName string) (string,
 	return r0, r1
 }
 
-// MockStore_InsertJobRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertJobRun'
-type MockStore_InsertJobRun_Call struct {
+// MockStore_GetWatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWatch'
+type MockStore_GetWatch_Call struct {
 	*mock.Call
 }
 
-// InsertJobRun is a helper method to define mock.On call
+// GetWatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - jobName string
-func (_e *MockStore_Expecter) InsertJobRun(ctx interface{}, jobName interface{}) *MockStore_InsertJobRun_Call {
-	return &MockStore_InsertJobRun_Call{Call: _e.mock.On("InsertJobRun", ctx, jobName)}
+//   - id string
+func (_e *MockStore_Expecter) GetWatch(ctx interface{}, id interface{}) *MockStore_GetWatch_Call {
+	return &MockStore_GetWatch_Call{Call: _e.mock.On("GetWatch", ctx, id)}
 }
 
-func (_c *MockStore_InsertJobRun_Call) Run(run func(ctx context.Context, jobName string)) *MockStore_InsertJobRun_Call {
+func (_c *MockStore_GetWatch_Call) Run(run func(ctx context.Context, id string)) *MockStore_GetWatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_InsertJobRun_Call) Return(id string, err error) *MockStore_InsertJobRun_Call {
-	_c.Call.Return(id, err)
+func (_c *MockStore_GetWatch_Call) Return(_a0 *domain.Watch, _a1 error) *MockStore_GetWatch_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_InsertJobRun_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStore_InsertJobRun_Call {
+func (_c *MockStore_GetWatch_Call) RunAndReturn(run func(context.Context, string) (*domain.Watch, error)) *MockStore_GetWatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertJudgeScore provides a mock function with given fields: ctx, s
-func (_m *MockStore) InsertJudgeScore(ctx context.Context, s *domain.JudgeScore) error {
-	ret := _m.Called(ctx, s)
+// GetWatchStats provides a mock function with given fields: ctx, watchID, since
+func (_m *MockStore) GetWatchStats(ctx context.Context, watchID string, since time.Time) (*domain.WatchStats, error) {
+	ret := _m.Called(ctx, watchID, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertJudgeScore")
+		panic("no return value specified for GetWatchStats")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.JudgeScore) error); ok {
-		r0 = rf(ctx, s)
+	var r0 *domain.WatchStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domain.WatchStats, error)); ok {
+		return rf(ctx, watchID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *domain.WatchStats); ok {
+		r0 = rf(ctx, watchID, since)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WatchStats)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, watchID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStore_InsertJudgeScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertJudgeScore'
-type MockStore_InsertJudgeScore_Call struct {
+// MockStore_GetWatchStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWatchStats'
+type MockStore_GetWatchStats_Call struct {
 	*mock.Call
 }
 
-// InsertJudgeScore is a helper method to define mock.On call
+// GetWatchStats is a helper method to define mock.On call
 //   - ctx context.Context
-//   - s *domain.JudgeScore
-func (_e *MockStore_Expecter) InsertJudgeScore(ctx interface{}, s interface{}) *MockStore_InsertJudgeScore_Call {
-	return &MockStore_InsertJudgeScore_Call{Call: _e.mock.On("InsertJudgeScore", ctx, s)}
+//   - watchID string
+//   - since time.Time
+func (_e *MockStore_Expecter) GetWatchStats(ctx interface{}, watchID interface{}, since interface{}) *MockStore_GetWatchStats_Call {
+	return &MockStore_GetWatchStats_Call{Call: _e.mock.On("GetWatchStats", ctx, watchID, since)}
 }
 
-func (_c *MockStore_InsertJudgeScore_Call) Run(run func(ctx context.Context, s *domain.JudgeScore)) *MockStore_InsertJudgeScore_Call {
+func (_c *MockStore_GetWatchStats_Call) Run(run func(ctx context.Context, watchID string, since time.Time)) *MockStore_GetWatchStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.JudgeScore))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_InsertJudgeScore_Call) Return(_a0 error) *MockStore_InsertJudgeScore_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStore_GetWatchStats_Call) Return(_a0 *domain.WatchStats, _a1 error) *MockStore_GetWatchStats_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_InsertJudgeScore_Call) RunAndReturn(run func(context.Context, *domain.JudgeScore) error) *MockStore_InsertJudgeScore_Call {
+func (_c *MockStore_GetWatchStats_Call) RunAndReturn(run func(context.Context, string, time.Time) (*domain.WatchStats, error)) *MockStore_GetWatchStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertNotificationAttempt provides a mock function with given fields: ctx, alertID, succeeded, httpStatus, errText
-func (_m *MockStore) InsertNotificationAttempt(ctx context.Context, alertID string, succeeded bool, httpStatus int, errText string) error {
-	ret := _m.Called(ctx, alertID, succeeded, httpStatus, errText)
+// HasAuctionReminder provides a mock function with given fields: ctx, watchID, listingID
+func (_m *MockStore) HasAuctionReminder(ctx context.Context, watchID string, listingID string) (bool, error) {
+	ret := _m.Called(ctx, watchID, listingID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertNotificationAttempt")
+		panic("no return value specified for HasAuctionReminder")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int, string) error); ok {
-		r0 = rf(ctx, alertID, succeeded, httpStatus, errText)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, watchID, listingID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, watchID, listingID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, watchID, listingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStore_InsertNotificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertNotificationAttempt'
-type MockStore_InsertNotificationAttempt_Call struct {
+// MockStore_HasAuctionReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasAuctionReminder'
+type MockStore_HasAuctionReminder_Call struct {
 	*mock.Call
 }
 
-// InsertNotificationAttempt is a helper method to define mock.On call
+// HasAuctionReminder is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alertID string
-//   - succeeded bool
-//   - httpStatus int
-//   - errText string
-func (_e *MockStore_Expecter) InsertNotificationAttempt(ctx interface{}, alertID interface{}, succeeded interface{}, httpStatus interface{}, errText interface{}) *MockStore_InsertNotificationAttempt_Call {
-	return &MockStore_InsertNotificationAttempt_Call{Call: _e.mock.On("InsertNotificationAttempt", ctx, alertID, succeeded, httpStatus, errText)}
+//   - watchID string
+//   - listingID string
+func (_e *MockStore_Expecter) HasAuctionReminder(ctx interface{}, watchID interface{}, listingID interface{}) *MockStore_HasAuctionReminder_Call {
+	return &MockStore_HasAuctionReminder_Call{Call: _e.mock.On("HasAuctionReminder", ctx, watchID, listingID)}
 }
 
-func (_c *MockStore_InsertNotificationAttempt_Call) Run(run func(ctx context.Context, alertID string, succeeded bool, httpStatus int, errText string)) *MockStore_InsertNotificationAttempt_Call {
+func (_c *MockStore_HasAuctionReminder_Call) Run(run func(ctx context.Context, watchID string, listingID string)) *MockStore_HasAuctionReminder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(int), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_InsertNotificationAttempt_Call) Return(_a0 error) *MockStore_InsertNotificationAttempt_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStore_HasAuctionReminder_Call) Return(_a0 bool, _a1 error) *MockStore_HasAuctionReminder_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_InsertNotificationAttempt_Call) RunAndReturn(run func(context.Context, string, bool, int, string) error) *MockStore_InsertNotificationAttempt_Call {
+func (_c *MockStore_HasAuctionReminder_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockStore_HasAuctionReminder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAlertsByWatch provides a mock function with given fields: ctx, watchID, limit
-func (_m *MockStore) ListAlertsByWatch(ctx context.Context, watchID string, limit int) ([]domain.Alert, error) {
-	ret := _m.Called(ctx, watchID, limit)
+// HasGroupAlert provides a mock function with given fields: ctx, watchID, listingID
+func (_m *MockStore) HasGroupAlert(ctx context.Context, watchID string, listingID string) (bool, error) {
+	ret := _m.Called(ctx, watchID, listingID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAlertsByWatch")
+		panic("no return value specified for HasGroupAlert")
 	}
 
-	var r0 []domain.Alert
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Alert, error)); ok {
-		return rf(ctx, watchID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, watchID, listingID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Alert); ok {
-		r0 = rf(ctx, watchID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, watchID, listingID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Alert)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, watchID, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, watchID, listingID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1268,59 +1621,57 @@ func (_m *MockStore) ListAlertsByWatch(ctx context.Context, watchID string, limi
 	return r0, r1
 }
 
-// MockStore_ListAlertsByWatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsByWatch'
-type MockStore_ListAlertsByWatch_Call struct {
+// MockStore_HasGroupAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasGroupAlert'
+type MockStore_HasGroupAlert_Call struct {
 	*mock.Call
 }
 
-// ListAlertsByWatch is a helper method to define mock.On call
+// HasGroupAlert is a helper method to define mock.On call
 //   - ctx context.Context
 //   - watchID string
-//   - limit int
-func (_e *MockStore_Expecter) ListAlertsByWatch(ctx interface{}, watchID interface{}, limit interface{}) *MockStore_ListAlertsByWatch_Call {
-	return &MockStore_ListAlertsByWatch_Call{Call: _e.mock.On("ListAlertsByWatch", ctx, watchID, limit)}
+//   - listingID string
+func (_e *MockStore_Expecter) HasGroupAlert(ctx interface{}, watchID interface{}, listingID interface{}) *MockStore_HasGroupAlert_Call {
+	return &MockStore_HasGroupAlert_Call{Call: _e.mock.On("HasGroupAlert", ctx, watchID, listingID)}
 }
 
-func (_c *MockStore_ListAlertsByWatch_Call) Run(run func(ctx context.Context, watchID string, limit int)) *MockStore_ListAlertsByWatch_Call {
+func (_c *MockStore_HasGroupAlert_Call) Run(run func(ctx context.Context, watchID string, listingID string)) *MockStore_HasGroupAlert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListAlertsByWatch_Call) Return(_a0 []domain.Alert, _a1 error) *MockStore_ListAlertsByWatch_Call {
+func (_c *MockStore_HasGroupAlert_Call) Return(_a0 bool, _a1 error) *MockStore_HasGroupAlert_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListAlertsByWatch_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Alert, error)) *MockStore_ListAlertsByWatch_Call {
+func (_c *MockStore_HasGroupAlert_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockStore_HasGroupAlert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAlertsForJudging provides a mock function with given fields: ctx, q
-func (_m *MockStore) ListAlertsForJudging(ctx context.Context, q *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error) {
-	ret := _m.Called(ctx, q)
+// HasRecentAlert provides a mock function with given fields: ctx, watchID, listingID, cooldown
+func (_m *MockStore) HasRecentAlert(ctx context.Context, watchID string, listingID string, cooldown time.Duration) (bool, error) {
+	ret := _m.Called(ctx, watchID, listingID, cooldown)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAlertsForJudging")
+		panic("no return value specified for HasRecentAlert")
 	}
 
-	var r0 []domain.JudgeCandidate
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error)); ok {
-		return rf(ctx, q)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, watchID, listingID, cooldown)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *store.JudgeCandidatesQuery) []domain.JudgeCandidate); ok {
-		r0 = rf(ctx, q)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, watchID, listingID, cooldown)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.JudgeCandidate)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *store.JudgeCandidatesQuery) error); ok {
-		r1 = rf(ctx, q)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, watchID, listingID, cooldown)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1328,56 +1679,1071 @@ func (_m *MockStore) ListAlertsForJudging(ctx context.Context, q *store.JudgeCan
 	return r0, r1
 }
 
-// MockStore_ListAlertsForJudging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsForJudging'
-type MockStore_ListAlertsForJudging_Call struct {
+// MockStore_HasRecentAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasRecentAlert'
+type MockStore_HasRecentAlert_Call struct {
 	*mock.Call
 }
 
-// ListAlertsForJudging is a helper method to define mock.On call
+// HasRecentAlert is a helper method to define mock.On call
 //   - ctx context.Context
-//   - q *store.JudgeCandidatesQuery
-func (_e *MockStore_Expecter) ListAlertsForJudging(ctx interface{}, q interface{}) *MockStore_ListAlertsForJudging_Call {
-	return &MockStore_ListAlertsForJudging_Call{Call: _e.mock.On("ListAlertsForJudging", ctx, q)}
-}
+//   - watchID string
+//   - listingID string
+//   - cooldown time.Duration
+func (_e *MockStore_Expecter) HasRecentAlert(ctx interface{}, watchID interface{}, listingID interface{}, cooldown interface{}) *MockStore_HasRecentAlert_Call {
+	return &MockStore_HasRecentAlert_Call{Call: _e.mock.On("HasRecentAlert", ctx, watchID, listingID, cooldown)}
+}
+
+func (_c *MockStore_HasRecentAlert_Call) Run(run func(ctx context.Context, watchID string, listingID string, cooldown time.Duration)) *MockStore_HasRecentAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_HasRecentAlert_Call) Return(_a0 bool, _a1 error) *MockStore_HasRecentAlert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_HasRecentAlert_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (bool, error)) *MockStore_HasRecentAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasSuccessfulNotification provides a mock function with given fields: ctx, alertID
+func (_m *MockStore) HasSuccessfulNotification(ctx context.Context, alertID string) (bool, error) {
+	ret := _m.Called(ctx, alertID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasSuccessfulNotification")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, alertID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, alertID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, alertID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_HasSuccessfulNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasSuccessfulNotification'
+type MockStore_HasSuccessfulNotification_Call struct {
+	*mock.Call
+}
+
+// HasSuccessfulNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alertID string
+func (_e *MockStore_Expecter) HasSuccessfulNotification(ctx interface{}, alertID interface{}) *MockStore_HasSuccessfulNotification_Call {
+	return &MockStore_HasSuccessfulNotification_Call{Call: _e.mock.On("HasSuccessfulNotification", ctx, alertID)}
+}
+
+func (_c *MockStore_HasSuccessfulNotification_Call) Run(run func(ctx context.Context, alertID string)) *MockStore_HasSuccessfulNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_HasSuccessfulNotification_Call) Return(_a0 bool, _a1 error) *MockStore_HasSuccessfulNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_HasSuccessfulNotification_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockStore_HasSuccessfulNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertJobRun provides a mock function with given fields: ctx, jobName
+func (_m *MockStore) InsertJobRun(ctx context.Context, jobName string) (string, error) {
+	ret := _m.Called(ctx, jobName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertJobRun")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, jobName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, jobName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_InsertJobRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertJobRun'
+type MockStore_InsertJobRun_Call struct {
+	*mock.Call
+}
+
+// InsertJobRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobName string
+func (_e *MockStore_Expecter) InsertJobRun(ctx interface{}, jobName interface{}) *MockStore_InsertJobRun_Call {
+	return &MockStore_InsertJobRun_Call{Call: _e.mock.On("InsertJobRun", ctx, jobName)}
+}
+
+func (_c *MockStore_InsertJobRun_Call) Run(run func(ctx context.Context, jobName string)) *MockStore_InsertJobRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertJobRun_Call) Return(id string, err error) *MockStore_InsertJobRun_Call {
+	_c.Call.Return(id, err)
+	return _c
+}
+
+func (_c *MockStore_InsertJobRun_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStore_InsertJobRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertJudgeScore provides a mock function with given fields: ctx, s
+func (_m *MockStore) InsertJudgeScore(ctx context.Context, s *domain.JudgeScore) error {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertJudgeScore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.JudgeScore) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertJudgeScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertJudgeScore'
+type MockStore_InsertJudgeScore_Call struct {
+	*mock.Call
+}
+
+// InsertJudgeScore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s *domain.JudgeScore
+func (_e *MockStore_Expecter) InsertJudgeScore(ctx interface{}, s interface{}) *MockStore_InsertJudgeScore_Call {
+	return &MockStore_InsertJudgeScore_Call{Call: _e.mock.On("InsertJudgeScore", ctx, s)}
+}
+
+func (_c *MockStore_InsertJudgeScore_Call) Run(run func(ctx context.Context, s *domain.JudgeScore)) *MockStore_InsertJudgeScore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.JudgeScore))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertJudgeScore_Call) Return(_a0 error) *MockStore_InsertJudgeScore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertJudgeScore_Call) RunAndReturn(run func(context.Context, *domain.JudgeScore) error) *MockStore_InsertJudgeScore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertNotificationAttempt provides a mock function with given fields: ctx, alertID, succeeded, httpStatus, errText
+func (_m *MockStore) InsertNotificationAttempt(ctx context.Context, alertID string, succeeded bool, httpStatus int, errText string) error {
+	ret := _m.Called(ctx, alertID, succeeded, httpStatus, errText)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertNotificationAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int, string) error); ok {
+		r0 = rf(ctx, alertID, succeeded, httpStatus, errText)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertNotificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertNotificationAttempt'
+type MockStore_InsertNotificationAttempt_Call struct {
+	*mock.Call
+}
+
+// InsertNotificationAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alertID string
+//   - succeeded bool
+//   - httpStatus int
+//   - errText string
+func (_e *MockStore_Expecter) InsertNotificationAttempt(ctx interface{}, alertID interface{}, succeeded interface{}, httpStatus interface{}, errText interface{}) *MockStore_InsertNotificationAttempt_Call {
+	return &MockStore_InsertNotificationAttempt_Call{Call: _e.mock.On("InsertNotificationAttempt", ctx, alertID, succeeded, httpStatus, errText)}
+}
+
+func (_c *MockStore_InsertNotificationAttempt_Call) Run(run func(ctx context.Context, alertID string, succeeded bool, httpStatus int, errText string)) *MockStore_InsertNotificationAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(int), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertNotificationAttempt_Call) Return(_a0 error) *MockStore_InsertNotificationAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertNotificationAttempt_Call) RunAndReturn(run func(context.Context, string, bool, int, string) error) *MockStore_InsertNotificationAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertShadowExtraction provides a mock function with given fields: ctx, s
+func (_m *MockStore) InsertShadowExtraction(ctx context.Context, s *domain.ShadowExtraction) error {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertShadowExtraction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ShadowExtraction) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertShadowExtraction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertShadowExtraction'
+type MockStore_InsertShadowExtraction_Call struct {
+	*mock.Call
+}
+
+// InsertShadowExtraction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s *domain.ShadowExtraction
+func (_e *MockStore_Expecter) InsertShadowExtraction(ctx interface{}, s interface{}) *MockStore_InsertShadowExtraction_Call {
+	return &MockStore_InsertShadowExtraction_Call{Call: _e.mock.On("InsertShadowExtraction", ctx, s)}
+}
+
+func (_c *MockStore_InsertShadowExtraction_Call) Run(run func(ctx context.Context, s *domain.ShadowExtraction)) *MockStore_InsertShadowExtraction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ShadowExtraction))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertShadowExtraction_Call) Return(_a0 error) *MockStore_InsertShadowExtraction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertShadowExtraction_Call) RunAndReturn(run func(context.Context, *domain.ShadowExtraction) error) *MockStore_InsertShadowExtraction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertSuppressedNotificationAttempt provides a mock function with given fields: ctx, alertID, succeeded, errText, reason
+func (_m *MockStore) InsertSuppressedNotificationAttempt(ctx context.Context, alertID string, succeeded bool, errText string, reason string) error {
+	ret := _m.Called(ctx, alertID, succeeded, errText, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSuppressedNotificationAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string, string) error); ok {
+		r0 = rf(ctx, alertID, succeeded, errText, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertSuppressedNotificationAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSuppressedNotificationAttempt'
+type MockStore_InsertSuppressedNotificationAttempt_Call struct {
+	*mock.Call
+}
+
+// InsertSuppressedNotificationAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alertID string
+//   - succeeded bool
+//   - errText string
+//   - reason string
+func (_e *MockStore_Expecter) InsertSuppressedNotificationAttempt(ctx interface{}, alertID interface{}, succeeded interface{}, errText interface{}, reason interface{}) *MockStore_InsertSuppressedNotificationAttempt_Call {
+	return &MockStore_InsertSuppressedNotificationAttempt_Call{Call: _e.mock.On("InsertSuppressedNotificationAttempt", ctx, alertID, succeeded, errText, reason)}
+}
+
+func (_c *MockStore_InsertSuppressedNotificationAttempt_Call) Run(run func(ctx context.Context, alertID string, succeeded bool, errText string, reason string)) *MockStore_InsertSuppressedNotificationAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertSuppressedNotificationAttempt_Call) Return(_a0 error) *MockStore_InsertSuppressedNotificationAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertSuppressedNotificationAttempt_Call) RunAndReturn(run func(context.Context, string, bool, string, string) error) *MockStore_InsertSuppressedNotificationAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWatchPoll provides a mock function with given fields: ctx, p
+func (_m *MockStore) InsertWatchPoll(ctx context.Context, p *domain.WatchPoll) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWatchPoll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WatchPoll) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_InsertWatchPoll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWatchPoll'
+type MockStore_InsertWatchPoll_Call struct {
+	*mock.Call
+}
+
+// InsertWatchPoll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p *domain.WatchPoll
+func (_e *MockStore_Expecter) InsertWatchPoll(ctx interface{}, p interface{}) *MockStore_InsertWatchPoll_Call {
+	return &MockStore_InsertWatchPoll_Call{Call: _e.mock.On("InsertWatchPoll", ctx, p)}
+}
+
+func (_c *MockStore_InsertWatchPoll_Call) Run(run func(ctx context.Context, p *domain.WatchPoll)) *MockStore_InsertWatchPoll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WatchPoll))
+	})
+	return _c
+}
+
+func (_c *MockStore_InsertWatchPoll_Call) Return(_a0 error) *MockStore_InsertWatchPoll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_InsertWatchPoll_Call) RunAndReturn(run func(context.Context, *domain.WatchPoll) error) *MockStore_InsertWatchPoll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlertsByWatch provides a mock function with given fields: ctx, watchID, limit
+func (_m *MockStore) ListAlertsByWatch(ctx context.Context, watchID string, limit int) ([]domain.Alert, error) {
+	ret := _m.Called(ctx, watchID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlertsByWatch")
+	}
+
+	var r0 []domain.Alert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Alert, error)); ok {
+		return rf(ctx, watchID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Alert); ok {
+		r0 = rf(ctx, watchID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, watchID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAlertsByWatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsByWatch'
+type MockStore_ListAlertsByWatch_Call struct {
+	*mock.Call
+}
+
+// ListAlertsByWatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - watchID string
+//   - limit int
+func (_e *MockStore_Expecter) ListAlertsByWatch(ctx interface{}, watchID interface{}, limit interface{}) *MockStore_ListAlertsByWatch_Call {
+	return &MockStore_ListAlertsByWatch_Call{Call: _e.mock.On("ListAlertsByWatch", ctx, watchID, limit)}
+}
+
+func (_c *MockStore_ListAlertsByWatch_Call) Run(run func(ctx context.Context, watchID string, limit int)) *MockStore_ListAlertsByWatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAlertsByWatch_Call) Return(_a0 []domain.Alert, _a1 error) *MockStore_ListAlertsByWatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAlertsByWatch_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Alert, error)) *MockStore_ListAlertsByWatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlertsForJudging provides a mock function with given fields: ctx, q
+func (_m *MockStore) ListAlertsForJudging(ctx context.Context, q *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlertsForJudging")
+	}
+
+	var r0 []domain.JudgeCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *store.JudgeCandidatesQuery) []domain.JudgeCandidate); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JudgeCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *store.JudgeCandidatesQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAlertsForJudging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsForJudging'
+type MockStore_ListAlertsForJudging_Call struct {
+	*mock.Call
+}
+
+// ListAlertsForJudging is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q *store.JudgeCandidatesQuery
+func (_e *MockStore_Expecter) ListAlertsForJudging(ctx interface{}, q interface{}) *MockStore_ListAlertsForJudging_Call {
+	return &MockStore_ListAlertsForJudging_Call{Call: _e.mock.On("ListAlertsForJudging", ctx, q)}
+}
+
+func (_c *MockStore_ListAlertsForJudging_Call) Run(run func(ctx context.Context, q *store.JudgeCandidatesQuery)) *MockStore_ListAlertsForJudging_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*store.JudgeCandidatesQuery))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAlertsForJudging_Call) Return(_a0 []domain.JudgeCandidate, _a1 error) *MockStore_ListAlertsForJudging_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAlertsForJudging_Call) RunAndReturn(run func(context.Context, *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error)) *MockStore_ListAlertsForJudging_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlertsForReview provides a mock function with given fields: ctx, q
+func (_m *MockStore) ListAlertsForReview(ctx context.Context, q *store.AlertReviewQuery) (store.AlertReviewResult, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlertsForReview")
+	}
+
+	var r0 store.AlertReviewResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *store.AlertReviewQuery) (store.AlertReviewResult, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *store.AlertReviewQuery) store.AlertReviewResult); ok {
+		r0 = rf(ctx, q)
+	} else {
+		r0 = ret.Get(0).(store.AlertReviewResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *store.AlertReviewQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListAlertsForReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsForReview'
+type MockStore_ListAlertsForReview_Call struct {
+	*mock.Call
+}
+
+// ListAlertsForReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q *store.AlertReviewQuery
+func (_e *MockStore_Expecter) ListAlertsForReview(ctx interface{}, q interface{}) *MockStore_ListAlertsForReview_Call {
+	return &MockStore_ListAlertsForReview_Call{Call: _e.mock.On("ListAlertsForReview", ctx, q)}
+}
+
+func (_c *MockStore_ListAlertsForReview_Call) Run(run func(ctx context.Context, q *store.AlertReviewQuery)) *MockStore_ListAlertsForReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*store.AlertReviewQuery))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListAlertsForReview_Call) Return(_a0 store.AlertReviewResult, _a1 error) *MockStore_ListAlertsForReview_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListAlertsForReview_Call) RunAndReturn(run func(context.Context, *store.AlertReviewQuery) (store.AlertReviewResult, error)) *MockStore_ListAlertsForReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBaselines provides a mock function with given fields: ctx
+func (_m *MockStore) ListBaselines(ctx context.Context) ([]domain.PriceBaseline, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBaselines")
+	}
+
+	var r0 []domain.PriceBaseline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PriceBaseline, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PriceBaseline); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PriceBaseline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListBaselines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBaselines'
+type MockStore_ListBaselines_Call struct {
+	*mock.Call
+}
+
+// ListBaselines is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListBaselines(ctx interface{}) *MockStore_ListBaselines_Call {
+	return &MockStore_ListBaselines_Call{Call: _e.mock.On("ListBaselines", ctx)}
+}
+
+func (_c *MockStore_ListBaselines_Call) Run(run func(ctx context.Context)) *MockStore_ListBaselines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListBaselines_Call) Return(_a0 []domain.PriceBaseline, _a1 error) *MockStore_ListBaselines_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListBaselines_Call) RunAndReturn(run func(context.Context) ([]domain.PriceBaseline, error)) *MockStore_ListBaselines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBaselinesMatching provides a mock function with given fields: ctx, pattern
+func (_m *MockStore) ListBaselinesMatching(ctx context.Context, pattern string) ([]domain.PriceBaseline, error) {
+	ret := _m.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBaselinesMatching")
+	}
+
+	var r0 []domain.PriceBaseline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PriceBaseline, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PriceBaseline); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PriceBaseline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListBaselinesMatching_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBaselinesMatching'
+type MockStore_ListBaselinesMatching_Call struct {
+	*mock.Call
+}
+
+// ListBaselinesMatching is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pattern string
+func (_e *MockStore_Expecter) ListBaselinesMatching(ctx interface{}, pattern interface{}) *MockStore_ListBaselinesMatching_Call {
+	return &MockStore_ListBaselinesMatching_Call{Call: _e.mock.On("ListBaselinesMatching", ctx, pattern)}
+}
+
+func (_c *MockStore_ListBaselinesMatching_Call) Run(run func(ctx context.Context, pattern string)) *MockStore_ListBaselinesMatching_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListBaselinesMatching_Call) Return(_a0 []domain.PriceBaseline, _a1 error) *MockStore_ListBaselinesMatching_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListBaselinesMatching_Call) RunAndReturn(run func(context.Context, string) ([]domain.PriceBaseline, error)) *MockStore_ListBaselinesMatching_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExtractionCorrections provides a mock function with given fields: ctx, limit
+func (_m *MockStore) ListExtractionCorrections(ctx context.Context, limit int) ([]domain.ExtractionCorrection, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExtractionCorrections")
+	}
+
+	var r0 []domain.ExtractionCorrection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.ExtractionCorrection, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.ExtractionCorrection); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ExtractionCorrection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListExtractionCorrections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExtractionCorrections'
+type MockStore_ListExtractionCorrections_Call struct {
+	*mock.Call
+}
+
+// ListExtractionCorrections is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockStore_Expecter) ListExtractionCorrections(ctx interface{}, limit interface{}) *MockStore_ListExtractionCorrections_Call {
+	return &MockStore_ListExtractionCorrections_Call{Call: _e.mock.On("ListExtractionCorrections", ctx, limit)}
+}
+
+func (_c *MockStore_ListExtractionCorrections_Call) Run(run func(ctx context.Context, limit int)) *MockStore_ListExtractionCorrections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListExtractionCorrections_Call) Return(_a0 []domain.ExtractionCorrection, _a1 error) *MockStore_ListExtractionCorrections_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListExtractionCorrections_Call) RunAndReturn(run func(context.Context, int) ([]domain.ExtractionCorrection, error)) *MockStore_ListExtractionCorrections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIncompleteExtractions provides a mock function with given fields: ctx, componentType, limit
+func (_m *MockStore) ListIncompleteExtractions(ctx context.Context, componentType string, limit int) ([]domain.Listing, error) {
+	ret := _m.Called(ctx, componentType, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIncompleteExtractions")
+	}
+
+	var r0 []domain.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Listing, error)); ok {
+		return rf(ctx, componentType, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Listing); ok {
+		r0 = rf(ctx, componentType, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, componentType, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListIncompleteExtractions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIncompleteExtractions'
+type MockStore_ListIncompleteExtractions_Call struct {
+	*mock.Call
+}
+
+// ListIncompleteExtractions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - componentType string
+//   - limit int
+func (_e *MockStore_Expecter) ListIncompleteExtractions(ctx interface{}, componentType interface{}, limit interface{}) *MockStore_ListIncompleteExtractions_Call {
+	return &MockStore_ListIncompleteExtractions_Call{Call: _e.mock.On("ListIncompleteExtractions", ctx, componentType, limit)}
+}
 
-func (_c *MockStore_ListAlertsForJudging_Call) Run(run func(ctx context.Context, q *store.JudgeCandidatesQuery)) *MockStore_ListAlertsForJudging_Call {
+func (_c *MockStore_ListIncompleteExtractions_Call) Run(run func(ctx context.Context, componentType string, limit int)) *MockStore_ListIncompleteExtractions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*store.JudgeCandidatesQuery))
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListAlertsForJudging_Call) Return(_a0 []domain.JudgeCandidate, _a1 error) *MockStore_ListAlertsForJudging_Call {
+func (_c *MockStore_ListIncompleteExtractions_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListIncompleteExtractions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListAlertsForJudging_Call) RunAndReturn(run func(context.Context, *store.JudgeCandidatesQuery) ([]domain.JudgeCandidate, error)) *MockStore_ListAlertsForJudging_Call {
+func (_c *MockStore_ListIncompleteExtractions_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Listing, error)) *MockStore_ListIncompleteExtractions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAlertsForReview provides a mock function with given fields: ctx, q
-func (_m *MockStore) ListAlertsForReview(ctx context.Context, q *store.AlertReviewQuery) (store.AlertReviewResult, error) {
-	ret := _m.Called(ctx, q)
+// ListJobRuns provides a mock function with given fields: ctx, jobName, limit
+func (_m *MockStore) ListJobRuns(ctx context.Context, jobName string, limit int) ([]domain.JobRun, error) {
+	ret := _m.Called(ctx, jobName, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobRuns")
+	}
+
+	var r0 []domain.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.JobRun, error)); ok {
+		return rf(ctx, jobName, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.JobRun); ok {
+		r0 = rf(ctx, jobName, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, jobName, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListJobRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobRuns'
+type MockStore_ListJobRuns_Call struct {
+	*mock.Call
+}
+
+// ListJobRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobName string
+//   - limit int
+func (_e *MockStore_Expecter) ListJobRuns(ctx interface{}, jobName interface{}, limit interface{}) *MockStore_ListJobRuns_Call {
+	return &MockStore_ListJobRuns_Call{Call: _e.mock.On("ListJobRuns", ctx, jobName, limit)}
+}
+
+func (_c *MockStore_ListJobRuns_Call) Run(run func(ctx context.Context, jobName string, limit int)) *MockStore_ListJobRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListJobRuns_Call) Return(_a0 []domain.JobRun, _a1 error) *MockStore_ListJobRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListJobRuns_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.JobRun, error)) *MockStore_ListJobRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLatestJobRuns provides a mock function with given fields: ctx
+func (_m *MockStore) ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLatestJobRuns")
+	}
+
+	var r0 []domain.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.JobRun, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.JobRun); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListLatestJobRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLatestJobRuns'
+type MockStore_ListLatestJobRuns_Call struct {
+	*mock.Call
+}
+
+// ListLatestJobRuns is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStore_Expecter) ListLatestJobRuns(ctx interface{}) *MockStore_ListLatestJobRuns_Call {
+	return &MockStore_ListLatestJobRuns_Call{Call: _e.mock.On("ListLatestJobRuns", ctx)}
+}
+
+func (_c *MockStore_ListLatestJobRuns_Call) Run(run func(ctx context.Context)) *MockStore_ListLatestJobRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListLatestJobRuns_Call) Return(_a0 []domain.JobRun, _a1 error) *MockStore_ListLatestJobRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListLatestJobRuns_Call) RunAndReturn(run func(context.Context) ([]domain.JobRun, error)) *MockStore_ListLatestJobRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListListings provides a mock function with given fields: ctx, opts
+func (_m *MockStore) ListListings(ctx context.Context, opts *store.ListingQuery) ([]domain.Listing, int, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListListings")
+	}
+
+	var r0 []domain.Listing
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ListingQuery) ([]domain.Listing, int, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ListingQuery) []domain.Listing); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *store.ListingQuery) int); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *store.ListingQuery) error); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockStore_ListListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListListings'
+type MockStore_ListListings_Call struct {
+	*mock.Call
+}
+
+// ListListings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts *store.ListingQuery
+func (_e *MockStore_Expecter) ListListings(ctx interface{}, opts interface{}) *MockStore_ListListings_Call {
+	return &MockStore_ListListings_Call{Call: _e.mock.On("ListListings", ctx, opts)}
+}
+
+func (_c *MockStore_ListListings_Call) Run(run func(ctx context.Context, opts *store.ListingQuery)) *MockStore_ListListings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*store.ListingQuery))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListListings_Call) Return(_a0 []domain.Listing, _a1 int, _a2 error) *MockStore_ListListings_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockStore_ListListings_Call) RunAndReturn(run func(context.Context, *store.ListingQuery) ([]domain.Listing, int, error)) *MockStore_ListListings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListListingsCursor provides a mock function with given fields: ctx, afterID, limit
+func (_m *MockStore) ListListingsCursor(ctx context.Context, afterID string, limit int) ([]domain.Listing, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListListingsCursor")
+	}
+
+	var r0 []domain.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Listing, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Listing); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ListListingsCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListListingsCursor'
+type MockStore_ListListingsCursor_Call struct {
+	*mock.Call
+}
+
+// ListListingsCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID string
+//   - limit int
+func (_e *MockStore_Expecter) ListListingsCursor(ctx interface{}, afterID interface{}, limit interface{}) *MockStore_ListListingsCursor_Call {
+	return &MockStore_ListListingsCursor_Call{Call: _e.mock.On("ListListingsCursor", ctx, afterID, limit)}
+}
+
+func (_c *MockStore_ListListingsCursor_Call) Run(run func(ctx context.Context, afterID string, limit int)) *MockStore_ListListingsCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockStore_ListListingsCursor_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListListingsCursor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ListListingsCursor_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Listing, error)) *MockStore_ListListingsCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPendingAlerts provides a mock function with given fields: ctx
+func (_m *MockStore) ListPendingAlerts(ctx context.Context) ([]domain.Alert, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAlertsForReview")
+		panic("no return value specified for ListPendingAlerts")
 	}
 
-	var r0 store.AlertReviewResult
+	var r0 []domain.Alert
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *store.AlertReviewQuery) (store.AlertReviewResult, error)); ok {
-		return rf(ctx, q)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Alert, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *store.AlertReviewQuery) store.AlertReviewResult); ok {
-		r0 = rf(ctx, q)
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Alert); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(store.AlertReviewResult)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Alert)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *store.AlertReviewQuery) error); ok {
-		r1 = rf(ctx, q)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1385,58 +2751,57 @@ func (_m *MockStore) ListAlertsForReview(ctx context.Context, q *store.AlertRevi
 	return r0, r1
 }
 
-// MockStore_ListAlertsForReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlertsForReview'
-type MockStore_ListAlertsForReview_Call struct {
+// MockStore_ListPendingAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingAlerts'
+type MockStore_ListPendingAlerts_Call struct {
 	*mock.Call
 }
 
-// ListAlertsForReview is a helper method to define mock.On call
+// ListPendingAlerts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - q *store.AlertReviewQuery
-func (_e *MockStore_Expecter) ListAlertsForReview(ctx interface{}, q interface{}) *MockStore_ListAlertsForReview_Call {
-	return &MockStore_ListAlertsForReview_Call{Call: _e.mock.On("ListAlertsForReview", ctx, q)}
+func (_e *MockStore_Expecter) ListPendingAlerts(ctx interface{}) *MockStore_ListPendingAlerts_Call {
+	return &MockStore_ListPendingAlerts_Call{Call: _e.mock.On("ListPendingAlerts", ctx)}
 }
 
-func (_c *MockStore_ListAlertsForReview_Call) Run(run func(ctx context.Context, q *store.AlertReviewQuery)) *MockStore_ListAlertsForReview_Call {
+func (_c *MockStore_ListPendingAlerts_Call) Run(run func(ctx context.Context)) *MockStore_ListPendingAlerts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*store.AlertReviewQuery))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListAlertsForReview_Call) Return(_a0 store.AlertReviewResult, _a1 error) *MockStore_ListAlertsForReview_Call {
+func (_c *MockStore_ListPendingAlerts_Call) Return(_a0 []domain.Alert, _a1 error) *MockStore_ListPendingAlerts_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListAlertsForReview_Call) RunAndReturn(run func(context.Context, *store.AlertReviewQuery) (store.AlertReviewResult, error)) *MockStore_ListAlertsForReview_Call {
+func (_c *MockStore_ListPendingAlerts_Call) RunAndReturn(run func(context.Context) ([]domain.Alert, error)) *MockStore_ListPendingAlerts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListBaselines provides a mock function with given fields: ctx
-func (_m *MockStore) ListBaselines(ctx context.Context) ([]domain.PriceBaseline, error) {
-	ret := _m.Called(ctx)
+// ListPriceCheckListings provides a mock function with given fields: ctx, checkedBefore, limit
+func (_m *MockStore) ListPriceCheckListings(ctx context.Context, checkedBefore time.Time, limit int) ([]domain.Listing, error) {
+	ret := _m.Called(ctx, checkedBefore, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListBaselines")
+		panic("no return value specified for ListPriceCheckListings")
 	}
 
-	var r0 []domain.PriceBaseline
+	var r0 []domain.Listing
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PriceBaseline, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.Listing, error)); ok {
+		return rf(ctx, checkedBefore, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.PriceBaseline); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.Listing); ok {
+		r0 = rf(ctx, checkedBefore, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.PriceBaseline)
+			r0 = ret.Get(0).([]domain.Listing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, checkedBefore, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1444,57 +2809,59 @@ func (_m *MockStore) ListBaselines(ctx context.Context) ([]domain.PriceBaseline,
 	return r0, r1
 }
 
-// MockStore_ListBaselines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBaselines'
-type MockStore_ListBaselines_Call struct {
+// MockStore_ListPriceCheckListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceCheckListings'
+type MockStore_ListPriceCheckListings_Call struct {
 	*mock.Call
 }
 
-// ListBaselines is a helper method to define mock.On call
+// ListPriceCheckListings is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) ListBaselines(ctx interface{}) *MockStore_ListBaselines_Call {
-	return &MockStore_ListBaselines_Call{Call: _e.mock.On("ListBaselines", ctx)}
+//   - checkedBefore time.Time
+//   - limit int
+func (_e *MockStore_Expecter) ListPriceCheckListings(ctx interface{}, checkedBefore interface{}, limit interface{}) *MockStore_ListPriceCheckListings_Call {
+	return &MockStore_ListPriceCheckListings_Call{Call: _e.mock.On("ListPriceCheckListings", ctx, checkedBefore, limit)}
 }
 
-func (_c *MockStore_ListBaselines_Call) Run(run func(ctx context.Context)) *MockStore_ListBaselines_Call {
+func (_c *MockStore_ListPriceCheckListings_Call) Run(run func(ctx context.Context, checkedBefore time.Time, limit int)) *MockStore_ListPriceCheckListings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListBaselines_Call) Return(_a0 []domain.PriceBaseline, _a1 error) *MockStore_ListBaselines_Call {
+func (_c *MockStore_ListPriceCheckListings_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListPriceCheckListings_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListBaselines_Call) RunAndReturn(run func(context.Context) ([]domain.PriceBaseline, error)) *MockStore_ListBaselines_Call {
+func (_c *MockStore_ListPriceCheckListings_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]domain.Listing, error)) *MockStore_ListPriceCheckListings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListIncompleteExtractions provides a mock function with given fields: ctx, componentType, limit
-func (_m *MockStore) ListIncompleteExtractions(ctx context.Context, componentType string, limit int) ([]domain.Listing, error) {
-	ret := _m.Called(ctx, componentType, limit)
+// ListPriceHistory provides a mock function with given fields: ctx, listingID
+func (_m *MockStore) ListPriceHistory(ctx context.Context, listingID string) ([]domain.PricePoint, error) {
+	ret := _m.Called(ctx, listingID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListIncompleteExtractions")
+		panic("no return value specified for ListPriceHistory")
 	}
 
-	var r0 []domain.Listing
+	var r0 []domain.PricePoint
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Listing, error)); ok {
-		return rf(ctx, componentType, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PricePoint, error)); ok {
+		return rf(ctx, listingID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Listing); ok {
-		r0 = rf(ctx, componentType, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PricePoint); ok {
+		r0 = rf(ctx, listingID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Listing)
+			r0 = ret.Get(0).([]domain.PricePoint)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, componentType, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, listingID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1502,59 +2869,58 @@ func (_m *MockStore) ListIncompleteExtractions(ctx context.Context, componentTyp
 	return r0, r1
 }
 
-// MockStore_ListIncompleteExtractions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIncompleteExtractions'
-type MockStore_ListIncompleteExtractions_Call struct {
+// MockStore_ListPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceHistory'
+type MockStore_ListPriceHistory_Call struct {
 	*mock.Call
 }
 
-// ListIncompleteExtractions is a helper method to define mock.On call
+// ListPriceHistory is a helper method to define mock.On call
 //   - ctx context.Context
-//   - componentType string
-//   - limit int
-func (_e *MockStore_Expecter) ListIncompleteExtractions(ctx interface{}, componentType interface{}, limit interface{}) *MockStore_ListIncompleteExtractions_Call {
-	return &MockStore_ListIncompleteExtractions_Call{Call: _e.mock.On("ListIncompleteExtractions", ctx, componentType, limit)}
+//   - listingID string
+func (_e *MockStore_Expecter) ListPriceHistory(ctx interface{}, listingID interface{}) *MockStore_ListPriceHistory_Call {
+	return &MockStore_ListPriceHistory_Call{Call: _e.mock.On("ListPriceHistory", ctx, listingID)}
 }
 
-func (_c *MockStore_ListIncompleteExtractions_Call) Run(run func(ctx context.Context, componentType string, limit int)) *MockStore_ListIncompleteExtractions_Call {
+func (_c *MockStore_ListPriceHistory_Call) Run(run func(ctx context.Context, listingID string)) *MockStore_ListPriceHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListIncompleteExtractions_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListIncompleteExtractions_Call {
+func (_c *MockStore_ListPriceHistory_Call) Return(_a0 []domain.PricePoint, _a1 error) *MockStore_ListPriceHistory_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListIncompleteExtractions_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Listing, error)) *MockStore_ListIncompleteExtractions_Call {
+func (_c *MockStore_ListPriceHistory_Call) RunAndReturn(run func(context.Context, string) ([]domain.PricePoint, error)) *MockStore_ListPriceHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListJobRuns provides a mock function with given fields: ctx, jobName, limit
-func (_m *MockStore) ListJobRuns(ctx context.Context, jobName string, limit int) ([]domain.JobRun, error) {
-	ret := _m.Called(ctx, jobName, limit)
+// ListProductKeyAliases provides a mock function with given fields: ctx
+func (_m *MockStore) ListProductKeyAliases(ctx context.Context) ([]domain.ProductKeyAlias, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListJobRuns")
+		panic("no return value specified for ListProductKeyAliases")
 	}
 
-	var r0 []domain.JobRun
+	var r0 []domain.ProductKeyAlias
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.JobRun, error)); ok {
-		return rf(ctx, jobName, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ProductKeyAlias, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.JobRun); ok {
-		r0 = rf(ctx, jobName, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ProductKeyAlias); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.JobRun)
+			r0 = ret.Get(0).([]domain.ProductKeyAlias)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, jobName, limit)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1562,54 +2928,52 @@ func (_m *MockStore) ListJobRuns(ctx context.Context, jobName string, limit int)
 	return r0, r1
 }
 
-// MockStore_ListJobRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobRuns'
-type MockStore_ListJobRuns_Call struct {
+// MockStore_ListProductKeyAliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductKeyAliases'
+type MockStore_ListProductKeyAliases_Call struct {
 	*mock.Call
 }
 
-// ListJobRuns is a helper method to define mock.On call
+// ListProductKeyAliases is a helper method to define mock.On call
 //   - ctx context.Context
-//   - jobName string
-//   - limit int
-func (_e *MockStore_Expecter) ListJobRuns(ctx interface{}, jobName interface{}, limit interface{}) *MockStore_ListJobRuns_Call {
-	return &MockStore_ListJobRuns_Call{Call: _e.mock.On("ListJobRuns", ctx, jobName, limit)}
+func (_e *MockStore_Expecter) ListProductKeyAliases(ctx interface{}) *MockStore_ListProductKeyAliases_Call {
+	return &MockStore_ListProductKeyAliases_Call{Call: _e.mock.On("ListProductKeyAliases", ctx)}
 }
 
-func (_c *MockStore_ListJobRuns_Call) Run(run func(ctx context.Context, jobName string, limit int)) *MockStore_ListJobRuns_Call {
+func (_c *MockStore_ListProductKeyAliases_Call) Run(run func(ctx context.Context)) *MockStore_ListProductKeyAliases_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListJobRuns_Call) Return(_a0 []domain.JobRun, _a1 error) *MockStore_ListJobRuns_Call {
+func (_c *MockStore_ListProductKeyAliases_Call) Return(_a0 []domain.ProductKeyAlias, _a1 error) *MockStore_ListProductKeyAliases_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListJobRuns_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.JobRun, error)) *MockStore_ListJobRuns_Call {
+func (_c *MockStore_ListProductKeyAliases_Call) RunAndReturn(run func(context.Context) ([]domain.ProductKeyAlias, error)) *MockStore_ListProductKeyAliases_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListLatestJobRuns provides a mock function with given fields: ctx
-func (_m *MockStore) ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, error) {
+// ListProductKeyCounts provides a mock function with given fields: ctx
+func (_m *MockStore) ListProductKeyCounts(ctx context.Context) ([]domain.ProductKeyCount, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLatestJobRuns")
+		panic("no return value specified for ListProductKeyCounts")
 	}
 
-	var r0 []domain.JobRun
+	var r0 []domain.ProductKeyCount
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.JobRun, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ProductKeyCount, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.JobRun); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ProductKeyCount); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.JobRun)
+			r0 = ret.Get(0).([]domain.ProductKeyCount)
 		}
 	}
 
@@ -1622,123 +2986,116 @@ func (_m *MockStore) ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, er
 	return r0, r1
 }
 
-// MockStore_ListLatestJobRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLatestJobRuns'
-type MockStore_ListLatestJobRuns_Call struct {
+// MockStore_ListProductKeyCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductKeyCounts'
+type MockStore_ListProductKeyCounts_Call struct {
 	*mock.Call
 }
 
-// ListLatestJobRuns is a helper method to define mock.On call
+// ListProductKeyCounts is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) ListLatestJobRuns(ctx interface{}) *MockStore_ListLatestJobRuns_Call {
-	return &MockStore_ListLatestJobRuns_Call{Call: _e.mock.On("ListLatestJobRuns", ctx)}
+func (_e *MockStore_Expecter) ListProductKeyCounts(ctx interface{}) *MockStore_ListProductKeyCounts_Call {
+	return &MockStore_ListProductKeyCounts_Call{Call: _e.mock.On("ListProductKeyCounts", ctx)}
 }
 
-func (_c *MockStore_ListLatestJobRuns_Call) Run(run func(ctx context.Context)) *MockStore_ListLatestJobRuns_Call {
+func (_c *MockStore_ListProductKeyCounts_Call) Run(run func(ctx context.Context)) *MockStore_ListProductKeyCounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListLatestJobRuns_Call) Return(_a0 []domain.JobRun, _a1 error) *MockStore_ListLatestJobRuns_Call {
+func (_c *MockStore_ListProductKeyCounts_Call) Return(_a0 []domain.ProductKeyCount, _a1 error) *MockStore_ListProductKeyCounts_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListLatestJobRuns_Call) RunAndReturn(run func(context.Context) ([]domain.JobRun, error)) *MockStore_ListLatestJobRuns_Call {
+func (_c *MockStore_ListProductKeyCounts_Call) RunAndReturn(run func(context.Context) ([]domain.ProductKeyCount, error)) *MockStore_ListProductKeyCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListListings provides a mock function with given fields: ctx, opts
-func (_m *MockStore) ListListings(ctx context.Context, opts *store.ListingQuery) ([]domain.Listing, int, error) {
-	ret := _m.Called(ctx, opts)
+// ListScoringLabels provides a mock function with given fields: ctx, q
+func (_m *MockStore) ListScoringLabels(ctx context.Context, q *store.ScoringLabelsQuery) ([]domain.ScoringLabel, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListListings")
+		panic("no return value specified for ListScoringLabels")
 	}
 
-	var r0 []domain.Listing
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *store.ListingQuery) ([]domain.Listing, int, error)); ok {
-		return rf(ctx, opts)
+	var r0 []domain.ScoringLabel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ScoringLabelsQuery) ([]domain.ScoringLabel, error)); ok {
+		return rf(ctx, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *store.ListingQuery) []domain.Listing); ok {
-		r0 = rf(ctx, opts)
+	if rf, ok := ret.Get(0).(func(context.Context, *store.ScoringLabelsQuery) []domain.ScoringLabel); ok {
+		r0 = rf(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Listing)
+			r0 = ret.Get(0).([]domain.ScoringLabel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *store.ListingQuery) int); ok {
-		r1 = rf(ctx, opts)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, *store.ListingQuery) error); ok {
-		r2 = rf(ctx, opts)
+	if rf, ok := ret.Get(1).(func(context.Context, *store.ScoringLabelsQuery) error); ok {
+		r1 = rf(ctx, q)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStore_ListListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListListings'
-type MockStore_ListListings_Call struct {
+// MockStore_ListScoringLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScoringLabels'
+type MockStore_ListScoringLabels_Call struct {
 	*mock.Call
 }
 
-// ListListings is a helper method to define mock.On call
+// ListScoringLabels is a helper method to define mock.On call
 //   - ctx context.Context
-//   - opts *store.ListingQuery
-func (_e *MockStore_Expecter) ListListings(ctx interface{}, opts interface{}) *MockStore_ListListings_Call {
-	return &MockStore_ListListings_Call{Call: _e.mock.On("ListListings", ctx, opts)}
+//   - q *store.ScoringLabelsQuery
+func (_e *MockStore_Expecter) ListScoringLabels(ctx interface{}, q interface{}) *MockStore_ListScoringLabels_Call {
+	return &MockStore_ListScoringLabels_Call{Call: _e.mock.On("ListScoringLabels", ctx, q)}
 }
 
-func (_c *MockStore_ListListings_Call) Run(run func(ctx context.Context, opts *store.ListingQuery)) *MockStore_ListListings_Call {
+func (_c *MockStore_ListScoringLabels_Call) Run(run func(ctx context.Context, q *store.ScoringLabelsQuery)) *MockStore_ListScoringLabels_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*store.ListingQuery))
+		run(args[0].(context.Context), args[1].(*store.ScoringLabelsQuery))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListListings_Call) Return(_a0 []domain.Listing, _a1 int, _a2 error) *MockStore_ListListings_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockStore_ListScoringLabels_Call) Return(_a0 []domain.ScoringLabel, _a1 error) *MockStore_ListScoringLabels_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListListings_Call) RunAndReturn(run func(context.Context, *store.ListingQuery) ([]domain.Listing, int, error)) *MockStore_ListListings_Call {
+func (_c *MockStore_ListScoringLabels_Call) RunAndReturn(run func(context.Context, *store.ScoringLabelsQuery) ([]domain.ScoringLabel, error)) *MockStore_ListScoringLabels_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListListingsCursor provides a mock function with given fields: ctx, afterID, limit
-func (_m *MockStore) ListListingsCursor(ctx context.Context, afterID string, limit int) ([]domain.Listing, error) {
-	ret := _m.Called(ctx, afterID, limit)
+// ListTrackedAuctions provides a mock function with given fields: ctx, endsBefore
+func (_m *MockStore) ListTrackedAuctions(ctx context.Context, endsBefore time.Time) ([]domain.Listing, error) {
+	ret := _m.Called(ctx, endsBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListListingsCursor")
+		panic("no return value specified for ListTrackedAuctions")
 	}
 
 	var r0 []domain.Listing
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Listing, error)); ok {
-		return rf(ctx, afterID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Listing, error)); ok {
+		return rf(ctx, endsBefore)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Listing); ok {
-		r0 = rf(ctx, afterID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Listing); ok {
+		r0 = rf(ctx, endsBefore)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Listing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, afterID, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, endsBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1746,59 +3103,58 @@ func (_m *MockStore) ListListingsCursor(ctx context.Context, afterID string, lim
 	return r0, r1
 }
 
-// MockStore_ListListingsCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListListingsCursor'
-type MockStore_ListListingsCursor_Call struct {
+// MockStore_ListTrackedAuctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrackedAuctions'
+type MockStore_ListTrackedAuctions_Call struct {
 	*mock.Call
 }
 
-// ListListingsCursor is a helper method to define mock.On call
+// ListTrackedAuctions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - afterID string
-//   - limit int
-func (_e *MockStore_Expecter) ListListingsCursor(ctx interface{}, afterID interface{}, limit interface{}) *MockStore_ListListingsCursor_Call {
-	return &MockStore_ListListingsCursor_Call{Call: _e.mock.On("ListListingsCursor", ctx, afterID, limit)}
+//   - endsBefore time.Time
+func (_e *MockStore_Expecter) ListTrackedAuctions(ctx interface{}, endsBefore interface{}) *MockStore_ListTrackedAuctions_Call {
+	return &MockStore_ListTrackedAuctions_Call{Call: _e.mock.On("ListTrackedAuctions", ctx, endsBefore)}
 }
 
-func (_c *MockStore_ListListingsCursor_Call) Run(run func(ctx context.Context, afterID string, limit int)) *MockStore_ListListingsCursor_Call {
+func (_c *MockStore_ListTrackedAuctions_Call) Run(run func(ctx context.Context, endsBefore time.Time)) *MockStore_ListTrackedAuctions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListListingsCursor_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListListingsCursor_Call {
+func (_c *MockStore_ListTrackedAuctions_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListTrackedAuctions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListListingsCursor_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Listing, error)) *MockStore_ListListingsCursor_Call {
+func (_c *MockStore_ListTrackedAuctions_Call) RunAndReturn(run func(context.Context, time.Time) ([]domain.Listing, error)) *MockStore_ListTrackedAuctions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPendingAlerts provides a mock function with given fields: ctx
-func (_m *MockStore) ListPendingAlerts(ctx context.Context) ([]domain.Alert, error) {
-	ret := _m.Called(ctx)
+// ListUnextractedListings provides a mock function with given fields: ctx, limit
+func (_m *MockStore) ListUnextractedListings(ctx context.Context, limit int) ([]domain.Listing, error) {
+	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPendingAlerts")
+		panic("no return value specified for ListUnextractedListings")
 	}
 
-	var r0 []domain.Alert
+	var r0 []domain.Listing
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Alert, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Listing, error)); ok {
+		return rf(ctx, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.Alert); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Listing); ok {
+		r0 = rf(ctx, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Alert)
+			r0 = ret.Get(0).([]domain.Listing)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1806,40 +3162,41 @@ func (_m *MockStore) ListPendingAlerts(ctx context.Context) ([]domain.Alert, err
 	return r0, r1
 }
 
-// MockStore_ListPendingAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingAlerts'
-type MockStore_ListPendingAlerts_Call struct {
+// MockStore_ListUnextractedListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnextractedListings'
+type MockStore_ListUnextractedListings_Call struct {
 	*mock.Call
 }
 
-// ListPendingAlerts is a helper method to define mock.On call
+// ListUnextractedListings is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStore_Expecter) ListPendingAlerts(ctx interface{}) *MockStore_ListPendingAlerts_Call {
-	return &MockStore_ListPendingAlerts_Call{Call: _e.mock.On("ListPendingAlerts", ctx)}
+//   - limit int
+func (_e *MockStore_Expecter) ListUnextractedListings(ctx interface{}, limit interface{}) *MockStore_ListUnextractedListings_Call {
+	return &MockStore_ListUnextractedListings_Call{Call: _e.mock.On("ListUnextractedListings", ctx, limit)}
 }
 
-func (_c *MockStore_ListPendingAlerts_Call) Run(run func(ctx context.Context)) *MockStore_ListPendingAlerts_Call {
+func (_c *MockStore_ListUnextractedListings_Call) Run(run func(ctx context.Context, limit int)) *MockStore_ListUnextractedListings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListPendingAlerts_Call) Return(_a0 []domain.Alert, _a1 error) *MockStore_ListPendingAlerts_Call {
+func (_c *MockStore_ListUnextractedListings_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListUnextractedListings_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListPendingAlerts_Call) RunAndReturn(run func(context.Context) ([]domain.Alert, error)) *MockStore_ListPendingAlerts_Call {
+func (_c *MockStore_ListUnextractedListings_Call) RunAndReturn(run func(context.Context, int) ([]domain.Listing, error)) *MockStore_ListUnextractedListings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUnextractedListings provides a mock function with given fields: ctx, limit
-func (_m *MockStore) ListUnextractedListings(ctx context.Context, limit int) ([]domain.Listing, error) {
+// ListUnscoredListings provides a mock function with given fields: ctx, limit
+func (_m *MockStore) ListUnscoredListings(ctx context.Context, limit int) ([]domain.Listing, error) {
 	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUnextractedListings")
+		panic("no return value specified for ListUnscoredListings")
 	}
 
 	var r0 []domain.Listing
@@ -1864,58 +3221,58 @@ func (_m *MockStore) ListUnextractedListings(ctx context.Context, limit int) ([]
 	return r0, r1
 }
 
-// MockStore_ListUnextractedListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnextractedListings'
-type MockStore_ListUnextractedListings_Call struct {
+// MockStore_ListUnscoredListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnscoredListings'
+type MockStore_ListUnscoredListings_Call struct {
 	*mock.Call
 }
 
-// ListUnextractedListings is a helper method to define mock.On call
+// ListUnscoredListings is a helper method to define mock.On call
 //   - ctx context.Context
 //   - limit int
-func (_e *MockStore_Expecter) ListUnextractedListings(ctx interface{}, limit interface{}) *MockStore_ListUnextractedListings_Call {
-	return &MockStore_ListUnextractedListings_Call{Call: _e.mock.On("ListUnextractedListings", ctx, limit)}
+func (_e *MockStore_Expecter) ListUnscoredListings(ctx interface{}, limit interface{}) *MockStore_ListUnscoredListings_Call {
+	return &MockStore_ListUnscoredListings_Call{Call: _e.mock.On("ListUnscoredListings", ctx, limit)}
 }
 
-func (_c *MockStore_ListUnextractedListings_Call) Run(run func(ctx context.Context, limit int)) *MockStore_ListUnextractedListings_Call {
+func (_c *MockStore_ListUnscoredListings_Call) Run(run func(ctx context.Context, limit int)) *MockStore_ListUnscoredListings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListUnextractedListings_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListUnextractedListings_Call {
+func (_c *MockStore_ListUnscoredListings_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListUnscoredListings_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListUnextractedListings_Call) RunAndReturn(run func(context.Context, int) ([]domain.Listing, error)) *MockStore_ListUnextractedListings_Call {
+func (_c *MockStore_ListUnscoredListings_Call) RunAndReturn(run func(context.Context, int) ([]domain.Listing, error)) *MockStore_ListUnscoredListings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUnscoredListings provides a mock function with given fields: ctx, limit
-func (_m *MockStore) ListUnscoredListings(ctx context.Context, limit int) ([]domain.Listing, error) {
-	ret := _m.Called(ctx, limit)
+// ListWatchYields provides a mock function with given fields: ctx, since
+func (_m *MockStore) ListWatchYields(ctx context.Context, since time.Time) ([]domain.WatchYield, error) {
+	ret := _m.Called(ctx, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUnscoredListings")
+		panic("no return value specified for ListWatchYields")
 	}
 
-	var r0 []domain.Listing
+	var r0 []domain.WatchYield
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Listing, error)); ok {
-		return rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.WatchYield, error)); ok {
+		return rf(ctx, since)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Listing); ok {
-		r0 = rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.WatchYield); ok {
+		r0 = rf(ctx, since)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Listing)
+			r0 = ret.Get(0).([]domain.WatchYield)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1923,31 +3280,31 @@ func (_m *MockStore) ListUnscoredListings(ctx context.Context, limit int) ([]dom
 	return r0, r1
 }
 
-// MockStore_ListUnscoredListings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnscoredListings'
-type MockStore_ListUnscoredListings_Call struct {
+// MockStore_ListWatchYields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWatchYields'
+type MockStore_ListWatchYields_Call struct {
 	*mock.Call
 }
 
-// ListUnscoredListings is a helper method to define mock.On call
+// ListWatchYields is a helper method to define mock.On call
 //   - ctx context.Context
-//   - limit int
-func (_e *MockStore_Expecter) ListUnscoredListings(ctx interface{}, limit interface{}) *MockStore_ListUnscoredListings_Call {
-	return &MockStore_ListUnscoredListings_Call{Call: _e.mock.On("ListUnscoredListings", ctx, limit)}
+//   - since time.Time
+func (_e *MockStore_Expecter) ListWatchYields(ctx interface{}, since interface{}) *MockStore_ListWatchYields_Call {
+	return &MockStore_ListWatchYields_Call{Call: _e.mock.On("ListWatchYields", ctx, since)}
 }
 
-func (_c *MockStore_ListUnscoredListings_Call) Run(run func(ctx context.Context, limit int)) *MockStore_ListUnscoredListings_Call {
+func (_c *MockStore_ListWatchYields_Call) Run(run func(ctx context.Context, since time.Time)) *MockStore_ListWatchYields_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStore_ListUnscoredListings_Call) Return(_a0 []domain.Listing, _a1 error) *MockStore_ListUnscoredListings_Call {
+func (_c *MockStore_ListWatchYields_Call) Return(_a0 []domain.WatchYield, _a1 error) *MockStore_ListWatchYields_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStore_ListUnscoredListings_Call) RunAndReturn(run func(context.Context, int) ([]domain.Listing, error)) *MockStore_ListUnscoredListings_Call {
+func (_c *MockStore_ListWatchYields_Call) RunAndReturn(run func(context.Context, time.Time) ([]domain.WatchYield, error)) *MockStore_ListWatchYields_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2163,6 +3520,111 @@ func (_c *MockStore_MarkAlertsNotified_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// MarkPriceChecked provides a mock function with given fields: ctx, listingID
+func (_m *MockStore) MarkPriceChecked(ctx context.Context, listingID string) error {
+	ret := _m.Called(ctx, listingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPriceChecked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, listingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_MarkPriceChecked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPriceChecked'
+type MockStore_MarkPriceChecked_Call struct {
+	*mock.Call
+}
+
+// MarkPriceChecked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listingID string
+func (_e *MockStore_Expecter) MarkPriceChecked(ctx interface{}, listingID interface{}) *MockStore_MarkPriceChecked_Call {
+	return &MockStore_MarkPriceChecked_Call{Call: _e.mock.On("MarkPriceChecked", ctx, listingID)}
+}
+
+func (_c *MockStore_MarkPriceChecked_Call) Run(run func(ctx context.Context, listingID string)) *MockStore_MarkPriceChecked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_MarkPriceChecked_Call) Return(_a0 error) *MockStore_MarkPriceChecked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_MarkPriceChecked_Call) RunAndReturn(run func(context.Context, string) error) *MockStore_MarkPriceChecked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeProductKeys provides a mock function with given fields: ctx, from, to
+func (_m *MockStore) MergeProductKeys(ctx context.Context, from string, to string) (int, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeProductKeys")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_MergeProductKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeProductKeys'
+type MockStore_MergeProductKeys_Call struct {
+	*mock.Call
+}
+
+// MergeProductKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+func (_e *MockStore_Expecter) MergeProductKeys(ctx interface{}, from interface{}, to interface{}) *MockStore_MergeProductKeys_Call {
+	return &MockStore_MergeProductKeys_Call{Call: _e.mock.On("MergeProductKeys", ctx, from, to)}
+}
+
+func (_c *MockStore_MergeProductKeys_Call) Run(run func(ctx context.Context, from string, to string)) *MockStore_MergeProductKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_MergeProductKeys_Call) Return(_a0 int, _a1 error) *MockStore_MergeProductKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_MergeProductKeys_Call) RunAndReturn(run func(context.Context, string, string) (int, error)) *MockStore_MergeProductKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Migrate provides a mock function with given fields: ctx
 func (_m *MockStore) Migrate(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -2399,6 +3861,54 @@ func (_c *MockStore_RecomputeBaseline_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// RecordAuctionReminder provides a mock function with given fields: ctx, watchID, listingID
+func (_m *MockStore) RecordAuctionReminder(ctx context.Context, watchID string, listingID string) error {
+	ret := _m.Called(ctx, watchID, listingID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAuctionReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, watchID, listingID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_RecordAuctionReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAuctionReminder'
+type MockStore_RecordAuctionReminder_Call struct {
+	*mock.Call
+}
+
+// RecordAuctionReminder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - watchID string
+//   - listingID string
+func (_e *MockStore_Expecter) RecordAuctionReminder(ctx interface{}, watchID interface{}, listingID interface{}) *MockStore_RecordAuctionReminder_Call {
+	return &MockStore_RecordAuctionReminder_Call{Call: _e.mock.On("RecordAuctionReminder", ctx, watchID, listingID)}
+}
+
+func (_c *MockStore_RecordAuctionReminder_Call) Run(run func(ctx context.Context, watchID string, listingID string)) *MockStore_RecordAuctionReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_RecordAuctionReminder_Call) Return(_a0 error) *MockStore_RecordAuctionReminder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_RecordAuctionReminder_Call) RunAndReturn(run func(context.Context, string, string) error) *MockStore_RecordAuctionReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RecoverStaleJobRuns provides a mock function with given fields: ctx, olderThan
 func (_m *MockStore) RecoverStaleJobRuns(ctx context.Context, olderThan time.Duration) (int, error) {
 	ret := _m.Called(ctx, olderThan)
@@ -2504,6 +4014,63 @@ func (_c *MockStore_ReleaseSchedulerLock_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ResolveProductKey provides a mock function with given fields: ctx, productKey
+func (_m *MockStore) ResolveProductKey(ctx context.Context, productKey string) (string, error) {
+	ret := _m.Called(ctx, productKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveProductKey")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, productKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, productKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, productKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_ResolveProductKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveProductKey'
+type MockStore_ResolveProductKey_Call struct {
+	*mock.Call
+}
+
+// ResolveProductKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productKey string
+func (_e *MockStore_Expecter) ResolveProductKey(ctx interface{}, productKey interface{}) *MockStore_ResolveProductKey_Call {
+	return &MockStore_ResolveProductKey_Call{Call: _e.mock.On("ResolveProductKey", ctx, productKey)}
+}
+
+func (_c *MockStore_ResolveProductKey_Call) Run(run func(ctx context.Context, productKey string)) *MockStore_ResolveProductKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_ResolveProductKey_Call) Return(_a0 string, _a1 error) *MockStore_ResolveProductKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_ResolveProductKey_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockStore_ResolveProductKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RestoreAlerts provides a mock function with given fields: ctx, ids
 func (_m *MockStore) RestoreAlerts(ctx context.Context, ids []string) (int, []string, error) {
 	ret := _m.Called(ctx, ids)
@@ -2726,17 +4293,66 @@ func (_c *MockStore_UpdateListingExtraction_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// UpdateScore provides a mock function with given fields: ctx, id, score, breakdown
-func (_m *MockStore) UpdateScore(ctx context.Context, id string, score int, breakdown json.RawMessage) error {
-	ret := _m.Called(ctx, id, score, breakdown)
+// UpdatePartOut provides a mock function with given fields: ctx, id, value, ratio
+func (_m *MockStore) UpdatePartOut(ctx context.Context, id string, value *float64, ratio *float64) error {
+	ret := _m.Called(ctx, id, value, ratio)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePartOut")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *float64, *float64) error); ok {
+		r0 = rf(ctx, id, value, ratio)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdatePartOut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePartOut'
+type MockStore_UpdatePartOut_Call struct {
+	*mock.Call
+}
+
+// UpdatePartOut is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - value *float64
+//   - ratio *float64
+func (_e *MockStore_Expecter) UpdatePartOut(ctx interface{}, id interface{}, value interface{}, ratio interface{}) *MockStore_UpdatePartOut_Call {
+	return &MockStore_UpdatePartOut_Call{Call: _e.mock.On("UpdatePartOut", ctx, id, value, ratio)}
+}
+
+func (_c *MockStore_UpdatePartOut_Call) Run(run func(ctx context.Context, id string, value *float64, ratio *float64)) *MockStore_UpdatePartOut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*float64), args[3].(*float64))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdatePartOut_Call) Return(_a0 error) *MockStore_UpdatePartOut_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpdatePartOut_Call) RunAndReturn(run func(context.Context, string, *float64, *float64) error) *MockStore_UpdatePartOut_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateScore provides a mock function with given fields: ctx, id, score, breakdown, risk, riskSignals
+func (_m *MockStore) UpdateScore(ctx context.Context, id string, score int, breakdown json.RawMessage, risk int, riskSignals json.RawMessage) error {
+	ret := _m.Called(ctx, id, score, breakdown, risk, riskSignals)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateScore")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, json.RawMessage) error); ok {
-		r0 = rf(ctx, id, score, breakdown)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, json.RawMessage, int, json.RawMessage) error); ok {
+		r0 = rf(ctx, id, score, breakdown, risk, riskSignals)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2754,13 +4370,15 @@ type MockStore_UpdateScore_Call struct {
 //   - id string
 //   - score int
 //   - breakdown json.RawMessage
-func (_e *MockStore_Expecter) UpdateScore(ctx interface{}, id interface{}, score interface{}, breakdown interface{}) *MockStore_UpdateScore_Call {
-	return &MockStore_UpdateScore_Call{Call: _e.mock.On("UpdateScore", ctx, id, score, breakdown)}
+//   - risk int
+//   - riskSignals json.RawMessage
+func (_e *MockStore_Expecter) UpdateScore(ctx interface{}, id interface{}, score interface{}, breakdown interface{}, risk interface{}, riskSignals interface{}) *MockStore_UpdateScore_Call {
+	return &MockStore_UpdateScore_Call{Call: _e.mock.On("UpdateScore", ctx, id, score, breakdown, risk, riskSignals)}
 }
 
-func (_c *MockStore_UpdateScore_Call) Run(run func(ctx context.Context, id string, score int, breakdown json.RawMessage)) *MockStore_UpdateScore_Call {
+func (_c *MockStore_UpdateScore_Call) Run(run func(ctx context.Context, id string, score int, breakdown json.RawMessage, risk int, riskSignals json.RawMessage)) *MockStore_UpdateScore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(json.RawMessage))
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(json.RawMessage), args[4].(int), args[5].(json.RawMessage))
 	})
 	return _c
 }
@@ -2770,7 +4388,57 @@ func (_c *MockStore_UpdateScore_Call) Return(_a0 error) *MockStore_UpdateScore_C
 	return _c
 }
 
-func (_c *MockStore_UpdateScore_Call) RunAndReturn(run func(context.Context, string, int, json.RawMessage) error) *MockStore_UpdateScore_Call {
+func (_c *MockStore_UpdateScore_Call) RunAndReturn(run func(context.Context, string, int, json.RawMessage, int, json.RawMessage) error) *MockStore_UpdateScore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateValueMetric provides a mock function with given fields: ctx, id, valueClass, valueUnit, pricePerUnit
+func (_m *MockStore) UpdateValueMetric(ctx context.Context, id string, valueClass string, valueUnit string, pricePerUnit *float64) error {
+	ret := _m.Called(ctx, id, valueClass, valueUnit, pricePerUnit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateValueMetric")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *float64) error); ok {
+		r0 = rf(ctx, id, valueClass, valueUnit, pricePerUnit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_UpdateValueMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateValueMetric'
+type MockStore_UpdateValueMetric_Call struct {
+	*mock.Call
+}
+
+// UpdateValueMetric is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - valueClass string
+//   - valueUnit string
+//   - pricePerUnit *float64
+func (_e *MockStore_Expecter) UpdateValueMetric(ctx interface{}, id interface{}, valueClass interface{}, valueUnit interface{}, pricePerUnit interface{}) *MockStore_UpdateValueMetric_Call {
+	return &MockStore_UpdateValueMetric_Call{Call: _e.mock.On("UpdateValueMetric", ctx, id, valueClass, valueUnit, pricePerUnit)}
+}
+
+func (_c *MockStore_UpdateValueMetric_Call) Run(run func(ctx context.Context, id string, valueClass string, valueUnit string, pricePerUnit *float64)) *MockStore_UpdateValueMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*float64))
+	})
+	return _c
+}
+
+func (_c *MockStore_UpdateValueMetric_Call) Return(_a0 error) *MockStore_UpdateValueMetric_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_UpdateValueMetric_Call) RunAndReturn(run func(context.Context, string, string, string, *float64) error) *MockStore_UpdateValueMetric_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -69,17 +69,22 @@ func (s *PostgresStore) Migrate(ctx context.Context) error {
 	return RunMigrations(ctx, s.pool)
 }
 
-// UpsertListing inserts or updates a listing by ebay_item_id.
+// UpsertListing inserts or updates a listing by ebay_item_id, recording
+// a price history entry when the listing is new or its price changed.
 func (s *PostgresStore) UpsertListing(ctx context.Context, l *domain.Listing) error {
 	args := pgx.NamedArgs{
 		"ebay_item_id":          l.EbayID,
 		"title":                 l.Title,
 		"item_url":              l.ItemURL,
 		"image_url":             l.ImageURL,
+		"item_country":          l.ItemCountry,
 		"price":                 l.Price,
 		"currency":              l.Currency,
 		"shipping_cost":         l.ShippingCost,
+		"price_usd":             l.PriceUSD,
+		"shipping_cost_usd":     l.ShippingCostUSD,
 		"listing_type":          string(l.ListingType),
+		"bid_count":             l.BidCount,
 		"seller_name":           l.SellerName,
 		"seller_feedback_score": l.SellerFeedback,
 		"seller_feedback_pct":   l.SellerFeedbackPct,
@@ -88,6 +93,7 @@ func (s *PostgresStore) UpsertListing(ctx context.Context, l *domain.Listing) er
 		"condition_norm":        string(l.ConditionNorm),
 		"quantity":              l.Quantity,
 		"listed_at":             l.ListedAt,
+		"auction_end_at":        l.AuctionEndAt,
 	}
 
 	return s.pool.QueryRow(ctx, queryUpsertListing, args).Scan(
@@ -115,6 +121,52 @@ func (s *PostgresStore) GetListingByID(ctx context.Context, id string) (*domain.
 	return l, nil
 }
 
+// ListPriceHistory returns a listing's recorded prices, oldest first.
+func (s *PostgresStore) ListPriceHistory(
+	ctx context.Context,
+	listingID string,
+) ([]domain.PricePoint, error) {
+	rows, err := s.pool.Query(ctx, queryListPriceHistory, listingID)
+	if err != nil {
+		return nil, fmt.Errorf("querying price history: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.PricePoint
+	for rows.Next() {
+		var p domain.PricePoint
+		if err := scanPricePoint(rows, &p); err != nil {
+			return nil, fmt.Errorf("scanning price history: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// GetAlertedPrice returns the listing's price when the most recent
+// notified alert for the (watch, listing) pair went out, or nil when
+// there is no such alert or no history from before it.
+func (s *PostgresStore) GetAlertedPrice(
+	ctx context.Context,
+	watchID, listingID string,
+) (*domain.PricePoint, error) {
+	var p domain.PricePoint
+	err := scanPricePoint(s.pool.QueryRow(ctx, queryGetAlertedPrice, watchID, listingID), &p)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting alerted price: %w", err)
+	}
+	return &p, nil
+}
+
+func scanPricePoint(row pgx.Row, p *domain.PricePoint) error {
+	return row.Scan(
+		&p.Price, &p.ShippingCost, &p.Currency, &p.PriceUSD, &p.ShippingCostUSD, &p.RecordedAt,
+	)
+}
+
 // ListListings queries listings with optional filters, returning results and total count.
 func (s *PostgresStore) ListListings(
 	ctx context.Context,
@@ -150,7 +202,8 @@ func (s *PostgresStore) ListListings(
 	return listings, total, nil
 }
 
-// UpdateListingExtraction updates the extraction fields for a listing.
+// UpdateListingExtraction updates the extraction fields for a listing
+// unless an operator correction has locked them.
 func (s *PostgresStore) UpdateListingExtraction(
 	ctx context.Context,
 	id string,
@@ -173,20 +226,221 @@ func (s *PostgresStore) UpdateListingExtraction(
 	return nil
 }
 
+// CorrectListingExtraction applies an operator correction to the
+// listing, locks its extraction, and records the correction.
+func (s *PostgresStore) CorrectListingExtraction(ctx context.Context, c *domain.ExtractionCorrection) error {
+	attrsJSON, err := json.Marshal(c.Attributes)
+	if err != nil {
+		return fmt.Errorf("marshaling attributes: %w", err)
+	}
+	prevJSON, err := json.Marshal(c.PreviousAttributes)
+	if err != nil {
+		return fmt.Errorf("marshaling previous attributes: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning extraction correction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, queryCorrectListingExtraction,
+		c.ListingID, c.ComponentType, attrsJSON, c.ProductKey,
+	)
+	if err != nil {
+		return fmt.Errorf("correcting listing extraction: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	if err := tx.QueryRow(ctx, queryInsertExtractionCorrection,
+		c.ListingID, c.Title,
+		c.PreviousComponentType, prevJSON, c.PreviousProductKey,
+		c.ComponentType, attrsJSON, c.ProductKey, c.Note,
+	).Scan(&c.ID, &c.CreatedAt); err != nil {
+		return fmt.Errorf("inserting extraction correction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing extraction correction: %w", err)
+	}
+	return nil
+}
+
+// ListExtractionCorrections returns up to limit corrections, newest
+// first.
+func (s *PostgresStore) ListExtractionCorrections(ctx context.Context, limit int) ([]domain.ExtractionCorrection, error) {
+	rows, err := s.pool.Query(ctx, queryListExtractionCorrections, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying extraction corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.ExtractionCorrection
+	for rows.Next() {
+		var c domain.ExtractionCorrection
+		if err := rows.Scan(
+			&c.ID, &c.ListingID, &c.Title,
+			&c.PreviousComponentType, &c.PreviousAttributes, &c.PreviousProductKey,
+			&c.ComponentType, &c.Attributes, &c.ProductKey, &c.Note, &c.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning extraction correction: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// InsertShadowExtraction records a shadow extraction comparison.
+func (s *PostgresStore) InsertShadowExtraction(ctx context.Context, se *domain.ShadowExtraction) error {
+	if err := s.pool.QueryRow(ctx, queryInsertShadowExtraction,
+		se.Title, se.PrimaryBackend, se.PrimaryModel, se.ShadowBackend, se.ShadowModel, se.PromptVersion,
+		se.PrimaryComponentType, se.ShadowComponentType, se.PrimaryProductKey, se.ShadowProductKey,
+		se.PrimaryAttributes, se.ShadowAttributes, se.Disagreements, se.ShadowError,
+		se.PrimaryTokens, se.ShadowTokens, se.PrimaryCostUSD, se.ShadowCostUSD,
+		se.PrimaryLatencyMs, se.ShadowLatencyMs,
+	).Scan(&se.ID, &se.CreatedAt); err != nil {
+		return fmt.Errorf("inserting shadow extraction: %w", err)
+	}
+	return nil
+}
+
+// GetShadowReport aggregates shadow extraction comparisons.
+func (s *PostgresStore) GetShadowReport(
+	ctx context.Context,
+	q *ShadowReportQuery,
+) (*domain.ShadowReport, error) {
+	r := &domain.ShadowReport{Since: q.Since, ShadowModel: q.ShadowModel, PromptVersion: q.PromptVersion}
+	args := []any{q.Since, q.ShadowModel, q.PromptVersion}
+
+	if err := s.pool.QueryRow(ctx, queryShadowReportTotals, args...).Scan(
+		&r.Samples, &r.ShadowErrors, &r.ComponentTypeMatches, &r.ProductKeyMatches, &r.FullMatches,
+		&r.CostedSamples, &r.PrimaryCostUSD, &r.ShadowCostUSD, &r.PrimaryTokens, &r.ShadowTokens,
+		&r.AvgPrimaryLatencyMs, &r.AvgShadowLatencyMs,
+	); err != nil {
+		return nil, fmt.Errorf("querying shadow report totals: %w", err)
+	}
+
+	var err error
+	if r.Fields, err = s.shadowReportFields(ctx, args); err != nil {
+		return nil, err
+	}
+	if r.Components, err = s.shadowReportComponents(ctx, args); err != nil {
+		return nil, err
+	}
+	if r.Examples, err = s.shadowReportExamples(ctx, append(args, q.Examples)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// shadowReportFields counts disagreements per attribute.
+func (s *PostgresStore) shadowReportFields(ctx context.Context, args []any) ([]domain.ShadowFieldStat, error) {
+	rows, err := s.pool.Query(ctx, queryShadowReportFields, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying shadow report fields: %w", err)
+	}
+	defer rows.Close()
+
+	fields := []domain.ShadowFieldStat{}
+	for rows.Next() {
+		var f domain.ShadowFieldStat
+		if err := rows.Scan(&f.Field, &f.Disagreements); err != nil {
+			return nil, fmt.Errorf("scanning shadow report field: %w", err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, rows.Err()
+}
+
+// shadowReportComponents counts agreement per primary component type.
+func (s *PostgresStore) shadowReportComponents(ctx context.Context, args []any) ([]domain.ShadowComponentStat, error) {
+	rows, err := s.pool.Query(ctx, queryShadowReportComponents, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying shadow report components: %w", err)
+	}
+	defer rows.Close()
+
+	components := []domain.ShadowComponentStat{}
+	for rows.Next() {
+		var c domain.ShadowComponentStat
+		if err := rows.Scan(&c.ComponentType, &c.Compared, &c.ComponentTypeMatches, &c.ProductKeyMatches); err != nil {
+			return nil, fmt.Errorf("scanning shadow report component: %w", err)
+		}
+		components = append(components, c)
+	}
+	return components, rows.Err()
+}
+
+// shadowReportExamples returns the most recent disagreeing comparisons.
+func (s *PostgresStore) shadowReportExamples(ctx context.Context, args []any) ([]domain.ShadowExtraction, error) {
+	rows, err := s.pool.Query(ctx, queryShadowReportExamples, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying shadow report examples: %w", err)
+	}
+	defer rows.Close()
+
+	examples := []domain.ShadowExtraction{}
+	for rows.Next() {
+		var e domain.ShadowExtraction
+		if err := rows.Scan(
+			&e.ID, &e.Title, &e.PrimaryBackend, &e.PrimaryModel, &e.ShadowBackend, &e.ShadowModel, &e.PromptVersion,
+			&e.PrimaryComponentType, &e.ShadowComponentType, &e.PrimaryProductKey, &e.ShadowProductKey,
+			&e.PrimaryAttributes, &e.ShadowAttributes, &e.Disagreements, &e.ShadowError,
+			&e.PrimaryTokens, &e.ShadowTokens, &e.PrimaryCostUSD, &e.ShadowCostUSD,
+			&e.PrimaryLatencyMs, &e.ShadowLatencyMs, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning shadow report example: %w", err)
+		}
+		examples = append(examples, e)
+	}
+	return examples, rows.Err()
+}
+
 // UpdateScore updates the score and breakdown for a listing.
 func (s *PostgresStore) UpdateScore(
 	ctx context.Context,
 	id string,
 	score int,
 	breakdown json.RawMessage,
+	risk int,
+	riskSignals json.RawMessage,
 ) error {
-	_, err := s.pool.Exec(ctx, queryUpdateScore, id, score, breakdown)
+	_, err := s.pool.Exec(ctx, queryUpdateScore, id, score, breakdown, risk, riskSignals)
 	if err != nil {
 		return fmt.Errorf("updating score: %w", err)
 	}
 	return nil
 }
 
+// UpdateValueMetric stores a listing's value class, unit and price per
+// unit.
+func (s *PostgresStore) UpdateValueMetric(
+	ctx context.Context,
+	id, valueClass, valueUnit string,
+	pricePerUnit *float64,
+) error {
+	_, err := s.pool.Exec(ctx, queryUpdateValueMetric, id, valueClass, valueUnit, pricePerUnit)
+	if err != nil {
+		return fmt.Errorf("updating value metric: %w", err)
+	}
+	return nil
+}
+
+// UpdatePartOut stores a server's part-out value and ratio; nil
+// clears them.
+func (s *PostgresStore) UpdatePartOut(
+	ctx context.Context,
+	id string,
+	value, ratio *float64,
+) error {
+	_, err := s.pool.Exec(ctx, queryUpdatePartOut, id, value, ratio)
+	if err != nil {
+		return fmt.Errorf("updating part-out estimate: %w", err)
+	}
+	return nil
+}
+
 // ListUnextractedListings returns listings that haven't been classified yet.
 func (s *PostgresStore) ListUnextractedListings(
 	ctx context.Context,
@@ -203,22 +457,60 @@ func (s *PostgresStore) ListUnscoredListings(
 	return s.queryListings(ctx, queryListUnscoredListings, limit)
 }
 
-// CreateWatch inserts a new watch.
-func (s *PostgresStore) CreateWatch(ctx context.Context, w *domain.Watch) error {
+// watchArgs binds the writable watch columns for queryCreateWatch and
+// queryUpdateWatch.
+func watchArgs(w *domain.Watch) (pgx.NamedArgs, error) {
 	filtersJSON, err := json.Marshal(w.Filters)
 	if err != nil {
-		return fmt.Errorf("marshaling filters: %w", err)
+		return nil, fmt.Errorf("marshaling filters: %w", err)
+	}
+
+	return pgx.NamedArgs{
+		"id":                    w.ID,
+		"name":                  w.Name,
+		"search_query":          w.SearchQuery,
+		"category_id":           w.CategoryID,
+		"component_type":        string(w.ComponentType),
+		"filters":               filtersJSON,
+		"score_threshold":       w.ScoreThreshold,
+		"enabled":               w.Enabled,
+		"poll_interval_seconds": int(time.Duration(w.PollInterval) / time.Second),
+		"priority":              w.Priority,
+		"marketplace":           w.Marketplace,
+		"realert_drop_pct":      w.RealertDropPct,
+	}, nil
+}
+
+// scanWatch scans one row of the shared watch column list into w.
+// Errors from Scan are returned unwrapped so GetWatch callers can
+// still match pgx.ErrNoRows.
+func scanWatch(row pgx.Row, w *domain.Watch) error {
+	var (
+		filtersJSON  []byte
+		pollInterval int
+	)
+	if err := row.Scan(
+		&w.ID, &w.Name, &w.SearchQuery, &w.CategoryID, &w.ComponentType,
+		&filtersJSON, &w.ScoreThreshold, &w.Enabled, &pollInterval, &w.Priority,
+		&w.Marketplace, &w.RealertDropPct, &w.LastPolledAt, &w.CreatedAt, &w.UpdatedAt,
+	); err != nil {
+		return err
 	}
+	w.PollInterval = domain.Duration(time.Duration(pollInterval) * time.Second)
 
-	args := pgx.NamedArgs{
-		"name":            w.Name,
-		"search_query":    w.SearchQuery,
-		"category_id":     w.CategoryID,
-		"component_type":  string(w.ComponentType),
-		"filters":         filtersJSON,
-		"score_threshold": w.ScoreThreshold,
-		"enabled":         w.Enabled,
+	if err := json.Unmarshal(filtersJSON, &w.Filters); err != nil {
+		return fmt.Errorf("unmarshaling watch filters: %w", err)
 	}
+	return nil
+}
+
+// CreateWatch inserts a new watch.
+func (s *PostgresStore) CreateWatch(ctx context.Context, w *domain.Watch) error {
+	args, err := watchArgs(w)
+	if err != nil {
+		return err
+	}
+	delete(args, "id")
 
 	return s.pool.QueryRow(ctx, queryCreateWatch, args).Scan(
 		&w.ID, &w.CreatedAt, &w.UpdatedAt,
@@ -228,20 +520,9 @@ func (s *PostgresStore) CreateWatch(ctx context.Context, w *domain.Watch) error
 // GetWatch retrieves a watch by its ID.
 func (s *PostgresStore) GetWatch(ctx context.Context, id string) (*domain.Watch, error) {
 	w := &domain.Watch{}
-	var filtersJSON []byte
-
-	err := s.pool.QueryRow(ctx, queryGetWatch, id).Scan(
-		&w.ID, &w.Name, &w.SearchQuery, &w.CategoryID, &w.ComponentType,
-		&filtersJSON, &w.ScoreThreshold, &w.Enabled, &w.LastPolledAt, &w.CreatedAt, &w.UpdatedAt,
-	)
-	if err != nil {
+	if err := scanWatch(s.pool.QueryRow(ctx, queryGetWatch, id), w); err != nil {
 		return nil, err
 	}
-
-	if err := json.Unmarshal(filtersJSON, &w.Filters); err != nil {
-		return nil, fmt.Errorf("unmarshaling watch filters: %w", err)
-	}
-
 	return w, nil
 }
 
@@ -264,19 +545,10 @@ func (s *PostgresStore) ListWatches(
 	var watches []domain.Watch
 	for rows.Next() {
 		var w domain.Watch
-		var filtersJSON []byte
-
-		if err := rows.Scan(
-			&w.ID, &w.Name, &w.SearchQuery, &w.CategoryID, &w.ComponentType,
-			&filtersJSON, &w.ScoreThreshold, &w.Enabled, &w.LastPolledAt, &w.CreatedAt, &w.UpdatedAt,
-		); err != nil {
+		if err := scanWatch(rows, &w); err != nil {
 			return nil, fmt.Errorf("scanning watch: %w", err)
 		}
 
-		if err := json.Unmarshal(filtersJSON, &w.Filters); err != nil {
-			return nil, fmt.Errorf("unmarshaling watch filters: %w", err)
-		}
-
 		watches = append(watches, w)
 	}
 
@@ -285,20 +557,9 @@ func (s *PostgresStore) ListWatches(
 
 // UpdateWatch updates an existing watch.
 func (s *PostgresStore) UpdateWatch(ctx context.Context, w *domain.Watch) error {
-	filtersJSON, err := json.Marshal(w.Filters)
+	args, err := watchArgs(w)
 	if err != nil {
-		return fmt.Errorf("marshaling filters: %w", err)
-	}
-
-	args := pgx.NamedArgs{
-		"id":              w.ID,
-		"name":            w.Name,
-		"search_query":    w.SearchQuery,
-		"category_id":     w.CategoryID,
-		"component_type":  string(w.ComponentType),
-		"filters":         filtersJSON,
-		"score_threshold": w.ScoreThreshold,
-		"enabled":         w.Enabled,
+		return err
 	}
 
 	_, err = s.pool.Exec(ctx, queryUpdateWatch, args)
@@ -317,6 +578,134 @@ func (s *PostgresStore) DeleteWatch(ctx context.Context, id string) error {
 	return nil
 }
 
+// ApplyWatches writes a declarative watch plan in one transaction so a
+// failed apply never leaves the watch set half-converged.
+func (s *PostgresStore) ApplyWatches(ctx context.Context, set *WatchApplySet) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning watch apply: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, w := range set.Create {
+		args, err := watchArgs(w)
+		if err != nil {
+			return err
+		}
+		delete(args, "id")
+		if err := tx.QueryRow(ctx, queryCreateWatch, args).Scan(
+			&w.ID, &w.CreatedAt, &w.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("creating watch %q: %w", w.Name, err)
+		}
+	}
+
+	for _, w := range set.Update {
+		args, err := watchArgs(w)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, queryUpdateWatch, args); err != nil {
+			return fmt.Errorf("updating watch %q: %w", w.Name, err)
+		}
+	}
+
+	for _, id := range set.Delete {
+		if _, err := tx.Exec(ctx, queryDeleteWatch, id); err != nil {
+			return fmt.Errorf("deleting watch %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing watch apply: %w", err)
+	}
+	return nil
+}
+
+// InsertWatchPoll records one ingestion poll of a watch.
+func (s *PostgresStore) InsertWatchPoll(ctx context.Context, p *domain.WatchPoll) error {
+	if p.PolledAt.IsZero() {
+		p.PolledAt = time.Now()
+	}
+	err := s.pool.QueryRow(ctx, queryInsertWatchPoll,
+		p.WatchID, p.PolledAt, p.PagesUsed, p.TotalSeen, p.NewListings, p.StoppedAt, p.ErrorText,
+	).Scan(&p.ID)
+	if err != nil {
+		return fmt.Errorf("inserting watch poll: %w", err)
+	}
+	return nil
+}
+
+// GetWatchStats aggregates watch_polls, alerts and judge_scores for one
+// watch since the given time. BestDeal is nil when no alert fired.
+func (s *PostgresStore) GetWatchStats(
+	ctx context.Context,
+	watchID string,
+	since time.Time,
+) (*domain.WatchStats, error) {
+	st := &domain.WatchStats{WatchID: watchID, Since: since}
+
+	if err := s.pool.QueryRow(ctx, queryWatchPollStats, watchID, since).Scan(
+		&st.Polls, &st.PollErrors, &st.PagesUsed, &st.ListingsSeen, &st.ListingsIngested,
+	); err != nil {
+		return nil, fmt.Errorf("querying watch poll stats: %w", err)
+	}
+
+	if err := s.pool.QueryRow(ctx, queryWatchAlertStats, watchID, since).Scan(
+		&st.AlertsCreated, &st.AlertsNotified, &st.AlertsDismissed,
+		&st.AlertsJudged, &st.MedianJudgeScore,
+	); err != nil {
+		return nil, fmt.Errorf("querying watch alert stats: %w", err)
+	}
+
+	var (
+		d        domain.WatchStatsDeal
+		price    float64
+		shipping *float64
+		quantity int
+	)
+	err := s.pool.QueryRow(ctx, queryWatchBestDeal, watchID, since).Scan(
+		&d.AlertID, &d.ListingID, &d.Title, &d.ItemURL, &price, &shipping, &quantity,
+		&d.Score, &d.CreatedAt,
+	)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return nil, fmt.Errorf("querying watch best deal: %w", err)
+	default:
+		l := domain.Listing{Price: price, ShippingCost: shipping, Quantity: quantity}
+		d.UnitPrice = l.UnitPrice()
+		st.BestDeal = &d
+	}
+
+	return st, nil
+}
+
+// ListWatchYields sums successful watch_polls per watch since the given
+// time, for the ingestion budget planner.
+func (s *PostgresStore) ListWatchYields(
+	ctx context.Context,
+	since time.Time,
+) ([]domain.WatchYield, error) {
+	rows, err := s.pool.Query(ctx, queryListWatchYields, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing watch yields: %w", err)
+	}
+	defer rows.Close()
+
+	var yields []domain.WatchYield
+	for rows.Next() {
+		var y domain.WatchYield
+		if err := rows.Scan(
+			&y.WatchID, &y.Polls, &y.PagesUsed, &y.NewListings, &y.MaxPagesStops,
+		); err != nil {
+			return nil, fmt.Errorf("scanning watch yield: %w", err)
+		}
+		yields = append(yields, y)
+	}
+	return yields, rows.Err()
+}
+
 // SetWatchEnabled enables or disables a watch.
 func (s *PostgresStore) SetWatchEnabled(ctx context.Context, id string, enabled bool) error {
 	_, err := s.pool.Exec(ctx, querySetWatchEnabled, id, enabled)
@@ -343,9 +732,44 @@ func (s *PostgresStore) GetBaseline(
 	return b, nil
 }
 
+// GetValueBaseline retrieves the price-per-unit baseline of a value
+// class.
+func (s *PostgresStore) GetValueBaseline(
+	ctx context.Context,
+	valueClass string,
+) (*domain.ValueBaseline, error) {
+	b := &domain.ValueBaseline{}
+	err := s.pool.QueryRow(ctx, queryGetValueBaseline, valueClass).Scan(
+		&b.ValueClass, &b.ValueUnit, &b.SampleCount,
+		&b.P10, &b.P25, &b.P50, &b.P75, &b.P90, &b.Mean,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // ListBaselines returns all price baselines.
 func (s *PostgresStore) ListBaselines(ctx context.Context) ([]domain.PriceBaseline, error) {
-	rows, err := s.pool.Query(ctx, queryListBaselines)
+	return s.queryBaselines(ctx, queryListBaselines)
+}
+
+// ListBaselinesMatching returns the baselines whose product key
+// matches a SQL LIKE pattern.
+func (s *PostgresStore) ListBaselinesMatching(
+	ctx context.Context,
+	pattern string,
+) ([]domain.PriceBaseline, error) {
+	return s.queryBaselines(ctx, queryListBaselinesMatching, pattern)
+}
+
+func (s *PostgresStore) queryBaselines(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]domain.PriceBaseline, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("querying baselines: %w", err)
 	}
@@ -406,9 +830,97 @@ func (s *PostgresStore) RecomputeAllBaselines(ctx context.Context, windowDays in
 		}
 	}
 
+	if _, err := s.pool.Exec(ctx, queryRecomputeValueBaselines, windowDays); err != nil {
+		return fmt.Errorf("recomputing value baselines: %w", err)
+	}
+
 	return nil
 }
 
+// ResolveProductKey returns the canonical key for productKey, or
+// productKey itself when it isn't an alias.
+func (s *PostgresStore) ResolveProductKey(ctx context.Context, productKey string) (string, error) {
+	var canonical string
+	err := s.pool.QueryRow(ctx, queryResolveProductKey, productKey).Scan(&canonical)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return productKey, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolving product key: %w", err)
+	}
+	return canonical, nil
+}
+
+// MergeProductKeys makes from an alias of to and moves its listings.
+func (s *PostgresStore) MergeProductKeys(ctx context.Context, from, to string) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning product key merge: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, queryDeleteProductKeyAlias, to); err != nil {
+		return 0, fmt.Errorf("clearing alias of %s: %w", to, err)
+	}
+	if _, err := tx.Exec(ctx, queryRepointProductKeyAliases, from, to); err != nil {
+		return 0, fmt.Errorf("repointing aliases of %s: %w", from, err)
+	}
+	if _, err := tx.Exec(ctx, queryUpsertProductKeyAlias, from, to); err != nil {
+		return 0, fmt.Errorf("creating alias %s: %w", from, err)
+	}
+	tag, err := tx.Exec(ctx, queryMoveListingsProductKey, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("moving listings to %s: %w", to, err)
+	}
+	if _, err := tx.Exec(ctx, queryDeleteBaseline, from); err != nil {
+		return 0, fmt.Errorf("deleting baseline of %s: %w", from, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing product key merge: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ListProductKeyAliases returns every alias, grouped by canonical key.
+func (s *PostgresStore) ListProductKeyAliases(ctx context.Context) ([]domain.ProductKeyAlias, error) {
+	rows, err := s.pool.Query(ctx, queryListProductKeyAliases)
+	if err != nil {
+		return nil, fmt.Errorf("querying product key aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []domain.ProductKeyAlias
+	for rows.Next() {
+		var a domain.ProductKeyAlias
+		if err := rows.Scan(&a.Alias, &a.Canonical, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning product key alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// ListProductKeyCounts returns every product key in use with its
+// listing count.
+func (s *PostgresStore) ListProductKeyCounts(ctx context.Context) ([]domain.ProductKeyCount, error) {
+	rows, err := s.pool.Query(ctx, queryListProductKeyCounts)
+	if err != nil {
+		return nil, fmt.Errorf("querying product key counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []domain.ProductKeyCount
+	for rows.Next() {
+		var c domain.ProductKeyCount
+		if err := rows.Scan(&c.ProductKey, &c.Listings); err != nil {
+			return nil, fmt.Errorf("scanning product key count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
 // CreateAlert inserts a new alert, silently ignoring duplicates.
 //
 // The trace_id is derived from a.TraceID; nil or empty string both
@@ -479,6 +991,33 @@ func (s *PostgresStore) HasRecentAlert(
 	return exists, nil
 }
 
+// AssignListingGroup sets listing_group_id for the listing and returns it.
+func (s *PostgresStore) AssignListingGroup(
+	ctx context.Context,
+	listingID string,
+	priceTolerancePct float64,
+) (string, error) {
+	var groupID string
+	err := s.pool.QueryRow(ctx, queryAssignListingGroup, listingID, priceTolerancePct).Scan(&groupID)
+	if err != nil {
+		return "", fmt.Errorf("assigning listing group: %w", err)
+	}
+	return groupID, nil
+}
+
+// HasGroupAlert reports whether another listing in the listing's group
+// has a notified alert for the watch.
+func (s *PostgresStore) HasGroupAlert(
+	ctx context.Context,
+	watchID, listingID string,
+) (bool, error) {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, queryHasGroupAlert, watchID, listingID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking group alert: %w", err)
+	}
+	return exists, nil
+}
+
 // InsertNotificationAttempt records the outcome of a notification send attempt.
 func (s *PostgresStore) InsertNotificationAttempt(
 	ctx context.Context,
@@ -499,6 +1038,28 @@ func (s *PostgresStore) InsertNotificationAttempt(
 	return nil
 }
 
+// InsertSuppressedNotificationAttempt records a judge-gated alert's
+// attempt along with the reason it was held back. Summarized alerts
+// count as a success, suppressed ones as "suppressed".
+func (s *PostgresStore) InsertSuppressedNotificationAttempt(
+	ctx context.Context,
+	alertID string,
+	succeeded bool,
+	errText string,
+	reason string,
+) error {
+	_, err := s.pool.Exec(ctx, queryInsertSuppressedNotificationAttempt, alertID, succeeded, errText, reason)
+	if err != nil {
+		return fmt.Errorf("inserting suppressed notification attempt: %w", err)
+	}
+	result := "suppressed"
+	if succeeded {
+		result = "success"
+	}
+	metrics.NotificationAttemptsInsertedTotal.WithLabelValues(result).Inc()
+	return nil
+}
+
 // HasSuccessfulNotification returns true if at least one successful notification
 // attempt exists for the given alert.
 func (s *PostgresStore) HasSuccessfulNotification(ctx context.Context, alertID string) (bool, error) {
@@ -665,12 +1226,12 @@ func scanAlertWithListing(rows pgx.Rows) (domain.AlertWithListing, error) {
 	err := rows.Scan(
 		&a.ID, &a.WatchID, &a.ListingID, &a.Score,
 		&a.Notified, &a.NotifiedAt, &a.CreatedAt, &a.DismissedAt, &a.TraceID,
-		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL,
-		&l.Price, &l.Currency, &l.ShippingCost, &l.ListingType,
+		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL, &l.ItemCountry,
+		&l.Price, &l.Currency, &l.ShippingCost, &l.PriceUSD, &l.ShippingCostUSD, &l.ListingType, &l.BidCount,
 		&l.SellerName, &l.SellerFeedback, &l.SellerFeedbackPct, &l.SellerTopRated,
 		&l.ConditionRaw, &l.ConditionNorm, &l.ComponentType, &l.Quantity, &l.Attributes,
-		&l.ExtractionConfidence, &l.ProductKey, &l.Score, &l.ScoreBreakdown,
-		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.FirstSeenAt, &l.UpdatedAt,
+		&l.ExtractionConfidence, &l.ProductKey, &l.ListingGroupID, &l.Score, &l.ScoreBreakdown, &l.RiskScore, &l.RiskSignals, &l.PartOutValue, &l.PartOutRatio, &l.ValueClass, &l.ValueUnit, &l.PricePerUnit, &l.ExtractionLocked,
+		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.AuctionEndAt, &l.FirstSeenAt, &l.UpdatedAt,
 		&out.WatchName,
 	)
 	if err != nil {
@@ -720,14 +1281,64 @@ func (s *PostgresStore) GetAlertDetail(ctx context.Context, id string) (*domain.
 		return nil, err
 	}
 
+	prices, err := s.ListPriceHistory(ctx, row.Listing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seller, others, err := s.sellerPanel(ctx, &row.Listing)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.AlertDetail{
 		Alert:               row.Alert,
 		Listing:             row.Listing,
 		Watch:               *watch,
 		NotificationHistory: history,
+		PriceHistory:        prices,
+		Seller:              seller,
+		SellerListings:      others,
 	}, nil
 }
 
+// sellerPanel loads the listing's seller and up to 10 of the seller's
+// other active listings for the alert detail page. The seller is nil
+// when the listing has none or it predates the sellers table.
+func (s *PostgresStore) sellerPanel(
+	ctx context.Context,
+	l *domain.Listing,
+) (*domain.Seller, []domain.Listing, error) {
+	if l.SellerName == "" {
+		return nil, nil, nil
+	}
+	seller, err := s.GetSeller(ctx, l.SellerName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	const panelListings = 10
+	listings, _, err := s.ListListings(ctx, &ListingQuery{
+		SellerName: &l.SellerName,
+		ActiveOnly: true,
+		OrderBy:    orderByScore,
+		Limit:      panelListings + 1,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing seller listings: %w", err)
+	}
+	others := make([]domain.Listing, 0, len(listings))
+	for i := range listings {
+		if listings[i].ID != l.ID && len(others) < panelListings {
+			others = append(others, listings[i])
+		}
+	}
+	return seller, others, nil
+}
+
 func (s *PostgresStore) listNotificationAttempts(
 	ctx context.Context,
 	alertID string,
@@ -742,7 +1353,7 @@ func (s *PostgresStore) listNotificationAttempts(
 	for rows.Next() {
 		var a domain.NotificationAttempt
 		if err := rows.Scan(
-			&a.ID, &a.AlertID, &a.AttemptedAt, &a.Succeeded, &a.HTTPStatus, &a.ErrorText,
+			&a.ID, &a.AlertID, &a.AttemptedAt, &a.Succeeded, &a.HTTPStatus, &a.ErrorText, &a.SuppressionReason,
 		); err != nil {
 			return nil, fmt.Errorf("scanning notification attempt: %w", err)
 		}
@@ -890,6 +1501,20 @@ func (s *PostgresStore) SumJudgeCostSince(ctx context.Context, since time.Time)
 	return sum, nil
 }
 
+// GetJudgeBacklog counts the un-judged alerts inside the lookback
+// window and those that aged out of it in the preceding 24h.
+func (s *PostgresStore) GetJudgeBacklog(ctx context.Context, lookback time.Duration) (*domain.JudgeBacklog, error) {
+	defer observeQueryDuration("judge.backlog", time.Now())
+	cutoff := time.Now().Add(-lookback)
+	var b domain.JudgeBacklog
+	if err := s.pool.QueryRow(ctx, queryGetJudgeBacklog, cutoff, cutoff.Add(-24*time.Hour)).Scan(
+		&b.Pending, &b.OldestAt, &b.AgedOut,
+	); err != nil {
+		return nil, fmt.Errorf("getting judge backlog: %w", err)
+	}
+	return &b, nil
+}
+
 // GetJudgeScore returns the verdict for one alert, or nil + nil error
 // when no row exists. Used by the alert review UI to populate the
 // judge_score column on the detail page.
@@ -910,6 +1535,77 @@ func (s *PostgresStore) GetJudgeScore(ctx context.Context, alertID string) (*dom
 	return &sc, nil
 }
 
+// GetJudgeScores returns the verdicts for alertIDs in one query, keyed
+// by alert ID. Used by the alert judge gate, which checks the whole
+// pending pool every tick.
+func (s *PostgresStore) GetJudgeScores(
+	ctx context.Context,
+	alertIDs []string,
+) (map[string]*domain.JudgeScore, error) {
+	defer observeQueryDuration("judge.get_scores", time.Now())
+	rows, err := s.pool.Query(ctx, queryGetJudgeScores, alertIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching judge scores: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]*domain.JudgeScore, len(alertIDs))
+	for rows.Next() {
+		var sc domain.JudgeScore
+		if err := rows.Scan(
+			&sc.AlertID, &sc.Score, &sc.Reason, &sc.Model,
+			&sc.InputTokens, &sc.OutputTokens, &sc.CostUSD, &sc.JudgedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning judge score: %w", err)
+		}
+		out[sc.AlertID] = &sc
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating judge scores: %w", err)
+	}
+	return out, nil
+}
+
+// ListScoringLabels returns labelled alerts in the lookback window for
+// offline weight calibration. JudgeMinScore defaults to 0.5 when the
+// caller passes 0. Rows whose score_breakdown fails to decode are
+// skipped rather than failing the whole pull — the breakdown is
+// written by ScoreListing so a bad row means manual DB surgery.
+func (s *PostgresStore) ListScoringLabels(ctx context.Context, q *ScoringLabelsQuery) ([]domain.ScoringLabel, error) {
+	defer observeQueryDuration("scoring.list_labels", time.Now())
+
+	judgeMin := q.JudgeMinScore
+	if judgeMin <= 0 {
+		judgeMin = 0.5
+	}
+	since := time.Now().Add(-q.Lookback)
+
+	rows, err := s.pool.Query(ctx, queryListScoringLabels, since, judgeMin)
+	if err != nil {
+		return nil, fmt.Errorf("listing scoring labels: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.ScoringLabel
+	for rows.Next() {
+		var (
+			l             domain.ScoringLabel
+			breakdownJSON []byte
+		)
+		if err := rows.Scan(
+			&l.AlertID, &l.ComponentType, &breakdownJSON, &l.Score, &l.Threshold,
+			&l.Source, &l.Good,
+		); err != nil {
+			return nil, fmt.Errorf("scanning scoring label: %w", err)
+		}
+		if err := json.Unmarshal(breakdownJSON, &l.Breakdown); err != nil {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
 // GetSystemState queries the system_state view for a single-row snapshot.
 func (s *PostgresStore) GetSystemState(ctx context.Context) (*domain.SystemState, error) {
 	var st domain.SystemState
@@ -973,6 +1669,116 @@ func (s *PostgresStore) ListListingsCursor(ctx context.Context, afterID string,
 	return listings, rows.Err()
 }
 
+// GetSeller returns the named seller with its listing and alert counts
+// and feedback history, oldest first.
+func (s *PostgresStore) GetSeller(ctx context.Context, name string) (*domain.Seller, error) {
+	sel := &domain.Seller{}
+	err := s.pool.QueryRow(ctx, querySellerByName, name).Scan(
+		&sel.Name, &sel.FeedbackScore, &sel.FeedbackPct, &sel.TopRated,
+		&sel.FirstSeenAt, &sel.LastSeenAt,
+		&sel.ListingsTotal, &sel.ListingsActive, &sel.AlertsTotal, &sel.AlertsDismissed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("seller %s: %w", name, err)
+	}
+	if sel.ListingsTotal > 0 {
+		sel.AlertRate = float64(sel.AlertsTotal) / float64(sel.ListingsTotal)
+	}
+	if sel.AlertsTotal > 0 {
+		sel.DismissalRate = float64(sel.AlertsDismissed) / float64(sel.AlertsTotal)
+	}
+
+	rows, err := s.pool.Query(ctx, querySellerFeedbackHistory, name)
+	if err != nil {
+		return nil, fmt.Errorf("querying seller feedback history: %w", err)
+	}
+	defer rows.Close()
+
+	sel.FeedbackHistory = []domain.SellerFeedbackPoint{}
+	for rows.Next() {
+		var p domain.SellerFeedbackPoint
+		if err := rows.Scan(&p.FeedbackScore, &p.FeedbackPct, &p.RecordedAt); err != nil {
+			return nil, fmt.Errorf("scanning seller feedback point: %w", err)
+		}
+		sel.FeedbackHistory = append(sel.FeedbackHistory, p)
+	}
+	return sel, rows.Err()
+}
+
+// ListTrackedAuctions returns the active auctions ending before
+// endsBefore that are alerted or watched, soonest first.
+func (s *PostgresStore) ListTrackedAuctions(
+	ctx context.Context,
+	endsBefore time.Time,
+) ([]domain.Listing, error) {
+	rows, err := s.pool.Query(ctx, queryListTrackedAuctions, endsBefore)
+	if err != nil {
+		return nil, fmt.Errorf("listing tracked auctions: %w", err)
+	}
+	defer rows.Close()
+
+	var listings []domain.Listing
+	for rows.Next() {
+		var l domain.Listing
+		if err := scanListingRow(rows, &l); err != nil {
+			return nil, fmt.Errorf("scanning listing: %w", err)
+		}
+		listings = append(listings, l)
+	}
+	return listings, rows.Err()
+}
+
+// ListPriceCheckListings returns the alerted listings due a getItem
+// price check, least recently checked first.
+func (s *PostgresStore) ListPriceCheckListings(
+	ctx context.Context,
+	checkedBefore time.Time,
+	limit int,
+) ([]domain.Listing, error) {
+	rows, err := s.pool.Query(ctx, queryListPriceCheckListings, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing price check listings: %w", err)
+	}
+	defer rows.Close()
+
+	var listings []domain.Listing
+	for rows.Next() {
+		var l domain.Listing
+		if err := scanListingRow(rows, &l); err != nil {
+			return nil, fmt.Errorf("scanning listing: %w", err)
+		}
+		listings = append(listings, l)
+	}
+	return listings, rows.Err()
+}
+
+// MarkPriceChecked records that a listing's price was just re-read.
+func (s *PostgresStore) MarkPriceChecked(ctx context.Context, listingID string) error {
+	if _, err := s.pool.Exec(ctx, queryMarkPriceChecked, listingID); err != nil {
+		return fmt.Errorf("marking listing %s price checked: %w", listingID, err)
+	}
+	return nil
+}
+
+// HasAuctionReminder reports whether an ending-soon reminder was sent
+// for the (watch, listing) pair.
+func (s *PostgresStore) HasAuctionReminder(ctx context.Context, watchID, listingID string) (bool, error) {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, queryHasAuctionReminder, watchID, listingID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking auction reminder: %w", err)
+	}
+	return exists, nil
+}
+
+// RecordAuctionReminder records that an ending-soon reminder was sent
+// for the (watch, listing) pair. Recording twice is a no-op.
+func (s *PostgresStore) RecordAuctionReminder(ctx context.Context, watchID, listingID string) error {
+	if _, err := s.pool.Exec(ctx, queryRecordAuctionReminder, watchID, listingID); err != nil {
+		return fmt.Errorf("recording auction reminder: %w", err)
+	}
+	return nil
+}
+
 // ListIncompleteExtractions returns listings with incomplete extraction data.
 // If componentType is empty, returns all component types. Otherwise filters by type.
 func (s *PostgresStore) ListIncompleteExtractions(
@@ -1197,24 +2003,24 @@ type scannable interface {
 // scanListing scans a full listing row from a pgx.Row.
 func scanListing(row scannable, l *domain.Listing) error {
 	return row.Scan(
-		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL,
-		&l.Price, &l.Currency, &l.ShippingCost, &l.ListingType,
+		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL, &l.ItemCountry,
+		&l.Price, &l.Currency, &l.ShippingCost, &l.PriceUSD, &l.ShippingCostUSD, &l.ListingType, &l.BidCount,
 		&l.SellerName, &l.SellerFeedback, &l.SellerFeedbackPct, &l.SellerTopRated,
 		&l.ConditionRaw, &l.ConditionNorm, &l.ComponentType, &l.Quantity, &l.Attributes,
-		&l.ExtractionConfidence, &l.ProductKey, &l.Score, &l.ScoreBreakdown,
-		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.FirstSeenAt, &l.UpdatedAt,
+		&l.ExtractionConfidence, &l.ProductKey, &l.ListingGroupID, &l.Score, &l.ScoreBreakdown, &l.RiskScore, &l.RiskSignals, &l.PartOutValue, &l.PartOutRatio, &l.ValueClass, &l.ValueUnit, &l.PricePerUnit, &l.ExtractionLocked,
+		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.AuctionEndAt, &l.FirstSeenAt, &l.UpdatedAt,
 	)
 }
 
 // scanListingRow scans a listing from pgx.Rows (same fields).
 func scanListingRow(rows pgx.Rows, l *domain.Listing) error {
 	return rows.Scan(
-		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL,
-		&l.Price, &l.Currency, &l.ShippingCost, &l.ListingType,
+		&l.ID, &l.EbayID, &l.Title, &l.ItemURL, &l.ImageURL, &l.ItemCountry,
+		&l.Price, &l.Currency, &l.ShippingCost, &l.PriceUSD, &l.ShippingCostUSD, &l.ListingType, &l.BidCount,
 		&l.SellerName, &l.SellerFeedback, &l.SellerFeedbackPct, &l.SellerTopRated,
 		&l.ConditionRaw, &l.ConditionNorm, &l.ComponentType, &l.Quantity, &l.Attributes,
-		&l.ExtractionConfidence, &l.ProductKey, &l.Score, &l.ScoreBreakdown,
-		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.FirstSeenAt, &l.UpdatedAt,
+		&l.ExtractionConfidence, &l.ProductKey, &l.ListingGroupID, &l.Score, &l.ScoreBreakdown, &l.RiskScore, &l.RiskSignals, &l.PartOutValue, &l.PartOutRatio, &l.ValueClass, &l.ValueUnit, &l.PricePerUnit, &l.ExtractionLocked,
+		&l.Active, &l.ListedAt, &l.SoldAt, &l.SoldPrice, &l.AuctionEndAt, &l.FirstSeenAt, &l.UpdatedAt,
 	)
 }
 
@@ -312,9 +312,13 @@ func TestPostgresStore_WatchCRUD(t *testing.T) {
 	assert.Equal(t, domain.ComponentRAM, got.ComponentType)
 	assert.True(t, got.Enabled)
 
+	assert.Zero(t, got.PollInterval)
+
 	// Update.
 	got.ScoreThreshold = 80
 	got.Name = "DDR4 ECC 32GB (updated)"
+	got.PollInterval = domain.Duration(5 * time.Minute)
+	got.Priority = 10
 	err = s.UpdateWatch(ctx, got)
 	require.NoError(t, err)
 
@@ -322,6 +326,8 @@ func TestPostgresStore_WatchCRUD(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 80, updated.ScoreThreshold)
 	assert.Equal(t, "DDR4 ECC 32GB (updated)", updated.Name)
+	assert.Equal(t, domain.Duration(5*time.Minute), updated.PollInterval)
+	assert.Equal(t, 10, updated.Priority)
 
 	// List all.
 	watches, err := s.ListWatches(ctx, false)
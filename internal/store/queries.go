@@ -5,55 +5,110 @@ package store
 
 // Listing queries.
 const (
+	// queryUpsertListing appends a listing_price_history row when the
+	// listing is new or its price, shipping or currency changed. prev
+	// reads the row as it was before this statement. It also upserts the
+	// seller and appends a seller_feedback_history row when the seller
+	// is new or its feedback changed; prev_seller plays prev's part.
 	queryUpsertListing = `
-		INSERT INTO listings (
-			ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
-			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
-			condition_raw, condition_norm,
-			quantity, listed_at, first_seen_at, updated_at
-		) VALUES (
-			@ebay_item_id, @title, @item_url, @image_url,
-			@price, @currency, @shipping_cost, @listing_type,
-			@seller_name, @seller_feedback_score, @seller_feedback_pct, @seller_top_rated,
-			@condition_raw, @condition_norm,
-			@quantity, @listed_at, now(), now()
+		WITH prev AS (
+			SELECT price, shipping_cost, currency
+			FROM listings
+			WHERE ebay_item_id = @ebay_item_id
+		), upserted AS (
+			INSERT INTO listings (
+				ebay_item_id, title, item_url, image_url, item_country,
+				price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
+				seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
+				condition_raw, condition_norm,
+				quantity, listed_at, auction_end_at, first_seen_at, updated_at
+			) VALUES (
+				@ebay_item_id, @title, @item_url, @image_url, @item_country,
+				@price, @currency, @shipping_cost, @price_usd, @shipping_cost_usd, @listing_type, @bid_count,
+				@seller_name, @seller_feedback_score, @seller_feedback_pct, @seller_top_rated,
+				@condition_raw, @condition_norm,
+				@quantity, @listed_at, @auction_end_at, now(), now()
+			)
+			ON CONFLICT (ebay_item_id) DO UPDATE SET
+				title = EXCLUDED.title,
+				item_country = EXCLUDED.item_country,
+				price = EXCLUDED.price,
+				currency = EXCLUDED.currency,
+				shipping_cost = EXCLUDED.shipping_cost,
+				price_usd = EXCLUDED.price_usd,
+				shipping_cost_usd = EXCLUDED.shipping_cost_usd,
+				listing_type = EXCLUDED.listing_type,
+				bid_count = EXCLUDED.bid_count,
+				seller_name = EXCLUDED.seller_name,
+				seller_feedback_score = EXCLUDED.seller_feedback_score,
+				seller_feedback_pct = EXCLUDED.seller_feedback_pct,
+				seller_top_rated = EXCLUDED.seller_top_rated,
+				condition_raw = EXCLUDED.condition_raw,
+				condition_norm = EXCLUDED.condition_norm,
+				quantity = EXCLUDED.quantity,
+				listed_at = EXCLUDED.listed_at,
+				auction_end_at = EXCLUDED.auction_end_at,
+				active = true,
+				updated_at = now()
+			RETURNING id, first_seen_at, updated_at,
+				price, shipping_cost, currency, price_usd, shipping_cost_usd
+		), history AS (
+			INSERT INTO listing_price_history (
+				listing_id, price, shipping_cost, currency, price_usd, shipping_cost_usd
+			)
+			SELECT u.id, u.price, u.shipping_cost, u.currency, u.price_usd, u.shipping_cost_usd
+			FROM upserted u
+			WHERE NOT EXISTS (
+				SELECT 1 FROM prev p
+				WHERE p.price = u.price
+				  AND p.shipping_cost IS NOT DISTINCT FROM u.shipping_cost
+				  AND p.currency = u.currency
+			)
+		), prev_seller AS (
+			SELECT feedback_score, feedback_pct
+			FROM sellers
+			WHERE name = @seller_name
+		), seller AS (
+			INSERT INTO sellers (
+				name, feedback_score, feedback_pct, top_rated, first_seen_at, last_seen_at
+			)
+			SELECT @seller_name, @seller_feedback_score, @seller_feedback_pct, @seller_top_rated, now(), now()
+			WHERE @seller_name <> ''
+			ON CONFLICT (name) DO UPDATE SET
+				feedback_score = EXCLUDED.feedback_score,
+				feedback_pct = EXCLUDED.feedback_pct,
+				top_rated = EXCLUDED.top_rated,
+				last_seen_at = now()
+			RETURNING name, feedback_score, feedback_pct
+		), seller_history AS (
+			INSERT INTO seller_feedback_history (seller_name, feedback_score, feedback_pct)
+			SELECT s.name, s.feedback_score, s.feedback_pct
+			FROM seller s
+			WHERE NOT EXISTS (
+				SELECT 1 FROM prev_seller p
+				WHERE p.feedback_score = s.feedback_score
+				  AND p.feedback_pct = s.feedback_pct
+			)
 		)
-		ON CONFLICT (ebay_item_id) DO UPDATE SET
-			title = EXCLUDED.title,
-			price = EXCLUDED.price,
-			currency = EXCLUDED.currency,
-			shipping_cost = EXCLUDED.shipping_cost,
-			listing_type = EXCLUDED.listing_type,
-			seller_name = EXCLUDED.seller_name,
-			seller_feedback_score = EXCLUDED.seller_feedback_score,
-			seller_feedback_pct = EXCLUDED.seller_feedback_pct,
-			seller_top_rated = EXCLUDED.seller_top_rated,
-			condition_raw = EXCLUDED.condition_raw,
-			condition_norm = EXCLUDED.condition_norm,
-			quantity = EXCLUDED.quantity,
-			listed_at = EXCLUDED.listed_at,
-			active = true,
-			updated_at = now()
-		RETURNING id, first_seen_at, updated_at`
+		SELECT id, first_seen_at, updated_at FROM upserted`
 
 	queryGetListingByEbayID = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
 		WHERE ebay_item_id = $1`
 
 	queryGetListingByID = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
 		WHERE id = $1`
 
@@ -64,80 +119,291 @@ const (
 			extraction_confidence = $4,
 			product_key = $5,
 			updated_at = now()
+		WHERE id = $1 AND NOT extraction_locked`
+
+	queryCorrectListingExtraction = `
+		UPDATE listings SET
+			component_type = $2,
+			attributes = $3,
+			extraction_confidence = 1.0,
+			product_key = $4,
+			extraction_locked = true,
+			updated_at = now()
 		WHERE id = $1`
 
 	queryUpdateScore = `
 		UPDATE listings SET
 			score = $2,
 			score_breakdown = $3,
+			risk_score = $4,
+			risk_signals = $5,
+			updated_at = now()
+		WHERE id = $1`
+
+	queryUpdateValueMetric = `
+		UPDATE listings SET
+			value_class = $2,
+			value_unit = $3,
+			price_per_unit = $4,
+			updated_at = now()
+		WHERE id = $1`
+
+	queryUpdatePartOut = `
+		UPDATE listings SET
+			partout_value = $2,
+			partout_ratio = $3,
 			updated_at = now()
 		WHERE id = $1`
 
 	queryListUnextractedListings = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
 		WHERE active = true AND component_type IS NULL
 		ORDER BY first_seen_at DESC
 		LIMIT $1`
 
 	queryListUnscoredListings = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
 		WHERE active = true AND component_type IS NOT NULL AND score IS NULL
 		ORDER BY first_seen_at DESC
 		LIMIT $1`
 
 	queryListListingsCursor = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity,
 			COALESCE(attributes, '{}'), COALESCE(extraction_confidence, 0), COALESCE(product_key, ''),
-			score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
 		WHERE active = true AND id > $1
 		ORDER BY id ASC
 		LIMIT $2`
 )
 
+// Listing price history queries.
+const (
+	queryListPriceHistory = `
+		SELECT price, shipping_cost, currency, price_usd, shipping_cost_usd, recorded_at
+		FROM listing_price_history
+		WHERE listing_id = $1
+		ORDER BY recorded_at ASC`
+
+	// queryGetAlertedPrice returns the alerted listing's price as of the
+	// most recent notified alert for the watch on the listing or any
+	// other listing in its group: the last history row recorded at or
+	// before notification.
+	queryGetAlertedPrice = `
+		SELECT h.price, h.shipping_cost, h.currency, h.price_usd, h.shipping_cost_usd, h.recorded_at
+		FROM (
+			SELECT a.listing_id, a.notified_at
+			FROM alerts a
+			JOIN listings l ON l.id = a.listing_id
+			WHERE a.watch_id = $1
+			  AND (a.listing_id = $2
+			    OR l.listing_group_id = (SELECT listing_group_id FROM listings WHERE id = $2))
+			  AND a.notified = true
+			ORDER BY a.notified_at DESC
+			LIMIT 1
+		) a
+		JOIN listing_price_history h
+		  ON h.listing_id = a.listing_id
+		 AND h.recorded_at <= a.notified_at
+		ORDER BY h.recorded_at DESC
+		LIMIT 1`
+)
+
+// Listing group queries.
+const (
+	// queryAssignListingGroup puts $1 in the group of the earliest other
+	// listing from the same seller with the same product key and
+	// normalized title, priced within $2 percent in the same currency.
+	// With no match the listing starts its own group.
+	queryAssignListingGroup = `
+		WITH me AS (
+			SELECT id, seller_name, product_key, title_norm, currency, first_seen_at,
+				price + COALESCE(shipping_cost, 0) AS total
+			FROM listings
+			WHERE id = $1
+		), earlier AS (
+			SELECT COALESCE(o.listing_group_id, o.id) AS group_id
+			FROM listings o, me
+			WHERE o.id <> me.id
+			  AND me.seller_name <> '' AND o.seller_name = me.seller_name
+			  AND me.product_key <> '' AND o.product_key = me.product_key
+			  AND o.title_norm = me.title_norm
+			  AND o.currency = me.currency
+			  AND o.first_seen_at <= me.first_seen_at
+			  AND abs(o.price + COALESCE(o.shipping_cost, 0) - me.total) <= me.total * $2::numeric / 100
+			ORDER BY o.first_seen_at ASC
+			LIMIT 1
+		)
+		UPDATE listings l
+		SET listing_group_id = COALESCE((SELECT group_id FROM earlier), l.id)
+		WHERE l.id = $1
+		RETURNING listing_group_id::text`
+
+	// queryHasGroupAlert reports whether another listing in $2's group
+	// has a notified alert for watch $1.
+	queryHasGroupAlert = `
+		SELECT EXISTS (
+			SELECT 1 FROM alerts a
+			JOIN listings l ON l.id = a.listing_id
+			WHERE a.watch_id = $1
+			  AND a.listing_id <> $2
+			  AND a.notified = true
+			  AND l.listing_group_id = (SELECT listing_group_id FROM listings WHERE id = $2)
+		)`
+)
+
+// Seller queries.
+const (
+	querySellerByName = `
+		SELECT s.name, s.feedback_score, s.feedback_pct, s.top_rated,
+			s.first_seen_at, s.last_seen_at,
+			l.total, l.active, a.total, a.dismissed
+		FROM sellers s
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE active) AS active
+			FROM listings
+			WHERE seller_name = s.name
+		) l
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE al.dismissed_at IS NOT NULL) AS dismissed
+			FROM alerts al
+			JOIN listings li ON li.id = al.listing_id
+			WHERE li.seller_name = s.name
+		) a
+		WHERE s.name = $1`
+
+	querySellerFeedbackHistory = `
+		SELECT feedback_score, feedback_pct, recorded_at
+		FROM seller_feedback_history
+		WHERE seller_name = $1
+		ORDER BY recorded_at ASC`
+)
+
+// Auction tracking queries.
+const (
+	// queryListTrackedAuctions selects active auctions ending before $1
+	// that an undismissed alert points at, or that score at or above an
+	// enabled watch's threshold for their component type.
+	queryListTrackedAuctions = `
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
+			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
+			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
+		FROM listings l
+		WHERE l.active = true
+		  AND l.listing_type = 'auction'
+		  AND l.auction_end_at > now()
+		  AND l.auction_end_at <= $1
+		  AND (
+			EXISTS (
+				SELECT 1 FROM alerts a
+				WHERE a.listing_id = l.id AND a.dismissed_at IS NULL
+			)
+			OR EXISTS (
+				SELECT 1 FROM watches w
+				WHERE w.enabled = true
+				  AND w.component_type = l.component_type
+				  AND l.score >= w.score_threshold
+			)
+		  )
+		ORDER BY l.auction_end_at ASC`
+
+	queryHasAuctionReminder = `
+		SELECT EXISTS (
+			SELECT 1 FROM auction_reminders
+			WHERE watch_id = $1 AND listing_id = $2
+		)`
+
+	queryRecordAuctionReminder = `
+		INSERT INTO auction_reminders (watch_id, listing_id, sent_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (watch_id, listing_id) DO NOTHING`
+)
+
+// Price check queries.
+const (
+	// queryListPriceCheckListings selects up to $2 active fixed-price
+	// listings that an undismissed alert from an enabled watch with a
+	// realert_drop_pct points at, whose price wasn't checked (or, never
+	// checked, first seen) since $1, least recently checked first.
+	queryListPriceCheckListings = `
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
+			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
+			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
+		FROM listings l
+		WHERE l.active = true
+		  AND l.listing_type <> 'auction'
+		  AND COALESCE(l.price_checked_at, l.first_seen_at) < $1
+		  AND EXISTS (
+			SELECT 1 FROM alerts a
+			JOIN watches w ON w.id = a.watch_id
+			WHERE a.listing_id = l.id
+			  AND a.dismissed_at IS NULL
+			  AND w.enabled = true
+			  AND w.realert_drop_pct > 0
+		  )
+		ORDER BY COALESCE(l.price_checked_at, l.first_seen_at) ASC
+		LIMIT $2`
+
+	// price_checked_at only; updated_at is left alone so a check that
+	// found nothing new doesn't count as a sighting.
+	queryMarkPriceChecked = `
+		UPDATE listings SET price_checked_at = now() WHERE id = $1`
+)
+
 // Watch queries.
 const (
 	queryCreateWatch = `
 		INSERT INTO watches (
 			name, search_query, category_id, component_type,
-			filters, score_threshold, enabled, created_at, updated_at
+			filters, score_threshold, enabled, poll_interval_seconds, priority,
+			marketplace, realert_drop_pct, created_at, updated_at
 		) VALUES (
 			@name, @search_query, @category_id, @component_type,
-			@filters, @score_threshold, @enabled, now(), now()
+			@filters, @score_threshold, @enabled, @poll_interval_seconds, @priority,
+			@marketplace, @realert_drop_pct, now(), now()
 		)
 		RETURNING id, created_at, updated_at`
 
 	queryGetWatch = `
 		SELECT id, name, search_query, category_id, component_type,
-			filters, score_threshold, enabled, last_polled_at, created_at, updated_at
+			filters, score_threshold, enabled, poll_interval_seconds, priority,
+			marketplace, realert_drop_pct, last_polled_at, created_at, updated_at
 		FROM watches
 		WHERE id = $1`
 
 	queryListWatchesAll = `
 		SELECT id, name, search_query, category_id, component_type,
-			filters, score_threshold, enabled, last_polled_at, created_at, updated_at
+			filters, score_threshold, enabled, poll_interval_seconds, priority,
+			marketplace, realert_drop_pct, last_polled_at, created_at, updated_at
 		FROM watches
 		ORDER BY created_at DESC`
 
 	queryListWatchesEnabled = `
 		SELECT id, name, search_query, category_id, component_type,
-			filters, score_threshold, enabled, last_polled_at, created_at, updated_at
+			filters, score_threshold, enabled, poll_interval_seconds, priority,
+			marketplace, realert_drop_pct, last_polled_at, created_at, updated_at
 		FROM watches
 		WHERE enabled = true
 		ORDER BY created_at DESC`
@@ -151,6 +417,10 @@ const (
 			filters = @filters,
 			score_threshold = @score_threshold,
 			enabled = @enabled,
+			poll_interval_seconds = @poll_interval_seconds,
+			priority = @priority,
+			marketplace = @marketplace,
+			realert_drop_pct = @realert_drop_pct,
 			updated_at = now()
 		WHERE id = @id`
 
@@ -163,6 +433,56 @@ const (
 		WHERE id = $1`
 )
 
+// Watch poll and stats queries.
+const (
+	queryInsertWatchPoll = `
+		INSERT INTO watch_polls (
+			watch_id, polled_at, pages_used, total_seen, new_listings, stopped_at, error_text
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	queryWatchPollStats = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE error_text IS NOT NULL),
+			COALESCE(SUM(pages_used), 0),
+			COALESCE(SUM(total_seen), 0),
+			COALESCE(SUM(new_listings), 0)
+		FROM watch_polls
+		WHERE watch_id = $1 AND polled_at >= $2`
+
+	queryListWatchYields = `
+		SELECT
+			watch_id,
+			COUNT(*),
+			COALESCE(SUM(pages_used), 0),
+			COALESCE(SUM(new_listings), 0),
+			COUNT(*) FILTER (WHERE stopped_at = 'max_pages')
+		FROM watch_polls
+		WHERE polled_at >= $1 AND error_text IS NULL
+		GROUP BY watch_id`
+
+	queryWatchAlertStats = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE a.notified),
+			COUNT(*) FILTER (WHERE a.dismissed_at IS NOT NULL),
+			COUNT(js.alert_id),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY js.score)
+		FROM alerts a
+		LEFT JOIN judge_scores js ON js.alert_id = a.id
+		WHERE a.watch_id = $1 AND a.created_at >= $2`
+
+	queryWatchBestDeal = `
+		SELECT a.id, l.id, l.title, l.item_url, l.price, l.shipping_cost, l.quantity,
+			a.score, a.created_at
+		FROM alerts a
+		JOIN listings l ON l.id = a.listing_id
+		WHERE a.watch_id = $1 AND a.created_at >= $2
+		ORDER BY a.score DESC, a.created_at DESC
+		LIMIT 1`
+)
+
 // Baseline queries.
 const (
 	queryGetBaseline = `
@@ -175,25 +495,81 @@ const (
 		FROM price_baselines
 		ORDER BY product_key`
 
+	queryListBaselinesMatching = `
+		SELECT id, product_key, sample_count, p10, p25, p50, p75, p90, mean, updated_at
+		FROM price_baselines
+		WHERE product_key LIKE $1
+		ORDER BY product_key`
+
 	queryRecomputeBaseline = `SELECT recompute_baseline($1, $2)`
 
+	queryGetValueBaseline = `
+		SELECT value_class, value_unit, sample_count, p10, p25, p50, p75, p90, mean, updated_at
+		FROM value_baselines
+		WHERE value_class = $1`
+
+	queryRecomputeValueBaselines = `SELECT recompute_value_baselines($1)`
+
+	// Listings under an alias not yet backfilled count toward their
+	// canonical key (see recompute_baseline).
 	queryListDistinctProductKeys = `
-		SELECT DISTINCT product_key
+		SELECT DISTINCT COALESCE(a.canonical, l.product_key)
+		FROM listings l
+		LEFT JOIN product_key_aliases a ON a.alias = l.product_key
+		WHERE l.active = true AND l.product_key IS NOT NULL AND l.product_key != ''`
+)
+
+// Product key alias queries.
+const (
+	queryResolveProductKey = `
+		SELECT canonical FROM product_key_aliases WHERE alias = $1`
+
+	queryUpsertProductKeyAlias = `
+		INSERT INTO product_key_aliases (alias, canonical)
+		VALUES ($1, $2)
+		ON CONFLICT (alias) DO UPDATE SET canonical = EXCLUDED.canonical`
+
+	// Clears the target's own alias: merging b into a after a into b
+	// would otherwise leave a cycle.
+	queryDeleteProductKeyAlias = `
+		DELETE FROM product_key_aliases WHERE alias = $1`
+
+	queryRepointProductKeyAliases = `
+		UPDATE product_key_aliases SET canonical = $2 WHERE canonical = $1`
+
+	// updated_at is left alone so moved listings keep their place in
+	// (or out of) the baseline window.
+	queryMoveListingsProductKey = `
+		UPDATE listings SET product_key = $2
+		WHERE product_key = $1`
+
+	queryDeleteBaseline = `
+		DELETE FROM price_baselines WHERE product_key = $1`
+
+	queryListProductKeyAliases = `
+		SELECT alias, canonical, created_at
+		FROM product_key_aliases
+		ORDER BY canonical, alias`
+
+	queryListProductKeyCounts = `
+		SELECT product_key, count(*)
 		FROM listings
-		WHERE active = true AND product_key IS NOT NULL AND product_key != ''`
+		WHERE product_key IS NOT NULL AND product_key != ''
+		GROUP BY product_key
+		ORDER BY product_key`
 )
 
 // Extraction quality queries.
 const (
 	queryListIncompleteExtractions = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
-		WHERE active = true AND component_type IS NOT NULL AND (
+		WHERE active = true AND component_type IS NOT NULL AND NOT extraction_locked AND (
 			(component_type = 'ram' AND (product_key LIKE '%:0' OR (attributes->>'speed_mhz') IS NULL))
 			OR (component_type = 'drive' AND (product_key LIKE '%:unknown%'))
 		)
@@ -201,14 +577,14 @@ const (
 		LIMIT $1`
 
 	queryListIncompleteExtractionsForType = `
-		SELECT id, ebay_item_id, title, item_url, image_url,
-			price, currency, shipping_cost, listing_type,
+		SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+			price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 			seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 			condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-			active, listed_at, sold_at, sold_price, first_seen_at, updated_at
+			COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+			active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 		FROM listings
-		WHERE active = true AND component_type = $1 AND (
+		WHERE active = true AND component_type = $1 AND NOT extraction_locked AND (
 			(component_type = 'ram' AND (product_key LIKE '%:0' OR (attributes->>'speed_mhz') IS NULL))
 			OR (component_type = 'drive' AND (product_key LIKE '%:unknown%'))
 		)
@@ -216,6 +592,97 @@ const (
 		LIMIT $2`
 )
 
+// Extraction correction queries.
+const (
+	queryInsertExtractionCorrection = `
+		INSERT INTO extraction_corrections (
+			listing_id, title,
+			previous_component_type, previous_attributes, previous_product_key,
+			component_type, attributes, product_key, note
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at`
+
+	queryListExtractionCorrections = `
+		SELECT id, listing_id, title,
+			previous_component_type, previous_attributes, previous_product_key,
+			component_type, attributes, product_key, note, created_at
+		FROM extraction_corrections
+		ORDER BY created_at DESC
+		LIMIT $1`
+)
+
+// Shadow extraction queries. The report queries share the filter
+// created_at >= $1, optional shadow_model ($2) and prompt_version ($3).
+const (
+	queryInsertShadowExtraction = `
+		INSERT INTO shadow_extractions (
+			title, primary_backend, primary_model, shadow_backend, shadow_model, prompt_version,
+			primary_component_type, shadow_component_type, primary_product_key, shadow_product_key,
+			primary_attributes, shadow_attributes, disagreements, shadow_error,
+			primary_tokens, shadow_tokens, primary_cost_usd, shadow_cost_usd,
+			primary_latency_ms, shadow_latency_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING id, created_at`
+
+	shadowReportFilter = `
+		created_at >= $1
+		AND ($2 = '' OR shadow_model = $2)
+		AND ($3 = '' OR prompt_version = $3)`
+
+	queryShadowReportTotals = `
+		SELECT
+			count(*),
+			count(*) FILTER (WHERE shadow_error <> ''),
+			count(*) FILTER (WHERE shadow_error = '' AND shadow_component_type = primary_component_type),
+			count(*) FILTER (WHERE shadow_error = '' AND shadow_product_key = primary_product_key),
+			count(*) FILTER (WHERE shadow_error = ''
+				AND shadow_component_type = primary_component_type
+				AND shadow_product_key = primary_product_key
+				AND disagreements = '[]'::jsonb),
+			count(*) FILTER (WHERE primary_cost_usd IS NOT NULL AND shadow_cost_usd IS NOT NULL),
+			COALESCE(sum(primary_cost_usd) FILTER (WHERE primary_cost_usd IS NOT NULL AND shadow_cost_usd IS NOT NULL), 0),
+			COALESCE(sum(shadow_cost_usd) FILTER (WHERE primary_cost_usd IS NOT NULL AND shadow_cost_usd IS NOT NULL), 0),
+			COALESCE(sum(primary_tokens), 0),
+			COALESCE(sum(shadow_tokens), 0),
+			COALESCE(avg(primary_latency_ms), 0),
+			COALESCE(avg(shadow_latency_ms) FILTER (WHERE shadow_error = ''), 0)
+		FROM shadow_extractions
+		WHERE` + shadowReportFilter
+
+	queryShadowReportFields = `
+		SELECT d->>'field', count(*)
+		FROM shadow_extractions
+		CROSS JOIN LATERAL jsonb_array_elements(disagreements) AS d
+		WHERE` + shadowReportFilter + `
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`
+
+	queryShadowReportComponents = `
+		SELECT primary_component_type,
+			count(*),
+			count(*) FILTER (WHERE shadow_component_type = primary_component_type),
+			count(*) FILTER (WHERE shadow_product_key = primary_product_key)
+		FROM shadow_extractions
+		WHERE shadow_error = '' AND` + shadowReportFilter + `
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`
+
+	queryShadowReportExamples = `
+		SELECT id, title, primary_backend, primary_model, shadow_backend, shadow_model, prompt_version,
+			primary_component_type, shadow_component_type, primary_product_key, shadow_product_key,
+			primary_attributes, shadow_attributes, disagreements, shadow_error,
+			primary_tokens, shadow_tokens, primary_cost_usd, shadow_cost_usd,
+			primary_latency_ms, shadow_latency_ms, created_at
+		FROM shadow_extractions
+		WHERE shadow_error = ''
+			AND (shadow_component_type <> primary_component_type
+				OR shadow_product_key <> primary_product_key
+				OR disagreements <> '[]'::jsonb)
+			AND` + shadowReportFilter + `
+		ORDER BY created_at DESC
+		LIMIT $4`
+)
+
 // Scheduler queries.
 const (
 	queryInsertJobRun = `
@@ -379,6 +846,10 @@ const (
 		INSERT INTO notification_attempts (alert_id, succeeded, http_status, error_text)
 		VALUES ($1, $2, $3, NULLIF($4, ''))`
 
+	queryInsertSuppressedNotificationAttempt = `
+		INSERT INTO notification_attempts (alert_id, succeeded, error_text, suppression_reason)
+		VALUES ($1, $2, NULLIF($3, ''), $4)`
+
 	queryHasSuccessfulNotification = `
 		SELECT EXISTS (
 			SELECT 1 FROM notification_attempts
@@ -399,13 +870,14 @@ const (
 	alertReviewSelectColumns = `
 		a.id, a.watch_id, a.listing_id, a.score, a.notified, a.notified_at,
 		a.created_at, a.dismissed_at, a.trace_id,
-		l.id, l.ebay_item_id, l.title, l.item_url, l.image_url, l.price,
-		l.currency, l.shipping_cost, l.listing_type, l.seller_name,
+		l.id, l.ebay_item_id, l.title, l.item_url, l.image_url, l.item_country, l.price,
+		l.currency, l.shipping_cost, l.price_usd, l.shipping_cost_usd,
+		l.listing_type, l.bid_count, l.seller_name,
 		l.seller_feedback_score, l.seller_feedback_pct, l.seller_top_rated,
 		l.condition_raw, l.condition_norm, l.component_type, l.quantity,
-		l.attributes, l.extraction_confidence, l.product_key, l.score,
-		l.score_breakdown, l.active, l.listed_at, l.sold_at, l.sold_price,
-		l.first_seen_at, l.updated_at,
+		l.attributes, l.extraction_confidence, l.product_key, COALESCE(l.listing_group_id::text, ''), l.score,
+		l.score_breakdown, l.risk_score, l.risk_signals, l.partout_value, l.partout_ratio, l.value_class, l.value_unit, l.price_per_unit, l.extraction_locked, l.active, l.listed_at, l.sold_at, l.sold_price,
+		l.auction_end_at, l.first_seen_at, l.updated_at,
 		w.name`
 
 	queryDismissAlerts = `
@@ -415,13 +887,13 @@ const (
 		RETURNING id, COALESCE(trace_id, '')`
 
 	queryRestoreAlerts = `
-		UPDATE alerts SET dismissed_at = NULL
+		UPDATE alerts SET dismissed_at = NULL, restored_at = now()
 		WHERE id = ANY($1)
 		  AND dismissed_at IS NOT NULL
 		RETURNING id, COALESCE(trace_id, '')`
 
 	queryNotificationAttemptsByAlert = `
-		SELECT id, alert_id, attempted_at, succeeded, http_status, error_text
+		SELECT id, alert_id, attempted_at, succeeded, http_status, error_text, suppression_reason
 		FROM notification_attempts
 		WHERE alert_id = $1
 		ORDER BY attempted_at DESC`
@@ -435,12 +907,14 @@ const (
 	// caused SQLSTATE 42703 on every judge-bootstrap run.)
 	//
 	// `judge_scores js IS NULL` filter makes the worker idempotent —
-	// re-running the cron entry never re-judges an alert.
+	// re-running the cron entry never re-judges an alert. Listings with
+	// no USD price (no exchange rate for their currency) are skipped:
+	// the judge weighs the price against USD baselines.
 	queryListAlertsForJudging = `
 		SELECT
 		    a.id, a.watch_id, w.name,
 		    a.listing_id, l.title, l.component_type,
-		    l.condition_norm, l.price,
+		    l.condition_norm, l.price_usd,
 		    COALESCE(pb.p25, 0), COALESCE(pb.p50, 0), COALESCE(pb.p75, 0), COALESCE(pb.sample_count, 0),
 		    a.score, w.score_threshold, a.trace_id, a.created_at
 		FROM alerts a
@@ -450,6 +924,7 @@ const (
 		LEFT JOIN judge_scores js ON js.alert_id = a.id
 		WHERE a.created_at >= $1
 		  AND js.alert_id IS NULL
+		  AND l.price_usd IS NOT NULL
 		ORDER BY a.created_at DESC
 		LIMIT $2`
 
@@ -459,6 +934,23 @@ const (
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (alert_id) DO NOTHING`
 
+	// queryGetJudgeBacklog counts the alerts ListAlertsForJudging would
+	// return without its batch limit ($1 is the lookback cutoff), plus
+	// those that aged out of the window unjudged since $2, a day before
+	// the cutoff.
+	queryGetJudgeBacklog = `
+		SELECT
+		    COUNT(*) FILTER (WHERE a.created_at >= $1),
+		    MIN(a.created_at) FILTER (WHERE a.created_at >= $1),
+		    COUNT(*) FILTER (WHERE a.created_at < $1)
+		FROM alerts a
+		JOIN listings l ON l.id = a.listing_id
+		JOIN watches w ON w.id = a.watch_id
+		LEFT JOIN judge_scores js ON js.alert_id = a.id
+		WHERE a.created_at >= $2
+		  AND js.alert_id IS NULL
+		  AND l.price_usd IS NOT NULL`
+
 	querySumJudgeCostSince = `
 		SELECT COALESCE(SUM(cost_usd), 0)
 		FROM judge_scores
@@ -468,4 +960,40 @@ const (
 		SELECT alert_id, score, reason, model, input_tokens, output_tokens, cost_usd, judged_at
 		FROM judge_scores
 		WHERE alert_id = $1`
+
+	queryGetJudgeScores = `
+		SELECT alert_id, score, reason, model, input_tokens, output_tokens, cost_usd, judged_at
+		FROM judge_scores
+		WHERE alert_id = ANY($1)`
+
+	// queryListScoringLabels backs the offline weight calibration
+	// (tools/score-calibrate). An operator dismissal is the strongest
+	// negative label and wins over everything else; an explicit restore
+	// is the strongest positive; otherwise the judge verdict decides
+	// against the caller-supplied floor ($2). Alerts with none of the
+	// three are unlabelled and excluded. score_breakdown is the
+	// listing's current per-factor breakdown — recalibration reweights
+	// factors, it doesn't recompute them.
+	queryListScoringLabels = `
+		SELECT
+		    a.id, l.component_type, l.score_breakdown, a.score, w.score_threshold,
+		    CASE
+		        WHEN a.dismissed_at IS NOT NULL THEN 'dismissed'
+		        WHEN a.restored_at IS NOT NULL THEN 'restored'
+		        ELSE 'judge'
+		    END,
+		    CASE
+		        WHEN a.dismissed_at IS NOT NULL THEN false
+		        WHEN a.restored_at IS NOT NULL THEN true
+		        ELSE js.score >= $2
+		    END
+		FROM alerts a
+		JOIN listings l ON l.id = a.listing_id
+		JOIN watches w ON w.id = a.watch_id
+		LEFT JOIN judge_scores js ON js.alert_id = a.id
+		WHERE a.created_at >= $1
+		  AND l.component_type IS NOT NULL
+		  AND l.score_breakdown IS NOT NULL
+		  AND (a.dismissed_at IS NOT NULL OR a.restored_at IS NOT NULL OR js.alert_id IS NOT NULL)
+		ORDER BY a.created_at DESC`
 )
@@ -12,6 +12,7 @@ const (
 	orderByScore     = "score"
 	orderByPrice     = "price"
 	orderByFirstSeen = "first_seen_at"
+	orderByPPU       = "price_per_unit"
 )
 
 // validOrderBy maps allowed OrderBy values to their SQL column expressions.
@@ -19,16 +20,17 @@ var validOrderBy = map[string]string{
 	orderByScore:     "score DESC NULLS LAST",
 	orderByPrice:     "price ASC",
 	orderByFirstSeen: "first_seen_at DESC",
+	orderByPPU:       "price_per_unit ASC NULLS LAST",
 }
 
 const defaultOrderBy = "first_seen_at DESC"
 
-const baseListingsSelect = `SELECT id, ebay_item_id, title, item_url, image_url,
-	price, currency, shipping_cost, listing_type,
+const baseListingsSelect = `SELECT id, ebay_item_id, title, item_url, image_url, item_country,
+	price, currency, shipping_cost, price_usd, shipping_cost_usd, listing_type, bid_count,
 	seller_name, seller_feedback_score, seller_feedback_pct, seller_top_rated,
 	condition_raw, COALESCE(condition_norm, 'unknown'), COALESCE(component_type, ''), quantity, COALESCE(attributes, '{}'),
-	COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), score, score_breakdown,
-	listed_at, sold_at, sold_price, first_seen_at, updated_at
+	COALESCE(extraction_confidence, 0), COALESCE(product_key, ''), COALESCE(listing_group_id::text, ''), score, score_breakdown, risk_score, risk_signals, partout_value, partout_ratio, value_class, value_unit, price_per_unit, extraction_locked,
+	active, listed_at, sold_at, sold_price, auction_end_at, first_seen_at, updated_at
 FROM listings`
 
 const countListingsSelect = "SELECT COUNT(*) FROM listings"
@@ -70,6 +72,28 @@ func (q *ListingQuery) ToSQL() (dataSQL, countSQL string, args []any) {
 		paramIdx++
 	}
 
+	if q.SellerName != nil {
+		conditions = append(conditions, fmt.Sprintf("seller_name = $%d", paramIdx))
+		args = append(args, *q.SellerName)
+		paramIdx++
+	}
+
+	if q.ValueClass != nil {
+		conditions = append(conditions, fmt.Sprintf("value_class = $%d", paramIdx))
+		args = append(args, *q.ValueClass)
+		paramIdx++
+	}
+
+	if q.MaxPricePerUnit != nil {
+		conditions = append(conditions, fmt.Sprintf("price_per_unit <= $%d", paramIdx))
+		args = append(args, *q.MaxPricePerUnit)
+		paramIdx++
+	}
+
+	if q.ActiveOnly {
+		conditions = append(conditions, "active = true")
+	}
+
 	if len(q.Conditions) > 0 {
 		placeholders := make([]string, len(q.Conditions))
 		for i, c := range q.Conditions {
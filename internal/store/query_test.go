@@ -82,6 +82,30 @@ func TestListingQuery_ToSQL(t *testing.T) {
 			wantCountSQL: "SELECT COUNT(*) FROM listings WHERE seller_feedback_score >= $1",
 			wantArgs:     []any{100},
 		},
+		{
+			name: "seller name filter with active only",
+			query: ListingQuery{
+				SellerName: ptr("server_parts_inc"),
+				ActiveOnly: true,
+			},
+			wantDataHas:  []string{"WHERE seller_name = $1 AND active = true"},
+			wantCountSQL: "SELECT COUNT(*) FROM listings WHERE seller_name = $1 AND active = true",
+			wantArgs:     []any{"server_parts_inc"},
+		},
+		{
+			name: "value class sorted by price per unit",
+			query: ListingQuery{
+				ValueClass:      ptr("ram:ddr4:ecc_reg"),
+				MaxPricePerUnit: ptr(1.5),
+				OrderBy:         "price_per_unit",
+			},
+			wantDataHas: []string{
+				"WHERE value_class = $1 AND price_per_unit <= $2",
+				"ORDER BY price_per_unit ASC NULLS LAST",
+			},
+			wantCountSQL: "SELECT COUNT(*) FROM listings WHERE value_class = $1 AND price_per_unit <= $2",
+			wantArgs:     []any{"ram:ddr4:ecc_reg", 1.5},
+		},
 		{
 			name: "single condition filter",
 			query: ListingQuery{
@@ -203,6 +227,14 @@ func TestListingQuery_ToSQL(t *testing.T) {
 			},
 			wantDataHas: []string{"LIMIT 500"},
 		},
+		{
+			name: "active only",
+			query: ListingQuery{
+				ActiveOnly: true,
+			},
+			wantDataHas:  []string{"WHERE active = true"},
+			wantCountSQL: "SELECT COUNT(*) FROM listings WHERE active = true",
+		},
 		{
 			name: "negative offset defaults to 0",
 			query: ListingQuery{
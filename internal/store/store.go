@@ -18,10 +18,16 @@ type ListingQuery struct {
 	MaxScore      *int
 	ProductKey    *string
 	SellerMinFB   *int
+	SellerName    *string
 	Conditions    []string
-	Limit         int // default 50
-	Offset        int
-	OrderBy       string // "score", "price", "first_seen_at"
+	ActiveOnly    bool
+	// ValueClass and MaxPricePerUnit filter on the value metric (see
+	// domain.Listing.PricePerUnit).
+	ValueClass      *string
+	MaxPricePerUnit *float64
+	Limit           int // default 50
+	Offset          int
+	OrderBy         string // "score", "price", "price_per_unit", "first_seen_at"
 }
 
 // AlertReviewStatus narrows the alert review list to a state subset.
@@ -70,6 +76,33 @@ type JudgeCandidatesQuery struct {
 	Limit    int           // 0 = use store default (50)
 }
 
+// ShadowReportQuery scopes a shadow extraction report. Empty
+// ShadowModel and PromptVersion match every comparison.
+type ShadowReportQuery struct {
+	Since         time.Time
+	ShadowModel   string
+	PromptVersion string
+	Examples      int // most recent disagreeing comparisons to include
+}
+
+// ScoringLabelsQuery scopes the labelled-alert pull used by offline
+// scoring-weight calibration. JudgeMinScore is the judge verdict at or
+// above which an alert without an operator label counts as a good
+// alert; operator dismiss/restore labels always take precedence.
+type ScoringLabelsQuery struct {
+	Lookback      time.Duration // alerts.created_at >= now() - Lookback
+	JudgeMinScore float64       // default 0.5
+}
+
+// WatchApplySet is the write half of a declarative watch apply. The
+// store applies it atomically: either every create, update and delete
+// lands or none do. Create entries have their ID and timestamps filled.
+type WatchApplySet struct {
+	Create []*domain.Watch
+	Update []*domain.Watch
+	Delete []string // watch IDs
+}
+
 // Store defines all data access operations for server-price-tracker.
 type Store interface {
 	// Listings
@@ -77,6 +110,8 @@ type Store interface {
 	GetListing(ctx context.Context, ebayID string) (*domain.Listing, error)
 	GetListingByID(ctx context.Context, id string) (*domain.Listing, error)
 	ListListings(ctx context.Context, opts *ListingQuery) ([]domain.Listing, int, error)
+	// UpdateListingExtraction stores an LLM extraction. Listings locked
+	// by an operator correction are left unchanged.
 	UpdateListingExtraction(
 		ctx context.Context,
 		id string,
@@ -85,11 +120,46 @@ type Store interface {
 		confidence float64,
 		productKey string,
 	) error
-	UpdateScore(ctx context.Context, id string, score int, breakdown json.RawMessage) error
+	// UpdateScore stores the deal score and the risk score (see
+	// scorer.Risk) computed with it, each with its breakdown.
+	UpdateScore(
+		ctx context.Context,
+		id string,
+		score int,
+		breakdown json.RawMessage,
+		risk int,
+		riskSignals json.RawMessage,
+	) error
+	// UpdatePartOut stores a server's part-out value (USD) and its
+	// ratio to the unit price; nil clears them.
+	UpdatePartOut(ctx context.Context, id string, value, ratio *float64) error
+	// UpdateValueMetric stores a listing's value class and unit and its
+	// USD price per unit; a nil price clears the metric.
+	UpdateValueMetric(ctx context.Context, id, valueClass, valueUnit string, pricePerUnit *float64) error
 	ListUnextractedListings(ctx context.Context, limit int) ([]domain.Listing, error)
 	ListUnscoredListings(ctx context.Context, limit int) ([]domain.Listing, error)
 	ListIncompleteExtractions(ctx context.Context, componentType string, limit int) ([]domain.Listing, error)
 	ListListingsCursor(ctx context.Context, afterID string, limit int) ([]domain.Listing, error)
+	// ListPriceHistory returns a listing's recorded prices, oldest first.
+	ListPriceHistory(ctx context.Context, listingID string) ([]domain.PricePoint, error)
+
+	// Extraction corrections
+	// CorrectListingExtraction stores an operator's correction in one
+	// transaction: the listing takes the corrected extraction at full
+	// confidence and is locked against re-extraction, and the
+	// correction is recorded. The correction's ID and CreatedAt are
+	// filled.
+	CorrectListingExtraction(ctx context.Context, c *domain.ExtractionCorrection) error
+	// ListExtractionCorrections returns corrections, newest first.
+	ListExtractionCorrections(ctx context.Context, limit int) ([]domain.ExtractionCorrection, error)
+
+	// Shadow extraction
+	// InsertShadowExtraction records a shadow extraction comparison and
+	// fills its ID and CreatedAt.
+	InsertShadowExtraction(ctx context.Context, s *domain.ShadowExtraction) error
+	// GetShadowReport aggregates the comparisons matching q. The counts
+	// are filled; the caller derives the rates.
+	GetShadowReport(ctx context.Context, q *ShadowReportQuery) (*domain.ShadowReport, error)
 
 	// Watches
 	CreateWatch(ctx context.Context, w *domain.Watch) error
@@ -98,12 +168,42 @@ type Store interface {
 	UpdateWatch(ctx context.Context, w *domain.Watch) error
 	DeleteWatch(ctx context.Context, id string) error
 	SetWatchEnabled(ctx context.Context, id string, enabled bool) error
+	// ApplyWatches writes a declarative watch plan in one transaction.
+	ApplyWatches(ctx context.Context, set *WatchApplySet) error
+	// InsertWatchPoll records one ingestion poll of a watch.
+	InsertWatchPoll(ctx context.Context, p *domain.WatchPoll) error
+	// GetWatchStats aggregates polls and alerts for one watch since the
+	// given time. Rates are left for the caller, which knows the window.
+	GetWatchStats(ctx context.Context, watchID string, since time.Time) (*domain.WatchStats, error)
+	// ListWatchYields sums successful polls per watch since the given
+	// time. Watches with no polls in the window are absent.
+	ListWatchYields(ctx context.Context, since time.Time) ([]domain.WatchYield, error)
 
 	// Baselines
 	GetBaseline(ctx context.Context, productKey string) (*domain.PriceBaseline, error)
 	ListBaselines(ctx context.Context) ([]domain.PriceBaseline, error)
+	// ListBaselinesMatching returns the baselines whose product key
+	// matches a SQL LIKE pattern (see extract.ServerParts).
+	ListBaselinesMatching(ctx context.Context, pattern string) ([]domain.PriceBaseline, error)
 	RecomputeBaseline(ctx context.Context, productKey string, windowDays int) error
+	// RecomputeAllBaselines recomputes every product key's baseline
+	// and then every value class's price-per-unit baseline.
 	RecomputeAllBaselines(ctx context.Context, windowDays int) error
+	GetValueBaseline(ctx context.Context, valueClass string) (*domain.ValueBaseline, error)
+
+	// Product key aliases
+	// ResolveProductKey returns the canonical key for productKey, or
+	// productKey itself when it isn't an alias.
+	ResolveProductKey(ctx context.Context, productKey string) (string, error)
+	// MergeProductKeys makes from an alias of to in one transaction:
+	// aliases of from are repointed to to, listings under from are
+	// moved to to, and from's baseline is dropped. Returns the number
+	// of listings moved.
+	MergeProductKeys(ctx context.Context, from, to string) (int, error)
+	ListProductKeyAliases(ctx context.Context) ([]domain.ProductKeyAlias, error)
+	// ListProductKeyCounts returns every product key in use with its
+	// listing count.
+	ListProductKeyCounts(ctx context.Context) ([]domain.ProductKeyCount, error)
 
 	// Alerts
 	CreateAlert(ctx context.Context, a *domain.Alert) error
@@ -112,9 +212,48 @@ type Store interface {
 	MarkAlertNotified(ctx context.Context, id string) error
 	MarkAlertsNotified(ctx context.Context, ids []string) error
 	HasRecentAlert(ctx context.Context, watchID, listingID string, cooldown time.Duration) (bool, error)
+	// GetAlertedPrice returns the alerted listing's price as of the most
+	// recent notified alert for the watch on the listing or its group,
+	// or nil when there is none.
+	GetAlertedPrice(ctx context.Context, watchID, listingID string) (*domain.PricePoint, error)
 	InsertNotificationAttempt(ctx context.Context, alertID string, succeeded bool, httpStatus int, errText string) error
+	// InsertSuppressedNotificationAttempt records an alert the judge gate
+	// held back from its own notification: succeeded reports whether the
+	// summary it was folded into went out (false when it was suppressed
+	// outright) and reason why it was held back.
+	InsertSuppressedNotificationAttempt(ctx context.Context, alertID string, succeeded bool, errText, reason string) error
 	HasSuccessfulNotification(ctx context.Context, alertID string) (bool, error)
 
+	// Listing groups
+	// AssignListingGroup puts the listing in the group of an earlier
+	// relist or duplicate, or in a group of its own, and returns the
+	// group ID.
+	AssignListingGroup(ctx context.Context, listingID string, priceTolerancePct float64) (string, error)
+	// HasGroupAlert reports whether another listing in the listing's
+	// group has a notified alert for the watch.
+	HasGroupAlert(ctx context.Context, watchID, listingID string) (bool, error)
+
+	// Sellers
+	// GetSeller returns the seller with its listing and alert counts and
+	// feedback history, or pgx.ErrNoRows when the name is unknown.
+	GetSeller(ctx context.Context, name string) (*domain.Seller, error)
+
+	// Auction tracking
+	// ListTrackedAuctions returns the active auctions ending before
+	// endsBefore that have an undismissed alert or qualify for an
+	// enabled watch, soonest first.
+	ListTrackedAuctions(ctx context.Context, endsBefore time.Time) ([]domain.Listing, error)
+	HasAuctionReminder(ctx context.Context, watchID, listingID string) (bool, error)
+	RecordAuctionReminder(ctx context.Context, watchID, listingID string) error
+
+	// Price checks
+	// ListPriceCheckListings returns up to limit active fixed-price
+	// listings with an undismissed alert from an enabled watch that sets
+	// realert_drop_pct, not price-checked since checkedBefore, least
+	// recently checked first.
+	ListPriceCheckListings(ctx context.Context, checkedBefore time.Time, limit int) ([]domain.Listing, error)
+	MarkPriceChecked(ctx context.Context, listingID string) error
+
 	// Alert review (DESIGN-0010)
 	ListAlertsForReview(ctx context.Context, q *AlertReviewQuery) (AlertReviewResult, error)
 	GetAlertDetail(ctx context.Context, id string) (*domain.AlertDetail, error)
@@ -148,9 +287,22 @@ type Store interface {
 	// cost_usd for verdicts judged on/after `since`; the worker
 	// multiplies UTC midnight in.
 	SumJudgeCostSince(ctx context.Context, since time.Time) (float64, error)
+	// GetJudgeBacklog counts the alerts awaiting a verdict inside the
+	// lookback window, the oldest one's creation time, and the alerts
+	// that aged out of the window unjudged in the 24h before it.
+	GetJudgeBacklog(ctx context.Context, lookback time.Duration) (*domain.JudgeBacklog, error)
 	// GetJudgeScore returns the persisted verdict for a single alert,
 	// or nil + nil error when no row exists yet (pre-judge alerts).
 	GetJudgeScore(ctx context.Context, alertID string) (*domain.JudgeScore, error)
+	// GetJudgeScores returns the persisted verdicts for alertIDs keyed
+	// by alert ID; alerts without a verdict are absent from the map.
+	GetJudgeScores(ctx context.Context, alertIDs []string) (map[string]*domain.JudgeScore, error)
+
+	// ListScoringLabels returns every alert in the lookback window that
+	// carries a good/bad label (operator dismiss, operator restore, or
+	// judge verdict) together with the listing's per-factor score
+	// breakdown. Feeds tools/score-calibrate.
+	ListScoringLabels(ctx context.Context, q *ScoringLabelsQuery) ([]domain.ScoringLabel, error)
 
 	GetSystemState(ctx context.Context) (*domain.SystemState, error)
 
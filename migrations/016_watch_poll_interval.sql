@@ -0,0 +1,16 @@
+-- Migration 016: Per-watch poll interval and priority.
+--
+-- Ingestion used to poll every enabled watch on every
+-- schedule.ingestion_interval tick, in creation order, so watches late
+-- in the list starved when max_calls_per_cycle ran out. The scheduler
+-- now ticks on schedule.poll_tick and polls only the watches that are
+-- due, highest priority and most overdue first.
+--
+-- poll_interval_seconds = 0 means "use schedule.ingestion_interval",
+-- so existing watches keep their cadence. priority defaults to 0;
+-- higher polls first when the cycle budget is short.
+
+ALTER TABLE watches
+    ADD COLUMN IF NOT EXISTS poll_interval_seconds INTEGER NOT NULL DEFAULT 0
+        CHECK (poll_interval_seconds >= 0),
+    ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
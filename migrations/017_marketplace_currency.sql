@@ -0,0 +1,88 @@
+-- Migration 017: Per-watch marketplaces and USD-normalized prices.
+--
+-- Watches can now search a marketplace other than ebay.marketplace
+-- (EBAY_GB, EBAY_DE, ...), so listings arrive priced in GBP, EUR and
+-- so on. Ingestion converts each listing's price and shipping to USD
+-- with the configured exchange rate table and stores the result next
+-- to the original. price_usd is NULL when the currency had no rate;
+-- those listings are left out of baselines rather than mixed in
+-- unconverted.
+--
+-- watches.marketplace = '' means "use ebay.marketplace", so existing
+-- watches keep searching where they did.
+
+BEGIN;
+
+ALTER TABLE watches
+    ADD COLUMN IF NOT EXISTS marketplace TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS price_usd         NUMERIC(10,2),
+    ADD COLUMN IF NOT EXISTS shipping_cost_usd NUMERIC(10,2);
+
+-- Every listing so far came from a USD marketplace, or was scored as
+-- if it had.
+UPDATE listings
+SET price_usd = price, shipping_cost_usd = shipping_cost
+WHERE currency = 'USD' AND price_usd IS NULL;
+
+-- Baselines on USD prices. A sold price is in the listing's currency,
+-- so it is converted at the listing's own price_usd / price ratio.
+CREATE OR REPLACE FUNCTION recompute_baseline(p_product_key TEXT, p_window_days INTEGER DEFAULT 90)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO price_baselines (product_key, sample_count, p10, p25, p50, p75, p90, mean, updated_at)
+    SELECT
+        p_product_key,
+        count(*),
+        percentile_cont(0.10) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.25) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.50) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.75) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.90) WITHIN GROUP (ORDER BY unit_price),
+        avg(unit_price),
+        now()
+    FROM (
+        SELECT
+            (COALESCE(sold_price * price_usd / NULLIF(price, 0), price_usd)
+                + COALESCE(shipping_cost_usd, 0)) / GREATEST(quantity, 1) AS unit_price
+        FROM listings
+        WHERE product_key = p_product_key
+          AND active = true
+          AND price_usd IS NOT NULL
+          AND updated_at >= now() - (p_window_days || ' days')::interval
+          AND condition_norm != 'for_parts'
+    ) sub
+    HAVING count(*) >= 5
+    ON CONFLICT (product_key) DO UPDATE SET
+        sample_count = EXCLUDED.sample_count,
+        p10 = EXCLUDED.p10,
+        p25 = EXCLUDED.p25,
+        p50 = EXCLUDED.p50,
+        p75 = EXCLUDED.p75,
+        p90 = EXCLUDED.p90,
+        mean = EXCLUDED.mean,
+        updated_at = now();
+END;
+$$ LANGUAGE plpgsql;
+
+-- l.* gains the new columns, so the view has to be rebuilt rather than
+-- replaced. unit_price stays in the listing's currency for display;
+-- unit_price_usd is what compares against baselines.
+DROP VIEW IF EXISTS listings_with_baseline;
+CREATE VIEW listings_with_baseline AS
+SELECT
+    l.*,
+    b.p10 AS baseline_p10,
+    b.p25 AS baseline_p25,
+    b.p50 AS baseline_p50,
+    b.p75 AS baseline_p75,
+    b.p90 AS baseline_p90,
+    b.sample_count AS baseline_samples,
+    (l.price + COALESCE(l.shipping_cost, 0)) / GREATEST(l.quantity, 1) AS unit_price,
+    (l.price_usd + COALESCE(l.shipping_cost_usd, 0)) / GREATEST(l.quantity, 1) AS unit_price_usd
+FROM listings l
+LEFT JOIN price_baselines b ON b.product_key = l.product_key
+WHERE l.active = true;
+
+COMMIT;
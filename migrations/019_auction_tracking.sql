@@ -0,0 +1,29 @@
+-- Migration 019: Auction tracking and ending-soon reminders.
+--
+-- Auctions used to be scored once at ingestion and never revisited,
+-- and auction_end_at was never written. Ingestion now stores the end
+-- time and bid count, and the auction tracking job refreshes the
+-- current bid of alerted or watched auctions as they near their end,
+-- re-scores them, and sends one "ending soon" reminder per watch.
+--
+-- auction_reminders records the reminders sent so each (watch,
+-- listing) pair is reminded once. Rows cascade with either side.
+
+BEGIN;
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS bid_count INTEGER NOT NULL DEFAULT 0;
+
+-- Tracking scan: active auctions ordered by end time.
+CREATE INDEX IF NOT EXISTS idx_listings_auction_end_at
+    ON listings (auction_end_at)
+    WHERE listing_type = 'auction' AND active = true;
+
+CREATE TABLE IF NOT EXISTS auction_reminders (
+    watch_id   UUID NOT NULL REFERENCES watches(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    sent_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (watch_id, listing_id)
+);
+
+COMMIT;
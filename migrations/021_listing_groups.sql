@@ -0,0 +1,69 @@
+-- Migration 021: Relist and duplicate listing groups.
+--
+-- Sellers end and relist the same item under a new eBay item ID, and
+-- each relist used to count as a separate baseline sample and could
+-- alert again. After extraction, a listing joins the group of the
+-- earliest listing with the same seller, product key and normalized
+-- title at a similar price; otherwise it starts its own group.
+-- listing_group_id is the ID of the group's first listing, and NULL
+-- for listings not grouped yet, which count as their own group.
+--
+-- Existing listings are not backfilled: they are grouped when next
+-- extracted, and relists already in the baseline window age out of it.
+
+BEGIN;
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS listing_group_id UUID,
+    ADD COLUMN IF NOT EXISTS title_norm TEXT
+        GENERATED ALWAYS AS (btrim(regexp_replace(lower(title), '[^a-z0-9]+', ' ', 'g'))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_listings_listing_group_id
+    ON listings (listing_group_id);
+
+-- Group lookup: same seller, product key and normalized title.
+CREATE INDEX IF NOT EXISTS idx_listings_relist_match
+    ON listings (seller_name, product_key, title_norm);
+
+-- Baselines count each group once, at its most recently updated
+-- listing's price.
+CREATE OR REPLACE FUNCTION recompute_baseline(p_product_key TEXT, p_window_days INTEGER DEFAULT 90)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO price_baselines (product_key, sample_count, p10, p25, p50, p75, p90, mean, updated_at)
+    SELECT
+        p_product_key,
+        count(*),
+        percentile_cont(0.10) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.25) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.50) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.75) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.90) WITHIN GROUP (ORDER BY unit_price),
+        avg(unit_price),
+        now()
+    FROM (
+        SELECT DISTINCT ON (COALESCE(listing_group_id, id))
+            (COALESCE(sold_price * price_usd / NULLIF(price, 0), price_usd)
+                + COALESCE(shipping_cost_usd, 0)) / GREATEST(quantity, 1) AS unit_price
+        FROM listings
+        WHERE product_key = p_product_key
+          AND active = true
+          AND price_usd IS NOT NULL
+          AND updated_at >= now() - (p_window_days || ' days')::interval
+          AND condition_norm != 'for_parts'
+        ORDER BY COALESCE(listing_group_id, id), updated_at DESC
+    ) sub
+    HAVING count(*) >= 5
+    ON CONFLICT (product_key) DO UPDATE SET
+        sample_count = EXCLUDED.sample_count,
+        p10 = EXCLUDED.p10,
+        p25 = EXCLUDED.p25,
+        p50 = EXCLUDED.p50,
+        p75 = EXCLUDED.p75,
+        p90 = EXCLUDED.p90,
+        mean = EXCLUDED.mean,
+        updated_at = now();
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Migration 022: Listing risk scores.
+--
+-- The deal score rewards exactly what scam listings look like: a price
+-- far below P10 from a brand-new seller. The risk score is computed
+-- next to it from warning signs (price far below P10, low seller
+-- feedback, a generic title, no image, a foreign location at a
+-- domestic price) and stored separately, so it never moves the deal
+-- score. risk_signals holds the signals behind the score as
+-- [{"name": ..., "points": ...}].
+--
+-- item_country is where the item ships from, as reported by eBay.
+-- Existing listings get it on their next ingestion and a risk score on
+-- their next rescore.
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS item_country TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS risk_score INTEGER,
+    ADD COLUMN IF NOT EXISTS risk_signals JSONB;
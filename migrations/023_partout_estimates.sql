@@ -0,0 +1,12 @@
+-- Migration 023: Server part-out estimates.
+--
+-- Server baselines are sparse, and a server is often worth more as
+-- parts than as a whole. partout_value is the baseline value (USD) of
+-- the CPUs and RAM a server's extraction names; partout_ratio is that
+-- value divided by the listing's unit price. Both stay NULL for
+-- non-server listings and for servers whose parts couldn't be priced.
+-- Existing servers get an estimate on their next rescore.
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS partout_value NUMERIC(12,2),
+    ADD COLUMN IF NOT EXISTS partout_ratio NUMERIC(8,3);
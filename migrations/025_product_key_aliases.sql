@@ -0,0 +1,62 @@
+-- Migration 025: Product key aliases.
+--
+-- extract.ProductKey builds keys from the extracted strings as they
+-- come, so "e5-2690v4" and "e5-2690_v4" become two keys whose baselines
+-- each stay cold. An alias maps a key onto its canonical key.
+-- Extraction stores the canonical key, merging a key backfills the
+-- listings already stored under it, and baselines count listings
+-- under any alias of the key they are computed for.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS product_key_aliases (
+    alias       TEXT PRIMARY KEY,
+    canonical   TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (alias != canonical)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_key_aliases_canonical
+    ON product_key_aliases (canonical);
+
+CREATE OR REPLACE FUNCTION recompute_baseline(p_product_key TEXT, p_window_days INTEGER DEFAULT 90)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO price_baselines (product_key, sample_count, p10, p25, p50, p75, p90, mean, updated_at)
+    SELECT
+        p_product_key,
+        count(*),
+        percentile_cont(0.10) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.25) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.50) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.75) WITHIN GROUP (ORDER BY unit_price),
+        percentile_cont(0.90) WITHIN GROUP (ORDER BY unit_price),
+        avg(unit_price),
+        now()
+    FROM (
+        SELECT DISTINCT ON (COALESCE(listing_group_id, id))
+            (COALESCE(sold_price * price_usd / NULLIF(price, 0), price_usd)
+                + COALESCE(shipping_cost_usd, 0)) / GREATEST(quantity, 1) AS unit_price
+        FROM listings
+        WHERE (product_key = p_product_key
+               OR product_key IN (SELECT alias FROM product_key_aliases WHERE canonical = p_product_key))
+          AND active = true
+          AND price_usd IS NOT NULL
+          AND updated_at >= now() - (p_window_days || ' days')::interval
+          AND condition_norm != 'for_parts'
+        ORDER BY COALESCE(listing_group_id, id), updated_at DESC
+    ) sub
+    HAVING count(*) >= 5
+    ON CONFLICT (product_key) DO UPDATE SET
+        sample_count = EXCLUDED.sample_count,
+        p10 = EXCLUDED.p10,
+        p25 = EXCLUDED.p25,
+        p50 = EXCLUDED.p50,
+        p75 = EXCLUDED.p75,
+        p90 = EXCLUDED.p90,
+        mean = EXCLUDED.mean,
+        updated_at = now();
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Migration 027: Allow 'switch' and 'transceiver' as component_type
+-- values on watches and listings.
+--
+-- Network switches and pluggable optics / DACs get their own
+-- ComponentTypes in the Go domain layer instead of landing in 'nic' or
+-- 'other'. Extend both CHECK constraints. Mirror migration 026 shape
+-- exactly.
+
+ALTER TABLE watches
+    DROP CONSTRAINT watches_component_type_check;
+
+ALTER TABLE watches
+    ADD CONSTRAINT watches_component_type_check
+    CHECK (component_type IN ('ram', 'drive', 'server', 'cpu', 'nic', 'gpu', 'hba', 'switch', 'transceiver', 'workstation', 'desktop', 'other'));
+
+ALTER TABLE listings
+    DROP CONSTRAINT listings_component_type_check;
+
+ALTER TABLE listings
+    ADD CONSTRAINT listings_component_type_check
+    CHECK (component_type IN ('ram', 'drive', 'server', 'cpu', 'nic', 'gpu', 'hba', 'switch', 'transceiver', 'workstation', 'desktop', 'other'));
@@ -0,0 +1,35 @@
+-- Migration 028: Operator extraction corrections.
+--
+-- Operators can fix a listing's extraction from the alert detail page or
+-- PATCH /api/v1/listings/{id}/extraction. A corrected listing is locked:
+-- the extraction queue and re-extraction skip it so the next LLM pass
+-- can't undo the fix. Each correction is kept, with the values it
+-- replaced, as a labelled example that exports to the golden dataset
+-- (testdata/golden_classifications.json) and to a Langfuse dataset.
+
+BEGIN;
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS extraction_locked BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS extraction_corrections (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    listing_id              UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    title                   TEXT NOT NULL,
+    previous_component_type TEXT NOT NULL DEFAULT '',
+    previous_attributes     JSONB NOT NULL DEFAULT '{}',
+    previous_product_key    TEXT NOT NULL DEFAULT '',
+    component_type          TEXT NOT NULL,
+    attributes              JSONB NOT NULL,
+    product_key             TEXT NOT NULL,
+    note                    TEXT NOT NULL DEFAULT '',
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_extraction_corrections_created
+    ON extraction_corrections (created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_extraction_corrections_listing
+    ON extraction_corrections (listing_id);
+
+COMMIT;
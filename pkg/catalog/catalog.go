@@ -0,0 +1,267 @@
+// Package catalog is a local hardware catalog: specifications the
+// listing title doesn't state (core counts, TDP, release year, FP16
+// throughput) keyed by canonical model. The catalog ships as YAML data
+// files embedded in the binary; operators can add or override entries
+// with files of the same format loaded from disk.
+package catalog
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/*.yaml
+var dataFS embed.FS
+
+// Entry is one catalog model. ID is the model's product-key prefix
+// (component type, manufacturer, family, model), e.g.
+// "cpu:intel:xeon:e5-2680_v4". Specs holds the attributes enrichment
+// adds to a matching listing.
+type Entry struct {
+	ID            string         `json:"id"                yaml:"-"`
+	ComponentType string         `json:"component_type"    yaml:"-"`
+	Manufacturer  string         `json:"manufacturer"      yaml:"manufacturer"`
+	Family        string         `json:"family"            yaml:"family"`
+	Model         string         `json:"model"             yaml:"model"`
+	Aliases       []string       `json:"aliases,omitempty" yaml:"aliases"`
+	Specs         map[string]any `json:"specs"             yaml:"specs"`
+}
+
+// file is the layout of one catalog data file: a version and the
+// entries for one component type.
+type file struct {
+	Version       string  `yaml:"version"`
+	ComponentType string  `yaml:"component_type"`
+	Entries       []Entry `yaml:"entries"`
+}
+
+// Catalog is a loaded hardware catalog. Safe for concurrent use; it is
+// read-only once loaded.
+type Catalog struct {
+	versions map[string]string
+	entries  []Entry
+	index    map[string]int
+}
+
+// Default returns the catalog embedded in the binary.
+func Default() (*Catalog, error) {
+	return Load("")
+}
+
+// Load returns the embedded catalog with the *.yaml files in dir
+// layered over it. A file's entries replace embedded entries with the
+// same ID, and its version replaces the embedded version for its
+// component type. An empty dir loads only the embedded catalog.
+func Load(dir string) (*Catalog, error) {
+	var files []file
+
+	embedded, err := dataFS.ReadDir("data")
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded catalog: %w", err)
+	}
+	for _, de := range embedded {
+		data, err := dataFS.ReadFile("data/" + de.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading embedded catalog %s: %w", de.Name(), err)
+		}
+		f, err := parseFile(de.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	if dir != "" {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("listing catalog files: %w", err)
+		}
+		slices.Sort(paths)
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading catalog file: %w", err)
+			}
+			f, err := parseFile(path, data)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+		}
+	}
+
+	return build(files)
+}
+
+func parseFile(name string, data []byte) (file, error) {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return file{}, fmt.Errorf("parsing catalog %s: %w", name, err)
+	}
+	if f.ComponentType == "" {
+		return file{}, fmt.Errorf("catalog %s: component_type is required", name)
+	}
+	for i := range f.Entries {
+		e := &f.Entries[i]
+		if e.Manufacturer == "" || e.Family == "" || e.Model == "" {
+			return file{}, fmt.Errorf("catalog %s: entry %d: manufacturer, family and model are required", name, i+1)
+		}
+		e.ComponentType = f.ComponentType
+		e.ID = strings.Join([]string{
+			f.ComponentType, keySegment(e.Manufacturer), keySegment(e.Family), keySegment(e.Model),
+		}, ":")
+	}
+	return f, nil
+}
+
+func build(files []file) (*Catalog, error) {
+	c := &Catalog{versions: make(map[string]string), index: make(map[string]int)}
+
+	byID := make(map[string]int)
+	for _, f := range files {
+		c.versions[f.ComponentType] = f.Version
+		for _, e := range f.Entries {
+			if i, ok := byID[e.ID]; ok {
+				c.entries[i] = e
+				continue
+			}
+			byID[e.ID] = len(c.entries)
+			c.entries = append(c.entries, e)
+		}
+	}
+
+	var errs []error
+	for i := range c.entries {
+		e := &c.entries[i]
+		for _, name := range lookupNames(e) {
+			key := e.ComponentType + ":" + name
+			if j, ok := c.index[key]; ok && j != i {
+				errs = append(errs, fmt.Errorf("catalog: %s and %s both match %q", c.entries[j].ID, e.ID, name))
+				continue
+			}
+			c.index[key] = i
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+// lookupNames returns the normalized spellings an entry is found by:
+// its model and aliases, alone and prefixed with its family and
+// manufacturer, so "Xeon Gold 6148" and "6148" both find the 6148.
+func lookupNames(e *Entry) []string {
+	var names []string
+	for _, m := range append([]string{e.Model}, e.Aliases...) {
+		names = append(names,
+			normalize(m),
+			normalize(e.Family+m),
+			normalize(e.Manufacturer+m),
+			normalize(e.Manufacturer+e.Family+m),
+		)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Versions returns the catalog data version of each component type.
+func (c *Catalog) Versions() map[string]string {
+	return maps.Clone(c.versions)
+}
+
+// Covers reports whether the catalog has entries for componentType.
+func (c *Catalog) Covers(componentType string) bool {
+	_, ok := c.versions[componentType]
+	return ok
+}
+
+// Entries returns the entries of one component type, or every entry
+// when componentType is empty, in ID order.
+func (c *Catalog) Entries(componentType string) []Entry {
+	var out []Entry
+	for i := range c.entries {
+		if componentType == "" || c.entries[i].ComponentType == componentType {
+			out = append(out, c.entries[i])
+		}
+	}
+	slices.SortFunc(out, func(a, b Entry) int { return strings.Compare(a.ID, b.ID) })
+	return out
+}
+
+// Lookup finds the entry for a model of the given component type. The
+// model matches ignoring case, spacing and punctuation, with or without
+// its family and manufacturer in front.
+func (c *Catalog) Lookup(componentType, model string) (*Entry, bool) {
+	i, ok := c.index[componentType+":"+normalize(model)]
+	if !ok {
+		return nil, false
+	}
+	return &c.entries[i], true
+}
+
+// Enrich looks up the model named by extracted attributes and fills in
+// what the title left out: the catalog specs, plus manufacturer and
+// family when they are missing, which fills the "unknown" segments of
+// the product key. Extracted values are never overwritten, and an entry
+// whose manufacturer contradicts the extracted one is not applied.
+// Enriched attributes record the entry in catalog_id.
+func (c *Catalog) Enrich(componentType string, attrs map[string]any) (*Entry, bool) {
+	model, _ := attrs["model"].(string)
+	if model == "" {
+		return nil, false
+	}
+	e, ok := c.Lookup(componentType, model)
+	if !ok {
+		return nil, false
+	}
+	if mfr, _ := attrs["manufacturer"].(string); mfr != "" && !strings.EqualFold(mfr, e.Manufacturer) {
+		return nil, false
+	}
+
+	setMissing(attrs, "manufacturer", e.Manufacturer)
+	setMissing(attrs, "family", e.Family)
+	for k, v := range e.Specs {
+		setMissing(attrs, k, v)
+	}
+	attrs["catalog_id"] = e.ID
+	return e, true
+}
+
+func setMissing(attrs map[string]any, key string, v any) {
+	switch cur := attrs[key].(type) {
+	case nil:
+	case string:
+		if cur != "" && !strings.EqualFold(cur, "unknown") {
+			return
+		}
+	default:
+		return
+	}
+	attrs[key] = v
+}
+
+// normalize lowercases s and drops everything but letters and digits.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// keySegment formats s the way product keys do: lowercase, spaces as
+// underscores.
+func keySegment(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), " ", "_")
+}
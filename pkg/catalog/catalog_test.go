@@ -0,0 +1,176 @@
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
+)
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	c, err := catalog.Default()
+	require.NoError(t, err)
+
+	versions := c.Versions()
+	assert.NotEmpty(t, versions["cpu"])
+	assert.NotEmpty(t, versions["gpu"])
+
+	cpus := c.Entries("cpu")
+	require.NotEmpty(t, cpus)
+	for _, e := range cpus {
+		assert.Equal(t, "cpu", e.ComponentType)
+		assert.Contains(t, e.Specs, "cores", e.ID)
+	}
+	assert.Greater(t, len(c.Entries("")), len(cpus))
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	c, err := catalog.Default()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		componentType string
+		model         string
+		wantID        string
+	}{
+		{name: "bare model number", componentType: "cpu", model: "6148", wantID: "cpu:intel:xeon:6148"},
+		{name: "tier alias", componentType: "cpu", model: "Gold 6148", wantID: "cpu:intel:xeon:6148"},
+		{name: "family and tier", componentType: "cpu", model: "Xeon Gold 6148", wantID: "cpu:intel:xeon:6148"},
+		{name: "e5 without space", componentType: "cpu", model: "E5-2680v4", wantID: "cpu:intel:xeon:e5-2680_v4"},
+		{name: "epyc with manufacturer", componentType: "cpu", model: "AMD EPYC 7742", wantID: "cpu:amd:epyc:7742"},
+		{name: "gpu with family", componentType: "gpu", model: "Tesla P40", wantID: "gpu:nvidia:tesla:p40"},
+		{name: "gpu brand alias", componentType: "gpu", model: "RTX 3090", wantID: "gpu:nvidia:geforce-rtx:3090"},
+		{name: "wrong component type", componentType: "gpu", model: "6148"},
+		{name: "unknown model", componentType: "cpu", model: "E5-2999 v9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, ok := c.Lookup(tt.componentType, tt.model)
+			if tt.wantID == "" {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.wantID, e.ID)
+		})
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	t.Parallel()
+
+	c, err := catalog.Default()
+	require.NoError(t, err)
+
+	t.Run("fills missing fields only", func(t *testing.T) {
+		t.Parallel()
+
+		attrs := map[string]any{
+			"manufacturer": "Intel",
+			"family":       "",
+			"model":        "Gold 6148",
+			"cores":        18.0, // title said 18; keep it
+		}
+		e, ok := c.Enrich("cpu", attrs)
+		require.True(t, ok)
+		assert.Equal(t, "cpu:intel:xeon:6148", e.ID)
+		assert.Equal(t, "Xeon", attrs["family"])
+		assert.Equal(t, 18.0, attrs["cores"])
+		assert.Equal(t, 40, attrs["threads"])
+		assert.Equal(t, 150, attrs["tdp_watts"])
+		assert.Equal(t, 2017, attrs["release_year"])
+		assert.Equal(t, "cpu:intel:xeon:6148", attrs["catalog_id"])
+	})
+
+	t.Run("fills unknown gpu key segments", func(t *testing.T) {
+		t.Parallel()
+
+		attrs := map[string]any{"manufacturer": "NVIDIA", "model": "L4"}
+		_, ok := c.Enrich("gpu", attrs)
+		require.True(t, ok)
+		assert.Equal(t, "l-series", attrs["family"])
+		assert.Equal(t, 24, attrs["vram_gb"])
+		assert.InDelta(t, 121.0, attrs["fp16_tflops"], 0.001)
+	})
+
+	t.Run("manufacturer mismatch is not applied", func(t *testing.T) {
+		t.Parallel()
+
+		attrs := map[string]any{"manufacturer": "AMD", "model": "6148"}
+		_, ok := c.Enrich("cpu", attrs)
+		assert.False(t, ok)
+		assert.NotContains(t, attrs, "cores")
+	})
+
+	t.Run("no model", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := c.Enrich("cpu", map[string]any{"manufacturer": "Intel"})
+		assert.False(t, ok)
+	})
+}
+
+func TestLoad_Overrides(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu-local.yaml"), []byte(`
+version: local-1
+component_type: cpu
+entries:
+  - {manufacturer: Intel, family: Xeon, model: "6148", aliases: [Gold 6148], specs: {cores: 20, tdp_watts: 145}}
+  - {manufacturer: Intel, family: Xeon, model: "6150", specs: {cores: 18}}
+`), 0o600))
+
+	c, err := catalog.Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "local-1", c.Versions()["cpu"])
+
+	e, ok := c.Lookup("cpu", "Gold 6148")
+	require.True(t, ok)
+	assert.Equal(t, 145, e.Specs["tdp_watts"])
+	assert.NotContains(t, e.Specs, "release_year", "override replaces the whole entry")
+
+	_, ok = c.Lookup("cpu", "6150")
+	assert.True(t, ok)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing component type", data: "version: x\nentries: []\n"},
+		{name: "missing model", data: "component_type: cpu\nentries:\n  - {manufacturer: Intel, family: Xeon}\n"},
+		{
+			name: "ambiguous alias",
+			data: "component_type: cpu\nentries:\n  - {manufacturer: Intel, family: Xeon, model: \"9999\", aliases: [\"6148\"]}\n",
+		},
+		{name: "bad yaml", data: "component_type: [cpu\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte(tt.data), 0o600))
+			_, err := catalog.Load(dir)
+			require.Error(t, err)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -37,6 +39,8 @@ type LLMExtractor struct {
 	log         *slog.Logger
 	tracer      trace.Tracer    // no-op when OTel is disabled
 	langfuse    langfuse.Client // NoopClient when Langfuse is disabled
+	prompts     *Prompts
+	shadow      *Shadow // nil when shadow extraction is off
 	temperature float64
 	maxTokens   int
 }
@@ -89,6 +93,16 @@ func WithLangfuseClient(c langfuse.Client) LLMExtractorOption {
 	}
 }
 
+// WithPrompts replaces the built-in prompt set, e.g. with one from
+// LoadPrompts.
+func WithPrompts(p *Prompts) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		if p != nil {
+			e.prompts = p
+		}
+	}
+}
+
 // NewLLMExtractor creates a new LLMExtractor.
 func NewLLMExtractor(backend LLMBackend, opts ...LLMExtractorOption) *LLMExtractor {
 	e := &LLMExtractor{
@@ -97,6 +111,7 @@ func NewLLMExtractor(backend LLMBackend, opts ...LLMExtractorOption) *LLMExtract
 		log:         slog.Default(),
 		tracer:      otel.Tracer(extractorTracerName),
 		langfuse:    langfuse.NoopClient{},
+		prompts:     defaultPrompts,
 		temperature: 0.1,
 		maxTokens:   512,
 	}
@@ -121,10 +136,55 @@ func stripJSONFences(s string) string {
 	return strings.TrimSpace(s)
 }
 
-// recordTokens emits LLM token telemetry for a successful Generate response.
-// Called before JSON parse / validation so the metric reflects billed tokens,
-// not just tokens that produced useful output.
-func (e *LLMExtractor) recordTokens(resp GenerateResponse) {
+// extractionKey is the context key of the Extraction that recordTokens
+// tallies LLM calls into.
+type extractionKey struct{}
+
+// Extraction is one ClassifyAndExtract result with the LLM usage it
+// took. Calls is zero when a deterministic rule answered without the
+// LLM; Model is the model that answered the last call.
+type Extraction struct {
+	ComponentType domain.ComponentType
+	Attributes    map[string]any
+	Calls         int
+	Model         string
+	Usage         TokenUsage
+	Latency       time.Duration
+}
+
+// CostUSD prices the extraction's tokens from costs. ok is false when
+// its model has no rate.
+func (x *Extraction) CostUSD(costs map[string]langfuse.ModelCost) (cost float64, ok bool) {
+	rate, ok := costs[x.Model]
+	if !ok {
+		return 0, false
+	}
+	return rate.ComputeCost(langfuse.TokenUsage{
+		InputTokens:  x.Usage.PromptTokens,
+		OutputTokens: x.Usage.CompletionTokens,
+		TotalTokens:  x.Usage.TotalTokens,
+	}), true
+}
+
+// withExtraction returns ctx carrying a fresh Extraction that
+// recordTokens adds to.
+func withExtraction(ctx context.Context) (context.Context, *Extraction) {
+	x := &Extraction{}
+	return context.WithValue(ctx, extractionKey{}, x), x
+}
+
+// recordTokens emits LLM token telemetry for a successful Generate response
+// and adds it to the ctx usage tally, if any. Called before JSON parse /
+// validation so the metric reflects billed tokens, not just tokens that
+// produced useful output.
+func (e *LLMExtractor) recordTokens(ctx context.Context, resp GenerateResponse) {
+	if x, ok := ctx.Value(extractionKey{}).(*Extraction); ok {
+		x.Calls++
+		x.Model = resp.Model
+		x.Usage.PromptTokens += resp.Usage.PromptTokens
+		x.Usage.CompletionTokens += resp.Usage.CompletionTokens
+		x.Usage.TotalTokens += resp.Usage.TotalTokens
+	}
 	metrics.ExtractionTokensTotal.
 		WithLabelValues(e.backendName, resp.Model, directionInput).
 		Add(float64(resp.Usage.PromptTokens))
@@ -143,11 +203,29 @@ var validComponentTypes = map[string]domain.ComponentType{
 	"cpu":         domain.ComponentCPU,
 	"nic":         domain.ComponentNIC,
 	"gpu":         domain.ComponentGPU,
+	"hba":         domain.ComponentHBA,
+	"switch":      domain.ComponentSwitch,
+	"transceiver": domain.ComponentTransceiver,
 	"workstation": domain.ComponentWorkstation,
 	"desktop":     domain.ComponentDesktop,
 	"other":       domain.ComponentOther,
 }
 
+// ParseComponentType returns the component type named by s, reporting
+// false for names the extractor never produces.
+func ParseComponentType(s string) (domain.ComponentType, bool) {
+	ct, ok := validComponentTypes[s]
+	return ct, ok
+}
+
+// ComponentTypes returns every component type the extractor produces,
+// sorted by name.
+func ComponentTypes() []domain.ComponentType {
+	out := slices.Collect(maps.Values(validComponentTypes))
+	slices.Sort(out)
+	return out
+}
+
 // Classify determines the component type from a listing title.
 func (e *LLMExtractor) Classify(
 	ctx context.Context,
@@ -160,7 +238,7 @@ func (e *LLMExtractor) Classify(
 	)
 	defer span.End()
 
-	prompt, err := RenderClassifyPrompt(title)
+	prompt, err := e.prompts.RenderClassify(title)
 	if err != nil {
 		return "", recordSpanError(span, fmt.Errorf("rendering classify prompt: %w", err))
 	}
@@ -173,7 +251,7 @@ func (e *LLMExtractor) Classify(
 	if err != nil {
 		return "", recordSpanError(span, fmt.Errorf("calling LLM for classification: %w", err))
 	}
-	e.recordTokens(resp)
+	e.recordTokens(ctx, resp)
 	span.SetAttributes(
 		attribute.String("spt.llm.model", resp.Model),
 		attribute.Int("spt.llm.tokens.input", resp.Usage.PromptTokens),
@@ -208,7 +286,7 @@ func (e *LLMExtractor) Extract(
 	)
 	defer span.End()
 
-	prompt, err := RenderExtractPrompt(componentType, title, itemSpecifics)
+	prompt, err := e.prompts.RenderExtract(componentType, title, itemSpecifics)
 	if err != nil {
 		return nil, recordSpanError(span, fmt.Errorf("rendering extract prompt: %w", err))
 	}
@@ -222,7 +300,7 @@ func (e *LLMExtractor) Extract(
 	if err != nil {
 		return nil, recordSpanError(span, fmt.Errorf("calling LLM for extraction: %w", err))
 	}
-	e.recordTokens(resp)
+	e.recordTokens(ctx, resp)
 	span.SetAttributes(
 		attribute.String("spt.llm.model", resp.Model),
 		attribute.Int("spt.llm.tokens.input", resp.Usage.PromptTokens),
@@ -361,10 +439,55 @@ const accessoryShortCircuitConfidence = 0.95
 //     `Series`, `Product Line`) route to workstation/desktop without
 //     the LLM classifier. See DESIGN-0015 Open Question 1.
 //  4. LLM Classify → Extract.
+//
+// With a shadow configured, a sampled fraction of successful
+// extractions that reached the LLM are repeated asynchronously through
+// the shadow extractor for comparison; the result returned is always
+// this extractor's.
 func (e *LLMExtractor) ClassifyAndExtract(
 	ctx context.Context,
 	title string,
 	itemSpecifics map[string]string,
+) (domain.ComponentType, map[string]any, error) {
+	x, err := e.ClassifyAndExtractWithUsage(ctx, title, itemSpecifics)
+	return x.ComponentType, x.Attributes, err
+}
+
+// ClassifyAndExtractWithUsage is ClassifyAndExtract returning the LLM
+// calls, tokens and latency the extraction took alongside its result.
+// The Extraction is non-nil even on error, so usage billed before a
+// failure is still reported.
+func (e *LLMExtractor) ClassifyAndExtractWithUsage(
+	ctx context.Context,
+	title string,
+	itemSpecifics map[string]string,
+) (*Extraction, error) {
+	x, err := e.extraction(ctx, title, itemSpecifics)
+	if err == nil && e.shadow != nil && x.Calls > 0 {
+		e.shadow.maybeCompare(title, itemSpecifics, e.backendName, x)
+	}
+	return x, err
+}
+
+// extraction runs classifyAndExtract, tallying its usage.
+func (e *LLMExtractor) extraction(
+	ctx context.Context,
+	title string,
+	itemSpecifics map[string]string,
+) (*Extraction, error) {
+	ctx, x := withExtraction(ctx)
+	start := time.Now()
+	ct, attrs, err := e.classifyAndExtract(ctx, title, itemSpecifics)
+	x.ComponentType, x.Attributes, x.Latency = ct, attrs, time.Since(start)
+	return x, err
+}
+
+// classifyAndExtract is ClassifyAndExtract without the shadow
+// comparison; the shadow extractor runs it directly.
+func (e *LLMExtractor) classifyAndExtract(
+	ctx context.Context,
+	title string,
+	itemSpecifics map[string]string,
 ) (domain.ComponentType, map[string]any, error) {
 	ctx, span := e.tracer.Start(ctx, "extract.classify_and_extract")
 	defer span.End()
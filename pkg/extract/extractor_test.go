@@ -3,6 +3,7 @@ package extract_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -562,6 +563,67 @@ func TestLLMExtractor_ClassifyAndExtract(t *testing.T) {
 			},
 			wantType: domain.ComponentGPU,
 		},
+		{
+			name:  "hba lsi 9300-8i full pipeline",
+			title: "Broadcom LSI SAS9300-8i 12Gb/s SAS HBA IT Mode",
+			setupMock: func(m *extractMocks.MockLLMBackend) {
+				m.EXPECT().
+					Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool {
+						return r.Format == ""
+					})).
+					Return(extract.GenerateResponse{Content: "hba"}, nil).
+					Once()
+				m.EXPECT().
+					Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool {
+						return r.Format == "json"
+					})).
+					Return(extract.GenerateResponse{
+						Content: `{
+							"manufacturer": "Broadcom",
+							"model": "SAS9300-8i",
+							"chipset": "SAS3008",
+							"mode": "IT mode",
+							"cache_mb": 0,
+							"pcie_generation": 3,
+							"condition": "used_working",
+							"quantity": 1,
+							"confidence": 0.9
+						}`,
+					}, nil).
+					Once()
+			},
+			wantType: domain.ComponentHBA,
+		},
+		{
+			name:  "transceiver dac bypasses accessory short-circuit",
+			title: "Cisco SFP-H10GB-CU3M Compatible 10G SFP+ DAC Twinax Cable 3m",
+			setupMock: func(m *extractMocks.MockLLMBackend) {
+				m.EXPECT().
+					Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool {
+						return r.Format == ""
+					})).
+					Return(extract.GenerateResponse{Content: "transceiver"}, nil).
+					Once()
+				m.EXPECT().
+					Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool {
+						return r.Format == "json"
+					})).
+					Return(extract.GenerateResponse{
+						Content: `{
+							"form_factor": "SFP+",
+							"speed": "10G",
+							"media": "dac",
+							"reach_m": 3,
+							"vendor_coding": "Cisco compatible",
+							"condition": "new",
+							"quantity": 1,
+							"confidence": 0.9
+						}`,
+					}, nil).
+					Once()
+			},
+			wantType: domain.ComponentTransceiver,
+		},
 		{
 			name:  "gpu a100 vram unit confusion repaired",
 			title: "NVIDIA A100 80GB SXM4 GPU",
@@ -1187,3 +1249,26 @@ func TestLLMExtractor_AutoScoreErrorDoesNotPropagate(t *testing.T) {
 	require.NotNil(t, attrs)
 	assert.InDelta(t, 0.93, attrs["confidence"], 0.0001)
 }
+
+func TestParseComponentType(t *testing.T) {
+	t.Parallel()
+
+	ct, ok := extract.ParseComponentType("hba")
+	assert.True(t, ok)
+	assert.Equal(t, domain.ComponentHBA, ct)
+
+	_, ok = extract.ParseComponentType("tape")
+	assert.False(t, ok)
+}
+
+func TestComponentTypes(t *testing.T) {
+	t.Parallel()
+
+	types := extract.ComponentTypes()
+	assert.True(t, slices.IsSorted(types))
+	assert.Contains(t, types, domain.ComponentTransceiver)
+	for _, ct := range types {
+		_, ok := extract.ParseComponentType(string(ct))
+		assert.True(t, ok, ct)
+	}
+}
@@ -0,0 +1,135 @@
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hbaManufacturerAliases collapses the LSI → Avago → Broadcom rebrands
+// onto one token so a 9300-8i listed under any of the three names lands
+// on the same baseline. Keys are lowercased+trimmed before lookup.
+var hbaManufacturerAliases = map[string]string{
+	"lsi":                        "lsi",
+	"lsi logic":                  "lsi",
+	"lsi corp":                   "lsi",
+	"avago":                      "lsi",
+	"broadcom":                   "lsi",
+	"broadcom/lsi":               "lsi",
+	"lsi/broadcom":               "lsi",
+	"hp":                         "hp",
+	"hpe":                        "hp",
+	"hewlett packard enterprise": "hp",
+}
+
+// hbaModeAliases maps firmware-mode spellings to the validator's enum.
+// "HBA" and passthrough/JBOD firmware are IT mode in all but name.
+var hbaModeAliases = map[string]string{
+	"it":          "IT",
+	"it mode":     "IT",
+	"it-mode":     "IT",
+	"hba":         "IT",
+	"hba mode":    "IT",
+	"passthrough": "IT",
+	"jbod":        "IT",
+	"ir":          "IR",
+	"ir mode":     "IR",
+	"ir-mode":     "IR",
+	"raid":        "RAID",
+	"raid mode":   "RAID",
+	"megaraid":    "RAID",
+	"mr":          "RAID",
+}
+
+// hbaPortSuffixRe matches the LSI port suffix ("9300 8i", "9207_8e") so
+// the separator can be normalised to a hyphen and the ports inferred.
+var hbaPortSuffixRe = regexp.MustCompile(`(\d{4})[\s_-]*(\d{1,2})([ie])$`)
+
+// CanonicalizeHBAModel collapses common spellings of a storage
+// controller model to a canonical lowercase token: "PERC H730P" →
+// "h730p", "SAS9300-8I" → "9300-8i", "LSI 9207 8e" → "9207-8e".
+// Unknown formats fall through as lowercase + trim.
+func CanonicalizeHBAModel(s string) string {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	if lower == "" {
+		return ""
+	}
+	if stripped := hbaPrefixStrip(lower); stripped != "" {
+		lower = stripped
+	}
+	return hbaPortSuffixRe.ReplaceAllString(lower, "${1}-${2}${3}")
+}
+
+// hbaPrefixStrip removes the vendor and line prefixes the LLM leaves on
+// the model ("Dell PERC H730P", "MegaRAID 9361-8i", "SAS9300-8i",
+// "Smart Array P420i"). A "sas" prefix is only stripped in front of a
+// full part number, so chip names like "SAS3008" used as the model
+// survive.
+func hbaPrefixStrip(s string) string {
+	for _, prefix := range []string{"dell ", "hpe ", "hp ", "lsi ", "broadcom ", "avago "} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	for _, prefix := range []string{"perc", "megaraid", "smart array", "smartarray"} {
+		if rest, ok := strings.CutPrefix(s, prefix); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	if rest, ok := strings.CutPrefix(s, "sas"); ok && hbaPortSuffixRe.MatchString(rest) {
+		return rest
+	}
+	return s
+}
+
+// NormalizeHBAExtraction repairs common storage controller LLM
+// mistakes before validation. Mutates attrs in place:
+//
+//  1. Manufacturer — LSI / Avago / Broadcom collapse to "lsi", HP/HPE
+//     to "hp".
+//  2. Model canonicalisation — vendor and line prefixes stripped, port
+//     suffix separator normalised.
+//  3. Mode — spellings mapped onto IT / IR / RAID.
+//  4. Ports — inferred from an LSI-style "-8i" / "-8e" model suffix
+//     when the LLM left port_count or ports empty.
+func NormalizeHBAExtraction(attrs map[string]any) {
+	if mfr, ok := attrString(attrs, "manufacturer"); ok {
+		if canonical, ok := hbaManufacturerAliases[strings.ToLower(strings.TrimSpace(mfr))]; ok {
+			attrs["manufacturer"] = canonical
+		}
+	}
+	if model, ok := attrString(attrs, "model"); ok {
+		if canonical := CanonicalizeHBAModel(model); canonical != "" {
+			attrs["model"] = canonical
+		}
+	}
+	if mode, ok := attrString(attrs, "mode"); ok {
+		if canonical, ok := hbaModeAliases[strings.ToLower(strings.TrimSpace(mode))]; ok {
+			attrs["mode"] = canonical
+		}
+	}
+	if iface, ok := attrString(attrs, "interface"); ok {
+		attrs["interface"] = strings.ToUpper(strings.TrimSpace(iface))
+	}
+	inferHBAPorts(attrs)
+}
+
+// inferHBAPorts fills port_count and ports from the model's port
+// suffix. Values the LLM supplied are kept.
+func inferHBAPorts(attrs map[string]any) {
+	model, _ := attrString(attrs, "model")
+	m := hbaPortSuffixRe.FindStringSubmatch(model)
+	if m == nil {
+		return
+	}
+	if _, ok := attrInt(attrs, "port_count"); !ok {
+		if n, err := strconv.Atoi(m[2]); err == nil {
+			attrs["port_count"] = n
+		}
+	}
+	if _, ok := attrString(attrs, "ports"); !ok {
+		if m[3] == "e" {
+			attrs["ports"] = "external"
+		} else {
+			attrs["ports"] = "internal"
+		}
+	}
+}
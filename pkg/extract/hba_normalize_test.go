@@ -0,0 +1,78 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+)
+
+func TestCanonicalizeHBAModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"perc prefix", "PERC H730P", "h730p"},
+		{"perc joined", "PERCH330", "h330"},
+		{"dell perc prefix", "Dell PERC H740P", "h740p"},
+		{"hba330 unchanged", "HBA330", "hba330"},
+		{"sas part number prefix", "SAS9300-8I", "9300-8i"},
+		{"lsi spaced port suffix", "LSI 9207 8e", "9207-8e"},
+		{"underscore port suffix", "9211_8i", "9211-8i"},
+		{"megaraid prefix", "MegaRAID 9361-8i", "9361-8i"},
+		{"smart array prefix", "Smart Array P420i", "p420i"},
+		{"chip name survives", "SAS3008", "sas3008"},
+		{"unknown lowercased", "  ASR-71605  ", "asr-71605"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, extract.CanonicalizeHBAModel(tt.input))
+		})
+	}
+}
+
+func TestNormalizeHBAExtraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "avago collapses to lsi",
+			attrs: map[string]any{"manufacturer": "Avago", "model": "9361-8i", "mode": "MegaRAID", "port_count": 8},
+			want:  map[string]any{"manufacturer": "lsi", "model": "9361-8i", "mode": "RAID", "port_count": 8, "ports": "internal"},
+		},
+		{
+			name:  "external ports inferred",
+			attrs: map[string]any{"manufacturer": "LSI", "model": "9207-8e", "mode": "it"},
+			want:  map[string]any{"manufacturer": "lsi", "model": "9207-8e", "mode": "IT", "port_count": 8, "ports": "external"},
+		},
+		{
+			name:  "llm port values kept",
+			attrs: map[string]any{"manufacturer": "LSI", "model": "9400-16i", "mode": "HBA", "port_count": 16, "ports": "mixed"},
+			want:  map[string]any{"manufacturer": "lsi", "model": "9400-16i", "mode": "IT", "port_count": 16, "ports": "mixed"},
+		},
+		{
+			name:  "dell perc keeps vendor, unknown mode untouched",
+			attrs: map[string]any{"manufacturer": "Dell", "model": "PERC H730P", "mode": "JBOD+RAID", "interface": "sas3"},
+			want:  map[string]any{"manufacturer": "Dell", "model": "h730p", "mode": "JBOD+RAID", "interface": "SAS3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			extract.NormalizeHBAExtraction(tt.attrs)
+			assert.Equal(t, tt.want, tt.attrs)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package extract
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// switchManufacturerAliases collapses rebrands onto one token so a
+// switch line keeps one baseline across owners: Mellanox switches now
+// ship as NVIDIA, and the Brocade ICX line moved to Ruckus.
+var switchManufacturerAliases = map[string]string{
+	"mellanox":        "mellanox",
+	"nvidia":          "mellanox",
+	"nvidia mellanox": "mellanox",
+	"brocade":         "brocade",
+	"ruckus":          "brocade",
+	"commscope":       "brocade",
+	"hp":              "hp",
+	"hpe":             "hp",
+	"aruba":           "hp",
+}
+
+// transceiverFormFactorAliases maps lowercased form-factor spellings to
+// the validator's enum.
+var transceiverFormFactorAliases = map[string]string{
+	"sfp":      "SFP",
+	"sfp+":     "SFP+",
+	"sfp plus": "SFP+",
+	"sfpp":     "SFP+",
+	"sfp28":    "SFP28",
+	"qsfp":     "QSFP+",
+	"qsfp+":    "QSFP+",
+	"qsfp28":   "QSFP28",
+	"qsfp56":   "QSFP56",
+	"qsfp-dd":  "QSFP-DD",
+	"qsfpdd":   "QSFP-DD",
+	"osfp":     "OSFP",
+}
+
+// transceiverMediaAliases maps lowercased media spellings to the
+// validator's enum.
+var transceiverMediaAliases = map[string]string{
+	"optic":   "optic",
+	"optical": "optic",
+	"fiber":   "optic",
+	"fibre":   "optic",
+	"dac":     "dac",
+	"twinax":  "dac",
+	"copper":  "dac",
+	"aoc":     "aoc",
+	"base-t":  "base-t",
+	"baset":   "base-t",
+	"rj45":    "base-t",
+}
+
+// genericVendorCodings are the vendor_coding spellings meaning the
+// module is not coded for any vendor.
+var genericVendorCodings = []string{"generic", "none", "uncoded", "oem", "universal", "compatible", "msa"}
+
+// transceiverSpeedRe matches data-rate spellings: "10G", "10Gb",
+// "10Gbps", "10 GbE", "100GBE".
+var transceiverSpeedRe = regexp.MustCompile(`^(\d+)\s*g(?:b(?:e|ps|/s)?)?$`)
+
+// NormalizeSwitchExtraction repairs common switch LLM mistakes before
+// validation. Mutates attrs in place: the manufacturer collapses across
+// rebrands and the model loses a leading vendor token and is lowercased,
+// so "Arista DCS-7050S-52" and "dcs-7050s-52" share a baseline.
+func NormalizeSwitchExtraction(attrs map[string]any) {
+	mfr, _ := attrString(attrs, "manufacturer")
+	mfr = strings.ToLower(strings.TrimSpace(mfr))
+	if canonical, ok := switchManufacturerAliases[mfr]; ok {
+		mfr = canonical
+	}
+	if mfr != "" {
+		attrs["manufacturer"] = mfr
+	}
+
+	if model, ok := attrString(attrs, "model"); ok {
+		model = strings.ToLower(strings.TrimSpace(model))
+		for _, prefix := range []string{mfr + " ", "nvidia ", "mellanox ", "ruckus ", "brocade "} {
+			model = strings.TrimPrefix(model, prefix)
+		}
+		attrs["model"] = model
+	}
+}
+
+// NormalizeTransceiverExtraction repairs common transceiver LLM
+// mistakes before validation. Mutates attrs in place:
+//
+//  1. form_factor, speed and media spellings map onto their enums
+//     ("sfp+" → "SFP+", "10G" → "10GbE", "twinax" → "dac").
+//  2. vendor_coding is lowercased, loses a "compatible" suffix
+//     ("Cisco compatible" → "cisco") and uncoded spellings collapse
+//     to "generic".
+//  3. wavelength_nm is dropped for DAC and base-t modules, which have
+//     none.
+func NormalizeTransceiverExtraction(attrs map[string]any) {
+	if ff, ok := attrString(attrs, "form_factor"); ok {
+		if canonical, ok := transceiverFormFactorAliases[strings.ToLower(strings.TrimSpace(ff))]; ok {
+			attrs["form_factor"] = canonical
+		}
+	}
+	if spd, ok := attrString(attrs, "speed"); ok {
+		if m := transceiverSpeedRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(spd))); m != nil {
+			attrs["speed"] = m[1] + "GbE"
+		}
+	}
+	media, _ := attrString(attrs, "media")
+	if canonical, ok := transceiverMediaAliases[strings.ToLower(strings.TrimSpace(media))]; ok {
+		attrs["media"] = canonical
+		media = canonical
+	}
+	if media == "dac" || media == "base-t" {
+		delete(attrs, "wavelength_nm")
+	}
+	if coding, ok := attrString(attrs, "vendor_coding"); ok {
+		attrs["vendor_coding"] = CanonicalizeVendorCoding(coding)
+	}
+}
+
+// CanonicalizeVendorCoding returns the lowercase vendor a module's
+// EEPROM is coded for, with "compatible" suffixes removed, HP/HPE/Aruba
+// collapsed to "hp", NVIDIA to "mellanox", and uncoded spellings
+// collapsed to "generic".
+func CanonicalizeVendorCoding(s string) string {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	lower = strings.TrimSpace(strings.TrimSuffix(lower, "compatible"))
+	if lower == "" || slices.Contains(genericVendorCodings, lower) {
+		return "generic"
+	}
+	if canonical, ok := switchManufacturerAliases[lower]; ok {
+		return canonical
+	}
+	return lower
+}
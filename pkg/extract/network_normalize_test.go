@@ -0,0 +1,146 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestNormalizeSwitchExtraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "vendor prefix stripped from model",
+			attrs: map[string]any{"manufacturer": "Arista", "model": "Arista DCS-7050S-52"},
+			want:  map[string]any{"manufacturer": "arista", "model": "dcs-7050s-52"},
+		},
+		{
+			name:  "nvidia collapses to mellanox",
+			attrs: map[string]any{"manufacturer": "NVIDIA", "model": "Mellanox SN2410"},
+			want:  map[string]any{"manufacturer": "mellanox", "model": "sn2410"},
+		},
+		{
+			name:  "ruckus collapses to brocade",
+			attrs: map[string]any{"manufacturer": "Ruckus", "model": "ICX7150-48P"},
+			want:  map[string]any{"manufacturer": "brocade", "model": "icx7150-48p"},
+		},
+		{
+			name:  "missing manufacturer left alone",
+			attrs: map[string]any{"model": "EX4300-48T"},
+			want:  map[string]any{"model": "ex4300-48t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			extract.NormalizeSwitchExtraction(tt.attrs)
+			assert.Equal(t, tt.want, tt.attrs)
+		})
+	}
+}
+
+func TestNormalizeTransceiverExtraction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  map[string]any
+	}{
+		{
+			name: "optic spellings canonicalised",
+			attrs: map[string]any{
+				"form_factor": "sfp+", "speed": "10G", "media": "Fiber",
+				"wavelength_nm": 850, "vendor_coding": "Cisco Compatible",
+			},
+			want: map[string]any{
+				"form_factor": "SFP+", "speed": "10GbE", "media": "optic",
+				"wavelength_nm": 850, "vendor_coding": "cisco",
+			},
+		},
+		{
+			name: "dac drops wavelength",
+			attrs: map[string]any{
+				"form_factor": "QSFP", "speed": "40 Gbps", "media": "twinax",
+				"wavelength_nm": 850, "vendor_coding": "none",
+			},
+			want: map[string]any{
+				"form_factor": "QSFP+", "speed": "40GbE", "media": "dac",
+				"vendor_coding": "generic",
+			},
+		},
+		{
+			name: "unknown spellings left alone",
+			attrs: map[string]any{
+				"form_factor": "XFP", "speed": "8GFC", "media": "laser",
+			},
+			want: map[string]any{
+				"form_factor": "XFP", "speed": "8GFC", "media": "laser",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			extract.NormalizeTransceiverExtraction(tt.attrs)
+			assert.Equal(t, tt.want, tt.attrs)
+		})
+	}
+}
+
+func TestCanonicalizeVendorCoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "generic"},
+		{"Compatible", "generic"},
+		{"OEM", "generic"},
+		{"Arista", "arista"},
+		{"Juniper compatible", "juniper"},
+		{"HPE", "hp"},
+		{"NVIDIA", "mellanox"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, extract.CanonicalizeVendorCoding(tt.input))
+		})
+	}
+}
+
+// TestNormalizeExtraction_TransceiverNormalizesViaSwitch confirms the
+// transceiver hook runs from inside NormalizeExtraction.
+func TestNormalizeExtraction_TransceiverNormalizesViaSwitch(t *testing.T) {
+	t.Parallel()
+
+	attrs := map[string]any{
+		"form_factor":   "sfp28",
+		"speed":         "25G",
+		"media":         "DAC",
+		"connector":     "N/A",
+		"vendor_coding": "Mellanox",
+		"condition":     "new",
+		"confidence":    0.9,
+	}
+	extract.NormalizeExtraction(domain.ComponentTransceiver, "Mellanox MCP2M00-A003 25G SFP28 DAC 3m", attrs)
+
+	assert.Equal(t, "SFP28", attrs["form_factor"])
+	assert.Equal(t, "25GbE", attrs["speed"])
+	assert.Equal(t, "dac", attrs["media"])
+	assert.Equal(t, "mellanox", attrs["vendor_coding"])
+	assert.NotContains(t, attrs, "connector")
+}
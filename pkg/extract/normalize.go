@@ -13,7 +13,7 @@ var placeholderValues = []string{"n/a", "na", "unknown", "none", "null", "not sp
 
 // optionalEnumFields lists string fields that are optional enums and should
 // have placeholder values stripped (set to null) before validation.
-var optionalEnumFields = []string{"form_factor", "type", "interface", "port_type"}
+var optionalEnumFields = []string{"form_factor", "type", "interface", "port_type", "ports", "connector"}
 
 // NormalizeExtraction runs all pre-validation cleanups against the raw LLM
 // attribute map. It mutates attrs in place. Run this before
@@ -39,6 +39,18 @@ func NormalizeExtraction(componentType domain.ComponentType, title string, attrs
 		NormalizeGPUExtraction(attrs)
 	}
 
+	if componentType == domain.ComponentHBA {
+		NormalizeHBAExtraction(attrs)
+	}
+
+	if componentType == domain.ComponentSwitch {
+		NormalizeSwitchExtraction(attrs)
+	}
+
+	if componentType == domain.ComponentTransceiver {
+		NormalizeTransceiverExtraction(attrs)
+	}
+
 	if componentType == domain.ComponentWorkstation || componentType == domain.ComponentDesktop {
 		NormalizeSystemExtraction(componentType, attrs)
 	}
@@ -237,3 +237,28 @@ func TestNormalizeExtraction_GPUNormalizesViaSwitch(t *testing.T) {
 	assert.Equal(t, 24, attrs["vram_gb"])
 	assert.Equal(t, "tesla", attrs["family"])
 }
+
+// TestNormalizeExtraction_HBANormalizesViaSwitch confirms the HBA
+// normalisation hook runs from inside NormalizeExtraction.
+func TestNormalizeExtraction_HBANormalizesViaSwitch(t *testing.T) {
+	t.Parallel()
+
+	attrs := map[string]any{
+		"manufacturer": "Broadcom",
+		"model":        "SAS9300-8I",
+		"mode":         "IT Mode",
+		"ports":        "N/A",
+		"condition":    "used_working",
+		"confidence":   0.9,
+	}
+	extract.NormalizeExtraction(
+		domain.ComponentHBA,
+		"Broadcom LSI SAS9300-8I 12Gb/s HBA IT Mode",
+		attrs,
+	)
+	assert.Equal(t, "lsi", attrs["manufacturer"])
+	assert.Equal(t, "9300-8i", attrs["model"])
+	assert.Equal(t, "IT", attrs["mode"])
+	assert.Equal(t, 8, attrs["port_count"])
+	assert.Equal(t, "internal", attrs["ports"])
+}
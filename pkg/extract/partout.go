@@ -0,0 +1,123 @@
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ServerPart is one kind of part inside a server, identified by a SQL
+// LIKE pattern over the product keys its standalone listings are
+// priced under. Key is set when the part names one exact product key,
+// which may since have been merged into another (see KeyPatternFor).
+type ServerPart struct {
+	ComponentType string
+	Key           string
+	KeyPattern    string
+	Quantity      int
+}
+
+var (
+	// xeonERe matches Xeon E3/E5/E7/W model numbers with an optional
+	// version suffix, e.g. "E5-2680 v4", "E5-2680v4", "W-2145".
+	xeonERe = regexp.MustCompile(`\b([ew][357]?-\d{4}[a-z]?)(?:\s*(v\d))?\b`)
+	// modelNumberRe matches a bare Xeon Scalable or EPYC model number,
+	// e.g. "6248R", "8380", "7742", "7b13".
+	modelNumberRe = regexp.MustCompile(`\b(\d[0-9a-z]\d{2}[a-z]{0,2})\b`)
+	// xeonTierRe matches the Xeon Scalable tiers, which identify an
+	// Intel part even when "Xeon" is left out.
+	xeonTierRe = regexp.MustCompile(`\b(bronze|silver|gold|platinum)\b`)
+)
+
+// ServerParts maps a server's extracted CPU and RAM attributes onto
+// the parts a part-out would sell. CPUs resolve to the exact cpu key
+// when cpu_model names a Xeon or EPYC part; RAM resolves to registered
+// ECC sticks of ram_total_gb / ram_stick_count, any generation and
+// speed, since the server extraction records neither. Parts that
+// can't be identified are left out.
+func ServerParts(attrs map[string]any) []ServerPart {
+	var parts []ServerPart
+	if p, ok := serverCPUPart(attrs); ok {
+		parts = append(parts, p)
+	}
+	if p, ok := serverRAMPart(attrs); ok {
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func serverCPUPart(attrs map[string]any) (ServerPart, bool) {
+	if installed, ok := attrs["cpu_installed"].(bool); ok && !installed {
+		return ServerPart{}, false
+	}
+	raw, _ := attrs["cpu_model"].(string)
+	key, ok := cpuKeyFromModel(raw)
+	if !ok {
+		return ServerPart{}, false
+	}
+	count := pkInt(attrs, "cpu_count")
+	if count <= 0 {
+		count = 1
+	}
+	return ServerPart{ComponentType: "cpu", Key: key, KeyPattern: KeyPatternFor(key), Quantity: count}, true
+}
+
+// cpuKeyFromModel turns a free-form CPU model such as "2x Intel Xeon
+// Gold 6248R" into the cpu product key its standalone listings use.
+func cpuKeyFromModel(raw string) (string, bool) {
+	s := strings.ToLower(raw)
+	isEPYC := strings.Contains(s, "epyc")
+	isXeon := strings.Contains(s, "xeon") || xeonTierRe.MatchString(s)
+
+	if m := xeonERe.FindStringSubmatch(s); m != nil && !isEPYC {
+		model := m[1]
+		if m[2] != "" {
+			model += " " + m[2]
+		}
+		return cpuKey("intel", "xeon", model), true
+	}
+
+	m := modelNumberRe.FindStringSubmatch(s)
+	switch {
+	case m == nil:
+		return "", false
+	case isEPYC:
+		return cpuKey("amd", "epyc", m[1]), true
+	case isXeon:
+		return cpuKey("intel", "xeon", m[1]), true
+	default:
+		return "", false
+	}
+}
+
+func cpuKey(manufacturer, family, model string) string {
+	return ProductKey("cpu", map[string]any{
+		"manufacturer": manufacturer,
+		"family":       family,
+		"model":        model,
+	})
+}
+
+func serverRAMPart(attrs map[string]any) (ServerPart, bool) {
+	total := pkInt(attrs, "ram_total_gb")
+	sticks := pkInt(attrs, "ram_stick_count")
+	if total <= 0 || sticks <= 0 || total%sticks != 0 {
+		return ServerPart{}, false
+	}
+	return ServerPart{
+		ComponentType: "ram",
+		KeyPattern:    fmt.Sprintf(`ram:%%:ecc\_reg:%dgb:%%`, total/sticks),
+		Quantity:      sticks,
+	}, true
+}
+
+// KeyPatternFor is the LIKE pattern matching exactly the product key
+// key.
+func KeyPatternFor(key string) string {
+	return likeEscape(key)
+}
+
+// likeEscape escapes the LIKE wildcards in a literal product key.
+func likeEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,66 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+)
+
+func TestServerParts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  []extract.ServerPart
+	}{
+		{
+			name: "xeon scalable and ram",
+			attrs: map[string]any{
+				"cpu_model":       "Intel Xeon Gold 6248R",
+				"cpu_count":       2,
+				"ram_total_gb":    256,
+				"ram_stick_count": 8,
+			},
+			want: []extract.ServerPart{
+				{ComponentType: "cpu", Key: "cpu:intel:xeon:6248r", KeyPattern: "cpu:intel:xeon:6248r", Quantity: 2},
+				{ComponentType: "ram", KeyPattern: `ram:%:ecc\_reg:32gb:%`, Quantity: 8},
+			},
+		},
+		{
+			name:  "xeon e5 with version suffix",
+			attrs: map[string]any{"cpu_model": "E5-2680v4", "cpu_count": float64(2)},
+			want: []extract.ServerPart{
+				{ComponentType: "cpu", Key: "cpu:intel:xeon:e5-2680_v4", KeyPattern: `cpu:intel:xeon:e5-2680\_v4`, Quantity: 2},
+			},
+		},
+		{
+			name:  "epyc defaults to one cpu",
+			attrs: map[string]any{"cpu_model": "AMD EPYC 7742 64-Core"},
+			want: []extract.ServerPart{
+				{ComponentType: "cpu", Key: "cpu:amd:epyc:7742", KeyPattern: "cpu:amd:epyc:7742", Quantity: 1},
+			},
+		},
+		{
+			name:  "barebones server",
+			attrs: map[string]any{"cpu_model": "Xeon Gold 6248R", "cpu_installed": false},
+		},
+		{
+			name:  "unrecognized cpu",
+			attrs: map[string]any{"cpu_model": "2x 8-core"},
+		},
+		{
+			name:  "uneven ram sticks",
+			attrs: map[string]any{"ram_total_gb": 96, "ram_stick_count": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, extract.ServerParts(tt.attrs))
+		})
+	}
+}
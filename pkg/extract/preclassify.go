@@ -65,6 +65,16 @@ var primaryComponentPatterns = []*regexp.Regexp{
 	// accessory keyword ("Tesla P40 + heatsink") should defer to the LLM
 	// rather than short-circuit to "other".
 	regexp.MustCompile(`\b(tesla|quadro|rtx\s+a\d+|a100|h100|l40|mi\d{3}|radeon\s+pro)\b`),
+	// Storage controller tokens — a RAID controller or HBA sold with its
+	// battery or breakout cable ("PERC H730P + battery cable") defers to
+	// the LLM rather than short-circuiting to "other".
+	regexp.MustCompile(`\b(perc\s+h\d{3}p?|hba\d{3}|megaraid|raid\s+controller|\d{4}-\d{1,2}[ie])\b`),
+	// Transceiver and switch tokens — a DAC is a cable, and optics or a
+	// switch sold with rack ears or a power cable defer to the LLM
+	// rather than short-circuiting to "other". "sfp+" / "qsfp+" end in a
+	// non-word character, so they carry no trailing \b.
+	regexp.MustCompile(`\b(q?sfp\+|q?sfp28|qsfp56|qsfp-dd|osfp)|\b(dac|aoc|twinax|transceivers?)\b`),
+	regexp.MustCompile(`\b(dcs-\d{4}\w*|m?sn\d{4}|icx\d{4}|catalyst|nexus|network\s+switch)\b`),
 	// Workstation chassis tokens (DESIGN-0015) — a real workstation paired
 	// with an accessory keyword ("Precision T7920 + 80mm fan") defers to the
 	// LLM rather than short-circuiting to "other".
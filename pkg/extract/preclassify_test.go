@@ -77,6 +77,31 @@ func TestIsAccessoryOnly(t *testing.T) {
 			title: "Intel Xeon heatsink for LGA2011",
 			want:  false,
 		},
+		{
+			name:  "raid controller with battery cable defers to llm",
+			title: "Dell PERC H730P 2GB Mini Mono RAID Controller w/ Battery Cable",
+			want:  false,
+		},
+		{
+			name:  "lsi hba with bracket defers to llm",
+			title: "LSI 9300-8i 12Gb/s HBA IT Mode Full Height Bracket",
+			want:  false,
+		},
+		{
+			name:  "dac cable defers to llm",
+			title: "Cisco SFP-H10GB-CU3M Compatible 10G SFP+ DAC Twinax Cable 3m",
+			want:  false,
+		},
+		{
+			name:  "qsfp breakout cable defers to llm",
+			title: "QSFP28 100G to 4x SFP28 25G breakout cable",
+			want:  false,
+		},
+		{
+			name:  "switch with rack rails defers to llm",
+			title: "Arista DCS-7050S-52 switch with rack rails",
+			want:  false,
+		},
 		{
 			name:  "sas cable defers to llm",
 			title: "Dell mini-SAS HD cable 0.5m",
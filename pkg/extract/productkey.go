@@ -49,6 +49,28 @@ func ProductKey(componentType string, attrs map[string]any) string {
 			normalizeStr(attrs["model"]),
 			pkInt(attrs, "vram_gb"),
 		)
+	case "hba":
+		return fmt.Sprintf("hba:%s:%s:%s",
+			normalizeStr(attrs["manufacturer"]),
+			normalizeStr(attrs["model"]),
+			normalizeStr(attrs["mode"]),
+		)
+	case "switch":
+		return fmt.Sprintf("switch:%s:%s:%s:%s",
+			normalizeStr(attrs["manufacturer"]),
+			normalizeStr(attrs["model"]),
+			switchPortMix(attrs),
+			switchPoE(attrs),
+		)
+	case "transceiver":
+		return fmt.Sprintf("transceiver:%s:%s:%s:%dm:%dnm:%s",
+			normalizeStr(attrs["form_factor"]),
+			normalizeStr(attrs["speed"]),
+			normalizeStr(attrs["media"]),
+			pkInt(attrs, "reach_m"),
+			pkInt(attrs, "wavelength_nm"),
+			normalizeStr(attrs["vendor_coding"]),
+		)
 	case "workstation":
 		return fmt.Sprintf("workstation:%s:%s:%s",
 			normalizeStr(attrs["vendor"]),
@@ -148,3 +170,30 @@ func driveType(attrs map[string]any) string {
 		return "hdd"
 	}
 }
+
+// switchPortMix summarises a switch's front-panel ports by speed,
+// slowest first: "48x1g+4x10g".
+func switchPortMix(attrs map[string]any) string {
+	var parts []string
+	for _, field := range switchPortFields {
+		if n := pkInt(attrs, field); n > 0 {
+			parts = append(parts, fmt.Sprintf("%dx%s", n, strings.TrimPrefix(field, "ports_")))
+		}
+	}
+	if len(parts) == 0 {
+		return unknownKey
+	}
+	return strings.Join(parts, "+")
+}
+
+// switchPoE returns "poe" or "nopoe" from the poe flag.
+func switchPoE(attrs map[string]any) string {
+	poe, ok := attrs["poe"].(bool)
+	if !ok {
+		return unknownKey
+	}
+	if poe {
+		return "poe"
+	}
+	return "nopoe"
+}
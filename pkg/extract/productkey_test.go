@@ -170,6 +170,94 @@ func TestProductKey(t *testing.T) {
 			},
 			want: "nic:25gbe:2p:sfp28",
 		},
+		{
+			name:          "HBA Dell PERC H730P RAID",
+			componentType: "hba",
+			attrs: map[string]any{
+				"manufacturer": "dell",
+				"model":        "h730p",
+				"mode":         "RAID",
+			},
+			want: "hba:dell:h730p:raid",
+		},
+		{
+			name:          "HBA LSI 9300-8i IT mode",
+			componentType: "hba",
+			attrs: map[string]any{
+				"manufacturer": "lsi",
+				"model":        "9300-8i",
+				"mode":         "IT",
+			},
+			want: "hba:lsi:9300-8i:it",
+		},
+		{
+			name:          "HBA missing mode",
+			componentType: "hba",
+			attrs: map[string]any{
+				"manufacturer": "lsi",
+				"model":        "9207-8e",
+			},
+			want: "hba:lsi:9207-8e:unknown",
+		},
+		{
+			name:          "switch mixed speeds with PoE",
+			componentType: "switch",
+			attrs: map[string]any{
+				"manufacturer": "brocade",
+				"model":        "icx6610-48p",
+				"ports_1g":     48,
+				"ports_10g":    8,
+				"ports_40g":    float64(2),
+				"poe":          true,
+			},
+			want: "switch:brocade:icx6610-48p:48x1g+8x10g+2x40g:poe",
+		},
+		{
+			name:          "switch without PoE",
+			componentType: "switch",
+			attrs: map[string]any{
+				"manufacturer": "arista",
+				"model":        "dcs-7050s-52",
+				"ports_1g":     0,
+				"ports_10g":    52,
+				"poe":          false,
+			},
+			want: "switch:arista:dcs-7050s-52:52x10g:nopoe",
+		},
+		{
+			name:          "switch with no ports or poe flag",
+			componentType: "switch",
+			attrs: map[string]any{
+				"manufacturer": "mellanox",
+				"model":        "sn2410",
+			},
+			want: "switch:mellanox:sn2410:unknown:unknown",
+		},
+		{
+			name:          "transceiver SR optic",
+			componentType: "transceiver",
+			attrs: map[string]any{
+				"form_factor":   "SFP+",
+				"speed":         "10GbE",
+				"media":         "optic",
+				"reach_m":       300,
+				"wavelength_nm": 850,
+				"vendor_coding": "cisco",
+			},
+			want: "transceiver:sfp+:10gbe:optic:300m:850nm:cisco",
+		},
+		{
+			name:          "transceiver DAC has no wavelength",
+			componentType: "transceiver",
+			attrs: map[string]any{
+				"form_factor":   "QSFP28",
+				"speed":         "100GbE",
+				"media":         "dac",
+				"reach_m":       3,
+				"vendor_coding": "generic",
+			},
+			want: "transceiver:qsfp28:100gbe:dac:3m:0nm:generic",
+		},
 		{
 			name:          "unknown type falls through to other",
 			componentType: "psu",
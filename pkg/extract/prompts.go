@@ -3,6 +3,9 @@ package extract
 import (
 	"bytes"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -14,7 +17,7 @@ const classifyTmpl = `Classify this eBay listing into exactly one component type
 
 Title: {{.Title}}
 
-Types: ram, drive, server, cpu, nic, gpu, workstation, desktop, other
+Types: ram, drive, server, cpu, nic, gpu, hba, switch, transceiver, workstation, desktop, other
 
 Rules:
 - Pick the type of the actual item being sold, not what it is for or compatible with.
@@ -23,6 +26,11 @@ Rules:
 - Only pick "server" for complete or barebones rack-mountable server chassis (Dell PowerEdge, HP ProLiant, Cisco UCS, Supermicro). A tower with workstation lineage is NOT a server, regardless of CPU class.
 - Pick "gpu" for standalone graphics cards / accelerators (Tesla, Quadro, RTX, A/L/H-series, Radeon Pro, Instinct, Arc).
 - "gpu riser", "GPU bracket", and "GPU power cable" stay in "other" (they are accessories, not the GPU itself).
+- Pick "hba" for storage controller cards: RAID controllers and host bus adapters (Dell PERC H730/H740P/HBA330, LSI/Broadcom/Avago MegaRAID and 9200/9300/9400-series, HP Smart Array, IT-mode cards).
+- Controller batteries, cache modules sold alone, SAS cables, and SAS expanders stay in "other".
+- Only pick "nic" for network interface cards that install in a server. Pick "switch" for network switches (Arista DCS, Mellanox/NVIDIA SN, Brocade/Ruckus ICX, Cisco Catalyst/Nexus, Juniper EX/QFX, Ubiquiti, MikroTik).
+- Pick "transceiver" for pluggable optics and direct-attach cables: SFP/SFP+/SFP28/QSFP+/QSFP28 modules, DAC/twinax and AOC cables. These are NOT "other" even though DACs are cables.
+- A NIC or switch sold with optics included is still "nic" or "switch".
 - Pick "workstation" for vendor-defined workstation product lines: Dell Precision (T-series), Dell Pro Max, Lenovo ThinkStation (P-series), HP Z-series.
 - Pick "desktop" for tower-form general-purpose computers without a workstation product line: Dell OptiPlex / Dell Pro, Lenovo ThinkCentre, HP EliteDesk, custom builds.
 - Workstations and desktops that contain a GPU still classify as "workstation" or "desktop" — not "gpu". The GPU is part of the bundled system.
@@ -255,6 +263,128 @@ Schema:
   "confidence": float (0.0-1.0)
 }`
 
+// hbaTmpl is the storage controller (RAID controller / HBA) extraction
+// prompt template.
+const hbaTmpl = `Extract structured attributes from this eBay storage controller (RAID controller / HBA) listing.
+Respond ONLY with a valid JSON object. No markdown, no explanation.
+
+Rules:
+- For enum fields, you MUST use one of the listed values exactly. Never return null for enum fields.
+- "manufacturer": the brand on the card (e.g. "Dell", "LSI", "Broadcom", "HP", "Adaptec"). Never null.
+- "model": the card model token without the brand (e.g. "H730P", "9300-8i", "HBA330", "P420i"). Never null.
+- "chipset": the controller chip when stated or well known (e.g. "SAS3008", "SAS3108", "SAS2308").
+- "port_count": total SAS/SATA ports or lanes. "8i" = 8 internal, "8e" = 8 external, "16i" = 16 internal. Never null.
+- "mode": "IT" for IT-mode / passthrough / HBA firmware, "IR" for IR firmware, "RAID" for RAID firmware
+  (PERC H7xx/H8xx, MegaRAID, Smart Array). Never null.
+- "cache_mb": onboard cache in MB. Convert "2GB" to 2048. Use 0 for cacheless HBAs.
+- "condition": if the listing does not specify, use "unknown".
+- "confidence": always return a float between 0.0 and 1.0. Never null.
+- "quantity": default to 1 unless the title explicitly indicates a lot or bundle.
+- Only use null for optional string/integer/boolean fields that truly cannot be determined.
+
+Title: {{.Title}}
+Item Specifics: {{.ItemSpecifics}}
+
+Schema:
+{
+  "manufacturer": string,
+  "model": string,
+  "chipset": string | null,
+  "port_count": integer (1-32),
+  "ports": "internal" | "external" | "mixed" | null,
+  "mode": "IT" | "IR" | "RAID",
+  "cache_mb": integer (0-16384) | null,
+  "pcie_generation": integer (2-5) | null,
+  "interface": "SAS2" | "SAS3" | "SAS4" | "SATA" | null,
+  "form_factor": "full_height" | "low_profile" | "mini_mono" | "mezzanine" | null,
+  "battery_included": boolean | null,
+  "part_number": string | null,
+  "quantity": integer,
+  "condition": "new" | "like_new" | "used_working" | "for_parts" | "unknown",
+  "confidence": float (0.0-1.0)
+}`
+
+// switchTmpl is the network switch extraction prompt template.
+const switchTmpl = `Extract structured attributes from this eBay network switch listing.
+Respond ONLY with a valid JSON object. No markdown, no explanation.
+
+Rules:
+- For enum fields, you MUST use one of the listed values exactly. Never return null for enum fields.
+- "manufacturer": the switch vendor (e.g. "Arista", "Mellanox", "Brocade", "Cisco", "Juniper"). Never null.
+- "model": the switch model without the vendor (e.g. "DCS-7050S-52", "SN2410", "ICX6610-48P"). Never null.
+- "ports_1g" .. "ports_400g": number of front-panel ports at each speed. "48x 1GbE + 4x 10GbE SFP+" = ports_1g 48, ports_10g 4.
+  Use 0 for speeds the switch does not have. At least one must be greater than 0.
+- "poe": true only when the title or specifics state PoE/PoE+ ("-48P", "PoE+"). Never null; use false when not stated.
+- "condition": if the listing does not specify, use "unknown".
+- "confidence": always return a float between 0.0 and 1.0. Never null.
+- "quantity": default to 1 unless the title explicitly indicates a lot or bundle.
+- Only use null for optional string/integer/boolean fields that truly cannot be determined.
+
+Title: {{.Title}}
+Item Specifics: {{.ItemSpecifics}}
+
+Schema:
+{
+  "manufacturer": string,
+  "model": string,
+  "ports_1g": integer,
+  "ports_10g": integer,
+  "ports_25g": integer,
+  "ports_40g": integer,
+  "ports_100g": integer,
+  "ports_400g": integer,
+  "poe": boolean,
+  "poe_budget_watts": integer | null,
+  "layer": 2 | 3 | null,
+  "managed": boolean | null,
+  "rack_units": integer (1-4) | null,
+  "psu_count": integer | null,
+  "part_number": string | null,
+  "quantity": integer,
+  "condition": "new" | "like_new" | "used_working" | "for_parts" | "unknown",
+  "confidence": float (0.0-1.0)
+}`
+
+// transceiverTmpl is the optics / DAC extraction prompt template.
+const transceiverTmpl = `Extract structured attributes from this eBay network transceiver / DAC / AOC listing.
+Respond ONLY with a valid JSON object. No markdown, no explanation.
+
+Rules:
+- For enum fields, you MUST use one of the listed values exactly. Never return null for enum fields.
+- "form_factor": the module form factor. Never null.
+- "speed": the data rate. "10G" = "10GbE", "100G" = "100GbE". Never null.
+- "media": "optic" for fiber transceivers, "dac" for passive/active copper twinax, "aoc" for active optical cables,
+  "base-t" for RJ45 copper modules. Never null.
+- "standard": the optical standard when stated (e.g. "SR", "LR", "ER", "SR4", "LR4", "CWDM4", "10GBASE-T").
+- "reach_m": link reach or cable length in meters. SR = 300, SR4 = 100, LR/LR4 = 10000, ER = 40000. "3m DAC" = 3.
+- "wavelength_nm": optical wavelength in nm (850, 1310, 1550). null for DAC and base-t.
+- "vendor_coding": the switch/NIC vendor the EEPROM is coded for (e.g. "Cisco", "Arista", "Juniper", "Mellanox",
+  "Intel", "Dell", "HPE"). "Cisco compatible" = "Cisco". Use "generic" when uncoded. Never null.
+- "condition": if the listing does not specify, use "unknown".
+- "confidence": always return a float between 0.0 and 1.0. Never null.
+- "quantity": count of modules or cables. "Lot of 10" = 10. Default to 1.
+- Only use null for optional string/integer/boolean fields that truly cannot be determined.
+
+Title: {{.Title}}
+Item Specifics: {{.ItemSpecifics}}
+
+Schema:
+{
+  "manufacturer": string | null,
+  "form_factor": "SFP" | "SFP+" | "SFP28" | "QSFP+" | "QSFP28" | "QSFP56" | "QSFP-DD" | "OSFP",
+  "speed": "1GbE" | "10GbE" | "25GbE" | "40GbE" | "50GbE" | "100GbE" | "200GbE" | "400GbE",
+  "media": "optic" | "dac" | "aoc" | "base-t",
+  "standard": string | null,
+  "reach_m": integer | null,
+  "wavelength_nm": integer | null,
+  "connector": "LC" | "MPO" | "RJ45" | "fixed" | null,
+  "vendor_coding": string,
+  "part_number": string | null,
+  "quantity": integer,
+  "condition": "new" | "like_new" | "used_working" | "for_parts" | "unknown",
+  "confidence": float (0.0-1.0)
+}`
+
 // workstationTmpl is the workstation extraction prompt template.
 const workstationTmpl = `Extract structured attributes from this eBay workstation listing.
 Respond ONLY with a valid JSON object. No markdown, no explanation.
@@ -348,29 +478,98 @@ func init() {
 		domain.ComponentCPU:         template.Must(template.New("cpu").Parse(cpuTmpl)),
 		domain.ComponentNIC:         template.Must(template.New("nic").Parse(nicTmpl)),
 		domain.ComponentGPU:         template.Must(template.New("gpu").Parse(gpuTmpl)),
+		domain.ComponentHBA:         template.Must(template.New("hba").Parse(hbaTmpl)),
+		domain.ComponentSwitch:      template.Must(template.New("switch").Parse(switchTmpl)),
+		domain.ComponentTransceiver: template.Must(template.New("transceiver").Parse(transceiverTmpl)),
 		domain.ComponentWorkstation: template.Must(template.New("workstation").Parse(workstationTmpl)),
 		domain.ComponentDesktop:     template.Must(template.New("desktop").Parse(desktopTmpl)),
 	}
+	defaultPrompts = &Prompts{classify: classifyTemplate, extract: templates}
 }
 
 var classifyTemplate = template.Must(template.New("classify").Parse(classifyTmpl))
 
+// Prompts is a set of classification and extraction prompt templates.
+// The zero value is not usable; the built-in set backs the package
+// Render functions and LoadPrompts layers overrides on top of it.
+type Prompts struct {
+	classify *template.Template
+	extract  map[domain.ComponentType]*template.Template
+}
+
+// defaultPrompts is the built-in prompt set.
+var defaultPrompts *Prompts
+
+// LoadPrompts returns the built-in prompts with the templates in dir
+// layered on top: classify.tmpl replaces the classification prompt and
+// <component_type>.tmpl (ram.tmpl, gpu.tmpl, ...) replaces that type's
+// extraction prompt. Templates use the PromptData fields. Other files
+// are ignored; a template for a type without an extraction prompt is
+// an error.
+func LoadPrompts(dir string) (*Prompts, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading prompt dir: %w", err)
+	}
+
+	p := &Prompts{classify: classifyTemplate, extract: maps.Clone(templates)}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading prompt %s: %w", entry.Name(), err)
+		}
+		tmpl, err := template.New(name).Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing prompt %s: %w", entry.Name(), err)
+		}
+		if name == "classify" {
+			p.classify = tmpl
+			continue
+		}
+		ct, ok := ParseComponentType(name)
+		if _, builtin := templates[ct]; !ok || !builtin {
+			return nil, fmt.Errorf("prompt %s: no extraction prompt for component type %q", entry.Name(), name)
+		}
+		p.extract[ct] = tmpl
+	}
+	return p, nil
+}
+
 // RenderClassifyPrompt renders the classification prompt for a title.
 func RenderClassifyPrompt(title string) (string, error) {
+	return defaultPrompts.RenderClassify(title)
+}
+
+// RenderExtractPrompt renders the extraction prompt for a given component type.
+func RenderExtractPrompt(
+	componentType domain.ComponentType,
+	title string,
+	itemSpecifics map[string]string,
+) (string, error) {
+	return defaultPrompts.RenderExtract(componentType, title, itemSpecifics)
+}
+
+// RenderClassify renders the set's classification prompt for a title.
+func (p *Prompts) RenderClassify(title string) (string, error) {
 	var buf bytes.Buffer
-	if err := classifyTemplate.Execute(&buf, PromptData{Title: title}); err != nil {
+	if err := p.classify.Execute(&buf, PromptData{Title: title}); err != nil {
 		return "", fmt.Errorf("rendering classify prompt: %w", err)
 	}
 	return buf.String(), nil
 }
 
-// RenderExtractPrompt renders the extraction prompt for a given component type.
-func RenderExtractPrompt(
+// RenderExtract renders the set's extraction prompt for a component
+// type.
+func (p *Prompts) RenderExtract(
 	componentType domain.ComponentType,
 	title string,
 	itemSpecifics map[string]string,
 ) (string, error) {
-	tmpl, ok := templates[componentType]
+	tmpl, ok := p.extract[componentType]
 	if !ok {
 		return "", fmt.Errorf("no extraction prompt for component type %q", componentType)
 	}
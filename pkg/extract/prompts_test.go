@@ -1,6 +1,8 @@
 package extract_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,7 +25,7 @@ func TestRenderClassifyPrompt(t *testing.T) {
 			title: "Samsung 32GB DDR4 ECC",
 			wantSubs: []string{
 				"Title: Samsung 32GB DDR4 ECC",
-				"ram, drive, server, cpu, nic, gpu, workstation, desktop, other",
+				"ram, drive, server, cpu, nic, gpu, hba, switch, transceiver, workstation, desktop, other",
 				"Respond with ONLY a single word from the list above",
 			},
 		},
@@ -62,6 +64,24 @@ func TestRenderClassifyPrompt(t *testing.T) {
 				`"gpu riser", "GPU bracket"`,
 			},
 		},
+		{
+			name:  "hba routing guidance present",
+			title: "LSI 9300-8i 12Gb/s SAS HBA IT Mode",
+			wantSubs: []string{
+				`Pick "hba" for storage controller cards`,
+				"PERC H730/H740P/HBA330",
+				"SAS cables, and SAS expanders stay in \"other\"",
+			},
+		},
+		{
+			name:  "network routing guidance present",
+			title: "Cisco SFP-10G-SR 10GBASE-SR SFP+ Transceiver",
+			wantSubs: []string{
+				`Pick "switch" for network switches`,
+				`Pick "transceiver" for pluggable optics and direct-attach cables`,
+				`still "nic" or "switch"`,
+			},
+		},
 		{
 			name:  "title with special characters",
 			title: `Intel X710-DA2 10GbE SFP+ "Dual Port" & PCIe <x8>`,
@@ -183,6 +203,41 @@ func TestRenderExtractPrompt(t *testing.T) {
 				"Tesla",
 			},
 		},
+		{
+			name:          "HBA prompt",
+			componentType: domain.ComponentHBA,
+			title:         "LSI 9300-8i 12Gb/s SAS HBA IT Mode",
+			specs:         map[string]string{"Brand": "LSI"},
+			wantSubs: []string{
+				"Title: LSI 9300-8i 12Gb/s SAS HBA IT Mode",
+				"Brand: LSI",
+				`"chipset"`,
+				`"mode": "IT" | "IR" | "RAID"`,
+				`"cache_mb"`,
+				`"pcie_generation"`,
+			},
+		},
+		{
+			name:          "switch prompt",
+			componentType: domain.ComponentSwitch,
+			title:         "Arista DCS-7050S-52 52-Port 10GbE SFP+ Switch",
+			wantSubs: []string{
+				"Title: Arista DCS-7050S-52 52-Port 10GbE SFP+ Switch",
+				`"ports_10g"`,
+				`"poe": boolean`,
+			},
+		},
+		{
+			name:          "transceiver prompt",
+			componentType: domain.ComponentTransceiver,
+			title:         "Cisco SFP-10G-SR 10GBASE-SR SFP+ Transceiver",
+			wantSubs: []string{
+				"Title: Cisco SFP-10G-SR 10GBASE-SR SFP+ Transceiver",
+				`"media": "optic" | "dac" | "aoc" | "base-t"`,
+				`"wavelength_nm"`,
+				`"vendor_coding"`,
+			},
+		},
 		{
 			name:          "server uses generic render",
 			componentType: domain.ComponentServer,
@@ -303,3 +358,59 @@ func TestRenderExtractPrompt_RAMContainsPC4Rules(t *testing.T) {
 	assert.Contains(t, result, "PC5-38400=4800")
 	assert.Contains(t, result, "derived from PC module number if present")
 }
+
+func TestLoadPrompts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "classify.tmpl"), []byte("v2 classify: {{.Title}}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ram.tmpl"), []byte("v2 ram: {{.Title}} / {{.ItemSpecifics}}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o600))
+
+	p, err := extract.LoadPrompts(dir)
+	require.NoError(t, err)
+
+	got, err := p.RenderClassify("32GB DDR4")
+	require.NoError(t, err)
+	assert.Equal(t, "v2 classify: 32GB DDR4", got)
+
+	got, err = p.RenderExtract(domain.ComponentRAM, "32GB DDR4", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v2 ram: 32GB DDR4 / N/A", got)
+
+	got, err = p.RenderExtract(domain.ComponentCPU, "Xeon Gold 6130", nil)
+	require.NoError(t, err)
+	builtin, err := extract.RenderExtractPrompt(domain.ComponentCPU, "Xeon Gold 6130", nil)
+	require.NoError(t, err)
+	assert.Equal(t, builtin, got, "types without an override keep the built-in prompt")
+
+	builtin, err = extract.RenderExtractPrompt(domain.ComponentRAM, "32GB DDR4", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, builtin, "v2 ram", "loading a set leaves the built-in prompts alone")
+}
+
+func TestLoadPrompts_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown type", file: "tape.tmpl", content: "{{.Title}}", wantErr: "no extraction prompt"},
+		{name: "type without extraction", file: "other.tmpl", content: "{{.Title}}", wantErr: "no extraction prompt"},
+		{name: "bad template", file: "gpu.tmpl", content: "{{.Title", wantErr: "parsing prompt gpu.tmpl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0o600))
+			_, err := extract.LoadPrompts(dir)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/metrics"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// Shadow comparison results, the spt_shadow_extractions_total labels.
+const (
+	shadowResultAgree    = "agree"
+	shadowResultDisagree = "disagree"
+	shadowResultError    = "error"
+	shadowResultDropped  = "dropped"
+)
+
+// shadowRecordTimeout bounds the insert of a finished comparison.
+const shadowRecordTimeout = 10 * time.Second
+
+// ShadowRecorder persists shadow extraction comparisons. The store
+// implements it.
+type ShadowRecorder interface {
+	InsertShadowExtraction(ctx context.Context, s *domain.ShadowExtraction) error
+}
+
+// Shadow repeats a sampled fraction of an LLMExtractor's live
+// extractions through a second extractor — a candidate backend, model
+// or prompt set — and records where the two disagree and what each
+// cost. Comparisons run in the background on at most a fixed number of
+// goroutines; a sample arriving while every slot is busy is dropped
+// rather than queued, so the shadow never slows ingestion down.
+type Shadow struct {
+	extractor     *LLMExtractor
+	recorder      ShadowRecorder
+	log           *slog.Logger
+	sampleRate    float64
+	promptVersion string
+	costs         map[string]langfuse.ModelCost
+	timeout       time.Duration
+	slots         chan struct{}
+	wg            sync.WaitGroup
+}
+
+// ShadowOption configures a Shadow.
+type ShadowOption func(*Shadow)
+
+// WithShadowSampleRate sets the fraction of extractions, in [0, 1],
+// repeated through the shadow extractor. Defaults to 0.05.
+func WithShadowSampleRate(rate float64) ShadowOption {
+	return func(s *Shadow) {
+		s.sampleRate = rate
+	}
+}
+
+// WithShadowPromptVersion sets the label recorded with each comparison
+// to tell prompt candidates apart in reports.
+func WithShadowPromptVersion(v string) ShadowOption {
+	return func(s *Shadow) {
+		s.promptVersion = v
+	}
+}
+
+// WithShadowCosts supplies the per-model rate table both sides' costs
+// are computed from. Models without an entry record no cost.
+func WithShadowCosts(costs map[string]langfuse.ModelCost) ShadowOption {
+	return func(s *Shadow) {
+		s.costs = costs
+	}
+}
+
+// WithShadowConcurrency caps the comparisons running at once.
+// Defaults to 2.
+func WithShadowConcurrency(n int) ShadowOption {
+	return func(s *Shadow) {
+		if n > 0 {
+			s.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithShadowTimeout bounds each shadow extraction. Defaults to 2m.
+func WithShadowTimeout(d time.Duration) ShadowOption {
+	return func(s *Shadow) {
+		if d > 0 {
+			s.timeout = d
+		}
+	}
+}
+
+// WithShadowLogger sets the logger for shadow failures.
+func WithShadowLogger(l *slog.Logger) ShadowOption {
+	return func(s *Shadow) {
+		s.log = l
+	}
+}
+
+// NewShadow creates a Shadow that compares against extractor and
+// stores comparisons with recorder.
+func NewShadow(extractor *LLMExtractor, recorder ShadowRecorder, opts ...ShadowOption) *Shadow {
+	s := &Shadow{
+		extractor:  extractor,
+		recorder:   recorder,
+		log:        slog.Default(),
+		sampleRate: 0.05,
+		timeout:    2 * time.Minute,
+		slots:      make(chan struct{}, 2),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithShadow repeats a sampled fraction of the extractor's extractions
+// through s. See ClassifyAndExtract.
+func WithShadow(s *Shadow) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		e.shadow = s
+	}
+}
+
+// Wait blocks until every running comparison has been recorded.
+func (s *Shadow) Wait() {
+	s.wg.Wait()
+}
+
+// shadowSide is one side's result of a compared extraction.
+type shadowSide struct {
+	backend string
+	*Extraction
+}
+
+// maybeCompare samples the primary extraction and, when it is picked
+// and a slot is free, starts the shadow extraction in the background.
+// The inputs are copied first: the caller goes on to enrich attrs.
+func (s *Shadow) maybeCompare(title string, itemSpecifics map[string]string, backend string, primary *Extraction) {
+	if rand.Float64() >= s.sampleRate { //nolint:gosec // sampling, not security
+		return
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		metrics.ShadowExtractionsTotal.WithLabelValues(shadowResultDropped).Inc()
+		return
+	}
+
+	p := *primary
+	p.Attributes = maps.Clone(primary.Attributes)
+	specifics := maps.Clone(itemSpecifics)
+	s.wg.Go(func() {
+		defer func() { <-s.slots }()
+		s.compare(title, specifics, &shadowSide{backend: backend, Extraction: &p})
+	})
+}
+
+// compare runs the shadow extraction and records the comparison. It
+// starts from a fresh context: the primary's request or job may be
+// long gone, and shadow spans and scores must not land on its trace.
+func (s *Shadow) compare(title string, itemSpecifics map[string]string, primary *shadowSide) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	x, err := s.extractor.extraction(ctx, title, itemSpecifics)
+	cancel()
+
+	shadow := &shadowSide{backend: s.extractor.backendName, Extraction: x}
+	rec := s.comparison(title, primary, shadow, err)
+
+	result := shadowResultDisagree
+	switch {
+	case err != nil:
+		result = shadowResultError
+	case rec.Agrees():
+		result = shadowResultAgree
+	}
+	metrics.ShadowExtractionsTotal.WithLabelValues(result).Inc()
+
+	ctx, cancel = context.WithTimeout(context.Background(), shadowRecordTimeout)
+	defer cancel()
+	if err := s.recorder.InsertShadowExtraction(ctx, rec); err != nil {
+		s.log.Warn("recording shadow extraction failed", "title", title, "error", err)
+	}
+}
+
+// comparison builds the record of a shadow extraction against the
+// primary. Attributes are only diffed when both sides chose the same
+// component type; otherwise every attribute would disagree.
+func (s *Shadow) comparison(
+	title string,
+	primary, shadow *shadowSide,
+	shadowErr error,
+) *domain.ShadowExtraction {
+	rec := &domain.ShadowExtraction{
+		Title:                title,
+		PrimaryBackend:       primary.backend,
+		PrimaryModel:         primary.Model,
+		ShadowBackend:        shadow.backend,
+		ShadowModel:          shadow.Model,
+		PromptVersion:        s.promptVersion,
+		PrimaryComponentType: primary.ComponentType,
+		PrimaryProductKey:    ProductKey(string(primary.ComponentType), primary.Attributes),
+		PrimaryAttributes:    primary.Attributes,
+		ShadowAttributes:     map[string]any{},
+		Disagreements:        []domain.FieldDisagreement{},
+		PrimaryTokens:        primary.Usage.TotalTokens,
+		ShadowTokens:         shadow.Usage.TotalTokens,
+		PrimaryCostUSD:       s.cost(primary),
+		ShadowCostUSD:        s.cost(shadow),
+		PrimaryLatencyMs:     int(primary.Latency.Milliseconds()),
+		ShadowLatencyMs:      int(shadow.Latency.Milliseconds()),
+	}
+	if shadowErr != nil {
+		rec.ShadowError = shadowErr.Error()
+		return rec
+	}
+
+	rec.ShadowComponentType = shadow.ComponentType
+	rec.ShadowProductKey = ProductKey(string(shadow.ComponentType), shadow.Attributes)
+	rec.ShadowAttributes = shadow.Attributes
+	if shadow.ComponentType == primary.ComponentType {
+		rec.Disagreements = DiffAttributes(primary.Attributes, shadow.Attributes)
+	}
+	return rec
+}
+
+// cost prices a side's tokens, or returns nil when its model has no
+// rate.
+func (s *Shadow) cost(side *shadowSide) *float64 {
+	c, ok := side.CostUSD(s.costs)
+	if !ok {
+		return nil
+	}
+	return &c
+}
+
+// DiffAttributes returns the attributes a and b set differently,
+// sorted by name. confidence is ignored. Values compare by their
+// printed form, ignoring case, so 32 and 32.0 or "Samsung" and
+// "samsung" agree; a nil value counts as absent.
+func DiffAttributes(a, b map[string]any) []domain.FieldDisagreement {
+	fields := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		fields[k] = true
+	}
+	for k := range b {
+		fields[k] = true
+	}
+	delete(fields, "confidence")
+
+	diffs := []domain.FieldDisagreement{}
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		av, bv := a[field], b[field]
+		if AttributeValuesEqual(av, bv) {
+			continue
+		}
+		diffs = append(diffs, domain.FieldDisagreement{Field: field, Primary: av, Shadow: bv})
+	}
+	return diffs
+}
+
+// AttributeValuesEqual reports whether two attribute values agree the
+// way DiffAttributes compares them.
+func AttributeValuesEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return strings.EqualFold(
+		strings.TrimSpace(fmt.Sprint(a)),
+		strings.TrimSpace(fmt.Sprint(b)),
+	)
+}
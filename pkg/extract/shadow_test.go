@@ -0,0 +1,247 @@
+package extract_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// fakeShadowRecorder collects recorded comparisons.
+type fakeShadowRecorder struct {
+	mu   sync.Mutex
+	recs []*domain.ShadowExtraction
+}
+
+func (f *fakeShadowRecorder) InsertShadowExtraction(_ context.Context, s *domain.ShadowExtraction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recs = append(f.recs, s)
+	return nil
+}
+
+// expectCPUExtraction configures m to classify the title as a CPU and
+// extract model, answering as the given model.
+func expectCPUExtraction(m *extractMocks.MockLLMBackend, llmModel, cpuModel string, tokens int) {
+	usage := extract.TokenUsage{PromptTokens: tokens, CompletionTokens: tokens / 10, TotalTokens: tokens + tokens/10}
+	m.EXPECT().
+		Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool { return r.Format == "" })).
+		Return(extract.GenerateResponse{Content: "cpu", Model: llmModel, Usage: usage}, nil).
+		Once()
+	m.EXPECT().
+		Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool { return r.Format == "json" })).
+		Return(extract.GenerateResponse{
+			Content: `{"manufacturer": "Intel", "family": "Xeon", "model": "` + cpuModel + `",
+				"condition": "used_working", "confidence": 0.9, "quantity": 1}`,
+			Model: llmModel,
+			Usage: usage,
+		}, nil).
+		Once()
+}
+
+func TestShadow_RecordsDisagreementsAndCosts(t *testing.T) {
+	t.Parallel()
+
+	primaryBackend := extractMocks.NewMockLLMBackend(t)
+	expectName(primaryBackend, "ollama")
+	expectCPUExtraction(primaryBackend, "mistral", "6130", 1000)
+
+	shadowBackend := extractMocks.NewMockLLMBackend(t)
+	expectName(shadowBackend, "anthropic")
+	expectCPUExtraction(shadowBackend, "haiku", "6148", 400)
+
+	rec := &fakeShadowRecorder{}
+	shadow := extract.NewShadow(extract.NewLLMExtractor(shadowBackend), rec,
+		extract.WithShadowSampleRate(1),
+		extract.WithShadowPromptVersion("v2"),
+		extract.WithShadowCosts(map[string]langfuse.ModelCost{
+			"haiku": {InputUSDPerMillion: 1, OutputUSDPerMillion: 5},
+		}),
+	)
+	e := extract.NewLLMExtractor(primaryBackend, extract.WithShadow(shadow))
+
+	ct, attrs, err := e.ClassifyAndExtract(context.Background(), "Intel Xeon Gold 6130 SR3B0 2.1GHz", nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ComponentCPU, ct)
+	attrs["catalog_id"] = "cpu:intel:xeon:6130" // enrichment after return must not leak into the comparison
+	shadow.Wait()
+
+	require.Len(t, rec.recs, 1)
+	got := rec.recs[0]
+	assert.Equal(t, "ollama", got.PrimaryBackend)
+	assert.Equal(t, "mistral", got.PrimaryModel)
+	assert.Equal(t, "anthropic", got.ShadowBackend)
+	assert.Equal(t, "haiku", got.ShadowModel)
+	assert.Equal(t, "v2", got.PromptVersion)
+	assert.Equal(t, domain.ComponentCPU, got.ShadowComponentType)
+	assert.NotEqual(t, got.PrimaryProductKey, got.ShadowProductKey)
+	assert.Equal(t, []domain.FieldDisagreement{{Field: "model", Primary: "6130", Shadow: "6148"}}, got.Disagreements)
+	assert.NotContains(t, got.PrimaryAttributes, "catalog_id")
+	assert.Equal(t, 2200, got.PrimaryTokens)
+	assert.Equal(t, 880, got.ShadowTokens)
+	assert.Nil(t, got.PrimaryCostUSD, "primary model has no rate")
+	require.NotNil(t, got.ShadowCostUSD)
+	assert.InDelta(t, (800*1.0+80*5.0)/1e6, *got.ShadowCostUSD, 1e-12)
+	assert.False(t, got.Agrees())
+}
+
+func TestShadow_RecordsShadowError(t *testing.T) {
+	t.Parallel()
+
+	primaryBackend := extractMocks.NewMockLLMBackend(t)
+	expectName(primaryBackend, "ollama")
+	expectCPUExtraction(primaryBackend, "mistral", "6130", 100)
+
+	shadowBackend := extractMocks.NewMockLLMBackend(t)
+	expectName(shadowBackend, "openai_compat")
+	shadowBackend.EXPECT().Generate(mock.Anything, mock.Anything).
+		Return(extract.GenerateResponse{}, errors.New("connection refused")).Once()
+
+	rec := &fakeShadowRecorder{}
+	shadow := extract.NewShadow(extract.NewLLMExtractor(shadowBackend), rec, extract.WithShadowSampleRate(1))
+	e := extract.NewLLMExtractor(primaryBackend, extract.WithShadow(shadow))
+
+	_, _, err := e.ClassifyAndExtract(context.Background(), "Intel Xeon Gold 6130 SR3B0 2.1GHz", nil)
+	require.NoError(t, err, "shadow failures never reach the caller")
+	shadow.Wait()
+
+	require.Len(t, rec.recs, 1)
+	assert.Contains(t, rec.recs[0].ShadowError, "connection refused")
+	assert.Empty(t, rec.recs[0].ShadowComponentType)
+}
+
+func TestShadow_SkipsUnsampledAndLLMFreeExtractions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rate  float64
+		title string
+		setup func(*extractMocks.MockLLMBackend)
+	}{
+		{
+			name:  "not sampled",
+			rate:  0,
+			title: "Intel Xeon Gold 6130 SR3B0 2.1GHz",
+			setup: func(m *extractMocks.MockLLMBackend) { expectCPUExtraction(m, "mistral", "6130", 100) },
+		},
+		{
+			name:  "accessory short-circuit makes no LLM call",
+			rate:  1,
+			title: "Dell R740 2.5in Drive Caddy Tray",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			primaryBackend := extractMocks.NewMockLLMBackend(t)
+			expectName(primaryBackend, "ollama")
+			if tt.setup != nil {
+				tt.setup(primaryBackend)
+			}
+			shadowBackend := extractMocks.NewMockLLMBackend(t)
+			expectName(shadowBackend, "anthropic")
+
+			rec := &fakeShadowRecorder{}
+			shadow := extract.NewShadow(extract.NewLLMExtractor(shadowBackend), rec, extract.WithShadowSampleRate(tt.rate))
+			e := extract.NewLLMExtractor(primaryBackend, extract.WithShadow(shadow))
+
+			_, _, err := e.ClassifyAndExtract(context.Background(), tt.title, nil)
+			require.NoError(t, err)
+			shadow.Wait()
+			assert.Empty(t, rec.recs)
+		})
+	}
+}
+
+func TestLLMExtractor_ClassifyAndExtractWithUsage(t *testing.T) {
+	t.Parallel()
+
+	backend := extractMocks.NewMockLLMBackend(t)
+	expectName(backend, "ollama")
+	expectCPUExtraction(backend, "mistral", "6130", 1000)
+
+	e := extract.NewLLMExtractor(backend)
+	x, err := e.ClassifyAndExtractWithUsage(context.Background(), "Intel Xeon Gold 6130 SR3B0 2.1GHz", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ComponentCPU, x.ComponentType)
+	assert.Equal(t, "6130", x.Attributes["model"])
+	assert.Equal(t, 2, x.Calls)
+	assert.Equal(t, "mistral", x.Model)
+	assert.Equal(t, extract.TokenUsage{PromptTokens: 2000, CompletionTokens: 200, TotalTokens: 2200}, x.Usage)
+
+	cost, ok := x.CostUSD(map[string]langfuse.ModelCost{
+		"mistral": {InputUSDPerMillion: 1, OutputUSDPerMillion: 10},
+	})
+	require.True(t, ok)
+	assert.InDelta(t, 0.004, cost, 1e-9)
+
+	_, ok = x.CostUSD(map[string]langfuse.ModelCost{"haiku": {InputUSDPerMillion: 1}})
+	assert.False(t, ok)
+}
+
+func TestLLMExtractor_ClassifyAndExtractWithUsage_NoLLM(t *testing.T) {
+	t.Parallel()
+
+	backend := extractMocks.NewMockLLMBackend(t)
+	expectName(backend, "ollama")
+
+	e := extract.NewLLMExtractor(backend)
+	x, err := e.ClassifyAndExtractWithUsage(context.Background(), "Dell R740 2.5in Drive Caddy Tray", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ComponentOther, x.ComponentType)
+	assert.Zero(t, x.Calls)
+	assert.Zero(t, x.Usage)
+}
+
+func TestDiffAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b map[string]any
+		want []domain.FieldDisagreement
+	}{
+		{
+			name: "equal modulo case, number form and confidence",
+			a:    map[string]any{"manufacturer": "Samsung", "capacity_gb": 32, "confidence": 0.9},
+			b:    map[string]any{"manufacturer": "samsung", "capacity_gb": 32.0, "confidence": 0.5},
+			want: []domain.FieldDisagreement{},
+		},
+		{
+			name: "missing and nil count as absent",
+			a:    map[string]any{"speed_mhz": 2666, "part_number": nil},
+			b:    map[string]any{"rank": "2Rx4"},
+			want: []domain.FieldDisagreement{
+				{Field: "rank", Primary: nil, Shadow: "2Rx4"},
+				{Field: "speed_mhz", Primary: 2666, Shadow: nil},
+			},
+		},
+		{
+			name: "changed value",
+			a:    map[string]any{"generation": "DDR4"},
+			b:    map[string]any{"generation": "DDR3"},
+			want: []domain.FieldDisagreement{{Field: "generation", Primary: "DDR4", Shadow: "DDR3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, extract.DiffAttributes(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package extract
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// DefaultSimilarKeyDistance is the edit distance SimilarProductKeys
+// suggests merges within when the caller doesn't choose one.
+const DefaultSimilarKeyDistance = 2
+
+// SimilarProductKeys suggests near-duplicate product keys: pairs of the
+// same component type within maxDistance edits whose digits agree. The
+// digit rule keeps apart keys that differ by capacity or speed
+// ("32gb" vs "16gb"), which are close in edits but different products.
+// Each suggestion merges the key with fewer listings into the other.
+// Suggestions are ordered punctuation-only first, then by distance.
+func SimilarProductKeys(keys []domain.ProductKeyCount, maxDistance int) []domain.ProductKeySuggestion {
+	if maxDistance <= 0 {
+		maxDistance = DefaultSimilarKeyDistance
+	}
+
+	var out []domain.ProductKeySuggestion
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			a, b := keys[i], keys[j]
+			if keyType(a.ProductKey) != keyType(b.ProductKey) ||
+				abs(len(a.ProductKey)-len(b.ProductKey)) > maxDistance ||
+				digits(a.ProductKey) != digits(b.ProductKey) {
+				continue
+			}
+			d := editDistance(a.ProductKey, b.ProductKey)
+			if d == 0 || d > maxDistance {
+				continue
+			}
+			if b.Listings > a.Listings || (b.Listings == a.Listings && b.ProductKey < a.ProductKey) {
+				a, b = b, a
+			}
+			out = append(out, domain.ProductKeySuggestion{
+				From:            b.ProductKey,
+				To:              a.ProductKey,
+				FromListings:    b.Listings,
+				ToListings:      a.Listings,
+				Distance:        d,
+				PunctuationOnly: alnum(a.ProductKey) == alnum(b.ProductKey),
+			})
+		}
+	}
+
+	slices.SortFunc(out, func(x, y domain.ProductKeySuggestion) int {
+		if x.PunctuationOnly != y.PunctuationOnly {
+			if x.PunctuationOnly {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(
+			cmp.Compare(x.Distance, y.Distance),
+			cmp.Compare(y.FromListings+y.ToListings, x.FromListings+x.ToListings),
+			cmp.Compare(x.From, y.From),
+		)
+	})
+	return out
+}
+
+func keyType(key string) string {
+	t, _, _ := strings.Cut(key, ":")
+	return t
+}
+
+// digits returns the key's digits in order.
+func digits(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, key)
+}
+
+// alnum returns the key without its separators and punctuation.
+func alnum(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, key)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
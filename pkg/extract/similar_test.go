@@ -0,0 +1,50 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestSimilarProductKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := []domain.ProductKeyCount{
+		{ProductKey: "cpu:intel:xeon:e5-2690_v4", Listings: 40},
+		{ProductKey: "cpu:intel:xeon:e5-2690v4", Listings: 3},
+		{ProductKey: "cpu:intel:xeon:e5-2690_v3", Listings: 12},
+		{ProductKey: "ram:ddr4:ecc_reg:32gb:2666", Listings: 50},
+		{ProductKey: "ram:ddr4:ecc_reg:16gb:2666", Listings: 20},
+		{ProductKey: "ram:ddr4:ecc_rge:32gb:2666", Listings: 1},
+		{ProductKey: "drive:sas:3.5:12tb:7k2", Listings: 5},
+	}
+
+	got := extract.SimilarProductKeys(keys, 0)
+
+	assert.Equal(t, []domain.ProductKeySuggestion{
+		{
+			From: "cpu:intel:xeon:e5-2690v4", To: "cpu:intel:xeon:e5-2690_v4",
+			FromListings: 3, ToListings: 40, Distance: 1, PunctuationOnly: true,
+		},
+		{
+			From: "ram:ddr4:ecc_rge:32gb:2666", To: "ram:ddr4:ecc_reg:32gb:2666",
+			FromListings: 1, ToListings: 50, Distance: 2,
+		},
+	}, got, "v3/v4 and 16gb/32gb differ in digits and are not suggested")
+}
+
+func TestSimilarProductKeys_MaxDistance(t *testing.T) {
+	t.Parallel()
+
+	keys := []domain.ProductKeyCount{
+		{ProductKey: "gpu:nvidia:tesla:p40:24gb", Listings: 9},
+		{ProductKey: "gpu:nvidia:teslaa:p40:24gb", Listings: 2},
+	}
+	assert.Len(t, extract.SimilarProductKeys(keys, 1), 1)
+
+	keys[1].ProductKey = "gpu:nvidia:tsla_x:p40:24gb"
+	assert.Empty(t, extract.SimilarProductKeys(keys, 1))
+}
@@ -37,6 +37,12 @@ func ValidateExtraction(
 		return validateNIC(attrs)
 	case domain.ComponentGPU:
 		return validateGPU(attrs)
+	case domain.ComponentHBA:
+		return validateHBA(attrs)
+	case domain.ComponentSwitch:
+		return validateSwitch(attrs)
+	case domain.ComponentTransceiver:
+		return validateTransceiver(attrs)
 	case domain.ComponentWorkstation:
 		return validateWorkstation(attrs)
 	case domain.ComponentDesktop:
@@ -358,6 +364,209 @@ func validateGPUOptional(attrs map[string]any) error {
 	return nil
 }
 
+var (
+	validHBAModes       = []string{"IT", "IR", "RAID"}
+	validHBAPorts       = []string{"internal", "external", "mixed"}
+	validHBAInterfaces  = []string{"SAS2", "SAS3", "SAS4", "SATA"}
+	validHBAFormFactors = []string{"full_height", "low_profile", "mini_mono", "mezzanine"}
+)
+
+func validateHBA(attrs map[string]any) error {
+	if err := validateHBARequired(attrs); err != nil {
+		return err
+	}
+	return validateHBAOptional(attrs)
+}
+
+func validateHBARequired(attrs map[string]any) error {
+	mfr, ok := attrString(attrs, "manufacturer")
+	if !ok || mfr == "" {
+		return fmt.Errorf("manufacturer: %w", ErrMissingField)
+	}
+
+	model, ok := attrString(attrs, "model")
+	if !ok || model == "" {
+		return fmt.Errorf("model: %w", ErrMissingField)
+	}
+
+	// port_count: 1-32 (required)
+	pc, ok := attrInt(attrs, "port_count")
+	if !ok {
+		return fmt.Errorf("port_count: %w", ErrMissingField)
+	}
+	if pc < 1 || pc > 32 {
+		return fmt.Errorf("port_count %d: %w (must be 1-32)", pc, ErrOutOfRange)
+	}
+
+	// mode: required enum
+	mode, ok := attrString(attrs, "mode")
+	if !ok {
+		return fmt.Errorf("mode: %w", ErrMissingField)
+	}
+	if !slices.Contains(validHBAModes, mode) {
+		return fmt.Errorf("mode %q: %w", mode, ErrInvalidEnum)
+	}
+
+	return nil
+}
+
+func validateHBAOptional(attrs map[string]any) error {
+	if p, ok := attrString(attrs, "ports"); ok {
+		if !slices.Contains(validHBAPorts, p) {
+			return fmt.Errorf("ports %q: %w", p, ErrInvalidEnum)
+		}
+	}
+
+	if iface, ok := attrString(attrs, "interface"); ok {
+		if !slices.Contains(validHBAInterfaces, iface) {
+			return fmt.Errorf("interface %q: %w", iface, ErrInvalidEnum)
+		}
+	}
+
+	if ff, ok := attrString(attrs, "form_factor"); ok {
+		if !slices.Contains(validHBAFormFactors, ff) {
+			return fmt.Errorf("form_factor %q: %w", ff, ErrInvalidEnum)
+		}
+	}
+
+	// cache_mb: 0-16384 (optional; 0 for cacheless HBAs)
+	if cache, ok := attrInt(attrs, "cache_mb"); ok {
+		if cache < 0 || cache > 16384 {
+			return fmt.Errorf("cache_mb %d: %w (must be 0-16384)", cache, ErrOutOfRange)
+		}
+	}
+
+	// pcie_generation: 2-5 (optional)
+	if gen, ok := attrInt(attrs, "pcie_generation"); ok {
+		if gen < 2 || gen > 5 {
+			return fmt.Errorf("pcie_generation %d: %w (must be 2-5)", gen, ErrOutOfRange)
+		}
+	}
+
+	return nil
+}
+
+// switchPortFields are the per-speed port counts of a switch, slowest
+// first. The product key lists them in this order.
+var switchPortFields = []string{"ports_1g", "ports_10g", "ports_25g", "ports_40g", "ports_100g", "ports_400g"}
+
+func validateSwitch(attrs map[string]any) error {
+	mfr, ok := attrString(attrs, "manufacturer")
+	if !ok || mfr == "" {
+		return fmt.Errorf("manufacturer: %w", ErrMissingField)
+	}
+
+	model, ok := attrString(attrs, "model")
+	if !ok || model == "" {
+		return fmt.Errorf("model: %w", ErrMissingField)
+	}
+
+	// ports_*: 0-128 each, at least one port in total (required)
+	total := 0
+	for _, field := range switchPortFields {
+		n, ok := attrInt(attrs, field)
+		if !ok {
+			continue
+		}
+		if n < 0 || n > 128 {
+			return fmt.Errorf("%s %d: %w (must be 0-128)", field, n, ErrOutOfRange)
+		}
+		total += n
+	}
+	if total == 0 {
+		return fmt.Errorf("ports: %w", ErrMissingField)
+	}
+
+	// poe_budget_watts: 0-10000 (optional)
+	if w, ok := attrInt(attrs, "poe_budget_watts"); ok {
+		if w < 0 || w > 10000 {
+			return fmt.Errorf("poe_budget_watts %d: %w (must be 0-10000)", w, ErrOutOfRange)
+		}
+	}
+
+	// layer: 2 or 3 (optional)
+	if layer, ok := attrInt(attrs, "layer"); ok {
+		if layer != 2 && layer != 3 {
+			return fmt.Errorf("layer %d: %w", layer, ErrInvalidEnum)
+		}
+	}
+
+	// rack_units: 1-4 (optional)
+	if ru, ok := attrInt(attrs, "rack_units"); ok {
+		if ru < 1 || ru > 4 {
+			return fmt.Errorf("rack_units %d: %w (must be 1-4)", ru, ErrOutOfRange)
+		}
+	}
+
+	return nil
+}
+
+var (
+	validTransceiverFormFactors = []string{"SFP", "SFP+", "SFP28", "QSFP+", "QSFP28", "QSFP56", "QSFP-DD", "OSFP"}
+	validTransceiverSpeeds      = []string{"1GbE", "10GbE", "25GbE", "40GbE", "50GbE", "100GbE", "200GbE", "400GbE"}
+	validTransceiverMedia       = []string{"optic", "dac", "aoc", "base-t"}
+	validTransceiverConnectors  = []string{"LC", "MPO", "RJ45", "fixed"}
+)
+
+func validateTransceiver(attrs map[string]any) error {
+	if err := validateTransceiverRequired(attrs); err != nil {
+		return err
+	}
+	return validateTransceiverOptional(attrs)
+}
+
+func validateTransceiverRequired(attrs map[string]any) error {
+	ff, ok := attrString(attrs, "form_factor")
+	if !ok {
+		return fmt.Errorf("form_factor: %w", ErrMissingField)
+	}
+	if !slices.Contains(validTransceiverFormFactors, ff) {
+		return fmt.Errorf("form_factor %q: %w", ff, ErrInvalidEnum)
+	}
+
+	spd, ok := attrString(attrs, "speed")
+	if !ok {
+		return fmt.Errorf("speed: %w", ErrMissingField)
+	}
+	if !slices.Contains(validTransceiverSpeeds, spd) {
+		return fmt.Errorf("speed %q: %w", spd, ErrInvalidEnum)
+	}
+
+	media, ok := attrString(attrs, "media")
+	if !ok {
+		return fmt.Errorf("media: %w", ErrMissingField)
+	}
+	if !slices.Contains(validTransceiverMedia, media) {
+		return fmt.Errorf("media %q: %w", media, ErrInvalidEnum)
+	}
+
+	return nil
+}
+
+func validateTransceiverOptional(attrs map[string]any) error {
+	// reach_m: 1-200000 (optional; 200 km covers ZR-class optics)
+	if reach, ok := attrInt(attrs, "reach_m"); ok {
+		if reach < 1 || reach > 200000 {
+			return fmt.Errorf("reach_m %d: %w (must be 1-200000)", reach, ErrOutOfRange)
+		}
+	}
+
+	// wavelength_nm: 780-1625 (optional)
+	if wl, ok := attrInt(attrs, "wavelength_nm"); ok {
+		if wl < 780 || wl > 1625 {
+			return fmt.Errorf("wavelength_nm %d: %w (must be 780-1625)", wl, ErrOutOfRange)
+		}
+	}
+
+	if c, ok := attrString(attrs, "connector"); ok {
+		if !slices.Contains(validTransceiverConnectors, c) {
+			return fmt.Errorf("connector %q: %w", c, ErrInvalidEnum)
+		}
+	}
+
+	return nil
+}
+
 // validSystemFormFactors covers tower / small-form-factor / micro / mini
 // chassis used for both workstations and desktops. Form factor is optional
 // — left empty when the LLM is unsure or the listing is a barebone with
@@ -635,6 +635,306 @@ func TestValidateExtraction_GPU(t *testing.T) {
 	}
 }
 
+func TestValidateExtraction_HBA(t *testing.T) {
+	t.Parallel()
+
+	validHBA := map[string]any{
+		"condition":    "used_working",
+		"confidence":   0.9,
+		"quantity":     1,
+		"manufacturer": "lsi",
+		"model":        "9300-8i",
+		"port_count":   8,
+		"mode":         "IT",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(map[string]any)
+		wantErr string
+	}{
+		{
+			name:   "valid HBA passes",
+			modify: func(_ map[string]any) {},
+		},
+		{
+			name:    "missing manufacturer",
+			modify:  func(a map[string]any) { delete(a, "manufacturer") },
+			wantErr: "manufacturer",
+		},
+		{
+			name:    "empty model",
+			modify:  func(a map[string]any) { a["model"] = "" },
+			wantErr: "model",
+		},
+		{
+			name:    "missing port_count",
+			modify:  func(a map[string]any) { delete(a, "port_count") },
+			wantErr: "port_count",
+		},
+		{
+			name:    "port_count too high",
+			modify:  func(a map[string]any) { a["port_count"] = 33 },
+			wantErr: "port_count",
+		},
+		{
+			name:   "port_count at upper boundary",
+			modify: func(a map[string]any) { a["port_count"] = 32 },
+		},
+		{
+			name:    "missing mode",
+			modify:  func(a map[string]any) { delete(a, "mode") },
+			wantErr: "mode",
+		},
+		{
+			name:    "invalid mode",
+			modify:  func(a map[string]any) { a["mode"] = "JBOD" },
+			wantErr: "mode",
+		},
+		{
+			name:   "valid mode RAID",
+			modify: func(a map[string]any) { a["mode"] = "RAID" },
+		},
+		{
+			name:    "invalid ports",
+			modify:  func(a map[string]any) { a["ports"] = "both" },
+			wantErr: "ports",
+		},
+		{
+			name:    "invalid interface",
+			modify:  func(a map[string]any) { a["interface"] = "FC" },
+			wantErr: "interface",
+		},
+		{
+			name:    "invalid form_factor",
+			modify:  func(a map[string]any) { a["form_factor"] = "FHFL" },
+			wantErr: "form_factor",
+		},
+		{
+			name:    "negative cache_mb",
+			modify:  func(a map[string]any) { a["cache_mb"] = -1 },
+			wantErr: "cache_mb",
+		},
+		{
+			name:   "cacheless HBA",
+			modify: func(a map[string]any) { a["cache_mb"] = 0 },
+		},
+		{
+			name:    "pcie_generation too low",
+			modify:  func(a map[string]any) { a["pcie_generation"] = 1 },
+			wantErr: "pcie_generation",
+		},
+		{
+			name:   "pcie_generation 3",
+			modify: func(a map[string]any) { a["pcie_generation"] = 3 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs := copyAttrs(validHBA)
+			tt.modify(attrs)
+
+			err := extract.ValidateExtraction(domain.ComponentHBA, attrs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateExtraction_Switch(t *testing.T) {
+	t.Parallel()
+
+	validSwitch := map[string]any{
+		"condition":    "used_working",
+		"confidence":   0.9,
+		"quantity":     1,
+		"manufacturer": "arista",
+		"model":        "dcs-7050s-52",
+		"ports_10g":    52,
+		"poe":          false,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(map[string]any)
+		wantErr string
+	}{
+		{
+			name:   "valid switch passes",
+			modify: func(_ map[string]any) {},
+		},
+		{
+			name:    "missing manufacturer",
+			modify:  func(a map[string]any) { delete(a, "manufacturer") },
+			wantErr: "manufacturer",
+		},
+		{
+			name:    "missing model",
+			modify:  func(a map[string]any) { delete(a, "model") },
+			wantErr: "model",
+		},
+		{
+			name:    "no ports",
+			modify:  func(a map[string]any) { a["ports_10g"] = 0 },
+			wantErr: "ports",
+		},
+		{
+			name:    "negative port count",
+			modify:  func(a map[string]any) { a["ports_1g"] = -4 },
+			wantErr: "ports_1g",
+		},
+		{
+			name:    "port count too high",
+			modify:  func(a map[string]any) { a["ports_100g"] = 129 },
+			wantErr: "ports_100g",
+		},
+		{
+			name:   "mixed speeds",
+			modify: func(a map[string]any) { a["ports_1g"] = 48; a["ports_40g"] = 2 },
+		},
+		{
+			name:    "poe budget too high",
+			modify:  func(a map[string]any) { a["poe_budget_watts"] = 10001 },
+			wantErr: "poe_budget_watts",
+		},
+		{
+			name:    "invalid layer",
+			modify:  func(a map[string]any) { a["layer"] = 4 },
+			wantErr: "layer",
+		},
+		{
+			name:   "layer 3",
+			modify: func(a map[string]any) { a["layer"] = 3 },
+		},
+		{
+			name:    "rack_units too high",
+			modify:  func(a map[string]any) { a["rack_units"] = 5 },
+			wantErr: "rack_units",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs := copyAttrs(validSwitch)
+			tt.modify(attrs)
+
+			err := extract.ValidateExtraction(domain.ComponentSwitch, attrs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateExtraction_Transceiver(t *testing.T) {
+	t.Parallel()
+
+	validTransceiver := map[string]any{
+		"condition":     "used_working",
+		"confidence":    0.9,
+		"quantity":      1,
+		"form_factor":   "SFP+",
+		"speed":         "10GbE",
+		"media":         "optic",
+		"reach_m":       300,
+		"wavelength_nm": 850,
+		"vendor_coding": "cisco",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(map[string]any)
+		wantErr string
+	}{
+		{
+			name:   "valid transceiver passes",
+			modify: func(_ map[string]any) {},
+		},
+		{
+			name:    "missing form_factor",
+			modify:  func(a map[string]any) { delete(a, "form_factor") },
+			wantErr: "form_factor",
+		},
+		{
+			name:    "invalid form_factor",
+			modify:  func(a map[string]any) { a["form_factor"] = "XFP" },
+			wantErr: "form_factor",
+		},
+		{
+			name:    "missing speed",
+			modify:  func(a map[string]any) { delete(a, "speed") },
+			wantErr: "speed",
+		},
+		{
+			name:    "invalid speed",
+			modify:  func(a map[string]any) { a["speed"] = "8GFC" },
+			wantErr: "speed",
+		},
+		{
+			name:    "missing media",
+			modify:  func(a map[string]any) { delete(a, "media") },
+			wantErr: "media",
+		},
+		{
+			name:    "invalid media",
+			modify:  func(a map[string]any) { a["media"] = "fiber" },
+			wantErr: "media",
+		},
+		{
+			name:    "reach_m zero",
+			modify:  func(a map[string]any) { a["reach_m"] = 0 },
+			wantErr: "reach_m",
+		},
+		{
+			name:    "wavelength_nm out of range",
+			modify:  func(a map[string]any) { a["wavelength_nm"] = 3 },
+			wantErr: "wavelength_nm",
+		},
+		{
+			name:   "dac without wavelength",
+			modify: func(a map[string]any) { a["media"] = "dac"; a["reach_m"] = 3; delete(a, "wavelength_nm") },
+		},
+		{
+			name:    "invalid connector",
+			modify:  func(a map[string]any) { a["connector"] = "SC" },
+			wantErr: "connector",
+		},
+		{
+			name:   "valid connector MPO",
+			modify: func(a map[string]any) { a["connector"] = "MPO" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs := copyAttrs(validTransceiver)
+			tt.modify(attrs)
+
+			err := extract.ValidateExtraction(domain.ComponentTransceiver, attrs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateExtraction_Workstation(t *testing.T) {
 	t.Parallel()
 
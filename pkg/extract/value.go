@@ -0,0 +1,98 @@
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Value metric units.
+const (
+	ValueUnitGB     = "gb"
+	ValueUnitTB     = "tb"
+	ValueUnitCore   = "core"
+	ValueUnitVRAMGB = "vram_gb"
+)
+
+// ValueMetric is what a listing's price buys, measured across product
+// keys: the number of units (GB of RAM, TB of storage, CPU cores, GB
+// of VRAM) and the value class whose listings are compared per unit.
+// Classes drop the key segments that only change how many units there
+// are, so every ECC RDIMM of a generation shares one class whatever
+// its stick size or speed.
+type ValueMetric struct {
+	Class string
+	Unit  string
+	Units float64
+}
+
+// ComputeValueMetric derives the value metric from extracted
+// attributes. Lots count every unit in them (capacity times the
+// extracted quantity). ok is false for component types without a
+// value metric and when the attribute it is measured by is missing.
+func ComputeValueMetric(componentType string, attrs map[string]any) (ValueMetric, bool) {
+	qty := max(pkInt(attrs, "quantity"), 1)
+
+	var m ValueMetric
+	var perItem float64
+	switch componentType {
+	case "ram":
+		m = ValueMetric{
+			Class: "ram:" + normalizeStr(attrs["generation"]) + ":" + ramType(attrs),
+			Unit:  ValueUnitGB,
+		}
+		perItem = float64(pkInt(attrs, "capacity_gb"))
+	case "drive":
+		m = ValueMetric{
+			Class: "drive:" + normalizeStr(attrs["interface"]) + ":" +
+				normalizeStr(attrs["form_factor"]) + ":" + driveType(attrs),
+			Unit: ValueUnitTB,
+		}
+		perItem = driveCapacityTB(attrs)
+	case "cpu":
+		m = ValueMetric{
+			Class: "cpu:" + normalizeStr(attrs["manufacturer"]) + ":" + normalizeStr(attrs["family"]),
+			Unit:  ValueUnitCore,
+		}
+		perItem = float64(pkInt(attrs, "cores"))
+	case "gpu":
+		m = ValueMetric{
+			Class: "gpu:" + normalizeStr(attrs["manufacturer"]) + ":" + normalizeStr(attrs["family"]),
+			Unit:  ValueUnitVRAMGB,
+		}
+		perItem = float64(pkInt(attrs, "vram_gb"))
+	default:
+		return ValueMetric{}, false
+	}
+
+	if perItem <= 0 {
+		return ValueMetric{}, false
+	}
+	m.Units = perItem * float64(qty)
+	return m, true
+}
+
+// driveCapacityRe matches a capacity string such as "4TB", "960 GB"
+// or "1.92TB".
+var driveCapacityRe = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)\s*(tb|gb)\s*$`)
+
+// driveCapacityTB returns a drive's capacity in decimal TB, preferring
+// capacity_bytes over the capacity string.
+func driveCapacityTB(attrs map[string]any) float64 {
+	if b := pkInt(attrs, "capacity_bytes"); b > 0 {
+		return float64(b) / 1e12
+	}
+	s, _ := attrs["capacity"].(string)
+	m := driveCapacityRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	if strings.EqualFold(m[2], "gb") {
+		return n / 1000
+	}
+	return n
+}
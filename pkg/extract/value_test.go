@@ -0,0 +1,83 @@
+package extract_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+)
+
+func TestComputeValueMetric(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		componentType string
+		attrs         map[string]any
+		want          extract.ValueMetric
+		wantOK        bool
+	}{
+		{
+			name:          "ram lot",
+			componentType: "ram",
+			attrs: map[string]any{
+				"generation": "DDR4", "ecc": true, "registered": true,
+				"capacity_gb": 32, "speed_mhz": 2666, "quantity": 4,
+			},
+			want:   extract.ValueMetric{Class: "ram:ddr4:ecc_reg", Unit: extract.ValueUnitGB, Units: 128},
+			wantOK: true,
+		},
+		{
+			name:          "drive capacity string",
+			componentType: "drive",
+			attrs: map[string]any{
+				"interface": "SAS", "form_factor": "3.5", "type": "HDD", "rpm": 7200, "capacity": "12TB",
+			},
+			want:   extract.ValueMetric{Class: "drive:sas:3.5:7k2", Unit: extract.ValueUnitTB, Units: 12},
+			wantOK: true,
+		},
+		{
+			name:          "drive capacity in GB",
+			componentType: "drive",
+			attrs:         map[string]any{"interface": "SATA", "form_factor": "2.5", "type": "SSD", "capacity": "960 GB"},
+			want:          extract.ValueMetric{Class: "drive:sata:2.5:ssd", Unit: extract.ValueUnitTB, Units: 0.96},
+			wantOK:        true,
+		},
+		{
+			name:          "cpu cores",
+			componentType: "cpu",
+			attrs:         map[string]any{"manufacturer": "Intel", "family": "Xeon", "model": "6248R", "cores": 24},
+			want:          extract.ValueMetric{Class: "cpu:intel:xeon", Unit: extract.ValueUnitCore, Units: 24},
+			wantOK:        true,
+		},
+		{
+			name:          "gpu vram",
+			componentType: "gpu",
+			attrs:         map[string]any{"manufacturer": "NVIDIA", "family": "Tesla", "vram_gb": float64(16)},
+			want:          extract.ValueMetric{Class: "gpu:nvidia:tesla", Unit: extract.ValueUnitVRAMGB, Units: 16},
+			wantOK:        true,
+		},
+		{
+			name:          "missing capacity",
+			componentType: "ram",
+			attrs:         map[string]any{"generation": "DDR4"},
+		},
+		{
+			name:          "no metric for servers",
+			componentType: "server",
+			attrs:         map[string]any{"ram_total_gb": 256},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := extract.ComputeValueMetric(tt.componentType, tt.attrs)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want.Class, got.Class)
+			assert.Equal(t, tt.want.Unit, got.Unit)
+			assert.InDelta(t, tt.want.Units, got.Units, 1e-9)
+		})
+	}
+}
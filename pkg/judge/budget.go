@@ -0,0 +1,69 @@
+package judge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// budgetLedger makes the daily-budget check safe for concurrent judge
+// calls. Before each call the worker reserves the call's estimated
+// cost; the reservation is released when the call settles. A call is
+// admitted only while today's persisted spend plus the outstanding
+// reservations is under the cap, so N calls in flight can overshoot
+// the cap by at most one estimate rather than N actual costs.
+//
+// Persisted spend is re-read from the store on every reservation, so
+// the ledger also sees spend from other ticks (cron and HTTP backfill
+// share one Worker) and other replicas. A call counts twice between
+// its score being inserted and its reservation being released; that
+// only errs towards stopping early.
+type budgetLedger struct {
+	mu       sync.Mutex
+	reserved float64
+	// estimate is the per-call reservation: the configured estimate,
+	// raised to the largest actual cost settled so far.
+	estimate float64
+}
+
+// reserve admits one call against budget, returning the amount
+// reserved. ok is false when the cap is met; err is non-nil when the
+// spend lookup fails.
+func (l *budgetLedger) reserve(
+	ctx context.Context,
+	spendSince func(context.Context, time.Time) (float64, error),
+	budget float64,
+) (amount, spent float64, ok bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	spent, err = spendSince(ctx, todayUTCMidnight())
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if spent+l.reserved >= budget {
+		return 0, spent, false, nil
+	}
+	l.reserved += l.estimate
+	return l.estimate, spent, true, nil
+}
+
+// settle releases a reservation once its call has finished, learning
+// from the actual cost.
+func (l *budgetLedger) settle(reserved, actual float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reserved -= reserved
+	if l.reserved < 0 {
+		l.reserved = 0
+	}
+	if actual > l.estimate {
+		l.estimate = actual
+	}
+}
+
+// snapshot returns the outstanding reservations and current estimate.
+func (l *budgetLedger) snapshot() (reserved, estimate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.reserved, l.estimate
+}
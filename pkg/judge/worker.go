@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
@@ -13,12 +14,13 @@ import (
 
 // Store is the subset of internal/store.Store the judge worker needs.
 // Defined here so pkg/judge stays free of internal/* imports — any
-// implementation that satisfies these three methods can drive the
-// worker, including in-memory test fakes.
+// implementation that satisfies these methods can drive the worker,
+// including in-memory test fakes.
 type Store interface {
 	ListAlertsForJudging(ctx context.Context, q *JudgeStoreQuery) ([]domain.JudgeCandidate, error)
 	InsertJudgeScore(ctx context.Context, s *domain.JudgeScore) error
 	SumJudgeCostSince(ctx context.Context, since time.Time) (float64, error)
+	GetJudgeBacklog(ctx context.Context, lookback time.Duration) (*domain.JudgeBacklog, error)
 }
 
 // JudgeStoreQuery shadows internal/store.JudgeCandidatesQuery so the
@@ -40,21 +42,39 @@ type MetricsRecorder interface {
 	RecordScore(componentType string, score float64)
 	RecordCost(model string, costUSD float64)
 	RecordBudgetExhausted()
+	RecordTick(t *domain.JudgeTickStats)
+}
+
+// Status is the worker's configuration, budget ledger and catch-up
+// state, as served on the judge status route.
+type Status struct {
+	Concurrency      int     `json:"concurrency"`
+	BatchSize        int     `json:"batch_size"`
+	LookbackSeconds  float64 `json:"lookback_seconds"`
+	DailyBudgetUSD   float64 `json:"daily_budget_usd"`
+	SpentTodayUSD    float64 `json:"spent_today_usd"`
+	ReservedUSD      float64 `json:"reserved_usd"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+	// Backlog is read live; OldestAgeSeconds is derived from it.
+	Backlog          *domain.JudgeBacklog   `json:"backlog"`
+	OldestAgeSeconds float64                `json:"oldest_age_seconds"`
+	LastTick         *domain.JudgeTickStats `json:"last_tick,omitempty"`
 }
 
 // Worker is the cron-driven LLM-as-judge runner. Per tick:
-//  1. Compute today's spend so far via Store.SumJudgeCostSince.
-//  2. If already over budget, emit budget_exhausted and return.
-//  3. Pull a batch of un-judged alerts inside Lookback.
-//  4. For each alert: render AlertContext, call Judge, persist
-//     verdict, optionally write a Langfuse score on the alert's
-//     trace.
+//  1. Measure the backlog of un-judged alerts.
+//  2. Compute today's spend so far via Store.SumJudgeCostSince.
+//  3. If already over budget, emit budget_exhausted and return.
+//  4. Pull a batch of un-judged alerts inside Lookback.
+//  5. For each alert: reserve its estimated cost, render
+//     AlertContext, call Judge, persist verdict, optionally write a
+//     Langfuse score on the alert's trace, then settle the actual
+//     cost.
 //
-// Concurrency: today the worker processes alerts sequentially per
-// tick. Parallelism would yield faster catch-up but complicates budget
-// accounting (each call must check + reserve atomically). Sequential
-// is sufficient for current alert volumes; revisit if backlog
-// regularly exceeds the per-tick budget.
+// Concurrency: up to Concurrency alerts are judged at once. The
+// budget ledger (see budgetLedger) reserves each call's estimated cost
+// before it starts so concurrent calls can't all pass the cap check
+// on the same stale spend. With Concurrency 1 the tick is sequential.
 type Worker struct {
 	judge          Judge
 	store          Store
@@ -64,6 +84,11 @@ type Worker struct {
 	lookback       time.Duration
 	batchSize      int
 	dailyBudgetUSD float64
+	concurrency    int
+	ledger         budgetLedger
+
+	mu       sync.Mutex
+	lastTick *domain.JudgeTickStats
 }
 
 // WorkerConfig is the construction-time bag for Worker. All fields are
@@ -77,6 +102,13 @@ type WorkerConfig struct {
 	Lookback       time.Duration
 	BatchSize      int
 	DailyBudgetUSD float64
+	// Concurrency caps the judge calls in flight per tick. Defaults
+	// to 1.
+	Concurrency int
+	// EstimatedCostUSD is the cost reserved against the daily budget
+	// before each call. The worker raises it to the largest actual
+	// cost it has seen.
+	EstimatedCostUSD float64
 }
 
 // NewWorker validates config and returns a Worker. Judge + Store are
@@ -103,6 +135,8 @@ func NewWorker(cfg *WorkerConfig) (*Worker, error) {
 		lookback:       cfg.Lookback,
 		batchSize:      cfg.BatchSize,
 		dailyBudgetUSD: cfg.DailyBudgetUSD,
+		concurrency:    cfg.Concurrency,
+		ledger:         budgetLedger{estimate: max(cfg.EstimatedCostUSD, 0)},
 	}
 	if w.metrics == nil {
 		w.metrics = noopMetrics{}
@@ -122,20 +156,33 @@ func NewWorker(cfg *WorkerConfig) (*Worker, error) {
 	if w.dailyBudgetUSD < 0 {
 		w.dailyBudgetUSD = 0
 	}
+	if w.concurrency <= 0 {
+		w.concurrency = 1
+	}
 	return w, nil
 }
 
-// Run executes one full judge tick: budget check → list candidates →
-// per-alert evaluate → persist + optionally score on Langfuse. Returns
-// the number of alerts judged this tick (regardless of outcome) plus
-// the first error encountered, if any. Subsequent errors are logged
-// but don't stop the loop — one bad LLM call shouldn't poison the
-// whole batch.
+// Run executes one full judge tick: backlog → budget check → list
+// candidates → per-alert evaluate → persist + optionally score on
+// Langfuse. Returns the number of alerts judged this tick (regardless
+// of outcome) plus the first error encountered, if any. Subsequent
+// errors are logged but don't stop the loop — one bad LLM call
+// shouldn't poison the whole batch.
 //
 // Returns ErrJudgeBudgetExhausted as the error when the budget cap
 // halts the run early; callers (cron / HTTP backfill) treat this as
 // an info-level outcome, not a real error.
 func (w *Worker) Run(ctx context.Context) (int, error) {
+	stats := &domain.JudgeTickStats{StartedAt: time.Now()}
+	defer w.finishTick(stats)
+
+	b, err := w.store.GetJudgeBacklog(ctx, w.lookback)
+	if err != nil {
+		w.logger.Warn("judge backlog lookup failed", "error", err)
+	} else {
+		stats.Backlog = b
+	}
+
 	if w.dailyBudgetUSD > 0 {
 		spent, err := w.store.SumJudgeCostSince(ctx, todayUTCMidnight())
 		if err != nil {
@@ -145,6 +192,7 @@ func (w *Worker) Run(ctx context.Context) (int, error) {
 			w.metrics.RecordBudgetExhausted()
 			w.logger.Warn("judge daily budget exhausted",
 				"spent_usd", spent, "budget_usd", w.dailyBudgetUSD)
+			stats.BudgetExhausted = true
 			return 0, ErrJudgeBudgetExhausted
 		}
 	}
@@ -156,72 +204,154 @@ func (w *Worker) Run(ctx context.Context) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("listing judge candidates: %w", err)
 	}
+	stats.Candidates = len(candidates)
 
-	judged := 0
-	var firstErr error
+	err = w.judgeAll(ctx, candidates, stats)
+	stats.BudgetExhausted = errors.Is(err, ErrJudgeBudgetExhausted)
+	return stats.Judged, err
+}
+
+// judgeAll judges candidates on up to w.concurrency goroutines,
+// tallying outcomes into stats. Each call is admitted by reserve; a
+// refusal stops dispatch, and the calls already in flight finish. The
+// returned error follows Run: budget exhaustion wins, then the first
+// failure.
+func (w *Worker) judgeAll(ctx context.Context, candidates []domain.JudgeCandidate, stats *domain.JudgeTickStats) error {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		haltErr  error
+	)
+	slots := make(chan struct{}, w.concurrency)
 	for i := range candidates {
-		// In-loop budget recheck: a long-running batch could cross
-		// the cap mid-flight. checkBudget halts the tick on either
-		// a DB error or budget exhaustion — see INV-0001 MEDIUM-8.
-		if haltErr := w.checkBudget(ctx, judged, len(candidates)-i); haltErr != nil {
-			if errors.Is(haltErr, ErrJudgeBudgetExhausted) {
-				return judged, haltErr
-			}
-			if firstErr == nil {
-				firstErr = haltErr
-			}
-			return judged, firstErr
+		slots <- struct{}{}
+		mu.Lock()
+		judgedSoFar := stats.Judged
+		mu.Unlock()
+		reserved, err := w.reserve(ctx, judgedSoFar, len(candidates)-i)
+		if err != nil {
+			<-slots
+			haltErr = err
+			break
 		}
-		if err := w.judgeOne(ctx, &candidates[i]); err != nil {
-			w.logger.Warn("judge evaluate failed (continuing)",
-				"alert_id", candidates[i].AlertID, "error", err)
-			if firstErr == nil {
-				firstErr = err
+
+		c := &candidates[i]
+		wg.Go(func() {
+			defer func() { <-slots }()
+			cost, err := w.judgeOne(ctx, c)
+			w.ledger.settle(reserved, cost)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				w.logger.Warn("judge evaluate failed (continuing)",
+					"alert_id", c.AlertID, "error", err)
+				stats.Failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
 			}
-			continue
-		}
-		judged++
+			stats.Judged++
+		})
 	}
-	return judged, firstErr
+	wg.Wait()
+
+	if errors.Is(haltErr, ErrJudgeBudgetExhausted) {
+		return haltErr
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return haltErr
 }
 
-// checkBudget runs the in-loop budget recheck. Returns:
-//   - nil → still under budget, continue judging.
-//   - ErrJudgeBudgetExhausted → cap met or exceeded; caller halts.
+// reserve admits the next judge call against the daily budget,
+// returning the amount reserved for it. Returns:
+//   - nil → still under budget, judge the alert.
+//   - ErrJudgeBudgetExhausted → cap met or exceeded once in-flight
+//     reservations are counted; caller halts.
 //   - any other error → DB recheck failed; caller halts (don't
 //     silently continue spending — see INV-0001 MEDIUM-8).
 //
 // dailyBudgetUSD ≤ 0 disables the cap entirely.
-func (w *Worker) checkBudget(ctx context.Context, judgedSoFar, remainingInBatch int) error {
+func (w *Worker) reserve(ctx context.Context, judgedSoFar, remainingInBatch int) (float64, error) {
 	if w.dailyBudgetUSD <= 0 {
-		return nil
+		return 0, nil
 	}
-	spent, err := w.store.SumJudgeCostSince(ctx, todayUTCMidnight())
+	reserved, spent, ok, err := w.ledger.reserve(ctx, w.store.SumJudgeCostSince, w.dailyBudgetUSD)
 	if err != nil {
 		w.logger.Warn("judge budget recheck failed; halting tick",
 			"error", err,
 			"judged_so_far", judgedSoFar,
 			"remaining_in_batch", remainingInBatch)
-		return fmt.Errorf("judge budget recheck: %w", err)
+		return 0, fmt.Errorf("judge budget recheck: %w", err)
 	}
-	if spent >= w.dailyBudgetUSD {
+	if !ok {
 		w.metrics.RecordBudgetExhausted()
+		inFlight, _ := w.ledger.snapshot()
 		w.logger.Warn("judge daily budget exhausted mid-batch",
-			"spent_usd", spent, "budget_usd", w.dailyBudgetUSD,
+			"spent_usd", spent, "reserved_usd", inFlight, "budget_usd", w.dailyBudgetUSD,
 			"remaining_in_batch", remainingInBatch)
-		return ErrJudgeBudgetExhausted
+		return 0, ErrJudgeBudgetExhausted
+	}
+	return reserved, nil
+}
+
+// finishTick records a finished Run's stats for Status and metrics.
+func (w *Worker) finishTick(stats *domain.JudgeTickStats) {
+	stats.DurationSeconds = time.Since(stats.StartedAt).Seconds()
+	w.metrics.RecordTick(stats)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastTick = stats
+}
+
+// Status reports the worker's configuration, today's spend and
+// outstanding reservations, the live backlog, and the last tick.
+func (w *Worker) Status(ctx context.Context) (*Status, error) {
+	spent, err := w.store.SumJudgeCostSince(ctx, todayUTCMidnight())
+	if err != nil {
+		return nil, fmt.Errorf("summing judge cost: %w", err)
+	}
+	b, err := w.store.GetJudgeBacklog(ctx, w.lookback)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved, estimate := w.ledger.snapshot()
+	st := &Status{
+		Concurrency:      w.concurrency,
+		BatchSize:        w.batchSize,
+		LookbackSeconds:  w.lookback.Seconds(),
+		DailyBudgetUSD:   w.dailyBudgetUSD,
+		SpentTodayUSD:    spent,
+		ReservedUSD:      reserved,
+		EstimatedCostUSD: estimate,
+		Backlog:          b,
+	}
+	if b.OldestAt != nil {
+		st.OldestAgeSeconds = time.Since(*b.OldestAt).Seconds()
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.lastTick != nil {
+		last := *w.lastTick
+		st.LastTick = &last
 	}
-	return nil
+	return st, nil
 }
 
 // judgeOne is one alert's path through the worker — extracted so the
 // outer loop stays linear and so error wrapping has one consistent
-// shape per call site.
-func (w *Worker) judgeOne(ctx context.Context, c *domain.JudgeCandidate) error {
+// shape per call site. Returns the call's cost, which is non-zero even
+// when persisting the verdict fails.
+func (w *Worker) judgeOne(ctx context.Context, c *domain.JudgeCandidate) (float64, error) {
 	ac := candidateToContext(c)
 	v, err := w.judge.EvaluateAlert(ctx, &ac)
 	if err != nil {
-		return fmt.Errorf("evaluating alert %s: %w", c.AlertID, err)
+		return 0, fmt.Errorf("evaluating alert %s: %w", c.AlertID, err)
 	}
 
 	score := &domain.JudgeScore{
@@ -234,7 +364,7 @@ func (w *Worker) judgeOne(ctx context.Context, c *domain.JudgeCandidate) error {
 		CostUSD:      v.CostUSD,
 	}
 	if err := w.store.InsertJudgeScore(ctx, score); err != nil {
-		return fmt.Errorf("persisting judge score for %s: %w", c.AlertID, err)
+		return v.CostUSD, fmt.Errorf("persisting judge score for %s: %w", c.AlertID, err)
 	}
 
 	w.metrics.RecordVerdict(verdictBucket(v.Score))
@@ -250,7 +380,7 @@ func (w *Worker) judgeOne(ctx context.Context, c *domain.JudgeCandidate) error {
 			w.logger.Debug("langfuse Score (judge) failed", "alert_id", c.AlertID, "error", scoreErr)
 		}
 	}
-	return nil
+	return v.CostUSD, nil
 }
 
 // candidateToContext converts a store-side row into the prompt-side
@@ -307,7 +437,8 @@ func todayUTCMidnight() time.Time {
 // metrics package.
 type noopMetrics struct{}
 
-func (noopMetrics) RecordVerdict(string)        {}
-func (noopMetrics) RecordScore(string, float64) {}
-func (noopMetrics) RecordCost(string, float64)  {}
-func (noopMetrics) RecordBudgetExhausted()      {}
+func (noopMetrics) RecordVerdict(string)              {}
+func (noopMetrics) RecordScore(string, float64)       {}
+func (noopMetrics) RecordCost(string, float64)        {}
+func (noopMetrics) RecordBudgetExhausted()            {}
+func (noopMetrics) RecordTick(*domain.JudgeTickStats) {}
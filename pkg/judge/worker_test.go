@@ -3,6 +3,7 @@ package judge_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,10 +21,13 @@ import (
 type fakeJudge struct {
 	verdict judge.Verdict
 	err     error
+	mu      sync.Mutex
 	seen    []string
 }
 
 func (f *fakeJudge) EvaluateAlert(_ context.Context, ac *judge.AlertContext) (judge.Verdict, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.seen = append(f.seen, ac.AlertID)
 	if f.err != nil {
 		return judge.Verdict{}, f.err
@@ -35,7 +39,9 @@ func (f *fakeJudge) EvaluateAlert(_ context.Context, ac *judge.AlertContext) (ju
 // Keeps spend totals + persisted scores so the budget-enforcement and
 // idempotency paths can be observed without Postgres.
 type fakeStore struct {
+	mu         sync.Mutex
 	candidates []domain.JudgeCandidate
+	backlog    *domain.JudgeBacklog
 	persisted  []*domain.JudgeScore
 	preSpent   float64
 	// sumErrAfter, when > 0, makes SumJudgeCostSince return an error
@@ -54,12 +60,16 @@ func (s *fakeStore) ListAlertsForJudging(_ context.Context, q *judge.JudgeStoreQ
 }
 
 func (s *fakeStore) InsertJudgeScore(_ context.Context, sc *domain.JudgeScore) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.persisted = append(s.persisted, sc)
 	s.preSpent += sc.CostUSD
 	return nil
 }
 
 func (s *fakeStore) SumJudgeCostSince(_ context.Context, _ time.Time) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.sumCalls++
 	if s.sumErrAfter > 0 && s.sumCalls > s.sumErrAfter {
 		return 0, errors.New("simulated DB error on judge_scores SUM")
@@ -67,23 +77,57 @@ func (s *fakeStore) SumJudgeCostSince(_ context.Context, _ time.Time) (float64,
 	return s.preSpent, nil
 }
 
+func (s *fakeStore) GetJudgeBacklog(_ context.Context, _ time.Duration) (*domain.JudgeBacklog, error) {
+	if s.backlog != nil {
+		return s.backlog, nil
+	}
+	return &domain.JudgeBacklog{Pending: len(s.candidates)}, nil
+}
+
 // fakeMetrics records every counter increment so tests assert on
 // emission without booting Prometheus.
 type fakeMetrics struct {
+	mu              sync.Mutex
 	verdicts        []string
 	costs           map[string]float64
 	scores          []float64
 	budgetExhausted int
+	ticks           []*domain.JudgeTickStats
 }
 
 func newFakeMetrics() *fakeMetrics {
 	return &fakeMetrics{costs: map[string]float64{}}
 }
 
-func (m *fakeMetrics) RecordVerdict(v string)              { m.verdicts = append(m.verdicts, v) }
-func (m *fakeMetrics) RecordScore(_ string, score float64) { m.scores = append(m.scores, score) }
-func (m *fakeMetrics) RecordCost(model string, c float64)  { m.costs[model] += c }
-func (m *fakeMetrics) RecordBudgetExhausted()              { m.budgetExhausted++ }
+func (m *fakeMetrics) RecordVerdict(v string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verdicts = append(m.verdicts, v)
+}
+
+func (m *fakeMetrics) RecordScore(_ string, score float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scores = append(m.scores, score)
+}
+
+func (m *fakeMetrics) RecordCost(model string, c float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.costs[model] += c
+}
+
+func (m *fakeMetrics) RecordBudgetExhausted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.budgetExhausted++
+}
+
+func (m *fakeMetrics) RecordTick(t *domain.JudgeTickStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ticks = append(m.ticks, t)
+}
 
 // fakeLangfuseClient is the minimal langfuse.Client surface the worker
 // uses: just Score. Captures every call so tests can assert.
@@ -333,3 +377,172 @@ func TestWorker_NewWorker_ValidatesRequired(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, w)
 }
+
+// TestWorker_Run_ConcurrencyCapsInFlight: with Concurrency 3 the
+// worker must reach, and never exceed, 3 calls in flight. The judge
+// holds every call until the third arrives, so the run deadlocks
+// unless three really run at once.
+func TestWorker_Run_ConcurrencyCapsInFlight(t *testing.T) {
+	t.Parallel()
+
+	candidates := make([]domain.JudgeCandidate, 0, 7)
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		candidates = append(candidates, candidate(id))
+	}
+	s := &fakeStore{candidates: candidates}
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		once        sync.Once
+	)
+	full := make(chan struct{})
+	j := &flakyJudge{fn: func(_ context.Context, _ *judge.AlertContext) (judge.Verdict, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		if inFlight == 3 {
+			once.Do(func() { close(full) })
+		}
+		mu.Unlock()
+
+		<-full
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return judge.Verdict{Score: 0.5, Model: "m", CostUSD: 0.01}, nil
+	}}
+
+	w, err := judge.NewWorker(&judge.WorkerConfig{Judge: j, Store: s, Concurrency: 3, DailyBudgetUSD: 100})
+	require.NoError(t, err)
+
+	n, err := w.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Len(t, s.persisted, 7)
+	assert.Equal(t, 3, maxInFlight)
+}
+
+// TestWorker_Run_ReservationsHoldBudgetWhileInFlight: budget $10,
+// estimate $5, concurrency 4. Nothing is persisted until the test
+// releases the judge, so the store's spend stays $0 throughout
+// dispatch — only the reservations stop the third call.
+func TestWorker_Run_ReservationsHoldBudgetWhileInFlight(t *testing.T) {
+	t.Parallel()
+
+	s := &fakeStore{candidates: []domain.JudgeCandidate{
+		candidate("a"), candidate("b"), candidate("c"), candidate("d"),
+	}}
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	j := &flakyJudge{fn: func(_ context.Context, _ *judge.AlertContext) (judge.Verdict, error) {
+		started <- struct{}{}
+		<-release
+		return judge.Verdict{Score: 0.5, Model: "m", CostUSD: 5.0}, nil
+	}}
+	m := newFakeMetrics()
+
+	w, err := judge.NewWorker(&judge.WorkerConfig{
+		Judge:            j,
+		Store:            s,
+		Metrics:          m,
+		Concurrency:      4,
+		DailyBudgetUSD:   10.0,
+		EstimatedCostUSD: 5.0,
+	})
+	require.NoError(t, err)
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		n, err := w.Run(context.Background())
+		done <- result{n, err}
+	}()
+
+	<-started
+	<-started
+	st, err := w.Status(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, st.ReservedUSD, 1e-9, "both in-flight calls hold a reservation")
+	close(release)
+
+	res := <-done
+	require.ErrorIs(t, res.err, judge.ErrJudgeBudgetExhausted)
+	assert.Equal(t, 2, res.n)
+	assert.Len(t, s.persisted, 2)
+	assert.Equal(t, 1, m.budgetExhausted)
+
+	st, err = w.Status(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, st.ReservedUSD, "settled calls release their reservations")
+	assert.InDelta(t, 10.0, st.SpentTodayUSD, 1e-9)
+}
+
+// TestWorker_Run_EstimateLearnsFromActualCost: a call costing more
+// than the configured estimate raises the reservation for later calls.
+func TestWorker_Run_EstimateLearnsFromActualCost(t *testing.T) {
+	t.Parallel()
+
+	s := &fakeStore{candidates: []domain.JudgeCandidate{candidate("a")}}
+	j := &fakeJudge{verdict: judge.Verdict{Score: 0.5, Model: "m", CostUSD: 0.05}}
+
+	w, err := judge.NewWorker(&judge.WorkerConfig{
+		Judge:            j,
+		Store:            s,
+		DailyBudgetUSD:   10.0,
+		EstimatedCostUSD: 0.01,
+	})
+	require.NoError(t, err)
+
+	_, err = w.Run(context.Background())
+	require.NoError(t, err)
+
+	st, err := w.Status(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05, st.EstimatedCostUSD, 1e-9)
+}
+
+// TestWorker_Run_RecordsTickStats: each tick's catch-up stats reach
+// the metrics recorder and the next Status call.
+func TestWorker_Run_RecordsTickStats(t *testing.T) {
+	t.Parallel()
+
+	oldest := time.Now().Add(-2 * time.Hour)
+	s := &fakeStore{
+		candidates: []domain.JudgeCandidate{candidate("a"), candidate("b")},
+		backlog:    &domain.JudgeBacklog{Pending: 40, OldestAt: &oldest, AgedOut: 3},
+	}
+	j := &flakyJudge{fn: func(_ context.Context, ac *judge.AlertContext) (judge.Verdict, error) {
+		if ac.AlertID == "b" {
+			return judge.Verdict{}, errors.New("LLM blew up")
+		}
+		return judge.Verdict{Score: 0.6, Model: "m"}, nil
+	}}
+	m := newFakeMetrics()
+
+	w, err := judge.NewWorker(&judge.WorkerConfig{Judge: j, Store: s, Metrics: m, Concurrency: 2})
+	require.NoError(t, err)
+
+	_, err = w.Run(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, m.ticks, 1)
+	tick := m.ticks[0]
+	assert.Equal(t, 2, tick.Candidates)
+	assert.Equal(t, 1, tick.Judged)
+	assert.Equal(t, 1, tick.Failed)
+	assert.False(t, tick.BudgetExhausted)
+	assert.Equal(t, s.backlog, tick.Backlog)
+
+	st, err := w.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, st.Concurrency)
+	assert.Equal(t, 40, st.Backlog.Pending)
+	assert.InDelta(t, (2 * time.Hour).Seconds(), st.OldestAgeSeconds, 60)
+	require.NotNil(t, st.LastTick)
+	assert.Equal(t, 1, st.LastTick.Judged)
+}
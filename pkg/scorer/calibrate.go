@@ -0,0 +1,249 @@
+package score
+
+import "math"
+
+// Calibration defaults. A 0.05 grid over six factors is ~53k candidate
+// weight vectors, which searches a few thousand labelled alerts in a
+// couple of seconds. Beta 0.5 weights precision over recall — the
+// point of recalibrating is fewer noise alerts, not more alerts.
+const (
+	DefaultCalibrationStep       = 0.05
+	DefaultCalibrationBeta       = 0.5
+	DefaultCalibrationMinSamples = 30
+)
+
+// Sample is one labelled alert: the per-factor scores the listing was
+// evaluated with, the threshold of the watch that fired it, and whether
+// the operator (or judge) considered it a good alert.
+type Sample struct {
+	Factors   Breakdown
+	Threshold int
+	Good      bool
+}
+
+// Metrics is the confusion matrix and derived scores for one weights +
+// threshold configuration evaluated against a labelled sample set.
+type Metrics struct {
+	TP        int     `json:"tp"`
+	FP        int     `json:"fp"`
+	FN        int     `json:"fn"`
+	TN        int     `json:"tn"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	FBeta     float64 `json:"f_beta"`
+}
+
+// CalibrationOptions tunes the grid search. Zero values fall back to
+// the Default* constants above.
+type CalibrationOptions struct {
+	Step       float64 // weight grid resolution, (0, 1]
+	Beta       float64 // F-beta objective; < 1 favours precision
+	MinSamples int     // below this, calibration is skipped
+}
+
+// Calibration is the result of fitting weights for one sample set.
+// Current evaluates the weights in use against each sample's own watch
+// threshold; Fitted evaluates the proposed Weights against the single
+// proposed Threshold. When Skipped is non-empty the fit was not run and
+// Weights mirrors the current weights.
+type Calibration struct {
+	Samples   int     `json:"samples"`
+	Positives int     `json:"positives"`
+	Current   Metrics `json:"current"`
+	Weights   Weights `json:"weights"`
+	Threshold int     `json:"threshold"`
+	Fitted    Metrics `json:"fitted"`
+	Skipped   string  `json:"skipped,omitempty"`
+}
+
+// Lift is the F-beta improvement of the fitted configuration over the
+// current one. Zero when calibration was skipped.
+func (c *Calibration) Lift() float64 {
+	if c.Skipped != "" {
+		return 0
+	}
+	return c.Fitted.FBeta - c.Current.FBeta
+}
+
+// Evaluate scores samples with w and reports how well "Composite >=
+// threshold" separates good from bad alerts. A negative threshold
+// means "use each sample's own watch threshold", which is how the
+// current production configuration is measured.
+func Evaluate(samples []Sample, w Weights, threshold int, beta float64) Metrics {
+	var m Metrics
+	for i := range samples {
+		t := threshold
+		if t < 0 {
+			t = samples[i].Threshold
+		}
+		fired := Composite(samples[i].Factors, w) >= t
+		switch {
+		case fired && samples[i].Good:
+			m.TP++
+		case fired:
+			m.FP++
+		case samples[i].Good:
+			m.FN++
+		default:
+			m.TN++
+		}
+	}
+	m.fill(beta)
+	return m
+}
+
+func (m *Metrics) fill(beta float64) {
+	if m.TP+m.FP > 0 {
+		m.Precision = float64(m.TP) / float64(m.TP+m.FP)
+	}
+	if m.TP+m.FN > 0 {
+		m.Recall = float64(m.TP) / float64(m.TP+m.FN)
+	}
+	m.FBeta = fBeta(m.Precision, m.Recall, beta)
+}
+
+func fBeta(precision, recall, beta float64) float64 {
+	b2 := beta * beta
+	den := b2*precision + recall
+	if den == 0 {
+		return 0
+	}
+	return (1 + b2) * precision * recall / den
+}
+
+// Calibrate grid-searches non-negative weight vectors summing to 1 (at
+// opts.Step resolution) and, for each, the 0-100 threshold that
+// maximises F-beta on samples. Ties prefer the weights closest to
+// current (L1), so an uninformative label set leaves weights alone.
+// The optional Value weight is kept at current's; the grid spreads the
+// remaining 1 - Value over the other six factors.
+func Calibrate(samples []Sample, current Weights, opts CalibrationOptions) Calibration {
+	opts = opts.withDefaults()
+
+	c := Calibration{Samples: len(samples), Weights: current}
+	for i := range samples {
+		if samples[i].Good {
+			c.Positives++
+		}
+	}
+	c.Current = Evaluate(samples, current, -1, opts.Beta)
+
+	switch {
+	case len(samples) < opts.MinSamples:
+		c.Skipped = "insufficient labelled alerts"
+		return c
+	case c.Positives == 0:
+		c.Skipped = "no good alerts labelled"
+		return c
+	case c.Positives == len(samples):
+		c.Skipped = "no bad alerts labelled"
+		return c
+	}
+
+	units := int(math.Round(1 / opts.Step))
+	best := searchResult{fbeta: -1}
+	var parts [6]int
+	var walk func(idx, remaining int)
+	walk = func(idx, remaining int) {
+		if idx == len(parts)-1 {
+			parts[idx] = remaining
+			w := weightsFromUnits(parts, units, current.Value)
+			threshold, fb := bestThreshold(samples, w, opts.Beta)
+			dist := weightDistance(w, current)
+			if fb > best.fbeta+1e-12 || (math.Abs(fb-best.fbeta) <= 1e-12 && dist < best.dist) {
+				best = searchResult{weights: w, threshold: threshold, fbeta: fb, dist: dist}
+			}
+			return
+		}
+		for u := 0; u <= remaining; u++ {
+			parts[idx] = u
+			walk(idx+1, remaining-u)
+		}
+	}
+	walk(0, units)
+
+	c.Weights = best.weights
+	c.Threshold = best.threshold
+	c.Fitted = Evaluate(samples, best.weights, best.threshold, opts.Beta)
+	return c
+}
+
+type searchResult struct {
+	weights   Weights
+	threshold int
+	fbeta     float64
+	dist      float64
+}
+
+func (o CalibrationOptions) withDefaults() CalibrationOptions {
+	if o.Step <= 0 || o.Step > 1 {
+		o.Step = DefaultCalibrationStep
+	}
+	if o.Beta <= 0 {
+		o.Beta = DefaultCalibrationBeta
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = DefaultCalibrationMinSamples
+	}
+	return o
+}
+
+// bestThreshold sweeps every integer threshold in one pass over a
+// histogram of composite totals and returns the F-beta maximiser.
+// Ties keep the higher threshold (fewer alerts for the same quality).
+func bestThreshold(samples []Sample, w Weights, beta float64) (int, float64) {
+	var good, bad [101]int
+	totalGood := 0
+	for i := range samples {
+		t := Composite(samples[i].Factors, w)
+		if samples[i].Good {
+			good[t]++
+			totalGood++
+		} else {
+			bad[t]++
+		}
+	}
+
+	bestT, bestF := 100, -1.0
+	tp, fp := 0, 0
+	for t := 100; t >= 0; t-- {
+		tp += good[t]
+		fp += bad[t]
+		var precision, recall float64
+		if tp+fp > 0 {
+			precision = float64(tp) / float64(tp+fp)
+		}
+		if totalGood > 0 {
+			recall = float64(tp) / float64(totalGood)
+		}
+		if f := fBeta(precision, recall, beta); f > bestF+1e-12 {
+			bestT, bestF = t, f
+		}
+	}
+	return bestT, bestF
+}
+
+func weightsFromUnits(parts [6]int, units int, value float64) Weights {
+	f := func(u int) float64 {
+		return math.Round(float64(u)/float64(units)*(1-value)*1e4) / 1e4
+	}
+	return Weights{
+		Price:     f(parts[0]),
+		Seller:    f(parts[1]),
+		Condition: f(parts[2]),
+		Quantity:  f(parts[3]),
+		Quality:   f(parts[4]),
+		Time:      f(parts[5]),
+		Value:     value,
+	}
+}
+
+func weightDistance(a, b Weights) float64 {
+	return math.Abs(a.Price-b.Price) +
+		math.Abs(a.Seller-b.Seller) +
+		math.Abs(a.Condition-b.Condition) +
+		math.Abs(a.Quantity-b.Quantity) +
+		math.Abs(a.Quality-b.Quality) +
+		math.Abs(a.Time-b.Time) +
+		math.Abs(a.Value-b.Value)
+}
@@ -0,0 +1,172 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// priceDrivenSamples builds a label set where only the price factor
+// separates good from bad alerts; every other factor is noise that is
+// identical across the two classes. Threshold 30 means the current
+// config fired on every sample, as it did in production.
+func priceDrivenSamples(n int) []Sample {
+	out := make([]Sample, 0, 2*n)
+	for i := range n {
+		noise := float64(i % 5 * 20)
+		out = append(out,
+			Sample{
+				Factors:   Breakdown{Price: 100, Seller: noise, Condition: 70, Quantity: 50, Quality: 40, Time: 30},
+				Threshold: 30,
+				Good:      true,
+			},
+			Sample{
+				Factors:   Breakdown{Price: 30, Seller: noise, Condition: 70, Quantity: 50, Quality: 40, Time: 30},
+				Threshold: 30,
+				Good:      false,
+			},
+		)
+	}
+	return out
+}
+
+func TestComposite_MatchesScore(t *testing.T) {
+	t.Parallel()
+
+	data := &ListingData{
+		UnitPrice:         25,
+		SellerFeedback:    600,
+		SellerFeedbackPct: 99.0,
+		Condition:         "used_working",
+		Quantity:          4,
+		HasImages:         true,
+	}
+	baseline := &Baseline{P10: 20, P25: 30, P50: 50, P75: 70, P90: 100, SampleCount: 20}
+	b := Score(data, baseline, DefaultWeights())
+
+	assert.Equal(t, b.Total, Composite(b, DefaultWeights()))
+}
+
+func TestWeightSet_For(t *testing.T) {
+	t.Parallel()
+
+	ram := Weights{Price: 0.7, Seller: 0.3}
+	ws := WeightSet{Components: map[string]Weights{"ram": ram}}
+
+	assert.Equal(t, ram, ws.For("ram"))
+	assert.Equal(t, DefaultWeights(), ws.For("gpu"), "unset default falls back to DefaultWeights")
+
+	ws.Default = Weights{Price: 1}
+	assert.Equal(t, Weights{Price: 1}, ws.For("gpu"))
+}
+
+func TestEvaluate_UsesSampleThresholdWhenNegative(t *testing.T) {
+	t.Parallel()
+
+	samples := []Sample{
+		{Factors: Breakdown{Price: 80}, Threshold: 70, Good: true},
+		{Factors: Breakdown{Price: 80}, Threshold: 90, Good: true},
+	}
+	w := Weights{Price: 1}
+
+	m := Evaluate(samples, w, -1, 1)
+	assert.Equal(t, 1, m.TP)
+	assert.Equal(t, 1, m.FN)
+
+	m = Evaluate(samples, w, 50, 1)
+	assert.Equal(t, 2, m.TP)
+	assert.InDelta(t, 1.0, m.FBeta, 1e-9)
+}
+
+func TestCalibrate_FindsSeparatingWeights(t *testing.T) {
+	t.Parallel()
+
+	samples := priceDrivenSamples(20)
+	c := Calibrate(samples, DefaultWeights(), CalibrationOptions{Step: 0.1})
+
+	require.Empty(t, c.Skipped)
+	assert.Equal(t, 40, c.Samples)
+	assert.Equal(t, 20, c.Positives)
+	assert.InDelta(t, 1.0, c.Weights.Sum(), 1e-9)
+	assert.InDelta(t, 1.0, c.Fitted.Precision, 1e-9)
+	assert.InDelta(t, 1.0, c.Fitted.Recall, 1e-9)
+	assert.Positive(t, c.Lift(), "fitted weights should beat the current config")
+	assert.Greater(t, c.Weights.Price, 0.0, "price is the only informative factor")
+}
+
+func TestCalibrate_KeepsValueWeight(t *testing.T) {
+	t.Parallel()
+
+	current := DefaultWeights()
+	current.Price -= 0.2
+	current.Value = 0.2
+
+	c := Calibrate(priceDrivenSamples(20), current, CalibrationOptions{Step: 0.1})
+	require.Empty(t, c.Skipped)
+	assert.InDelta(t, 0.2, c.Weights.Value, 1e-9)
+	assert.InDelta(t, 1.0, c.Weights.Sum(), 1e-9)
+}
+
+func TestCalibrate_PrefersCurrentWeightsOnTie(t *testing.T) {
+	t.Parallel()
+
+	// Labels are perfectly separable by price alone, and the current
+	// weights already separate them, so the search should stay put.
+	current := Weights{Price: 0.5, Seller: 0.1, Condition: 0.1, Quantity: 0.1, Quality: 0.1, Time: 0.1}
+	samples := []Sample{}
+	for range 20 {
+		samples = append(samples,
+			Sample{Factors: Breakdown{Price: 100, Seller: 50, Condition: 50, Quantity: 50, Quality: 50, Time: 50}, Good: true},
+			Sample{Factors: Breakdown{Price: 0, Seller: 50, Condition: 50, Quantity: 50, Quality: 50, Time: 50}, Good: false},
+		)
+	}
+
+	c := Calibrate(samples, current, CalibrationOptions{Step: 0.1})
+	require.Empty(t, c.Skipped)
+	assert.Equal(t, current, c.Weights)
+}
+
+func TestCalibrate_Skips(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		want    string
+	}{
+		{
+			name:    "too few samples",
+			samples: priceDrivenSamples(2),
+			want:    "insufficient labelled alerts",
+		},
+		{
+			name:    "all good",
+			samples: repeatSample(Sample{Good: true}, 40),
+			want:    "no bad alerts labelled",
+		},
+		{
+			name:    "all bad",
+			samples: repeatSample(Sample{Good: false}, 40),
+			want:    "no good alerts labelled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := Calibrate(tt.samples, DefaultWeights(), CalibrationOptions{})
+			assert.Equal(t, tt.want, c.Skipped)
+			assert.Equal(t, DefaultWeights(), c.Weights)
+			assert.Zero(t, c.Lift())
+		})
+	}
+}
+
+func repeatSample(s Sample, n int) []Sample {
+	out := make([]Sample, n)
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package score
+
+import (
+	"strings"
+)
+
+// Risk signal names, as stored in a listing's risk signals.
+const (
+	RiskPriceFarBelowP10 = "price_far_below_p10"
+	RiskLowFeedback      = "low_seller_feedback"
+	RiskGenericTitle     = "generic_title"
+	RiskNoImage          = "no_image"
+	RiskForeignLocation  = "foreign_location"
+)
+
+// Points each risk signal adds. The total is capped at 100.
+const (
+	riskPointsFarBelowP10  = 40
+	riskPointsNoFeedback   = 30 // fewer than 10 feedback
+	riskPointsLowFeedback  = 15 // fewer than 50 feedback
+	riskPointsGenericTitle = 20
+	riskPointsNoImage      = 15
+	riskPointsForeign      = 25
+)
+
+// farBelowP10 is the fraction of P10 under which a unit price is
+// treated as too good to be true rather than a deal.
+const farBelowP10 = 0.5
+
+// genericTitlePhrases mark titles that withhold what is being sold.
+var genericTitlePhrases = []string{
+	"read description",
+	"see description",
+	"read desc",
+	"please read",
+	"read before",
+	"see pics",
+	"see photos",
+	"see details",
+}
+
+// RiskData holds the fields risk scoring looks at.
+type RiskData struct {
+	UnitPrice      float64
+	SellerFeedback int
+	Title          string
+	HasImages      bool
+	// ForeignLocation is set when the item ships from outside the
+	// countries of the marketplace it is priced for.
+	ForeignLocation bool
+}
+
+// RiskSignal is one warning sign and the points it added.
+type RiskSignal struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+// RiskBreakdown is a listing's risk score, 0 (no warning signs) to 100,
+// and the signals behind it. It is computed next to Breakdown but never
+// folded into the deal score: the listings priceScore likes best are
+// often the riskiest.
+type RiskBreakdown struct {
+	Signals []RiskSignal `json:"signals"`
+	Total   int          `json:"total"`
+}
+
+// Risk scores how likely a listing is to be a scam or junk. The price
+// signal needs a baseline with at least MinBaselineSamples; a foreign
+// location only counts when the price is at or below the baseline
+// median, i.e. priced like a domestic deal.
+func Risk(data *RiskData, baseline *Baseline) RiskBreakdown {
+	r := RiskBreakdown{Signals: []RiskSignal{}}
+	add := func(name string, points int) {
+		r.Signals = append(r.Signals, RiskSignal{Name: name, Points: points})
+		r.Total += points
+	}
+
+	hasBaseline := baseline != nil && baseline.SampleCount >= MinBaselineSamples
+	if hasBaseline && data.UnitPrice > 0 && data.UnitPrice < baseline.P10*farBelowP10 {
+		add(RiskPriceFarBelowP10, riskPointsFarBelowP10)
+	}
+
+	switch {
+	case data.SellerFeedback < 10:
+		add(RiskLowFeedback, riskPointsNoFeedback)
+	case data.SellerFeedback < 50:
+		add(RiskLowFeedback, riskPointsLowFeedback)
+	}
+
+	if genericTitle(data.Title) {
+		add(RiskGenericTitle, riskPointsGenericTitle)
+	}
+
+	if !data.HasImages {
+		add(RiskNoImage, riskPointsNoImage)
+	}
+
+	if data.ForeignLocation && (!hasBaseline || data.UnitPrice <= baseline.P50) {
+		add(RiskForeignLocation, riskPointsForeign)
+	}
+
+	r.Total = min(r.Total, 100)
+	return r
+}
+
+// genericTitle reports whether a title withholds what is being sold:
+// it points at the description or photos, or is under three words.
+func genericTitle(title string) bool {
+	t := strings.ToLower(title)
+	for _, p := range genericTitlePhrases {
+		if strings.Contains(t, p) {
+			return true
+		}
+	}
+	return len(strings.Fields(t)) < 3
+}
@@ -0,0 +1,117 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRisk(t *testing.T) {
+	t.Parallel()
+
+	baseline := &Baseline{P10: 100, P25: 120, P50: 150, P75: 180, P90: 220, SampleCount: 20}
+	clean := RiskData{
+		UnitPrice:      140,
+		SellerFeedback: 2500,
+		Title:          "Samsung 32GB DDR4 2666 ECC RDIMM",
+		HasImages:      true,
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(d *RiskData)
+		baseline *Baseline
+		want     []string
+		wantRisk int
+	}{
+		{
+			name:     "clean listing",
+			baseline: baseline,
+			want:     []string{},
+		},
+		{
+			name:     "price far below P10",
+			modify:   func(d *RiskData) { d.UnitPrice = 30 },
+			baseline: baseline,
+			want:     []string{RiskPriceFarBelowP10},
+			wantRisk: 40,
+		},
+		{
+			name:   "far below price ignored without baseline",
+			modify: func(d *RiskData) { d.UnitPrice = 30 },
+			want:   []string{},
+		},
+		{
+			name:     "new seller",
+			modify:   func(d *RiskData) { d.SellerFeedback = 2 },
+			baseline: baseline,
+			want:     []string{RiskLowFeedback},
+			wantRisk: 30,
+		},
+		{
+			name:     "generic title",
+			modify:   func(d *RiskData) { d.Title = "Server RAM lot - READ DESCRIPTION" },
+			baseline: baseline,
+			want:     []string{RiskGenericTitle},
+			wantRisk: 20,
+		},
+		{
+			name:     "missing image",
+			modify:   func(d *RiskData) { d.HasImages = false },
+			baseline: baseline,
+			want:     []string{RiskNoImage},
+			wantRisk: 15,
+		},
+		{
+			name:     "foreign location at domestic price",
+			modify:   func(d *RiskData) { d.ForeignLocation = true },
+			baseline: baseline,
+			want:     []string{RiskForeignLocation},
+			wantRisk: 25,
+		},
+		{
+			name: "foreign location above median",
+			modify: func(d *RiskData) {
+				d.ForeignLocation = true
+				d.UnitPrice = 200
+			},
+			baseline: baseline,
+			want:     []string{},
+		},
+		{
+			name: "everything capped at 100",
+			modify: func(d *RiskData) {
+				d.UnitPrice = 20
+				d.SellerFeedback = 0
+				d.Title = "RAM"
+				d.HasImages = false
+				d.ForeignLocation = true
+			},
+			baseline: baseline,
+			want: []string{
+				RiskPriceFarBelowP10, RiskLowFeedback, RiskGenericTitle,
+				RiskNoImage, RiskForeignLocation,
+			},
+			wantRisk: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := clean
+			if tt.modify != nil {
+				tt.modify(&d)
+			}
+			r := Risk(&d, tt.baseline)
+
+			names := []string{}
+			for _, s := range r.Signals {
+				names = append(names, s.Name)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, tt.wantRisk, r.Total)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 const MinBaselineSamples = 10
 
 // Weights defines the relative importance of each scoring factor.
+// Value is optional: DefaultWeights leaves it at zero.
 type Weights struct {
 	Price     float64
 	Seller    float64
@@ -18,6 +19,7 @@ type Weights struct {
 	Quantity  float64
 	Quality   float64
 	Time      float64
+	Value     float64
 }
 
 // DefaultWeights returns the default scoring weights.
@@ -32,6 +34,35 @@ func DefaultWeights() Weights {
 	}
 }
 
+// IsZero reports whether no weight is set. Config treats an all-zero
+// block as "not configured" and falls back to DefaultWeights.
+func (w Weights) IsZero() bool {
+	return w == Weights{}
+}
+
+// Sum returns the total of all factor weights.
+func (w Weights) Sum() float64 {
+	return w.Price + w.Seller + w.Condition + w.Quantity + w.Quality + w.Time + w.Value
+}
+
+// WeightSet resolves the weights for a listing's component type,
+// falling back to Default when no per-type override is configured.
+type WeightSet struct {
+	Default    Weights
+	Components map[string]Weights
+}
+
+// For returns the weights to score a listing of componentType with.
+func (ws WeightSet) For(componentType string) Weights {
+	if w, ok := ws.Components[componentType]; ok && !w.IsZero() {
+		return w
+	}
+	if ws.Default.IsZero() {
+		return DefaultWeights()
+	}
+	return ws.Default
+}
+
 // Baseline holds the percentile distribution for a product category.
 type Baseline struct {
 	P10         float64
@@ -56,6 +87,15 @@ type ListingData struct {
 	IsAuction         bool
 	AuctionEndingSoon bool // within 1 hour
 	IsNewListing      bool // listed within last 2 hours
+	// PartOutRatio is the baseline value of a server's CPUs and RAM
+	// divided by its unit price; zero when there's no estimate.
+	PartOutRatio float64
+	// PricePerUnit is the unit price divided by the listing's value
+	// metric units ($/GB, $/TB, ...), and ValueBaseline the percentiles
+	// of PricePerUnit across the listing's value class. Zero and nil
+	// score a neutral value factor.
+	PricePerUnit  float64
+	ValueBaseline *Baseline
 }
 
 // Breakdown shows per-factor scores.
@@ -66,6 +106,7 @@ type Breakdown struct {
 	Quantity  float64 `json:"quantity"`
 	Quality   float64 `json:"quality"`
 	Time      float64 `json:"time"`
+	Value     float64 `json:"value"`
 	Total     int     `json:"total"`
 }
 
@@ -79,6 +120,11 @@ func Score(data *ListingData, baseline *Baseline, w Weights) Breakdown {
 	} else {
 		b.Price = 50 // neutral when no baseline
 	}
+	// A server whose parts are worth more than it costs is a deal even
+	// when its own (often sparse) baseline says otherwise.
+	if data.PartOutRatio > 0 {
+		b.Price = math.Max(b.Price, partOutScore(data.PartOutRatio))
+	}
 
 	// Seller trust score
 	b.Seller = sellerScore(data)
@@ -95,23 +141,37 @@ func Score(data *ListingData, baseline *Baseline, w Weights) Breakdown {
 	// Time pressure score
 	b.Time = timeScore(data)
 
-	// Weighted composite
+	// Price per unit against the value class
+	b.Value = 50 // neutral without a value metric or baseline
+	if vb := data.ValueBaseline; data.PricePerUnit > 0 && vb != nil && vb.SampleCount >= MinBaselineSamples {
+		b.Value = priceScore(data.PricePerUnit, vb)
+	}
+
+	b.Total = Composite(b, w)
+
+	return b
+}
+
+// Composite combines per-factor scores into the 0-100 weighted total.
+// The Total field of b is ignored, so callers can re-weight a persisted
+// breakdown without recomputing the factors.
+func Composite(b Breakdown, w Weights) int {
 	total := b.Price*w.Price +
 		b.Seller*w.Seller +
 		b.Condition*w.Condition +
 		b.Quantity*w.Quantity +
 		b.Quality*w.Quality +
-		b.Time*w.Time
+		b.Time*w.Time +
+		b.Value*w.Value
 
-	b.Total = int(math.Round(total))
-	if b.Total > 100 {
-		b.Total = 100
+	rounded := int(math.Round(total))
+	if rounded > 100 {
+		return 100
 	}
-	if b.Total < 0 {
-		b.Total = 0
+	if rounded < 0 {
+		return 0
 	}
-
-	return b
+	return rounded
 }
 
 // priceScore maps unit price to a 0-100 score based on percentile position.
@@ -138,6 +198,23 @@ func priceScore(unitPrice float64, b *Baseline) float64 {
 	}
 }
 
+// partOutScore maps a parts-value-to-price ratio to a 0-100 price
+// score: parts worth 1.5x the price or more score 100, break-even
+// scores 70, and 0.75x scores 30, mirroring the P10/P25/P50 points of
+// priceScore.
+func partOutScore(ratio float64) float64 {
+	switch {
+	case ratio >= 1.5:
+		return 100
+	case ratio >= 1.0:
+		return lerp(ratio, 1.0, 1.5, 70, 100)
+	case ratio >= 0.75:
+		return lerp(ratio, 0.75, 1.0, 30, 70)
+	default:
+		return lerp(ratio, 0, 0.75, 0, 30)
+	}
+}
+
 // sellerScore evaluates seller trustworthiness.
 func sellerScore(d *ListingData) float64 {
 	// Feedback score component (how many transactions)
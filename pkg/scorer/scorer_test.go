@@ -56,6 +56,70 @@ func TestScore_InsufficientBaseline(t *testing.T) {
 	assert.Equal(t, 50.0, b.Price, "baseline with <10 samples should give neutral 50")
 }
 
+func TestScore_PartOutRatio(t *testing.T) {
+	t.Parallel()
+
+	baseline := &Baseline{P10: 200, P25: 300, P50: 400, P75: 500, P90: 600, SampleCount: 20}
+
+	tests := []struct {
+		name      string
+		unitPrice float64
+		baseline  *Baseline
+		ratio     float64
+		wantPrice float64
+	}{
+		{name: "no estimate keeps neutral", unitPrice: 400, ratio: 0, wantPrice: 50},
+		{name: "parts worth 1.5x", unitPrice: 400, ratio: 1.5, wantPrice: 100},
+		{name: "break-even", unitPrice: 400, ratio: 1.0, wantPrice: 70},
+		{name: "below break-even", unitPrice: 400, ratio: 0.75, wantPrice: 50},
+		{name: "baseline wins when higher", unitPrice: 200, baseline: baseline, ratio: 1.0, wantPrice: 100},
+		{name: "ratio lifts a poor baseline score", unitPrice: 600, baseline: baseline, ratio: 1.25, wantPrice: 85},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := &ListingData{UnitPrice: tt.unitPrice, Quantity: 1, PartOutRatio: tt.ratio}
+			b := Score(data, tt.baseline, DefaultWeights())
+			assert.InDelta(t, tt.wantPrice, b.Price, 0.01)
+		})
+	}
+}
+
+func TestScore_ValueFactor(t *testing.T) {
+	t.Parallel()
+
+	valueBaseline := &Baseline{P10: 1.0, P25: 1.5, P50: 2.0, P75: 2.5, P90: 3.0, SampleCount: 40}
+
+	tests := []struct {
+		name         string
+		pricePerUnit float64
+		baseline     *Baseline
+		want         float64
+	}{
+		{name: "no metric is neutral", baseline: valueBaseline, want: 50},
+		{name: "no baseline is neutral", pricePerUnit: 1.0, want: 50},
+		{name: "thin baseline is neutral", pricePerUnit: 1.0, baseline: &Baseline{P10: 1, SampleCount: 3}, want: 50},
+		{name: "cheap per unit", pricePerUnit: 0.9, baseline: valueBaseline, want: 100},
+		{name: "median per unit", pricePerUnit: 2.0, baseline: valueBaseline, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := &ListingData{UnitPrice: 64, Quantity: 1, PricePerUnit: tt.pricePerUnit, ValueBaseline: tt.baseline}
+			assert.InDelta(t, tt.want, Score(data, nil, DefaultWeights()).Value, 0.01)
+		})
+	}
+
+	w := DefaultWeights()
+	w.Price -= 0.2
+	w.Value = 0.2
+	data := &ListingData{UnitPrice: 64, Quantity: 1, PricePerUnit: 0.9, ValueBaseline: valueBaseline}
+	assert.Greater(t, Score(data, nil, w).Total, Score(data, nil, DefaultWeights()).Total,
+		"a cheap price per unit raises the total once the value factor is weighted")
+}
+
 func TestScore_WithBaseline(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,772 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Filter expressions extend WatchFilters with boolean logic the structured
+// fields can't express. An expression is compiled once (and cached by
+// source text), then evaluated against each candidate listing in Match.
+//
+// Grammar, loosest binding first:
+//
+//	expr    = and { ("or" | "||") and }
+//	and     = unary { ("and" | "&&") unary }
+//	unary   = ("not" | "!") unary | cmp
+//	cmp     = operand [ op operand ]
+//	op      = "==" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "!~" | "in" | "contains"
+//	operand = number | string | "true" | "false" | path | list | "(" expr ")"
+//	list    = "[" [ literal { "," literal } ] "]"
+//
+// Paths name listing fields (title, price, unit_price, shipping, quantity,
+// condition, component_type, product_key, listing_type, confidence,
+// seller.name, seller.feedback, seller.feedback_pct, seller.top_rated) or
+// extracted attributes via attrs.<key>. Strings take double quotes (Go
+// escapes) or single quotes (raw, handy for regexes).
+//
+// "=~" / "!~" match an RE2 regex (use (?i) for case-insensitive);
+// "contains" is a case-insensitive substring test against one string or
+// any string in a list. A comparison against a missing attribute is
+// false, whatever the operator — the same rule AttributeFilters applies.
+//
+//	not title contains ["caddy", "bracket", "tray"]
+//	attrs.capacity_gb >= 32 and (attrs.speed_mhz >= 2933 or unit_price < 40)
+
+// maxFilterExprLen bounds expression size so a pathological watch can't
+// turn every ingestion pass into a parser benchmark.
+const maxFilterExprLen = 4096
+
+// FilterExprError reports a compile failure at a 1-based column in the
+// expression source.
+type FilterExprError struct {
+	Pos int
+	Msg string
+}
+
+func (e *FilterExprError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos, e.Msg)
+}
+
+// FilterExpr is a compiled filter expression. Safe for concurrent use.
+type FilterExpr struct {
+	src  string
+	root exprNode
+}
+
+// String returns the source the expression was compiled from.
+func (x *FilterExpr) String() string { return x.src }
+
+// Match reports whether the listing satisfies the expression.
+func (x *FilterExpr) Match(l *Listing) bool {
+	return x.root.eval(l) == true
+}
+
+// CompileFilterExpr parses and type-checks src. Errors are
+// *FilterExprError values carrying the offending column.
+func CompileFilterExpr(src string) (*FilterExpr, error) {
+	if len(src) > maxFilterExprLen {
+		return nil, &FilterExprError{
+			Pos: maxFilterExprLen + 1,
+			Msg: fmt.Sprintf("expression longer than %d bytes", maxFilterExprLen),
+		}
+	}
+	toks, err := lexFilterExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	if k := root.kind(); k != kindBool && k != kindAny {
+		return nil, &FilterExprError{Pos: 1, Msg: fmt.Sprintf("expression is a %s, not a condition", k)}
+	}
+	return &FilterExpr{src: src, root: root}, nil
+}
+
+// maxCachedFilterExprs bounds filterExprCache. Saved watches need far
+// fewer entries; the cap only matters when preview requests send a
+// stream of distinct expressions.
+const maxCachedFilterExprs = 1024
+
+// filterExprCache memoises successfully compiled expressions by source
+// text so hot-path matching doesn't re-parse a watch's expression for
+// every listing. Compile errors are not cached. Preview requests can
+// match arbitrary expressions, so the cache is dropped wholesale once
+// it reaches maxCachedFilterExprs; saved watches repopulate it on their
+// next match.
+var filterExprCache = struct {
+	sync.Mutex
+	m map[string]*FilterExpr
+}{m: make(map[string]*FilterExpr)}
+
+func compileFilterExprCached(src string) (*FilterExpr, error) {
+	filterExprCache.Lock()
+	x, ok := filterExprCache.m[src]
+	filterExprCache.Unlock()
+	if ok {
+		return x, nil
+	}
+	x, err := CompileFilterExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	filterExprCache.Lock()
+	if len(filterExprCache.m) >= maxCachedFilterExprs {
+		clear(filterExprCache.m)
+	}
+	filterExprCache.m[src] = x
+	filterExprCache.Unlock()
+	return x, nil
+}
+
+// --- Lexer ---
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+)
+
+type token struct {
+	kind tokKind
+	text string // raw text (identifiers, operators) or decoded string value
+	num  float64
+	pos  int // 1-based column
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+var twoCharOps = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||"}
+
+func lexFilterExpr(src string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		pos := i + 1
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokLParen, text: "(", pos: pos})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokRParen, text: ")", pos: pos})
+			i++
+		case c == '[':
+			toks = append(toks, token{kind: tokLBrack, text: "[", pos: pos})
+			i++
+		case c == ']':
+			toks = append(toks, token{kind: tokRBrack, text: "]", pos: pos})
+			i++
+		case c == ',':
+			toks = append(toks, token{kind: tokComma, text: ",", pos: pos})
+			i++
+		case c == '"' || c == '\'':
+			s, n, err := lexString(src[i:], pos)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, text: s, pos: pos})
+			i += n
+		case c >= '0' && c <= '9' || c == '.' || c == '-' && i+1 < len(src) && isNumStart(src[i+1]):
+			j := i + 1
+			for j < len(src) && isNumByte(src[j], src[j-1]) {
+				j++
+			}
+			v, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, &FilterExprError{Pos: pos, Msg: fmt.Sprintf("invalid number %q", src[i:j])}
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[i:j], num: v, pos: pos})
+			i = j
+		case isIdentByte(c) && !unicode.IsDigit(rune(c)):
+			j := i + 1
+			for j < len(src) && (isIdentByte(src[j]) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[i:j], pos: pos})
+			i = j
+		default:
+			if i+1 < len(src) && slices.Contains(twoCharOps, src[i:i+2]) {
+				toks = append(toks, token{kind: tokOp, text: src[i : i+2], pos: pos})
+				i += 2
+				continue
+			}
+			if c == '<' || c == '>' || c == '!' {
+				toks = append(toks, token{kind: tokOp, text: string(c), pos: pos})
+				i++
+				continue
+			}
+			if c == '=' {
+				return nil, &FilterExprError{Pos: pos, Msg: `unexpected "=" (use "==" to compare)`}
+			}
+			return nil, &FilterExprError{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	toks = append(toks, token{kind: tokEOF, pos: len(src) + 1})
+	return toks, nil
+}
+
+// lexString decodes the quoted string at the start of s, returning the
+// value and the number of bytes consumed. Double-quoted strings use Go
+// escapes; single-quoted strings are raw.
+func lexString(s string, pos int) (string, int, error) {
+	quote := s[0]
+	for j := 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if quote == '"' {
+				j++
+			}
+		case quote:
+			if quote == '\'' {
+				return s[1:j], j + 1, nil
+			}
+			v, err := strconv.Unquote(s[:j+1])
+			if err != nil {
+				return "", 0, &FilterExprError{Pos: pos, Msg: "invalid escape in string"}
+			}
+			return v, j + 1, nil
+		}
+	}
+	return "", 0, &FilterExprError{Pos: pos, Msg: "unterminated string"}
+}
+
+func isNumStart(c byte) bool { return c >= '0' && c <= '9' || c == '.' }
+
+// isNumByte reports whether c continues a number literal after prev:
+// digits, a decimal point, an exponent marker, or the exponent's sign.
+func isNumByte(c, prev byte) bool {
+	switch c {
+	case 'e', 'E':
+		return true
+	case '+', '-':
+		return prev == 'e' || prev == 'E'
+	}
+	return isNumStart(c)
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// --- Parser ---
+
+type exprParser struct {
+	toks []token
+	i    int
+}
+
+func (p *exprParser) peek() token { return p.toks[p.i] }
+
+func (p *exprParser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *exprParser) errorf(t token, format string, args ...any) error {
+	return &FilterExprError{Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// isWord reports whether t is the operator or keyword w (keywords are
+// case-insensitive: AND, and, And all work).
+func isWord(t token, words ...string) bool {
+	if t.kind != tokOp && t.kind != tokIdent {
+		return false
+	}
+	return slices.ContainsFunc(words, func(w string) bool { return strings.EqualFold(t.text, w) })
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "or", "||") {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireBool(op, left, right); err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "and", "&&") {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireBool(op, left, right); err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if isWord(p.peek(), "not", "!") {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireBool(op, operand); err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseCmp()
+}
+
+var cmpOps = []string{"==", "!=", "<", "<=", ">", ">=", "=~", "!~", "in", "contains"}
+
+func (p *exprParser) parseCmp() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	if !isWord(op, cmpOps...) {
+		return left, nil
+	}
+	p.next()
+	rightTok := p.peek()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return newCmpNode(strings.ToLower(op.text), op, left, right, rightTok)
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &litNode{val: t.num}, nil
+	case tokString:
+		return &litNode{val: t.text}, nil
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, p.errorf(c, "expected \")\" to close \"(\" at column %d, got %s", t.pos, c)
+		}
+		return inner, nil
+	case tokLBrack:
+		return p.parseList(t)
+	case tokIdent:
+		return resolvePath(t)
+	case tokEOF:
+		return nil, p.errorf(t, "unexpected end of expression")
+	default:
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+}
+
+func (p *exprParser) parseList(open token) (exprNode, error) {
+	list := &listNode{}
+	if p.peek().kind == tokRBrack {
+		p.next()
+		return list, nil
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokNumber:
+			list.items = append(list.items, t.num)
+		case t.kind == tokString:
+			list.items = append(list.items, t.text)
+		case isWord(t, "true", "false"):
+			list.items = append(list.items, strings.EqualFold(t.text, "true"))
+		default:
+			return nil, p.errorf(t, "list elements must be literals, got %s", t)
+		}
+		switch sep := p.next(); sep.kind {
+		case tokComma:
+			continue
+		case tokRBrack:
+			return list, nil
+		default:
+			return nil, p.errorf(sep, "expected \",\" or \"]\" to close \"[\" at column %d, got %s", open.pos, sep)
+		}
+	}
+}
+
+// listingFields maps expression paths onto listing accessors. A nil
+// return means "not known" and fails any comparison.
+var listingFields = map[string]struct {
+	kind valueKind
+	get  func(*Listing) any
+}{
+	"title":               {kindString, func(l *Listing) any { return l.Title }},
+	"price":               {kindNumber, func(l *Listing) any { return l.Price }},
+	"unit_price":          {kindNumber, func(l *Listing) any { return l.UnitPrice() }},
+	"shipping":            {kindNumber, shippingValue},
+	"quantity":            {kindNumber, func(l *Listing) any { return float64(l.Quantity) }},
+	"condition":           {kindString, func(l *Listing) any { return string(l.ConditionNorm) }},
+	"component_type":      {kindString, func(l *Listing) any { return string(l.ComponentType) }},
+	"product_key":         {kindString, func(l *Listing) any { return l.ProductKey }},
+	"value_class":         {kindString, func(l *Listing) any { return l.ValueClass }},
+	"price_per_unit":      {kindNumber, pricePerUnitValue},
+	"listing_type":        {kindString, func(l *Listing) any { return string(l.ListingType) }},
+	"confidence":          {kindNumber, func(l *Listing) any { return l.ExtractionConfidence }},
+	"seller.name":         {kindString, func(l *Listing) any { return l.SellerName }},
+	"seller.feedback":     {kindNumber, func(l *Listing) any { return float64(l.SellerFeedback) }},
+	"seller.feedback_pct": {kindNumber, func(l *Listing) any { return l.SellerFeedbackPct }},
+	"seller.top_rated":    {kindBool, func(l *Listing) any { return l.SellerTopRated }},
+}
+
+func pricePerUnitValue(l *Listing) any {
+	if l.PricePerUnit == nil {
+		return nil
+	}
+	return *l.PricePerUnit
+}
+
+func shippingValue(l *Listing) any {
+	if l.ShippingCost == nil {
+		return nil
+	}
+	return *l.ShippingCost
+}
+
+func resolvePath(t token) (exprNode, error) {
+	name := strings.ToLower(t.text)
+	switch name {
+	case "true":
+		return &litNode{val: true}, nil
+	case "false":
+		return &litNode{val: false}, nil
+	}
+	if f, ok := listingFields[name]; ok {
+		return &fieldNode{name: name, k: f.kind, get: f.get}, nil
+	}
+	for _, prefix := range []string{"attrs.", "attributes."} {
+		if key, ok := strings.CutPrefix(t.text, prefix); ok {
+			if key == "" || strings.Contains(key, ".") {
+				return nil, &FilterExprError{Pos: t.pos, Msg: fmt.Sprintf("invalid attribute path %q", t.text)}
+			}
+			return &attrNode{key: key}, nil
+		}
+	}
+	if isWord(t, cmpOps...) || isWord(t, "and", "or", "not") {
+		return nil, &FilterExprError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q, expected a value", t.text)}
+	}
+	return nil, &FilterExprError{
+		Pos: t.pos,
+		Msg: fmt.Sprintf("unknown field %q (use attrs.%s for extracted attributes)", t.text, t.text),
+	}
+}
+
+// --- Type checking ---
+
+type valueKind int
+
+const (
+	kindAny valueKind = iota // attrs.* — decided at evaluation time
+	kindNumber
+	kindString
+	kindBool
+	kindList
+)
+
+func (k valueKind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindBool:
+		return "boolean"
+	case kindList:
+		return "list"
+	default:
+		return "value"
+	}
+}
+
+func requireBool(op token, operands ...exprNode) error {
+	for _, n := range operands {
+		if k := n.kind(); k != kindBool && k != kindAny {
+			return &FilterExprError{Pos: op.pos, Msg: fmt.Sprintf("%q needs conditions, got a %s", op.text, k)}
+		}
+	}
+	return nil
+}
+
+func newCmpNode(op string, opTok token, left, right exprNode, rightTok token) (exprNode, error) {
+	lk, rk := left.kind(), right.kind()
+	bad := func(format string, args ...any) error {
+		return &FilterExprError{Pos: opTok.pos, Msg: fmt.Sprintf(format, args...)}
+	}
+	n := &cmpNode{op: op, left: left, right: right}
+
+	switch op {
+	case "<", "<=", ">", ">=":
+		if lk != kindNumber && lk != kindAny || rk != kindNumber && rk != kindAny {
+			return nil, bad("%q compares numbers, got %s and %s", op, lk, rk)
+		}
+	case "==", "!=":
+		if lk == kindList || rk == kindList {
+			return nil, bad("%q cannot compare lists (use \"in\")", op)
+		}
+		if lk != kindAny && rk != kindAny && lk != rk {
+			return nil, bad("cannot compare %s with %s", lk, rk)
+		}
+	case "=~", "!~":
+		if lk != kindString && lk != kindAny {
+			return nil, bad("%q matches strings, got %s", op, lk)
+		}
+		lit, ok := right.(*litNode)
+		pattern, isStr := lit.valOrNil().(string)
+		if !ok || !isStr {
+			return nil, &FilterExprError{Pos: rightTok.pos, Msg: fmt.Sprintf("%q needs a quoted regex on the right", op)}
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, &FilterExprError{Pos: rightTok.pos, Msg: fmt.Sprintf("invalid regex: %v", err)}
+		}
+		n.re = re
+	case "contains":
+		if lk != kindString && lk != kindAny {
+			return nil, bad("\"contains\" searches strings, got %s", lk)
+		}
+		terms, err := containsTerms(right, rightTok)
+		if err != nil {
+			return nil, err
+		}
+		n.terms = terms
+	case "in":
+		if rk != kindList {
+			return nil, &FilterExprError{Pos: rightTok.pos, Msg: "\"in\" needs a [list] on the right"}
+		}
+	}
+	return n, nil
+}
+
+// containsTerms lower-cases the right-hand side of "contains" up front so
+// evaluation is a plain substring scan.
+func containsTerms(right exprNode, rightTok token) ([]string, error) {
+	var raw []any
+	switch r := right.(type) {
+	case *litNode:
+		raw = []any{r.val}
+	case *listNode:
+		raw = r.items
+	}
+	if len(raw) == 0 {
+		return nil, &FilterExprError{Pos: rightTok.pos, Msg: "\"contains\" needs a string or [list of strings] on the right"}
+	}
+	terms := make([]string, 0, len(raw))
+	for _, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, &FilterExprError{Pos: rightTok.pos, Msg: "\"contains\" terms must be strings"}
+		}
+		terms = append(terms, strings.ToLower(s))
+	}
+	return terms, nil
+}
+
+// --- Evaluation ---
+
+type exprNode interface {
+	kind() valueKind
+	eval(l *Listing) any
+}
+
+type litNode struct{ val any }
+
+func (n *litNode) kind() valueKind {
+	switch n.val.(type) {
+	case float64:
+		return kindNumber
+	case string:
+		return kindString
+	case bool:
+		return kindBool
+	}
+	return kindAny
+}
+
+func (n *litNode) eval(*Listing) any { return n.val }
+
+func (n *litNode) valOrNil() any {
+	if n == nil {
+		return nil
+	}
+	return n.val
+}
+
+type listNode struct{ items []any }
+
+func (*listNode) kind() valueKind     { return kindList }
+func (n *listNode) eval(*Listing) any { return n.items }
+
+type fieldNode struct {
+	name string
+	k    valueKind
+	get  func(*Listing) any
+}
+
+func (n *fieldNode) kind() valueKind     { return n.k }
+func (n *fieldNode) eval(l *Listing) any { return n.get(l) }
+
+type attrNode struct{ key string }
+
+func (*attrNode) kind() valueKind { return kindAny }
+
+func (n *attrNode) eval(l *Listing) any {
+	v, ok := l.Attributes[n.key]
+	if !ok {
+		return nil
+	}
+	if f, ok := toFloat64(v); ok {
+		return f
+	}
+	return v
+}
+
+type notNode struct{ operand exprNode }
+
+func (*notNode) kind() valueKind { return kindBool }
+
+func (n *notNode) eval(l *Listing) any { return n.operand.eval(l) != true }
+
+type andNode struct{ left, right exprNode }
+
+func (*andNode) kind() valueKind { return kindBool }
+
+func (n *andNode) eval(l *Listing) any {
+	return n.left.eval(l) == true && n.right.eval(l) == true
+}
+
+type orNode struct{ left, right exprNode }
+
+func (*orNode) kind() valueKind { return kindBool }
+
+func (n *orNode) eval(l *Listing) any {
+	return n.left.eval(l) == true || n.right.eval(l) == true
+}
+
+type cmpNode struct {
+	op          string
+	left, right exprNode
+	re          *regexp.Regexp
+	terms       []string
+}
+
+func (*cmpNode) kind() valueKind { return kindBool }
+
+func (n *cmpNode) eval(l *Listing) any {
+	a := n.left.eval(l)
+	if a == nil {
+		return false
+	}
+	switch n.op {
+	case "=~", "!~":
+		s, ok := a.(string)
+		return ok && n.re.MatchString(s) == (n.op == "=~")
+	case "contains":
+		s, ok := a.(string)
+		if !ok {
+			return false
+		}
+		s = strings.ToLower(s)
+		return slices.ContainsFunc(n.terms, func(t string) bool { return strings.Contains(s, t) })
+	case "in":
+		items, _ := n.right.eval(l).([]any)
+		return slices.ContainsFunc(items, func(v any) bool { return valuesEqual(a, v) })
+	}
+
+	b := n.right.eval(l)
+	if b == nil {
+		return false
+	}
+	switch n.op {
+	case "==":
+		return valuesEqual(a, b)
+	case "!=":
+		return !valuesEqual(a, b)
+	}
+	x, okA := a.(float64)
+	y, okB := b.(float64)
+	if !okA || !okB {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	case ">":
+		return x > y
+	default: // ">="
+		return x >= y
+	}
+}
+
+// valuesEqual compares expression values. Numbers compare numerically;
+// strings compare case-insensitively because extracted attribute casing
+// ("RDIMM" vs "rdimm") varies between LLM backends.
+func valuesEqual(a, b any) bool {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		return ok && x == y
+	case string:
+		y, ok := b.(string)
+		return ok && strings.EqualFold(x, y)
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+	return false
+}
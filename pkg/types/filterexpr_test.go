@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exprListing() *Listing {
+	shipping := 10.0
+	return &Listing{
+		Title:             "Samsung 32GB DDR4-2933 ECC RDIMM Server Memory",
+		Price:             70,
+		ShippingCost:      &shipping,
+		Quantity:          2,
+		ConditionNorm:     ConditionUsedWorking,
+		ComponentType:     ComponentRAM,
+		SellerFeedback:    1200,
+		SellerFeedbackPct: 99.5,
+		SellerTopRated:    true,
+		Attributes: map[string]any{
+			"capacity_gb": float64(32),
+			"speed_mhz":   2933,
+			"form_factor": "RDIMM",
+			"ecc":         true,
+		},
+	}
+}
+
+func TestFilterExpr_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{"title contains term", `title contains "ddr4"`, true},
+		{"title excludes terms", `not title contains ["caddy", "bracket"]`, true},
+		{"title excludes matching term", `!(title contains ["memory", "bracket"])`, false},
+		{"title regex", `title =~ '(?i)\bddr4-\d{4}\b'`, true},
+		{"title negated regex", `title !~ '(?i)lot of'`, true},
+		{"unit price includes shipping", `unit_price == 40`, true},
+		{"raw price", `price > 60 && price <= 70`, true},
+		{"exponent literal with plus sign", `price > 6.5e1 && price < 7e+1 && unit_price > 4000e-2`, false},
+		{"signed exponent literal", `price > 6.5e1 && unit_price >= 4000e-2 && -1e-5 < price`, true},
+		{"nested boolean", `attrs.capacity_gb >= 32 and (attrs.speed_mhz >= 3200 or unit_price < 45)`, true},
+		{"nested boolean fails", `attrs.capacity_gb >= 32 and (attrs.speed_mhz >= 3200 or unit_price < 30)`, false},
+		{"attribute string equality is case-insensitive", `attrs.form_factor == "rdimm"`, true},
+		{"attribute in list", `attributes.form_factor in ["LRDIMM", "RDIMM"]`, true},
+		{"bare boolean attribute", `attrs.ecc`, true},
+		{"bare boolean field", `seller.top_rated and seller.feedback_pct >= 99`, true},
+		{"condition", `condition in ["new", "used_working"]`, true},
+		{"missing attribute fails comparison", `attrs.rank == 2`, false},
+		{"missing attribute fails negated comparison", `attrs.rank != 2`, false},
+		{"missing attribute under not", `not attrs.rank == 2`, true},
+		{"keywords are case-insensitive", `attrs.ecc AND NOT title CONTAINS "lot"`, true},
+	}
+
+	l := exprListing()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			x, err := CompileFilterExpr(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, x.Match(l))
+		})
+	}
+}
+
+func TestCompileFilterExpr_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		expr    string
+		wantPos int
+		wantMsg string
+	}{
+		{"unknown field", `capacity_gb >= 32`, 1, `unknown field "capacity_gb"`},
+		{"unclosed paren", `price < 40 and (attrs.ecc`, 26, `expected ")"`},
+		{"single equals", `price = 40`, 7, `use "=="`},
+		{"type mismatch", `title > 5`, 7, `compares numbers`},
+		{"string vs number", `price == "cheap"`, 7, `cannot compare number with string`},
+		{"bad regex", `title =~ "("`, 10, `invalid regex`},
+		{"regex needs literal", `title =~ product_key`, 10, `quoted regex`},
+		{"in needs list", `condition in "new"`, 14, `[list]`},
+		{"non-condition operand", `price and attrs.ecc`, 7, `needs conditions`},
+		{"non-condition expression", `unit_price`, 1, `not a condition`},
+		{"unterminated string", `title contains "caddy`, 16, `unterminated string`},
+		{"trailing tokens", `attrs.ecc attrs.ecc`, 11, `unexpected "attrs.ecc"`},
+		{"list of paths", `condition in [condition]`, 15, `must be literals`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := CompileFilterExpr(tt.expr)
+			var exprErr *FilterExprError
+			require.True(t, errors.As(err, &exprErr), "got %v", err)
+			assert.Equal(t, tt.wantPos, exprErr.Pos)
+			assert.Contains(t, exprErr.Msg, tt.wantMsg)
+		})
+	}
+}
+
+func TestWatchFilters_MatchExpr(t *testing.T) {
+	t.Parallel()
+
+	priceMax := 100.0
+	f := WatchFilters{
+		PriceMax: &priceMax,
+		Expr:     `not title contains "bracket"`,
+	}
+	require.NoError(t, f.Validate())
+	assert.True(t, f.Match(exprListing()))
+
+	bracket := exprListing()
+	bracket.Title += " with bracket"
+	assert.False(t, f.Match(bracket), "expression is ANDed with the structured filters")
+
+	bad := WatchFilters{Expr: `price <`}
+	require.Error(t, bad.Validate())
+	assert.False(t, bad.Match(exprListing()), "uncompilable expression matches nothing")
+}
+
+func TestCompileFilterExprCached_Bounded(t *testing.T) {
+	t.Parallel()
+
+	for i := range maxCachedFilterExprs + 10 {
+		_, err := compileFilterExprCached(fmt.Sprintf("price < %d", i))
+		require.NoError(t, err)
+	}
+	_, err := compileFilterExprCached(`price <`)
+	require.Error(t, err)
+
+	filterExprCache.Lock()
+	defer filterExprCache.Unlock()
+	assert.LessOrEqual(t, len(filterExprCache.m), maxCachedFilterExprs)
+	assert.NotContains(t, filterExprCache.m, `price <`, "compile errors are not cached")
+}
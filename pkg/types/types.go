@@ -3,7 +3,11 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"maps"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,6 +22,9 @@ const (
 	ComponentCPU         ComponentType = "cpu"
 	ComponentNIC         ComponentType = "nic"
 	ComponentGPU         ComponentType = "gpu"
+	ComponentHBA         ComponentType = "hba"
+	ComponentSwitch      ComponentType = "switch"
+	ComponentTransceiver ComponentType = "transceiver"
 	ComponentWorkstation ComponentType = "workstation"
 	ComponentDesktop     ComponentType = "desktop"
 	ComponentOther       ComponentType = "other"
@@ -53,11 +60,21 @@ type Listing struct {
 	ItemURL  string `json:"item_url"            db:"item_url"`
 	ImageURL string `json:"image_url,omitempty" db:"image_url"`
 
+	// ItemCountry is the ISO 3166 code of the country the item ships
+	// from, empty when eBay didn't report it.
+	ItemCountry string `json:"item_country,omitempty" db:"item_country"`
+
 	// Pricing
 	Price        float64     `json:"price"                   db:"price"`
 	Currency     string      `json:"currency"                db:"currency"`
 	ShippingCost *float64    `json:"shipping_cost,omitempty" db:"shipping_cost"`
 	ListingType  ListingType `json:"listing_type"            db:"listing_type"`
+	BidCount     int         `json:"bid_count,omitempty"     db:"bid_count"` // auctions only
+
+	// Pricing normalized to USD at ingestion. Nil when the listing's
+	// currency had no exchange rate at the time.
+	PriceUSD        *float64 `json:"price_usd,omitempty"         db:"price_usd"`
+	ShippingCostUSD *float64 `json:"shipping_cost_usd,omitempty" db:"shipping_cost_usd"`
 
 	// Seller
 	SellerName        string  `json:"seller_name"           db:"seller_name"`
@@ -74,10 +91,37 @@ type Listing struct {
 	ExtractionConfidence float64        `json:"extraction_confidence"   db:"extraction_confidence"`
 	ProductKey           string         `json:"product_key,omitempty"   db:"product_key"`
 
+	// Relists and duplicates share a group, keyed by the ID of the
+	// group's first listing. Empty until the listing has been grouped.
+	ListingGroupID string `json:"listing_group_id,omitempty" db:"listing_group_id"`
+
 	// Scoring
 	Score          *int            `json:"score,omitempty"           db:"score"`
 	ScoreBreakdown json.RawMessage `json:"score_breakdown,omitempty" db:"score_breakdown"`
 
+	// Risk scoring: 0 (no warning signs) to 100, computed alongside the
+	// deal score. RiskSignals is a []RiskSignal.
+	RiskScore   *int            `json:"risk_score,omitempty"   db:"risk_score"`
+	RiskSignals json.RawMessage `json:"risk_signals,omitempty" db:"risk_signals"`
+
+	// Part-out estimate for servers: the baseline value of the CPUs and
+	// RAM inside (USD) and that value divided by the unit price. Nil
+	// when the parts couldn't be priced.
+	PartOutValue *float64 `json:"partout_value,omitempty" db:"partout_value"`
+	PartOutRatio *float64 `json:"partout_ratio,omitempty" db:"partout_ratio"`
+
+	// Value metric: the USD unit price per GB (RAM), TB (drives), core
+	// (CPUs) or GB of VRAM (GPUs), compared across the product keys of
+	// a value class such as "ram:ddr4:ecc_reg". Empty and nil until
+	// scored with the attribute the metric is measured by.
+	ValueClass   string   `json:"value_class,omitempty"    db:"value_class"`
+	ValueUnit    string   `json:"value_unit,omitempty"     db:"value_unit"`
+	PricePerUnit *float64 `json:"price_per_unit,omitempty" db:"price_per_unit"`
+
+	// ExtractionLocked is set once an operator has corrected the
+	// extraction; locked listings are never re-extracted.
+	ExtractionLocked bool `json:"extraction_locked,omitempty" db:"extraction_locked"`
+
 	// State
 	Active bool `json:"active" db:"active"`
 
@@ -102,19 +146,393 @@ func (l *Listing) UnitPrice() float64 {
 	return total
 }
 
+// UnitPriceUSD returns the per-unit price including shipping in USD.
+// Listings priced in USD (or with no currency) need no conversion;
+// other currencies use the normalized prices. ok is false when there
+// is no USD price.
+func (l *Listing) UnitPriceUSD() (price float64, ok bool) {
+	if l.PriceUSD == nil {
+		if l.Currency != "" && l.Currency != "USD" {
+			return 0, false
+		}
+		return l.UnitPrice(), true
+	}
+	total := *l.PriceUSD
+	if l.ShippingCostUSD != nil {
+		total += *l.ShippingCostUSD
+	}
+	if l.Quantity > 1 {
+		return total / float64(l.Quantity), true
+	}
+	return total, true
+}
+
+// RiskSignalList decodes RiskSignals. Listings scored before risk
+// scoring existed, or with undecodable signals, return nil.
+func (l *Listing) RiskSignalList() []RiskSignal {
+	if len(l.RiskSignals) == 0 {
+		return nil
+	}
+	var signals []RiskSignal
+	if err := json.Unmarshal(l.RiskSignals, &signals); err != nil {
+		return nil
+	}
+	return signals
+}
+
+// FormatPrice renders amount in currency for people: "$12.50" for USD
+// (or no currency), "12.50 GBP" otherwise.
+func FormatPrice(amount float64, currency string) string {
+	if currency == "" || currency == "USD" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+// PricePoint is one entry of a listing's price history, recorded when a
+// listing is first seen and whenever an upsert changes its price or
+// shipping.
+type PricePoint struct {
+	Price           float64   `json:"price"                       db:"price"`
+	ShippingCost    *float64  `json:"shipping_cost,omitempty"     db:"shipping_cost"`
+	Currency        string    `json:"currency"                    db:"currency"`
+	PriceUSD        *float64  `json:"price_usd,omitempty"         db:"price_usd"`
+	ShippingCostUSD *float64  `json:"shipping_cost_usd,omitempty" db:"shipping_cost_usd"`
+	RecordedAt      time.Time `json:"recorded_at"                 db:"recorded_at"`
+}
+
+// Total returns the price including shipping, in the point's currency.
+func (p *PricePoint) Total() float64 {
+	if p.ShippingCost != nil {
+		return p.Price + *p.ShippingCost
+	}
+	return p.Price
+}
+
+// Seller is an eBay seller as seen across its listings. Feedback is
+// the latest ingested value; the counts and rates are computed on read.
+type Seller struct {
+	Name            string                `json:"name"             db:"name"`
+	FeedbackScore   int                   `json:"feedback_score"   db:"feedback_score"`
+	FeedbackPct     float64               `json:"feedback_pct"     db:"feedback_pct"`
+	TopRated        bool                  `json:"top_rated"        db:"top_rated"`
+	FirstSeenAt     time.Time             `json:"first_seen_at"    db:"first_seen_at"`
+	LastSeenAt      time.Time             `json:"last_seen_at"     db:"last_seen_at"`
+	ListingsTotal   int                   `json:"listings_total"`
+	ListingsActive  int                   `json:"listings_active"`
+	AlertsTotal     int                   `json:"alerts_total"`
+	AlertsDismissed int                   `json:"alerts_dismissed"`
+	AlertRate       float64               `json:"alert_rate"`     // alerts per listing
+	DismissalRate   float64               `json:"dismissal_rate"` // dismissed per alert
+	FeedbackHistory []SellerFeedbackPoint `json:"feedback_history"`
+}
+
+// SellerFeedbackPoint is one entry of a seller's feedback history,
+// recorded when the seller is first seen and whenever ingestion finds
+// a different feedback score or percentage.
+type SellerFeedbackPoint struct {
+	FeedbackScore int       `json:"feedback_score" db:"feedback_score"`
+	FeedbackPct   float64   `json:"feedback_pct"   db:"feedback_pct"`
+	RecordedAt    time.Time `json:"recorded_at"    db:"recorded_at"`
+}
+
 // Watch represents a saved search with alert configuration.
 type Watch struct {
-	ID             string        `json:"id"                       db:"id"`
-	Name           string        `json:"name"                     db:"name"`
-	SearchQuery    string        `json:"search_query"             db:"search_query"`
-	CategoryID     string        `json:"category_id,omitempty"    db:"category_id"`
-	ComponentType  ComponentType `json:"component_type"           db:"component_type"`
-	Filters        WatchFilters  `json:"filters"                  db:"filters"`
-	ScoreThreshold int           `json:"score_threshold"          db:"score_threshold"`
-	Enabled        bool          `json:"enabled"                  db:"enabled"`
-	LastPolledAt   *time.Time    `json:"last_polled_at,omitempty" db:"last_polled_at"`
-	CreatedAt      time.Time     `json:"created_at"               db:"created_at"`
-	UpdatedAt      time.Time     `json:"updated_at"               db:"updated_at"`
+	ID             string        `json:"id"                         db:"id"`
+	Name           string        `json:"name"                       db:"name"`
+	SearchQuery    string        `json:"search_query"               db:"search_query"`
+	CategoryID     string        `json:"category_id,omitempty"      db:"category_id"`
+	ComponentType  ComponentType `json:"component_type"             db:"component_type"`
+	Filters        WatchFilters  `json:"filters"                    db:"filters"`
+	ScoreThreshold int           `json:"score_threshold"            db:"score_threshold"`
+	Enabled        bool          `json:"enabled"                    db:"enabled"`
+	PollInterval   Duration      `json:"poll_interval,omitempty"    db:"poll_interval_seconds"` // 0 = schedule.ingestion_interval
+	Priority       int           `json:"priority,omitempty"         db:"priority"`              // higher polls first
+	Marketplace    string        `json:"marketplace,omitempty"      db:"marketplace"`           // "" = ebay.marketplace
+	RealertDropPct float64       `json:"realert_drop_pct,omitempty" db:"realert_drop_pct"`      // 0 = cooldown always applies
+	LastPolledAt   *time.Time    `json:"last_polled_at,omitempty"   db:"last_polled_at"`
+	CreatedAt      time.Time     `json:"created_at"                 db:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"                 db:"updated_at"`
+}
+
+// EffectivePollInterval returns the watch's poll interval, or def when
+// the watch doesn't set one.
+func (w *Watch) EffectivePollInterval(def time.Duration) time.Duration {
+	if w.PollInterval > 0 {
+		return time.Duration(w.PollInterval)
+	}
+	return def
+}
+
+// Duration is a time.Duration that reads and writes as a string ("15m",
+// "1h30m", "2d") in JSON and YAML rather than as nanoseconds.
+type Duration time.Duration
+
+// ParseDuration parses a Go duration ("90m", "1h30m") or a whole number
+// of days ("2d").
+func ParseDuration(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// String formats d without trailing zero units: "15m", "1h30m", "36h".
+func (d Duration) String() string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; empty means zero.
+func (d *Duration) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*d = 0
+		return nil
+	}
+	v, err := ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// DefaultWatchScoreThreshold mirrors the watches.score_threshold column
+// default; manifest specs that omit a threshold get this value.
+const DefaultWatchScoreThreshold = 75
+
+// WatchSpec is the declarative form of a watch in a WatchManifest. The
+// watch name is the manifest key, so it is not repeated here; server-owned
+// state (ID, poll and audit timestamps) is omitted.
+type WatchSpec struct {
+	SearchQuery    string        `json:"search_query"`
+	CategoryID     string        `json:"category_id,omitempty"`
+	ComponentType  ComponentType `json:"component_type"`
+	Filters        WatchFilters  `json:"filters,omitzero"`
+	ScoreThreshold int           `json:"score_threshold,omitempty"` // 0 = DefaultWatchScoreThreshold
+	Enabled        *bool         `json:"enabled,omitempty"`         // nil = enabled
+	PollInterval   Duration      `json:"poll_interval,omitempty"`   // 0 = schedule.ingestion_interval
+	Priority       int           `json:"priority,omitempty"`
+	Marketplace    string        `json:"marketplace,omitempty"`      // "" = ebay.marketplace
+	RealertDropPct float64       `json:"realert_drop_pct,omitempty"` // 0 = cooldown always applies
+}
+
+// Watch returns the watch this spec describes, with defaults applied.
+func (s *WatchSpec) Watch(name string) Watch {
+	w := Watch{
+		Name:           name,
+		SearchQuery:    s.SearchQuery,
+		CategoryID:     s.CategoryID,
+		ComponentType:  s.ComponentType,
+		Filters:        s.Filters,
+		ScoreThreshold: s.ScoreThreshold,
+		Enabled:        s.Enabled == nil || *s.Enabled,
+		PollInterval:   s.PollInterval,
+		Priority:       s.Priority,
+		Marketplace:    s.Marketplace,
+		RealertDropPct: s.RealertDropPct,
+	}
+	if w.ScoreThreshold == 0 {
+		w.ScoreThreshold = DefaultWatchScoreThreshold
+	}
+	return w
+}
+
+// WatchSpecFromWatch is the inverse of WatchSpec.Watch, used by export.
+// Enabled and ScoreThreshold are always written so exported manifests
+// don't depend on defaults.
+func WatchSpecFromWatch(w *Watch) WatchSpec {
+	enabled := w.Enabled
+	return WatchSpec{
+		SearchQuery:    w.SearchQuery,
+		CategoryID:     w.CategoryID,
+		ComponentType:  w.ComponentType,
+		Filters:        w.Filters,
+		ScoreThreshold: w.ScoreThreshold,
+		Enabled:        &enabled,
+		PollInterval:   w.PollInterval,
+		Priority:       w.Priority,
+		Marketplace:    w.Marketplace,
+		RealertDropPct: w.RealertDropPct,
+	}
+}
+
+// WatchManifest is a declarative watch set keyed by watch name — the
+// `spt watches apply` / `export` file format.
+type WatchManifest struct {
+	Watches map[string]WatchSpec `json:"watches"`
+}
+
+// WatchChangeAction is what a declarative apply does to one watch.
+type WatchChangeAction string
+
+// Watch change actions.
+const (
+	WatchActionCreate    WatchChangeAction = "create"
+	WatchActionUpdate    WatchChangeAction = "update"
+	WatchActionDelete    WatchChangeAction = "delete"
+	WatchActionUnchanged WatchChangeAction = "unchanged"
+)
+
+// WatchFieldDiff is one changed field in a watch update.
+type WatchFieldDiff struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// WatchChange is one entry of a declarative apply plan.
+type WatchChange struct {
+	Name   string            `json:"name"`
+	ID     string            `json:"id,omitempty"`
+	Action WatchChangeAction `json:"action"`
+	Diff   []WatchFieldDiff  `json:"diff,omitempty"`
+}
+
+// WatchApplyResult is the plan of a declarative apply, sorted by name.
+// When DryRun is true nothing was written.
+type WatchApplyResult struct {
+	DryRun  bool          `json:"dry_run"`
+	Changes []WatchChange `json:"changes"`
+}
+
+// WatchPreview is the dry-run evaluation of a watch against stored
+// active listings. Nothing is written; cooldowns are not consulted.
+type WatchPreview struct {
+	Scanned    int                `json:"scanned"`
+	Truncated  bool               `json:"truncated"`   // scan cap hit before the last listing
+	Matches    int                `json:"matches"`     // pass every filter and seller list, any score
+	AlertCount int                `json:"alert_count"` // pass filters, seller lists and score_threshold
+	Alerts     []WatchPreviewItem `json:"alerts"`
+	NearMisses []WatchPreviewItem `json:"near_misses"`
+	// Rejections counts listings failing each check, keyed by filter
+	// name (see WatchFilters.Failures) plus "alerts.sellers.blocklist",
+	// "alerts.sellers.allowlist", "score_threshold" and "unscored".
+	Rejections map[string]int `json:"rejections"`
+}
+
+// WatchPreviewItem is one listing in a WatchPreview. RejectedBy names
+// the single check a near miss failed.
+type WatchPreviewItem struct {
+	ListingID  string  `json:"listing_id"`
+	Title      string  `json:"title"`
+	ItemURL    string  `json:"item_url"`
+	UnitPrice  float64 `json:"unit_price"`
+	Score      *int    `json:"score,omitempty"`
+	RejectedBy string  `json:"rejected_by,omitempty"`
+}
+
+// WatchPoll records one ingestion poll of a watch. StoppedAt mirrors the
+// paginator's stop reason; ErrorText is set when the poll failed.
+type WatchPoll struct {
+	ID          string    `json:"id"                   db:"id"`
+	WatchID     string    `json:"watch_id"             db:"watch_id"`
+	PolledAt    time.Time `json:"polled_at"            db:"polled_at"`
+	PagesUsed   int       `json:"pages_used"           db:"pages_used"`
+	TotalSeen   int       `json:"total_seen"           db:"total_seen"`
+	NewListings int       `json:"new_listings"         db:"new_listings"`
+	StoppedAt   string    `json:"stopped_at,omitempty" db:"stopped_at"`
+	ErrorText   *string   `json:"error_text,omitempty" db:"error_text"`
+}
+
+// WatchYield sums a watch's successful polls since some time. The
+// ingestion budget planner weights watches by it.
+type WatchYield struct {
+	WatchID     string `json:"watch_id"`
+	Polls       int    `json:"polls"`
+	PagesUsed   int    `json:"pages_used"`
+	NewListings int    `json:"new_listings"`
+	// MaxPagesStops counts polls cut off by the page cap rather than
+	// reaching a known listing: the watch wanted more pages.
+	MaxPagesStops int `json:"max_pages_stops"`
+}
+
+// BudgetPlan is how one ingestion cycle split the eBay quota: the
+// cycle's share of the remaining daily quota, less the getItem calls
+// (ItemCalls) refresh jobs made since the previous cycle, and per watch
+// the page cap it was given against the pages it actually used. Pages
+// the watches left unused go to price checks of alerted listings
+// (PriceChecks, included in CycleUsed).
+type BudgetPlan struct {
+	PlannedAt time.Time `json:"planned_at"`
+	// Adaptive is false when no rate limiter or poll tick is configured;
+	// the cycle budget is then just max_calls_per_cycle.
+	Adaptive       bool      `json:"adaptive"`
+	QuotaRemaining int64     `json:"quota_remaining"`
+	ResetAt        time.Time `json:"reset_at,omitzero"`
+	CyclesLeft     int       `json:"cycles_left"`
+	ItemCalls      int       `json:"item_calls"`
+	CycleBudget    int       `json:"cycle_budget"`
+	CycleUsed      int       `json:"cycle_used"`
+	PriceChecks    int       `json:"price_checks"`
+	Deferred       int       `json:"deferred"`
+
+	Watches []BudgetAllocation `json:"watches"`
+}
+
+// BudgetAllocation is one watch's line in a BudgetPlan. PagesPlanned is
+// zero for watches deferred to a later cycle.
+type BudgetAllocation struct {
+	WatchID      string  `json:"watch_id"`
+	Name         string  `json:"name"`
+	Weight       float64 `json:"weight"`
+	PagesPlanned int     `json:"pages_planned"`
+	PagesUsed    int     `json:"pages_used"`
+	Deferred     bool    `json:"deferred,omitempty"`
+}
+
+// WatchStats summarises what a watch produced over a window: eBay
+// pages spent, listings found, alerts raised and how operators and the
+// judge received them.
+type WatchStats struct {
+	WatchID string    `json:"watch_id"`
+	Since   time.Time `json:"since"`
+
+	Polls             int     `json:"polls"`
+	PollErrors        int     `json:"poll_errors"`
+	PagesUsed         int     `json:"pages_used"`
+	PagesPerPoll      float64 `json:"pages_per_poll"`
+	ListingsSeen      int     `json:"listings_seen"`
+	ListingsIngested  int     `json:"listings_ingested"` // new listings upserted
+	NewListingsPerDay float64 `json:"new_listings_per_day"`
+
+	AlertsCreated   int `json:"alerts_created"`
+	AlertsNotified  int `json:"alerts_notified"`
+	AlertsDismissed int `json:"alerts_dismissed"`
+	// DismissalRate is dismissed / created; 0 when no alerts fired.
+	DismissalRate float64 `json:"dismissal_rate"`
+
+	AlertsJudged     int      `json:"alerts_judged"`
+	MedianJudgeScore *float64 `json:"median_judge_score,omitempty"`
+
+	BestDeal *WatchStatsDeal `json:"best_deal,omitempty"`
+}
+
+// WatchStatsDeal is the highest-scoring alert a watch raised in the
+// stats window.
+type WatchStatsDeal struct {
+	AlertID   string    `json:"alert_id"`
+	ListingID string    `json:"listing_id"`
+	Title     string    `json:"title"`
+	ItemURL   string    `json:"item_url"`
+	UnitPrice float64   `json:"unit_price"`
+	Score     int       `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // JobRun records a single execution of a scheduled job.
@@ -176,14 +594,45 @@ type WatchFilters struct {
 	SellerMinFeedback    *int     `json:"seller_min_feedback,omitempty"`
 	SellerMinFeedbackPct *float64 `json:"seller_min_feedback_pct,omitempty"`
 	SellerTopRatedOnly   bool     `json:"seller_top_rated_only,omitempty"`
+	// SellerBlocklist never matches these sellers. SellerAllowlist, when
+	// set, matches only these sellers. Names compare case-insensitively.
+	SellerBlocklist []string `json:"seller_blocklist,omitempty"`
+	SellerAllowlist []string `json:"seller_allowlist,omitempty"`
 
 	// Condition
 	Conditions []Condition `json:"conditions,omitempty"`
 
+	// Listing format: auction, buy_it_now, best_offer.
+	BuyingOptions []ListingType `json:"buying_options,omitempty"`
+
+	// ItemLocationCountry is an ISO 3166 alpha-2 code ("US"). It is
+	// applied by eBay at search time only; Match ignores it, since
+	// listings stored before item_country was recorded have none.
+	ItemLocationCountry string `json:"item_location_country,omitempty"`
+
+	// MaxRisk rejects listings whose risk score is above it. Listings
+	// not yet risk-scored pass.
+	MaxRisk *int `json:"max_risk,omitempty"`
+
+	// MinPartOutRatio requires the listing's parts to be worth at least
+	// this multiple of its price. Listings without a part-out estimate
+	// fail.
+	MinPartOutRatio *float64 `json:"min_partout_ratio,omitempty"`
+
+	// MaxPricePerUnit rejects listings whose price per value unit
+	// (see Listing.PricePerUnit) is above it. Listings without a value
+	// metric fail.
+	MaxPricePerUnit *float64 `json:"max_price_per_unit,omitempty"`
+
 	// Component-specific attribute filters (flexible)
 	// These match against the extracted attributes JSON.
 	// Supports exact match, min/max ranges.
 	AttributeFilters map[string]AttributeFilter `json:"attribute_filters,omitempty"`
+
+	// Expr is an optional boolean expression ANDed with everything
+	// above, for title include/exclude terms, OR groups and nesting
+	// the structured fields can't express. See filterexpr.go.
+	Expr string `json:"expr,omitempty"`
 }
 
 // AttributeFilter supports exact match or range filtering on extracted attributes.
@@ -196,40 +645,133 @@ type AttributeFilter struct {
 
 // Match checks if a listing's attributes satisfy this filter.
 func (f *WatchFilters) Match(l *Listing) bool {
-	if !f.matchPrice(l) {
-		return false
-	}
-	if !f.matchSeller(l) {
-		return false
+	for _, c := range watchFilterChecks {
+		if !c.match(f, l) {
+			return false
+		}
 	}
-	if !f.matchCondition(l) {
-		return false
+	for key := range f.AttributeFilters {
+		if !f.matchAttributeFilter(l, key) {
+			return false
+		}
 	}
-	return f.matchAttributes(l)
+	return f.matchExpr(l)
 }
 
-func (f *WatchFilters) matchPrice(l *Listing) bool {
-	unitPrice := l.UnitPrice()
-	if f.PriceMax != nil && unitPrice > *f.PriceMax {
-		return false
+// Failures lists every filter the listing fails, by JSON field name
+// (attribute filters as attribute_filters.<key>, in key order). Unlike
+// Match it doesn't short-circuit, so a watch preview can tell a near
+// miss (one failure) from a listing that was never close.
+func (f *WatchFilters) Failures(l *Listing) []string {
+	var out []string
+	for _, c := range watchFilterChecks {
+		if !c.match(f, l) {
+			out = append(out, c.name)
+		}
 	}
-	if f.PriceMin != nil && unitPrice < *f.PriceMin {
-		return false
+	for _, key := range slices.Sorted(maps.Keys(f.AttributeFilters)) {
+		if !f.matchAttributeFilter(l, key) {
+			out = append(out, "attribute_filters."+key)
+		}
 	}
-	return true
+	if !f.matchExpr(l) {
+		out = append(out, "expr")
+	}
+	return out
 }
 
-func (f *WatchFilters) matchSeller(l *Listing) bool {
-	if f.SellerMinFeedback != nil && l.SellerFeedback < *f.SellerMinFeedback {
-		return false
+// watchFilterChecks are the single-field filters, by JSON field name,
+// in the order Match applies them. Attribute filters and Expr follow.
+var watchFilterChecks = []struct {
+	name  string
+	match func(*WatchFilters, *Listing) bool
+}{
+	{"price_max", (*WatchFilters).matchPriceMax},
+	{"price_min", (*WatchFilters).matchPriceMin},
+	{"seller_min_feedback", (*WatchFilters).matchSellerMinFeedback},
+	{"seller_min_feedback_pct", (*WatchFilters).matchSellerMinFeedbackPct},
+	{"seller_top_rated_only", (*WatchFilters).matchSellerTopRated},
+	{"seller_blocklist", (*WatchFilters).matchSellerBlocklist},
+	{"seller_allowlist", (*WatchFilters).matchSellerAllowlist},
+	{"conditions", (*WatchFilters).matchCondition},
+	{"buying_options", (*WatchFilters).matchBuyingOption},
+	{"max_risk", (*WatchFilters).matchRisk},
+	{"min_partout_ratio", (*WatchFilters).matchPartOut},
+	{"max_price_per_unit", (*WatchFilters).matchPricePerUnit},
+}
+
+func (f *WatchFilters) matchRisk(l *Listing) bool {
+	return f.MaxRisk == nil || l.RiskScore == nil || *l.RiskScore <= *f.MaxRisk
+}
+
+func (f *WatchFilters) matchPricePerUnit(l *Listing) bool {
+	return f.MaxPricePerUnit == nil ||
+		(l.PricePerUnit != nil && *l.PricePerUnit <= *f.MaxPricePerUnit)
+}
+
+func (f *WatchFilters) matchPartOut(l *Listing) bool {
+	return f.MinPartOutRatio == nil ||
+		(l.PartOutRatio != nil && *l.PartOutRatio >= *f.MinPartOutRatio)
+}
+
+// Validate compiles Expr so callers can reject a bad expression before
+// it is persisted. Compile errors are *FilterExprError values.
+func (f *WatchFilters) Validate() error {
+	if strings.TrimSpace(f.Expr) == "" {
+		return nil
 	}
-	if f.SellerMinFeedbackPct != nil && l.SellerFeedbackPct < *f.SellerMinFeedbackPct {
-		return false
+	_, err := CompileFilterExpr(f.Expr)
+	return err
+}
+
+// matchExpr evaluates Expr. An expression that fails to compile matches
+// nothing — the API validates on write, so this only guards rows written
+// before validation existed.
+func (f *WatchFilters) matchExpr(l *Listing) bool {
+	if strings.TrimSpace(f.Expr) == "" {
+		return true
 	}
-	if f.SellerTopRatedOnly && !l.SellerTopRated {
+	x, err := compileFilterExprCached(f.Expr)
+	if err != nil {
 		return false
 	}
-	return true
+	return x.Match(l)
+}
+
+func (f *WatchFilters) matchPriceMax(l *Listing) bool {
+	return f.PriceMax == nil || l.UnitPrice() <= *f.PriceMax
+}
+
+func (f *WatchFilters) matchPriceMin(l *Listing) bool {
+	return f.PriceMin == nil || l.UnitPrice() >= *f.PriceMin
+}
+
+func (f *WatchFilters) matchSellerMinFeedback(l *Listing) bool {
+	return f.SellerMinFeedback == nil || l.SellerFeedback >= *f.SellerMinFeedback
+}
+
+func (f *WatchFilters) matchSellerMinFeedbackPct(l *Listing) bool {
+	return f.SellerMinFeedbackPct == nil || l.SellerFeedbackPct >= *f.SellerMinFeedbackPct
+}
+
+func (f *WatchFilters) matchSellerTopRated(l *Listing) bool {
+	return !f.SellerTopRatedOnly || l.SellerTopRated
+}
+
+func (f *WatchFilters) matchSellerBlocklist(l *Listing) bool {
+	return !SellerListed(f.SellerBlocklist, l.SellerName)
+}
+
+func (f *WatchFilters) matchSellerAllowlist(l *Listing) bool {
+	return len(f.SellerAllowlist) == 0 || SellerListed(f.SellerAllowlist, l.SellerName)
+}
+
+// SellerListed reports whether name is in list, ignoring case as eBay
+// does for usernames.
+func SellerListed(list []string, name string) bool {
+	return slices.ContainsFunc(list, func(s string) bool {
+		return strings.EqualFold(s, name)
+	})
 }
 
 func (f *WatchFilters) matchCondition(l *Listing) bool {
@@ -239,17 +781,18 @@ func (f *WatchFilters) matchCondition(l *Listing) bool {
 	return slices.Contains(f.Conditions, l.ConditionNorm)
 }
 
-func (f *WatchFilters) matchAttributes(l *Listing) bool {
-	for key, filter := range f.AttributeFilters {
-		val, ok := l.Attributes[key]
-		if !ok {
-			return false
-		}
-		if !matchAttribute(val, filter) {
-			return false
-		}
+func (f *WatchFilters) matchBuyingOption(l *Listing) bool {
+	if len(f.BuyingOptions) == 0 {
+		return true
 	}
-	return true
+	return slices.Contains(f.BuyingOptions, l.ListingType)
+}
+
+// matchAttributeFilter checks the attribute filter on key; a listing
+// without the attribute fails it.
+func (f *WatchFilters) matchAttributeFilter(l *Listing, key string) bool {
+	val, ok := l.Attributes[key]
+	return ok && matchAttribute(val, f.AttributeFilters[key])
 }
 
 func matchAttribute(val any, f AttributeFilter) bool {
@@ -303,6 +846,165 @@ type PriceBaseline struct {
 	UpdatedAt   time.Time `json:"updated_at"   db:"updated_at"`
 }
 
+// ValueBaseline holds percentile statistics of the price per unit
+// across a value class.
+type ValueBaseline struct {
+	ValueClass  string    `json:"value_class"  db:"value_class"`
+	ValueUnit   string    `json:"value_unit"   db:"value_unit"`
+	SampleCount int       `json:"sample_count" db:"sample_count"`
+	P10         float64   `json:"p10"          db:"p10"`
+	P25         float64   `json:"p25"          db:"p25"`
+	P50         float64   `json:"p50"          db:"p50"`
+	P75         float64   `json:"p75"          db:"p75"`
+	P90         float64   `json:"p90"          db:"p90"`
+	Mean        float64   `json:"mean"         db:"mean"`
+	UpdatedAt   time.Time `json:"updated_at"   db:"updated_at"`
+}
+
+// ProductKeyAlias maps a product key onto the canonical key its
+// listings and baseline are merged into.
+type ProductKeyAlias struct {
+	Alias     string    `json:"alias"      db:"alias"`
+	Canonical string    `json:"canonical"  db:"canonical"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ProductKeyCount is a product key and how many listings carry it.
+type ProductKeyCount struct {
+	ProductKey string `json:"product_key"`
+	Listings   int    `json:"listings"`
+}
+
+// ProductKeySuggestion proposes merging From into To, the key with
+// more listings. PunctuationOnly is set when the keys differ only in
+// punctuation, the likeliest sign of a duplicate.
+type ProductKeySuggestion struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	FromListings    int    `json:"from_listings"`
+	ToListings      int    `json:"to_listings"`
+	Distance        int    `json:"distance"`
+	PunctuationOnly bool   `json:"punctuation_only"`
+}
+
+// ExtractionCorrection is an operator's fix to a listing's extraction,
+// kept with the values it replaced as a labelled example for the golden
+// dataset.
+type ExtractionCorrection struct {
+	ID                    string         `json:"id"                      db:"id"`
+	ListingID             string         `json:"listing_id"              db:"listing_id"`
+	Title                 string         `json:"title"                   db:"title"`
+	PreviousComponentType ComponentType  `json:"previous_component_type" db:"previous_component_type"`
+	PreviousAttributes    map[string]any `json:"previous_attributes"     db:"previous_attributes"`
+	PreviousProductKey    string         `json:"previous_product_key"    db:"previous_product_key"`
+	ComponentType         ComponentType  `json:"component_type"          db:"component_type"`
+	Attributes            map[string]any `json:"attributes"              db:"attributes"`
+	ProductKey            string         `json:"product_key"             db:"product_key"`
+	Note                  string         `json:"note,omitempty"          db:"note"`
+	CreatedAt             time.Time      `json:"created_at"              db:"created_at"`
+}
+
+// ShadowExtraction is one live extraction repeated through the shadow
+// extractor: what each side produced, the attributes they disagreed on,
+// and what each cost. Costs are nil when the model has no rate.
+type ShadowExtraction struct {
+	ID                   string              `json:"id"                       db:"id"`
+	Title                string              `json:"title"                    db:"title"`
+	PrimaryBackend       string              `json:"primary_backend"          db:"primary_backend"`
+	PrimaryModel         string              `json:"primary_model"            db:"primary_model"`
+	ShadowBackend        string              `json:"shadow_backend"           db:"shadow_backend"`
+	ShadowModel          string              `json:"shadow_model"             db:"shadow_model"`
+	PromptVersion        string              `json:"prompt_version,omitempty" db:"prompt_version"`
+	PrimaryComponentType ComponentType       `json:"primary_component_type"   db:"primary_component_type"`
+	ShadowComponentType  ComponentType       `json:"shadow_component_type"    db:"shadow_component_type"`
+	PrimaryProductKey    string              `json:"primary_product_key"      db:"primary_product_key"`
+	ShadowProductKey     string              `json:"shadow_product_key"       db:"shadow_product_key"`
+	PrimaryAttributes    map[string]any      `json:"primary_attributes"       db:"primary_attributes"`
+	ShadowAttributes     map[string]any      `json:"shadow_attributes"        db:"shadow_attributes"`
+	Disagreements        []FieldDisagreement `json:"disagreements"            db:"disagreements"`
+	ShadowError          string              `json:"shadow_error,omitempty"   db:"shadow_error"`
+	PrimaryTokens        int                 `json:"primary_tokens"           db:"primary_tokens"`
+	ShadowTokens         int                 `json:"shadow_tokens"            db:"shadow_tokens"`
+	PrimaryCostUSD       *float64            `json:"primary_cost_usd"         db:"primary_cost_usd"`
+	ShadowCostUSD        *float64            `json:"shadow_cost_usd"          db:"shadow_cost_usd"`
+	PrimaryLatencyMs     int                 `json:"primary_latency_ms"       db:"primary_latency_ms"`
+	ShadowLatencyMs      int                 `json:"shadow_latency_ms"        db:"shadow_latency_ms"`
+	CreatedAt            time.Time           `json:"created_at"               db:"created_at"`
+}
+
+// Agrees reports whether the shadow extraction matched the primary on
+// component type, product key and every attribute.
+func (s *ShadowExtraction) Agrees() bool {
+	return s.ShadowError == "" &&
+		s.ShadowComponentType == s.PrimaryComponentType &&
+		s.ShadowProductKey == s.PrimaryProductKey &&
+		len(s.Disagreements) == 0
+}
+
+// FieldDisagreement is an attribute two extractions of the same title
+// set differently. A side that left the attribute out has a nil value.
+type FieldDisagreement struct {
+	Field   string `json:"field"`
+	Primary any    `json:"primary"`
+	Shadow  any    `json:"shadow"`
+}
+
+// ShadowReport summarises shadow extraction comparisons since a time.
+// Compared excludes samples where the shadow extraction failed; the
+// agreement rates are over Compared, and field disagreement rates are
+// over samples where both sides chose the same component type. Costs
+// only sum samples with a rate for both models.
+type ShadowReport struct {
+	Since         time.Time `json:"since"`
+	ShadowModel   string    `json:"shadow_model,omitempty"`
+	PromptVersion string    `json:"prompt_version,omitempty"`
+
+	Samples              int `json:"samples"`
+	ShadowErrors         int `json:"shadow_errors"`
+	Compared             int `json:"compared"`
+	ComponentTypeMatches int `json:"component_type_matches"`
+	ProductKeyMatches    int `json:"product_key_matches"`
+	FullMatches          int `json:"full_matches"`
+
+	ComponentTypeAgreement float64 `json:"component_type_agreement"`
+	ProductKeyAgreement    float64 `json:"product_key_agreement"`
+	FullAgreement          float64 `json:"full_agreement"`
+
+	Fields     []ShadowFieldStat     `json:"fields"`
+	Components []ShadowComponentStat `json:"components"`
+
+	CostedSamples  int      `json:"costed_samples"`
+	PrimaryCostUSD float64  `json:"primary_cost_usd"`
+	ShadowCostUSD  float64  `json:"shadow_cost_usd"`
+	CostDeltaUSD   float64  `json:"cost_delta_usd"`           // shadow - primary
+	CostDeltaPct   *float64 `json:"cost_delta_pct,omitempty"` // nil when primary cost is 0
+	PrimaryTokens  int      `json:"primary_tokens"`
+	ShadowTokens   int      `json:"shadow_tokens"`
+
+	AvgPrimaryLatencyMs float64 `json:"avg_primary_latency_ms"`
+	AvgShadowLatencyMs  float64 `json:"avg_shadow_latency_ms"`
+
+	// Examples are the most recent comparisons that disagreed.
+	Examples []ShadowExtraction `json:"examples"`
+}
+
+// ShadowFieldStat counts disagreements on one attribute.
+type ShadowFieldStat struct {
+	Field         string  `json:"field"`
+	Disagreements int     `json:"disagreements"`
+	Rate          float64 `json:"rate"`
+}
+
+// ShadowComponentStat breaks agreement down by the primary extraction's
+// component type.
+type ShadowComponentStat struct {
+	ComponentType        ComponentType `json:"component_type"`
+	Compared             int           `json:"compared"`
+	ComponentTypeMatches int           `json:"component_type_matches"`
+	ProductKeyMatches    int           `json:"product_key_matches"`
+	ProductKeyAgreement  float64       `json:"product_key_agreement"`
+}
+
 // Alert represents a triggered notification.
 type Alert struct {
 	ID          string     `json:"id"                     db:"id"`
@@ -329,9 +1031,17 @@ type ScoreBreakdown struct {
 	Quantity  float64 `json:"quantity"`
 	Quality   float64 `json:"quality"`
 	Time      float64 `json:"time"`
+	Value     float64 `json:"value"`
 	Total     int     `json:"total"`
 }
 
+// RiskSignal is one warning sign behind a listing's risk score and the
+// points it added.
+type RiskSignal struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
 // AlertWithListing is one row of the alert review list, joining alert,
 // listing, and watch fields needed to render a single table row without
 // a follow-up query per row.
@@ -349,6 +1059,9 @@ type NotificationAttempt struct {
 	Succeeded   bool      `json:"succeeded"             db:"succeeded"`
 	HTTPStatus  *int      `json:"http_status,omitempty" db:"http_status"`
 	ErrorText   *string   `json:"error_text,omitempty"  db:"error_text"`
+	// SuppressionReason is set when the judge gate held the alert back
+	// from its own notification (suppressed or folded into a summary).
+	SuppressionReason *string `json:"suppression_reason,omitempty" db:"suppression_reason"`
 }
 
 // AlertDetail is the data backing the per-alert detail page. Carries the
@@ -359,6 +1072,11 @@ type AlertDetail struct {
 	Listing             Listing               `json:"listing"`
 	Watch               Watch                 `json:"watch"`
 	NotificationHistory []NotificationAttempt `json:"notification_history"`
+	PriceHistory        []PricePoint          `json:"price_history"`
+	// Seller is nil when the listing has no seller record. SellerListings
+	// holds the seller's other active listings, highest score first.
+	Seller         *Seller   `json:"seller,omitempty"`
+	SellerListings []Listing `json:"seller_listings"`
 }
 
 // JudgeCandidate is the fully-joined row the LLM-as-judge worker pulls
@@ -388,6 +1106,29 @@ type JudgeCandidate struct {
 	CreatedAt     time.Time     `json:"created_at"`
 }
 
+// JudgeBacklog summarises the alerts still waiting for a judge verdict.
+// Pending and OldestAt cover the worker's lookback window; AgedOut
+// counts alerts that left the window unjudged during the day before
+// it — the ones the worker will now never grade.
+type JudgeBacklog struct {
+	Pending  int        `json:"pending"`
+	OldestAt *time.Time `json:"oldest_at,omitempty"`
+	AgedOut  int        `json:"aged_out_last_24h"`
+}
+
+// JudgeTickStats describes one judge worker tick: how far it got
+// through its batch and the backlog it started against. Backlog is nil
+// when the backlog lookup failed.
+type JudgeTickStats struct {
+	StartedAt       time.Time     `json:"started_at"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Candidates      int           `json:"candidates"`
+	Judged          int           `json:"judged"`
+	Failed          int           `json:"failed"`
+	BudgetExhausted bool          `json:"budget_exhausted"`
+	Backlog         *JudgeBacklog `json:"backlog,omitempty"`
+}
+
 // JudgeScore is one row from the judge_scores table. Mirrors the
 // migration shape exactly so SELECT ... matches scan order.
 type JudgeScore struct {
@@ -400,3 +1141,17 @@ type JudgeScore struct {
 	CostUSD      float64   `json:"cost_usd"      db:"cost_usd"`
 	JudgedAt     time.Time `json:"judged_at"     db:"judged_at"`
 }
+
+// ScoringLabel is one labelled alert used to calibrate scoring weights
+// offline. Breakdown is the listing's persisted per-factor score; Good
+// is the verdict and Source records which signal produced it
+// ("dismissed", "restored" or "judge").
+type ScoringLabel struct {
+	AlertID       string         `json:"alert_id"`
+	ComponentType ComponentType  `json:"component_type"`
+	Breakdown     ScoreBreakdown `json:"breakdown"`
+	Score         int            `json:"score"`
+	Threshold     int            `json:"threshold"`
+	Good          bool           `json:"good"`
+	Source        string         `json:"source"`
+}
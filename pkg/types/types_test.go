@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFilters_Failures(t *testing.T) {
+	t.Parallel()
+
+	priceMax, minSpeed, looseMax := 30.0, 2933.0, 50.0
+	minFB := 500
+	f := WatchFilters{
+		PriceMax:          &priceMax,
+		SellerMinFeedback: &minFB,
+		Conditions:        []Condition{ConditionNew},
+		AttributeFilters: map[string]AttributeFilter{
+			"speed_mhz":   {Min: &minSpeed},
+			"capacity_gb": {Equals: float64(32)},
+			"rank":        {Equals: float64(2)},
+		},
+		Expr: `not title contains "lot"`,
+	}
+
+	l := exprListing() // unit price 40, 1200 feedback, used_working, 32GB @ 2933
+	l.Title = "lot of " + l.Title
+
+	assert.Equal(t, []string{
+		"price_max",
+		"conditions",
+		"attribute_filters.rank",
+		"expr",
+	}, f.Failures(l), "every failing check, attribute keys in sorted order")
+	assert.False(t, f.Match(l))
+
+	pass := WatchFilters{PriceMax: &looseMax}
+	assert.Empty(t, pass.Failures(exprListing()))
+	assert.True(t, pass.Match(exprListing()))
+
+	// Item location is applied by eBay only.
+	auctions := WatchFilters{BuyingOptions: []ListingType{ListingAuction}, ItemLocationCountry: "DE"}
+	bin := exprListing()
+	bin.ListingType = ListingBuyItNow
+	assert.Equal(t, []string{"buying_options"}, auctions.Failures(bin))
+	assert.False(t, auctions.Match(bin))
+	bin.ListingType = ListingAuction
+	assert.True(t, auctions.Match(bin))
+}
+
+func TestWatchFilters_SellerLists(t *testing.T) {
+	t.Parallel()
+
+	l := exprListing()
+	l.SellerName = "server_parts_inc"
+
+	blocked := WatchFilters{SellerBlocklist: []string{"Server_Parts_Inc"}}
+	assert.Equal(t, []string{"seller_blocklist"}, blocked.Failures(l))
+	assert.False(t, blocked.Match(l), "blocklist ignores case")
+
+	allowed := WatchFilters{SellerAllowlist: []string{"techrecycler", "server_parts_inc"}}
+	assert.Empty(t, allowed.Failures(l))
+	assert.True(t, allowed.Match(l))
+
+	l.SellerName = "someone_else"
+	assert.Equal(t, []string{"seller_allowlist"}, allowed.Failures(l))
+	assert.False(t, allowed.Match(l))
+}
+
+func TestWatchFilters_MaxRisk(t *testing.T) {
+	t.Parallel()
+
+	maxRisk := 40
+	f := WatchFilters{MaxRisk: &maxRisk}
+	l := exprListing()
+	assert.True(t, f.Match(l), "unscored listings pass")
+
+	risk := 40
+	l.RiskScore = &risk
+	assert.True(t, f.Match(l), "at the threshold passes")
+
+	risk = 55
+	assert.Equal(t, []string{"max_risk"}, f.Failures(l))
+	assert.False(t, f.Match(l))
+}
+
+func TestWatchFilters_MinPartOutRatio(t *testing.T) {
+	t.Parallel()
+
+	minRatio := 1.2
+	f := WatchFilters{MinPartOutRatio: &minRatio}
+	l := exprListing()
+	assert.Equal(t, []string{"min_partout_ratio"}, f.Failures(l), "no estimate fails")
+
+	ratio := 1.2
+	l.PartOutRatio = &ratio
+	assert.True(t, f.Match(l), "at the threshold passes")
+
+	ratio = 0.9
+	assert.False(t, f.Match(l))
+}
+
+func TestWatchFilters_MaxPricePerUnit(t *testing.T) {
+	t.Parallel()
+
+	maxPPU := 1.5
+	f := WatchFilters{MaxPricePerUnit: &maxPPU}
+	l := exprListing()
+	assert.Equal(t, []string{"max_price_per_unit"}, f.Failures(l), "no value metric fails")
+
+	ppu := 1.25
+	l.PricePerUnit = &ppu
+	assert.True(t, f.Match(l))
+
+	ppu = 1.75
+	assert.False(t, f.Match(l))
+}
+
+func TestDuration_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want time.Duration
+		out  string
+	}{
+		{in: `"15m"`, want: 15 * time.Minute, out: `"15m"`},
+		{in: `"1h30m"`, want: 90 * time.Minute, out: `"1h30m"`},
+		{in: `"2h"`, want: 2 * time.Hour, out: `"2h"`},
+		{in: `"2d"`, want: 48 * time.Hour, out: `"48h"`},
+		{in: `"90s"`, want: 90 * time.Second, out: `"1m30s"`},
+		{in: `""`, want: 0, out: `"0s"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			var d Duration
+			require.NoError(t, json.Unmarshal([]byte(tt.in), &d))
+			assert.Equal(t, tt.want, time.Duration(d))
+
+			b, err := json.Marshal(d)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.out, string(b))
+		})
+	}
+
+	var d Duration
+	assert.Error(t, json.Unmarshal([]byte(`"soon"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`"xd"`), &d))
+}
+
+func TestWatch_EffectivePollInterval(t *testing.T) {
+	t.Parallel()
+
+	w := Watch{}
+	assert.Equal(t, 15*time.Minute, w.EffectivePollInterval(15*time.Minute))
+	w.PollInterval = Duration(time.Hour)
+	assert.Equal(t, time.Hour, w.EffectivePollInterval(15*time.Minute))
+}
+
+func TestListing_UnitPriceUSD(t *testing.T) {
+	t.Parallel()
+
+	ship, priceUSD, shipUSD := 10.0, 120.0, 12.0
+	tests := []struct {
+		name    string
+		listing Listing
+		want    float64
+		wantOK  bool
+	}{
+		{
+			name:    "USD needs no conversion",
+			listing: Listing{Price: 100, Currency: "USD", ShippingCost: &ship, Quantity: 2},
+			want:    55,
+			wantOK:  true,
+		},
+		{
+			name: "normalized prices",
+			listing: Listing{
+				Price: 100, Currency: "GBP", ShippingCost: &ship, Quantity: 2,
+				PriceUSD: &priceUSD, ShippingCostUSD: &shipUSD,
+			},
+			want:   66,
+			wantOK: true,
+		},
+		{
+			name:    "no rate",
+			listing: Listing{Price: 100, Currency: "GBP", Quantity: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := tt.listing.UnitPriceUSD()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
@@ -19,10 +19,13 @@ var KnownMetrics = map[string]bool{
 	"spt_ingestion_duration_seconds": true,
 
 	// Extraction metrics.
-	"spt_extraction_duration_seconds":   true,
-	"spt_extraction_failures_total":     true,
-	"spt_extraction_tokens_total":       true,
-	"spt_extraction_tokens_per_request": true,
+	"spt_extraction_duration_seconds":    true,
+	"spt_extraction_failures_total":      true,
+	"spt_extraction_tokens_total":        true,
+	"spt_extraction_tokens_per_request":  true,
+	"spt_extractions_by_component_total": true,
+	"spt_extraction_corrections_total":   true,
+	"spt_shadow_extractions_total":       true,
 
 	// Scoring metrics.
 	"spt_scoring_distribution":        true,
@@ -51,10 +54,17 @@ var KnownMetrics = map[string]bool{
 	"spt_notification_attempts_inserted_total": true,
 
 	// Observability — judge metrics (IMPL-0019 Phase 5/7).
-	"spt_judge_evaluations_total":      true,
-	"spt_judge_score":                  true,
-	"spt_judge_cost_usd_total":         true,
-	"spt_judge_budget_exhausted_total": true,
+	"spt_judge_evaluations_total":          true,
+	"spt_judge_score":                      true,
+	"spt_judge_cost_usd_total":             true,
+	"spt_judge_budget_exhausted_total":     true,
+	"spt_judge_backlog_alerts":             true,
+	"spt_judge_backlog_oldest_age_seconds": true,
+	"spt_judge_aged_out_alerts":            true,
+	"spt_judge_tick_alerts":                true,
+	"spt_judge_tick_duration_seconds":      true,
+	"spt_alerts_judge_gate_total":          true,
+	"spt_alerts_judge_gate_held":           true,
 
 	// Notification metrics.
 	"spt_notification_duration_seconds":       true,
@@ -56,7 +56,11 @@ func BuildOverview() *dashboard.DashboardBuilder {
 		WithPanel(panels.ExtractionFailures()).
 		WithPanel(panels.ExtractionTokenRate()).
 		WithPanel(panels.ExtractionTokensPerRequest()).
-		WithPanel(panels.ExtractionTokensTotal()))
+		WithPanel(panels.ExtractionTokensTotal()).
+		WithPanel(panels.ExtractionsByComponent()).
+		WithPanel(panels.HBAExtractionShare()).
+		WithPanel(panels.ExtractionCorrections()).
+		WithPanel(panels.ShadowExtractions()))
 
 	// Row 6: Scoring.
 	b.WithRow(dashboard.NewRowBuilder("Scoring").
@@ -79,6 +83,9 @@ func BuildOverview() *dashboard.DashboardBuilder {
 		WithPanel(panels.JudgeScoreDistribution()).
 		WithPanel(panels.JudgeVsOperatorAgreement()).
 		WithPanel(panels.JudgeCostByModel()).
+		WithPanel(panels.JudgeBacklog()).
+		WithPanel(panels.JudgeBacklogAge()).
+		WithPanel(panels.JudgeGate()).
 		WithPanel(panels.PipelineStageVolume()))
 
 	return b
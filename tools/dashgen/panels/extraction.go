@@ -2,6 +2,7 @@ package panels
 
 import (
 	"github.com/grafana/grafana-foundation-sdk/go/common"
+	"github.com/grafana/grafana-foundation-sdk/go/stat"
 	"github.com/grafana/grafana-foundation-sdk/go/timeseries"
 )
 
@@ -132,3 +133,97 @@ func ExtractionTokensPerRequest() *timeseries.PanelBuilder {
 		ColorScheme(ColorSchemePaletteClassic()).
 		DrawStyle(common.GraphDrawStyleLine)
 }
+
+// ExtractionsByComponent returns a timeseries panel showing the rate of
+// successful extractions per classified component type.
+func ExtractionsByComponent() *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title("Extractions by Component").
+		Description("Successful extraction rate by classified component type").
+		Datasource(DSRef()).
+		Height(TSHeight).
+		Span(TSWidth).
+		WithTarget(PromQuery(
+			`sum by (component_type) (rate(spt_extractions_by_component_total{job="server-price-tracker"}[5m]))`,
+			"{{component_type}}",
+			"A",
+		)).
+		Unit("ops").
+		FillOpacity(10).
+		LineWidth(2).
+		Legend(TableLegend("mean", "lastNotNull")).
+		Tooltip(MultiTooltip()).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemePaletteClassic()).
+		DrawStyle(common.GraphDrawStyleLine)
+}
+
+// HBAExtractionShare returns a stat panel showing the share of the last
+// day's extractions classified as storage controllers (RAID controllers
+// and HBAs), which landed in "other" before they had their own type.
+func HBAExtractionShare() *stat.PanelBuilder {
+	return stat.NewPanelBuilder().
+		Title("HBA / RAID Share (24h)").
+		Description("Share of extractions in the last 24h classified as storage controllers").
+		Datasource(DSRef()).
+		Height(StatHeight).
+		Span(StatWidth).
+		WithTarget(PromQuery(
+			`sum(increase(spt_extractions_by_component_total{job="server-price-tracker",component_type="hba"}[24h])) / sum(increase(spt_extractions_by_component_total{job="server-price-tracker"}[24h])) * 100`,
+			"", "A",
+		)).
+		Unit("percent").
+		Decimals(1).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemeThresholds()).
+		ColorMode(common.BigValueColorModeBackground).
+		GraphMode(common.BigValueGraphModeNone)
+}
+
+// ExtractionCorrections returns a stat panel showing operator extraction
+// corrections over the last week by corrected component type; a rising
+// count for one type points at a prompt or normalizer to fix.
+func ExtractionCorrections() *stat.PanelBuilder {
+	return stat.NewPanelBuilder().
+		Title("Extraction Corrections (7d)").
+		Description("Operator extraction corrections in the last 7 days by corrected component type").
+		Datasource(DSRef()).
+		Height(StatHeight).
+		Span(StatWidth).
+		WithTarget(PromQuery(
+			`sum by (component_type) (increase(spt_extraction_corrections_total{job="server-price-tracker"}[7d]))`,
+			"{{component_type}}", "A",
+		)).
+		Unit("short").
+		Decimals(0).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemeThresholds()).
+		ColorMode(common.BigValueColorModeBackground).
+		GraphMode(common.BigValueGraphModeNone)
+}
+
+// ShadowExtractions returns a timeseries panel showing sampled shadow
+// extractions by result. A rising disagree share means the candidate
+// backend or prompt is drifting from the live one; dropped samples
+// mean the shadow can't keep up with llm.shadow.sample_rate.
+func ShadowExtractions() *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title("Shadow Extractions").
+		Description("Sampled shadow extraction rate by result: agree, disagree, error, dropped").
+		Datasource(DSRef()).
+		Height(TSHeight).
+		Span(TSWidth).
+		WithTarget(PromQuery(
+			`sum by (result) (rate(spt_shadow_extractions_total{job="server-price-tracker"}[5m]))`,
+			"{{result}}",
+			"A",
+		)).
+		Unit("ops").
+		FillOpacity(10).
+		LineWidth(2).
+		Legend(TableLegend("mean", "lastNotNull")).
+		Tooltip(MultiTooltip()).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemePaletteClassic()).
+		DrawStyle(common.GraphDrawStyleLine)
+}
@@ -81,6 +81,102 @@ func JudgeCostByModel() *timeseries.PanelBuilder {
 		DrawStyle(common.GraphDrawStyleLine)
 }
 
+// JudgeBacklog charts the un-judged alerts inside the lookback window
+// against how many the last tick judged, plus the alerts that aged out
+// of the window unjudged. A growing backlog with judged pinned at the
+// batch size means concurrency or batch_size should go up.
+func JudgeBacklog() *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title("Judge Backlog").
+		Description("Un-judged alerts in the lookback window, alerts judged by the last tick, and alerts that aged out unjudged in the last 24h").
+		Datasource(DSRef()).
+		Height(TSHeight).
+		Span(12).
+		WithTarget(PromQuery(
+			`max(spt_judge_backlog_alerts{job="server-price-tracker"})`,
+			"pending",
+			"A",
+		)).
+		WithTarget(PromQuery(
+			`max(spt_judge_tick_alerts{job="server-price-tracker",outcome="judged"})`,
+			"judged last tick",
+			"B",
+		)).
+		WithTarget(PromQuery(
+			`max(spt_judge_aged_out_alerts{job="server-price-tracker"})`,
+			"aged out (24h)",
+			"C",
+		)).
+		Unit("short").
+		FillOpacity(10).
+		LineWidth(2).
+		Legend(TableLegend("lastNotNull", "max")).
+		Tooltip(MultiTooltip()).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemePaletteClassic()).
+		DrawStyle(common.GraphDrawStyleLine)
+}
+
+// JudgeBacklogAge charts the age of the oldest un-judged alert. When
+// it approaches the lookback window, alerts are about to age out
+// unjudged.
+func JudgeBacklogAge() *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title("Judge Backlog Age").
+		Description("Age of the oldest un-judged alert in the lookback window, and judge tick p95 duration").
+		Datasource(DSRef()).
+		Height(TSHeight).
+		Span(12).
+		WithTarget(PromQuery(
+			`max(spt_judge_backlog_oldest_age_seconds{job="server-price-tracker"})`,
+			"oldest un-judged",
+			"A",
+		)).
+		WithTarget(PromQuery(
+			`histogram_quantile(0.95, sum by (le) (rate(spt_judge_tick_duration_seconds_bucket{job="server-price-tracker"}[1h])))`,
+			"tick p95",
+			"B",
+		)).
+		Unit("s").
+		FillOpacity(10).
+		LineWidth(2).
+		Legend(TableLegend("lastNotNull", "max")).
+		Tooltip(MultiTooltip()).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemePaletteClassic()).
+		DrawStyle(common.GraphDrawStyleLine)
+}
+
+// JudgeGate charts what the alert judge gate does with pending alerts:
+// released by outcome per hour, plus the alerts held for a verdict at
+// the last alert tick.
+func JudgeGate() *timeseries.PanelBuilder {
+	return timeseries.NewPanelBuilder().
+		Title("Judge Gate").
+		Description("Alerts released by the judge gate per hour by outcome, and alerts currently held for a verdict").
+		Datasource(DSRef()).
+		Height(TSHeight).
+		Span(12).
+		WithTarget(PromQuery(
+			`sum by (outcome) (increase(spt_alerts_judge_gate_total{job="server-price-tracker"}[1h]))`,
+			"{{outcome}}",
+			"A",
+		)).
+		WithTarget(PromQuery(
+			`max(spt_alerts_judge_gate_held{job="server-price-tracker"})`,
+			"held",
+			"B",
+		)).
+		Unit("short").
+		FillOpacity(10).
+		LineWidth(2).
+		Legend(TableLegend("lastNotNull", "max")).
+		Tooltip(MultiTooltip()).
+		Thresholds(ThresholdsGreenOnly()).
+		ColorScheme(ColorSchemePaletteClassic()).
+		DrawStyle(common.GraphDrawStyleLine)
+}
+
 // PipelineStageVolume approximates trace volume per pipeline stage by
 // charting the rate of completed operations per stage histogram. Each
 // `_count` rate is a proxy for span count per minute until OTel-derived
@@ -109,6 +109,9 @@ func stratifiedSample(
 		domain.ComponentCPU,
 		domain.ComponentNIC,
 		domain.ComponentGPU,
+		domain.ComponentHBA,
+		domain.ComponentSwitch,
+		domain.ComponentTransceiver,
 		domain.ComponentWorkstation,
 		domain.ComponentDesktop,
 		domain.ComponentOther,
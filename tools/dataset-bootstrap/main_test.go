@@ -64,7 +64,8 @@ func TestStratifiedSample_SkipsErroredBucketsAndSortsResults(t *testing.T) {
 	// iterates over — drift in that list shows up here as an
 	// unexpected-call failure.
 	for _, ct := range []domain.ComponentType{
-		domain.ComponentCPU, domain.ComponentNIC, domain.ComponentGPU,
+		domain.ComponentCPU, domain.ComponentNIC, domain.ComponentGPU, domain.ComponentHBA,
+		domain.ComponentSwitch, domain.ComponentTransceiver,
 		domain.ComponentWorkstation, domain.ComponentDesktop, domain.ComponentOther,
 	} {
 		st.EXPECT().
@@ -99,7 +100,7 @@ func TestStratifiedSample_PassesPerComponentAsLimit(t *testing.T) {
 			return ok && query.Limit == 7
 		})).
 		Return(nil, 0, nil).
-		Times(9) // nine ComponentTypes in stratifiedSample.
+		Times(12) // twelve ComponentTypes in stratifiedSample.
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
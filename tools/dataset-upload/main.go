@@ -91,7 +91,7 @@ func run() error {
 	uploaded := 0
 	for i := range dataset {
 		item := &dataset[i]
-		if err := client.CreateDatasetItem(ctx, *langfuseDatasetID, datasetItem(item)); err != nil {
+		if err := client.CreateDatasetItem(ctx, *langfuseDatasetID, regression.DatasetItem(item, "golden_classifications.json")); err != nil {
 			logger.Warn("CreateDatasetItem failed; skipping",
 				"title", truncate(item.Title, 60), "error", err)
 			continue
@@ -107,26 +107,6 @@ func run() error {
 	return nil
 }
 
-// datasetItem converts a regression.Item to a langfuse.DatasetItem, using
-// the same deterministic title hash the regression-runner uses for
-// DatasetItemID so runs and items align.
-func datasetItem(g *regression.Item) *langfuse.DatasetItem {
-	return &langfuse.DatasetItem{
-		ID: regression.TitleHash(g.Title),
-		Input: map[string]any{
-			"title":          g.Title,
-			"item_specifics": g.ItemSpecifics,
-		},
-		ExpectedOutput: map[string]any{
-			"component_type": string(g.ExpectedComponent),
-			"product_key":    g.ExpectedProductKey,
-		},
-		Metadata: map[string]string{
-			"source": "golden_classifications.json",
-		},
-	}
-}
-
 func truncate(s string, n int) string {
 	if len(s) <= n {
 		return s
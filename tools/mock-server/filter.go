@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// browseSorts are the sort= values the Browse API accepts.
+var browseSorts = []string{"", "newlyListed", "price", "-price", "endingSoonest", "distance"}
+
+// browseBuyingOptions are the buyingOptions values the Browse API accepts.
+var browseBuyingOptions = []string{"FIXED_PRICE", "AUCTION", "BEST_OFFER", "CLASSIFIED_AD"}
+
+// browseFilter is a parsed filter= value. Only the fields the tracker
+// sends are supported; anything else is rejected so a client change
+// that emits a new field has to teach the mock about it.
+type browseFilter struct {
+	priceMin       *float64
+	priceMax       *float64
+	priceCurrency  string
+	conditionIDs   []string
+	buyingOptions  []string
+	country        string
+	sellers        []string
+	excludeSellers []string
+}
+
+// parseBrowseFilter validates filter= syntax the way the Browse API
+// does: comma-separated field:value clauses, where a value is a range
+// "[low..high]" (either end may be empty), a set "{a|b}", or a bare
+// token. price requires priceCurrency.
+func parseBrowseFilter(raw string) (*browseFilter, error) {
+	f := &browseFilter{}
+	if raw == "" {
+		return f, nil
+	}
+
+	seen := make(map[string]bool)
+	for clause := range strings.SplitSeq(raw, ",") {
+		field, value, ok := strings.Cut(clause, ":")
+		if !ok || field == "" || value == "" {
+			return nil, fmt.Errorf("malformed filter clause %q: want field:value", clause)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("filter field %q given twice", field)
+		}
+		seen[field] = true
+
+		var err error
+		switch field {
+		case "price":
+			f.priceMin, f.priceMax, err = parseRange(value)
+		case "priceCurrency":
+			if !isUpperCode(value, 3) {
+				err = fmt.Errorf("invalid priceCurrency %q", value)
+			}
+			f.priceCurrency = value
+		case "conditionIds":
+			f.conditionIDs, err = parseSet(value)
+			for _, id := range f.conditionIDs {
+				if _, convErr := strconv.Atoi(id); convErr != nil {
+					err = fmt.Errorf("invalid condition ID %q", id)
+				}
+			}
+		case "buyingOptions":
+			f.buyingOptions, err = parseSet(value)
+			for _, opt := range f.buyingOptions {
+				if !slices.Contains(browseBuyingOptions, opt) {
+					err = fmt.Errorf("invalid buying option %q", opt)
+				}
+			}
+		case "itemLocationCountry":
+			if !isUpperCode(value, 2) {
+				err = fmt.Errorf("invalid itemLocationCountry %q", value)
+			}
+			f.country = value
+		case "sellers":
+			f.sellers, err = parseSet(value)
+		case "excludeSellers":
+			f.excludeSellers, err = parseSet(value)
+		default:
+			err = fmt.Errorf("filter field %q is not supported by the mock server", field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if seen["price"] && !seen["priceCurrency"] {
+		return nil, fmt.Errorf("the price filter requires priceCurrency")
+	}
+	return f, nil
+}
+
+// parseRange parses "[low..high]"; either bound may be empty, not both.
+func parseRange(value string) (low, high *float64, err error) {
+	inner, ok := strings.CutPrefix(value, "[")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, "]")
+	}
+	lowStr, highStr, found := strings.Cut(inner, "..")
+	if !ok || !found || (lowStr == "" && highStr == "") {
+		return nil, nil, fmt.Errorf("malformed range %q: want [low..high]", value)
+	}
+	parse := func(s string) (*float64, error) {
+		if s == "" {
+			return nil, nil
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed range bound %q", s)
+		}
+		return &v, nil
+	}
+	if low, err = parse(lowStr); err != nil {
+		return nil, nil, err
+	}
+	if high, err = parse(highStr); err != nil {
+		return nil, nil, err
+	}
+	if low != nil && high != nil && *low > *high {
+		return nil, nil, fmt.Errorf("range %q has low above high", value)
+	}
+	return low, high, nil
+}
+
+// parseSet parses "{a|b}" into its members.
+func parseSet(value string) ([]string, error) {
+	inner, ok := strings.CutPrefix(value, "{")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, "}")
+	}
+	if !ok || inner == "" {
+		return nil, fmt.Errorf("malformed set %q: want {a|b}", value)
+	}
+	members := strings.Split(inner, "|")
+	if slices.Contains(members, "") {
+		return nil, fmt.Errorf("malformed set %q: empty member", value)
+	}
+	return members, nil
+}
+
+func isUpperCode(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether a fixture item passes the filter.
+func (f *browseFilter) match(item *itemSummary) bool {
+	if f.priceMin != nil || f.priceMax != nil {
+		price, err := strconv.ParseFloat(item.Price.Value, 64)
+		if err != nil || item.Price.Currency != f.priceCurrency {
+			return false
+		}
+		if f.priceMin != nil && price < *f.priceMin {
+			return false
+		}
+		if f.priceMax != nil && price > *f.priceMax {
+			return false
+		}
+	}
+	if len(f.conditionIDs) > 0 && !slices.Contains(f.conditionIDs, item.ConditionID) {
+		return false
+	}
+	if len(f.buyingOptions) > 0 && !slices.ContainsFunc(item.BuyingOptions, func(o string) bool {
+		return slices.Contains(f.buyingOptions, o)
+	}) {
+		return false
+	}
+	if f.country != "" && item.ItemLocation.Country != f.country {
+		return false
+	}
+	// Seller usernames compare case-insensitively, as on eBay.
+	seller := func(name string) bool { return strings.EqualFold(name, item.Seller.Username) }
+	if len(f.sellers) > 0 && !slices.ContainsFunc(f.sellers, seller) {
+		return false
+	}
+	if slices.ContainsFunc(f.excludeSellers, seller) {
+		return false
+	}
+	return true
+}
@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,18 @@ type browseAPIResponse struct {
 
 type itemSummary struct {
 	Title string `json:"title"`
+	Price struct {
+		Value    string `json:"value"`
+		Currency string `json:"currency"`
+	} `json:"price"`
+	ConditionID   string   `json:"conditionId"`
+	BuyingOptions []string `json:"buyingOptions"`
+	ItemLocation  struct {
+		Country string `json:"country"`
+	} `json:"itemLocation"`
+	Seller struct {
+		Username string `json:"username"`
+	} `json:"seller"`
 }
 
 func main() {
@@ -108,21 +121,34 @@ func tokenHandler(logger *slog.Logger) http.HandlerFunc {
 }
 
 func searchHandler(logger *slog.Logger, fixture *browseAPIResponse) http.HandlerFunc {
-	// Pre-parse titles for filtering.
+	// Pre-parse items for filtering.
 	type indexedItem struct {
-		raw   json.RawMessage
-		title string
+		raw     json.RawMessage
+		title   string
+		summary itemSummary
 	}
 	items := make([]indexedItem, 0, len(fixture.ItemSummaries))
 	for _, raw := range fixture.ItemSummaries {
 		var s itemSummary
-		//nolint:errcheck,gosec // fixture data is trusted; title extraction is best-effort
+		//nolint:errcheck,gosec // fixture data is trusted; field extraction is best-effort
 		json.Unmarshal(raw, &s)
-		items = append(items, indexedItem{raw: raw, title: strings.ToLower(s.Title)})
+		items = append(items, indexedItem{raw: raw, title: strings.ToLower(s.Title), summary: s})
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		q := strings.ToLower(r.URL.Query().Get("q"))
+
+		if sort := r.URL.Query().Get("sort"); !slices.Contains(browseSorts, sort) {
+			writeBrowseError(w, fmt.Sprintf("invalid sort %q", sort))
+			logger.Warn("rejected search", "sort", sort)
+			return
+		}
+		filter, err := parseBrowseFilter(r.URL.Query().Get("filter"))
+		if err != nil {
+			writeBrowseError(w, err.Error())
+			logger.Warn("rejected search", "filter", r.URL.Query().Get("filter"), "error", err)
+			return
+		}
 		limitStr := r.URL.Query().Get("limit")
 		offsetStr := r.URL.Query().Get("offset")
 
@@ -139,11 +165,14 @@ func searchHandler(logger *slog.Logger, fixture *browseAPIResponse) http.Handler
 			}
 		}
 
-		// Filter items: every word in the query must appear in the title.
+		// Filter items: every word in the query must appear in the title,
+		// and the item must pass filter=. Fixture order stands in for
+		// newlyListed.
 		queryWords := strings.Fields(q)
 		var matched []json.RawMessage
-		for _, item := range items {
-			if q == "" || containsAllWords(item.title, queryWords) {
+		for i := range items {
+			item := &items[i]
+			if (q == "" || containsAllWords(item.title, queryWords)) && filter.match(&item.summary) {
 				matched = append(matched, item.raw)
 			}
 		}
@@ -184,6 +213,21 @@ func searchHandler(logger *slog.Logger, fixture *browseAPIResponse) http.Handler
 	}
 }
 
+// writeBrowseError writes a 400 in the Browse API's error envelope.
+func writeBrowseError(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	//nolint:errcheck,gosec // best-effort write to HTTP response in mock server
+	json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]any{{
+			"errorId":  12001,
+			"domain":   "API_BROWSE",
+			"category": "REQUEST",
+			"message":  msg,
+		}},
+	})
+}
+
 func containsAllWords(title string, words []string) bool {
 	for _, w := range words {
 		if !strings.Contains(title, w) {
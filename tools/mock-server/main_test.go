@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -8,6 +9,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/donaldgifford/server-price-tracker/internal/ebay"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
 func loadTestFixture(t *testing.T) *browseAPIResponse {
@@ -218,6 +222,129 @@ func TestSearchHandler_NoResults(t *testing.T) {
 	}
 }
 
+func TestSearchHandler_BrowseFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantTotal int
+	}{
+		{name: "price ceiling", query: "filter=price:[..40],priceCurrency:USD", wantTotal: 2},
+		{name: "price floor and ceiling", query: "filter=price:[100..200],priceCurrency:USD", wantTotal: 6},
+		{name: "condition IDs", query: "filter=conditionIds:{1000|2500}", wantTotal: 5},
+		{name: "buying options", query: "filter=buyingOptions:{AUCTION}", wantTotal: 3},
+		{name: "item location", query: "filter=itemLocationCountry:GB", wantTotal: 1},
+		{name: "seller allowlist", query: "filter=sellers:{Server_Parts_Wholesale|cpu_trader_pro}", wantTotal: 6},
+		{name: "seller blocklist", query: "filter=excludeSellers:{server_parts_wholesale|datacenter_liquidation}", wantTotal: 11},
+		{
+			name:      "clauses combine with the query",
+			query:     "q=DDR4&sort=newlyListed&filter=price:[..100],priceCurrency:USD,buyingOptions:{FIXED_PRICE}",
+			wantTotal: 2,
+		},
+	}
+
+	fixture := loadTestFixture(t)
+	handler := searchHandler(testLogger(), fixture)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/buy/browse/v1/item_summary/search?"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status=%d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			var resp browseAPIResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if resp.Total != tt.wantTotal {
+				t.Errorf("total=%d, want %d", resp.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestSearchHandler_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "price without currency", query: "filter=price:[..40]"},
+		{name: "range without brackets", query: "filter=price:..40,priceCurrency:USD"},
+		{name: "empty range", query: "filter=price:[..],priceCurrency:USD"},
+		{name: "inverted range", query: "filter=price:[50..10],priceCurrency:USD"},
+		{name: "set without braces", query: "filter=conditionIds:3000"},
+		{name: "non-numeric condition ID", query: "filter=conditionIds:{used}"},
+		{name: "unknown buying option", query: "filter=buyingOptions:{BUY_IT_NOW}"},
+		{name: "lower-case country", query: "filter=itemLocationCountry:us"},
+		{name: "empty seller set", query: "filter=sellers:{}"},
+		{name: "unsupported field", query: "filter=charityOnly:true"},
+		{name: "missing value", query: "filter=conditionIds"},
+		{name: "duplicate field", query: "filter=conditionIds:{1000},conditionIds:{3000}"},
+		{name: "unknown sort", query: "sort=oldest"},
+	}
+
+	fixture := loadTestFixture(t)
+	handler := searchHandler(testLogger(), fixture)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/buy/browse/v1/item_summary/search?"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status=%d, want %d", w.Code, http.StatusBadRequest)
+			}
+			var resp struct {
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Message == "" {
+				t.Errorf("errors=%+v, want one error with a message", resp.Errors)
+			}
+		})
+	}
+}
+
+type staticToken string
+
+func (s staticToken) Token(context.Context) (string, error) { return string(s), nil }
+
+// TestSearchHandler_AcceptsClientFilters round-trips the filters the
+// tracker builds for a watch through the real Browse client, so a change
+// to either side that breaks the syntax fails here.
+func TestSearchHandler_AcceptsClientFilters(t *testing.T) {
+	srv := httptest.NewServer(searchHandler(testLogger(), loadTestFixture(t)))
+	defer srv.Close()
+
+	priceMax := 100.0
+	filter := ebay.BrowseFilter(&domain.WatchFilters{
+		PriceMax:            &priceMax,
+		Conditions:          []domain.Condition{domain.ConditionUsedWorking},
+		BuyingOptions:       []domain.ListingType{domain.ListingBuyItNow},
+		ItemLocationCountry: "US",
+		SellerAllowlist:     []string{"server_parts_wholesale", "datacenter_liquidation"},
+		SellerBlocklist:     []string{"Datacenter_Liquidation"},
+	})
+	client := ebay.NewBrowseClient(staticToken("mock"), ebay.WithBrowseURL(srv.URL))
+
+	resp, err := client.Search(context.Background(), ebay.SearchRequest{
+		Query:   "DDR4",
+		Sort:    ebay.SortNewlyListed,
+		Filters: map[string]string{"filter": filter},
+	})
+	if err != nil {
+		t.Fatalf("search with %q: %v", filter, err)
+	}
+	if resp.Total != 1 {
+		t.Errorf("total=%d, want 1", resp.Total)
+	}
+}
+
 func testLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 }
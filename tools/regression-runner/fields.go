@@ -0,0 +1,98 @@
+package main
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+)
+
+// attrCounts tallies attribute-level extraction agreement with the
+// golden ExpectedAttributes. Correct counts expected attributes the
+// extractor set to an equal value; Extracted counts every attribute it
+// set on a labelled item.
+type attrCounts struct {
+	Expected  int `json:"expected"`
+	Extracted int `json:"extracted"`
+	Correct   int `json:"correct"`
+}
+
+// Precision returns Correct/Extracted as a percentage, 0 when nothing
+// was extracted.
+func (c attrCounts) Precision() float64 {
+	if c.Extracted == 0 {
+		return 0
+	}
+	return float64(c.Correct) / float64(c.Extracted) * 100
+}
+
+// Recall returns Correct/Expected as a percentage, 0 when nothing was
+// expected.
+func (c attrCounts) Recall() float64 {
+	if c.Expected == 0 {
+		return 0
+	}
+	return float64(c.Correct) / float64(c.Expected) * 100
+}
+
+func (c *attrCounts) add(o attrCounts) {
+	c.Expected += o.Expected
+	c.Extracted += o.Extracted
+	c.Correct += o.Correct
+}
+
+// fieldResult is the attrCounts of one attribute within a component.
+type fieldResult struct {
+	Field string `json:"field"`
+	attrCounts
+}
+
+// scoreAttributes compares extracted attrs against the labelled
+// expected attrs, returning the per-field counts. confidence and nil
+// values are ignored; values compare the way shadow extraction diffs
+// them, so 32 and 32.0 or "Samsung" and "samsung" agree.
+func scoreAttributes(expected, actual map[string]any) map[string]attrCounts {
+	fields := map[string]attrCounts{}
+	for k, v := range expected {
+		if k == "confidence" || v == nil {
+			continue
+		}
+		c := fields[k]
+		c.Expected++
+		if extract.AttributeValuesEqual(v, actual[k]) {
+			c.Correct++
+		}
+		fields[k] = c
+	}
+	for k, v := range actual {
+		if k == "confidence" || v == nil {
+			continue
+		}
+		c := fields[k]
+		c.Extracted++
+		fields[k] = c
+	}
+	return fields
+}
+
+// addFields adds per-field counts to the component's attribute totals
+// and field breakdown.
+func (r *componentResult) addFields(fields map[string]attrCounts) {
+	if r.byField == nil {
+		r.byField = map[string]attrCounts{}
+	}
+	for name, c := range fields {
+		r.Attributes.add(c)
+		f := r.byField[name]
+		f.add(c)
+		r.byField[name] = f
+	}
+}
+
+// finishFields sorts the accumulated field breakdown into Fields.
+func (r *componentResult) finishFields() {
+	r.Fields = make([]fieldResult, 0, len(r.byField))
+	for _, name := range slices.Sorted(maps.Keys(r.byField)) {
+		r.Fields = append(r.Fields, fieldResult{Field: name, attrCounts: r.byField[name]})
+	}
+}
@@ -17,10 +17,15 @@
 // concerns + API-key exfiltration risks rule out a CI workflow. The
 // PR template checkbox is the gate.
 //
-// Cost ($/1k extractions) in the comparison view is currently emitted
-// as "—" because per-extraction token usage is not surfaced through
-// the LLMExtractor return path; surfacing it is parked as a follow-up
-// alongside the Langfuse classify_prompt:<sha> dataset-run annotation.
+// Beyond classification accuracy, items labelled with expected
+// attributes or a product key (operator corrections export both) are
+// scored on attribute precision/recall and product-key accuracy. Token
+// usage and cost come from each extraction; cost is "—" for models
+// without a rate in observability.langfuse.model_costs.
+//
+// --report and --junit write machine-readable results; --baseline
+// compares the run against an earlier --report and exits non-zero when
+// any score drops by more than --tolerance percentage points.
 package main
 
 import (
@@ -40,6 +45,7 @@ import (
 
 	"github.com/donaldgifford/server-price-tracker/internal/config"
 	"github.com/donaldgifford/server-price-tracker/internal/regression"
+	"github.com/donaldgifford/server-price-tracker/pkg/catalog"
 	"github.com/donaldgifford/server-price-tracker/pkg/extract"
 	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
@@ -50,6 +56,16 @@ type componentResult struct {
 	Component domain.ComponentType `json:"component"`
 	Total     int                  `json:"total"`
 	Correct   int                  `json:"correct"`
+	// Attributes scores the items labelled with expected attributes;
+	// Fields breaks it down per attribute.
+	Attributes attrCounts    `json:"attributes"`
+	Fields     []fieldResult `json:"fields,omitempty"`
+	// KeyTotal counts the items labelled with an expected product key,
+	// KeyCorrect those whose extraction built the same key.
+	KeyTotal   int `json:"product_key_total"`
+	KeyCorrect int `json:"product_key_correct"`
+
+	byField map[string]attrCounts
 }
 
 // Accuracy returns the per-component accuracy as a percentage in
@@ -62,6 +78,15 @@ func (r componentResult) Accuracy() float64 {
 	return float64(r.Correct) / float64(r.Total) * 100
 }
 
+// KeyAccuracy returns the product-key accuracy as a percentage, 0 when
+// no item is labelled with a key.
+func (r componentResult) KeyAccuracy() float64 {
+	if r.KeyTotal == 0 {
+		return 0
+	}
+	return float64(r.KeyCorrect) / float64(r.KeyTotal) * 100
+}
+
 // runResult is the JSON shape emitted under --json. Suitable for piping
 // into `jq` or summarising in a Claude Code session.
 type runResult struct {
@@ -77,6 +102,43 @@ type runResult struct {
 	P95Latency time.Duration     `json:"p95_latency"`
 	PerComp    []componentResult `json:"per_component"`
 	Mismatches []mismatch        `json:"mismatches,omitempty"`
+
+	// Attributes and the key counts total the per-component scores.
+	Attributes  attrCounts `json:"attributes"`
+	KeyTotal    int        `json:"product_key_total"`
+	KeyCorrect  int        `json:"product_key_correct"`
+	KeyAccuracy float64    `json:"product_key_accuracy_percent"`
+
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	// CostUSD and CostPer1k are nil when any extraction's model has no
+	// configured rate.
+	CostUSD   *float64 `json:"cost_usd,omitempty"`
+	CostPer1k *float64 `json:"cost_per_1k_usd,omitempty"`
+
+	// Items holds every item's outcome for the JUnit report.
+	Items []itemResult `json:"-"`
+}
+
+// itemResult is one dataset item's outcome.
+type itemResult struct {
+	Title       string
+	Expected    domain.ComponentType
+	Actual      domain.ComponentType
+	Error       string
+	ExpectedKey string
+	ActualKey   string
+	Latency     time.Duration
+}
+
+// runConfig is what runDataset needs besides the extractor and dataset.
+type runConfig struct {
+	backend string
+	model   string
+	costs   map[string]langfuse.ModelCost
+	// catalog, when set, enriches extracted attributes before scoring,
+	// as the engine does before building the product key.
+	catalog *catalog.Catalog
 }
 
 // comparisonResult wraps multiple runResults for --backends side-by-side.
@@ -112,6 +174,16 @@ func main() {
 		"sha", "",
 		"override the run-name SHA (default: `git rev-parse HEAD` from the working tree)",
 	)
+	reportPath := flag.String("report", "", "write a JSON report of the run to this path")
+	junitPath := flag.String("junit", "", "write a JUnit XML report of the run to this path")
+	baselinePath := flag.String(
+		"baseline", "",
+		"compare the run against this earlier --report and exit 1 on regressions",
+	)
+	tolerance := flag.Float64(
+		"tolerance", 1.0,
+		"percentage points a score may drop against --baseline before it counts as a regression",
+	)
 	flag.Parse()
 
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
@@ -139,6 +211,21 @@ func main() {
 	annotateLangfuse(cfg, logger, results, dataset, *langfuseDatasetID, *sha)
 
 	emit(results, *jsonOut)
+
+	rep := &report{GeneratedAt: time.Now().UTC(), Dataset: *datasetPath, Backends: results}
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, rep); err != nil {
+			fatal("writing report: %v", err)
+		}
+	}
+	if *junitPath != "" {
+		if err := writeJUnit(*junitPath, rep); err != nil {
+			fatal("writing JUnit report: %v", err)
+		}
+	}
+	if *baselinePath != "" {
+		checkBaseline(*baselinePath, rep, *tolerance)
+	}
 }
 
 // executeAll runs the dataset against each requested backend and
@@ -150,9 +237,18 @@ func executeAll(
 	dataset []regression.Item,
 	backends []string,
 ) []runResult {
+	var cat *catalog.Catalog
+	if cfg.Catalog.Enabled {
+		c, err := catalog.Load(cfg.Catalog.Path)
+		if err != nil {
+			fatal("loading catalog %s: %v", cfg.Catalog.Path, err)
+		}
+		cat = c
+	}
+
 	out := make([]runResult, 0, len(backends))
 	for _, b := range backends {
-		r := executeBackend(cfg, logger, dataset, b)
+		r := executeBackend(cfg, logger, dataset, b, cat)
 		if r == nil {
 			logger.Warn("skipping backend (not configured)", "backend", b)
 			continue
@@ -212,13 +308,19 @@ func executeBackend(
 	logger *slog.Logger,
 	dataset []regression.Item,
 	backendName string,
+	cat *catalog.Catalog,
 ) *runResult {
 	backend := buildBackendByName(cfg, logger, backendName)
 	if backend == nil {
 		return nil
 	}
 	extractor := extract.NewLLMExtractor(backend, extract.WithLogger(logger))
-	r := runDataset(context.Background(), extractor, dataset, backendName, modelOfBackend(cfg, backendName))
+	r := runDataset(context.Background(), extractor, dataset, &runConfig{
+		backend: backendName,
+		model:   modelOfBackend(cfg, backendName),
+		costs:   cfg.Observability.Langfuse.ModelCosts,
+		catalog: cat,
+	})
 	return &r
 }
 
@@ -390,14 +492,13 @@ func runDataset(
 	ctx context.Context,
 	extractor *extract.LLMExtractor,
 	dataset []regression.Item,
-	backendName, modelName string,
+	rc *runConfig,
 ) runResult {
 	start := time.Now()
+	run := runResult{Backend: rc.backend, Model: rc.model, Total: len(dataset)}
 	perComp := map[domain.ComponentType]*componentResult{}
-	var mismatches []mismatch
 	latencies := make([]time.Duration, 0, len(dataset))
-	correct := 0
-	errCount := 0
+	cost, priced := 0.0, true
 
 	for i := range dataset {
 		item := &dataset[i]
@@ -408,62 +509,109 @@ func runDataset(
 		}
 		bucket.Total++
 
-		callStart := time.Now()
-		actual, _, err := extractor.ClassifyAndExtract(ctx, item.Title, item.ItemSpecifics)
-		latencies = append(latencies, time.Since(callStart))
-		if err != nil {
-			errCount++
-			mismatches = append(mismatches, mismatch{
-				Title:    item.Title,
-				Expected: item.ExpectedComponent,
-				Actual:   actual,
-				Error:    err.Error(),
-			})
-			continue
+		x, err := extractor.ClassifyAndExtractWithUsage(ctx, item.Title, item.ItemSpecifics)
+		latencies = append(latencies, x.Latency)
+		run.PromptTokens += x.Usage.PromptTokens
+		run.CompletionTokens += x.Usage.CompletionTokens
+		if x.Calls > 0 {
+			if run.Model == "" {
+				run.Model = x.Model
+			}
+			c, ok := x.CostUSD(rc.costs)
+			cost += c
+			priced = priced && ok
 		}
-		if actual == item.ExpectedComponent {
+
+		res := scoreItem(item, x, err, rc.catalog, bucket)
+		run.Items = append(run.Items, res)
+		switch {
+		case err != nil:
+			run.Errors++
+		case res.Actual == item.ExpectedComponent:
 			bucket.Correct++
-			correct++
+			run.Correct++
 			continue
 		}
-		mismatches = append(mismatches, mismatch{
+		run.Mismatches = append(run.Mismatches, mismatch{
 			Title:    item.Title,
 			Expected: item.ExpectedComponent,
-			Actual:   actual,
+			Actual:   res.Actual,
+			Error:    res.Error,
 		})
 	}
 
-	results := make([]componentResult, 0, len(perComp))
-	for _, r := range perComp {
-		results = append(results, *r)
+	run.finish(perComp, latencies)
+	if priced && run.Total > 0 {
+		per1k := cost / float64(run.Total) * 1000
+		run.CostUSD, run.CostPer1k = &cost, &per1k
 	}
-	sort.Slice(results, func(i, j int) bool {
-		return string(results[i].Component) < string(results[j].Component)
+	run.Duration = time.Since(start)
+	return run
+}
+
+// finish totals the per-component scores into the run and derives its
+// rates and latency percentiles.
+func (run *runResult) finish(perComp map[domain.ComponentType]*componentResult, latencies []time.Duration) {
+	run.PerComp = make([]componentResult, 0, len(perComp))
+	for _, r := range perComp {
+		r.finishFields()
+		run.Attributes.add(r.Attributes)
+		run.KeyTotal += r.KeyTotal
+		run.KeyCorrect += r.KeyCorrect
+		run.PerComp = append(run.PerComp, *r)
+	}
+	sort.Slice(run.PerComp, func(i, j int) bool {
+		return string(run.PerComp[i].Component) < string(run.PerComp[j].Component)
 	})
 
-	overall := 0.0
-	errorRate := 0.0
-	if total := len(dataset); total > 0 {
-		overall = float64(correct) / float64(total) * 100
-		errorRate = float64(errCount) / float64(total) * 100
-	}
-
-	p50, p95 := percentiles(latencies)
-
-	return runResult{
-		Backend:    backendName,
-		Model:      modelName,
-		Total:      len(dataset),
-		Correct:    correct,
-		Errors:     errCount,
-		Accuracy:   overall,
-		ErrorRate:  errorRate,
-		Duration:   time.Since(start),
-		P50Latency: p50,
-		P95Latency: p95,
-		PerComp:    results,
-		Mismatches: mismatches,
+	if run.Total > 0 {
+		run.Accuracy = float64(run.Correct) / float64(run.Total) * 100
+		run.ErrorRate = float64(run.Errors) / float64(run.Total) * 100
+	}
+	if run.KeyTotal > 0 {
+		run.KeyAccuracy = float64(run.KeyCorrect) / float64(run.KeyTotal) * 100
+	}
+	run.P50Latency, run.P95Latency = percentiles(latencies)
+}
+
+// scoreItem records one extraction's attribute and product-key scores
+// in bucket and returns its outcome. A failed extraction scores as
+// extracting nothing.
+func scoreItem(
+	item *regression.Item,
+	x *extract.Extraction,
+	err error,
+	cat *catalog.Catalog,
+	bucket *componentResult,
+) itemResult {
+	res := itemResult{
+		Title:       item.Title,
+		Expected:    item.ExpectedComponent,
+		Actual:      x.ComponentType,
+		ExpectedKey: item.ExpectedProductKey,
+		Latency:     x.Latency,
+	}
+	attrs := x.Attributes
+	if err != nil {
+		res.Error = err.Error()
+		attrs = nil
+	} else if cat != nil && cat.Covers(string(x.ComponentType)) {
+		cat.Enrich(string(x.ComponentType), attrs)
+	}
+
+	if len(item.ExpectedAttributes) > 0 {
+		bucket.addFields(scoreAttributes(item.ExpectedAttributes, attrs))
+	}
+	if item.ExpectedProductKey != "" {
+		bucket.KeyTotal++
+		if err == nil {
+			res.ActualKey = extract.ProductKey(string(x.ComponentType), attrs)
+		}
+		if res.ActualKey == item.ExpectedProductKey {
+			bucket.KeyCorrect++
+		}
 	}
+	return res
 }
 
 // percentiles returns p50 and p95 of the latency sample. Uses
@@ -490,17 +638,28 @@ func emitTable(r *runResult) {
 		r.P50Latency.Round(time.Millisecond),
 		r.P95Latency.Round(time.Millisecond),
 	)
+	fmt.Printf("Attributes: precision %s  recall %s  product keys: %s  tokens: %d in / %d out  cost: %s ($/1k %s)\n\n",
+		percentOrDash(r.Attributes.Extracted, r.Attributes.Precision()),
+		percentOrDash(r.Attributes.Expected, r.Attributes.Recall()),
+		percentOrDash(r.KeyTotal, r.KeyAccuracy),
+		r.PromptTokens, r.CompletionTokens,
+		usdOrDash(r.CostUSD), usdOrDash(r.CostPer1k),
+	)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	if _, err := fmt.Fprintln(w, "Component\tCorrect\tTotal\tAccuracy"); err != nil {
+	if _, err := fmt.Fprintln(w, "Component\tCorrect\tTotal\tAccuracy\tAttr P\tAttr R\tKey"); err != nil {
 		fatal("writing header: %v", err)
 	}
-	if _, err := fmt.Fprintln(w, "---------\t-------\t-----\t--------"); err != nil {
+	if _, err := fmt.Fprintln(w, "---------\t-------\t-----\t--------\t------\t------\t---"); err != nil {
 		fatal("writing separator: %v", err)
 	}
 	for _, c := range r.PerComp {
-		if _, err := fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n",
-			c.Component, c.Correct, c.Total, c.Accuracy()); err != nil {
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%s\t%s\t%s\n",
+			c.Component, c.Correct, c.Total, c.Accuracy(),
+			percentOrDash(c.Attributes.Extracted, c.Attributes.Precision()),
+			percentOrDash(c.Attributes.Expected, c.Attributes.Recall()),
+			percentOrDash(c.KeyTotal, c.KeyAccuracy()),
+		); err != nil {
 			fatal("writing row: %v", err)
 		}
 	}
@@ -523,26 +682,27 @@ func emitTable(r *runResult) {
 }
 
 // emitComparison renders the multi-backend table. $/1k extractions
-// shows "—" today because per-extraction token usage is not surfaced
-// through the LLMExtractor return path — see the package-level
-// follow-up note.
+// shows "—" for a backend whose model has no configured rate.
 func emitComparison(c *comparisonResult) {
 	fmt.Printf("Backend comparison — %d backend(s)\n\n", len(c.Backends))
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	if _, err := fmt.Fprintln(w, "Backend\tModel\tAccuracy\tErrors\tp50\tp95\t$/1k"); err != nil {
+	if _, err := fmt.Fprintln(w, "Backend\tModel\tAccuracy\tErrors\tAttr P\tAttr R\tKey\tp50\tp95\t$/1k"); err != nil {
 		fatal("writing header: %v", err)
 	}
-	if _, err := fmt.Fprintln(w, "-------\t-----\t--------\t------\t---\t---\t----"); err != nil {
+	if _, err := fmt.Fprintln(w, "-------\t-----\t--------\t------\t------\t------\t---\t---\t---\t----"); err != nil {
 		fatal("writing separator: %v", err)
 	}
 	for i := range c.Backends {
 		r := &c.Backends[i]
-		if _, err := fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%.1f%%\t%s\t%s\t%s\n",
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%.1f%%\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			r.Backend, r.Model, r.Accuracy, r.ErrorRate,
+			percentOrDash(r.Attributes.Extracted, r.Attributes.Precision()),
+			percentOrDash(r.Attributes.Expected, r.Attributes.Recall()),
+			percentOrDash(r.KeyTotal, r.KeyAccuracy),
 			r.P50Latency.Round(time.Millisecond),
 			r.P95Latency.Round(time.Millisecond),
-			"—",
+			usdOrDash(r.CostPer1k),
 		); err != nil {
 			fatal("writing row: %v", err)
 		}
@@ -560,6 +720,22 @@ func emitJSON(r *runResult) {
 	}
 }
 
+// percentOrDash formats pct, or "—" when its denominator n is zero.
+func percentOrDash(n int, pct float64) string {
+	if n == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f%%", pct)
+}
+
+// usdOrDash formats a cost, or "—" when it is unknown.
+func usdOrDash(v *float64) string {
+	if v == nil {
+		return "—"
+	}
+	return fmt.Sprintf("$%.4f", *v)
+}
+
 func truncate(s string, n int) string {
 	if len(s) <= n {
 		return s
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/donaldgifford/server-price-tracker/internal/regression"
+	"github.com/donaldgifford/server-price-tracker/pkg/extract"
+	extractMocks "github.com/donaldgifford/server-price-tracker/pkg/extract/mocks"
+	"github.com/donaldgifford/server-price-tracker/pkg/observability/langfuse"
 	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
 )
 
@@ -160,6 +166,105 @@ func TestComponentResultAccuracy(t *testing.T) {
 	}
 }
 
+func TestScoreAttributes(t *testing.T) {
+	t.Parallel()
+
+	got := scoreAttributes(
+		map[string]any{"manufacturer": "Intel", "model": "6130", "cores": 16, "confidence": 0.9, "socket": nil},
+		map[string]any{"manufacturer": "intel", "model": "6148", "cores": 16.0, "speed_ghz": 2.1, "confidence": 0.8},
+	)
+
+	assert.Equal(t, map[string]attrCounts{
+		"manufacturer": {Expected: 1, Extracted: 1, Correct: 1},
+		"model":        {Expected: 1, Extracted: 1},
+		"cores":        {Expected: 1, Extracted: 1, Correct: 1},
+		"speed_ghz":    {Extracted: 1},
+	}, got)
+}
+
+// expectCPU configures m to classify the next title as a CPU and
+// extract cpuModel, answering as mistral with 100 tokens each way.
+func expectCPU(m *extractMocks.MockLLMBackend, cpuModel string) {
+	usage := extract.TokenUsage{PromptTokens: 100, CompletionTokens: 100, TotalTokens: 200}
+	m.EXPECT().
+		Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool { return r.Format == "" })).
+		Return(extract.GenerateResponse{Content: "cpu", Model: "mistral", Usage: usage}, nil).
+		Once()
+	m.EXPECT().
+		Generate(mock.Anything, mock.MatchedBy(func(r extract.GenerateRequest) bool { return r.Format == "json" })).
+		Return(extract.GenerateResponse{
+			Content: `{"manufacturer": "Intel", "family": "Xeon", "model": "` + cpuModel + `",
+				"condition": "used_working", "confidence": 0.9, "quantity": 1}`,
+			Model: "mistral",
+			Usage: usage,
+		}, nil).
+		Once()
+}
+
+func TestRunDataset(t *testing.T) {
+	t.Parallel()
+
+	backend := extractMocks.NewMockLLMBackend(t)
+	backend.EXPECT().Name().Return("ollama").Maybe()
+	expectCPU(backend, "6130")
+	expectCPU(backend, "6148")
+	backend.EXPECT().
+		Generate(mock.Anything, mock.Anything).
+		Return(extract.GenerateResponse{}, errors.New("backend down")).
+		Once()
+
+	cpuAttrs := map[string]any{"manufacturer": "Intel", "family": "Xeon", "model": "6130", "confidence": 0.9}
+	dataset := []regression.Item{
+		{
+			Title:              "Intel Xeon Gold 6130 SR3B0 2.1GHz",
+			ExpectedComponent:  domain.ComponentCPU,
+			ExpectedProductKey: "cpu:intel:xeon:6130",
+			ExpectedAttributes: cpuAttrs,
+		},
+		{
+			Title:              "Intel Xeon Gold 6130 16 core",
+			ExpectedComponent:  domain.ComponentCPU,
+			ExpectedProductKey: "cpu:intel:xeon:6130",
+			ExpectedAttributes: cpuAttrs,
+		},
+		{Title: "Samsung 32GB DDR4 2666 RDIMM", ExpectedComponent: domain.ComponentRAM},
+	}
+
+	r := runDataset(context.Background(), extract.NewLLMExtractor(backend), dataset, &runConfig{
+		backend: "ollama",
+		costs:   map[string]langfuse.ModelCost{"mistral": {InputUSDPerMillion: 1, OutputUSDPerMillion: 2}},
+	})
+
+	assert.Equal(t, "mistral", r.Model)
+	assert.Equal(t, 2, r.Correct)
+	assert.Equal(t, 1, r.Errors)
+	assert.Len(t, r.Items, 3)
+	assert.Len(t, r.Mismatches, 1)
+
+	// Three labelled attributes per CPU item; the second got the model
+	// wrong. Both also extracted condition and quantity, which aren't
+	// labelled, so precision counts them against the extractor.
+	assert.Equal(t, attrCounts{Expected: 6, Extracted: 10, Correct: 5}, r.Attributes)
+	assert.Equal(t, 2, r.KeyTotal)
+	assert.Equal(t, 1, r.KeyCorrect)
+	assert.InDelta(t, 50, r.KeyAccuracy, 0.01)
+	assert.Equal(t, "cpu:intel:xeon:6148", r.Items[1].ActualKey)
+
+	require.Len(t, r.PerComp, 2)
+	cpu := r.PerComp[0]
+	assert.Equal(t, domain.ComponentCPU, cpu.Component)
+	require.NotEmpty(t, cpu.Fields)
+	assert.Equal(t, "condition", cpu.Fields[0].Field)
+
+	// 400 prompt + 400 completion tokens priced at $1/$2 per million.
+	assert.Equal(t, 400, r.PromptTokens)
+	assert.Equal(t, 400, r.CompletionTokens)
+	require.NotNil(t, r.CostUSD)
+	assert.InDelta(t, 0.0012, *r.CostUSD, 1e-9)
+	require.NotNil(t, r.CostPer1k)
+	assert.InDelta(t, 0.4, *r.CostPer1k, 1e-9)
+}
+
 func TestBuildDatasetRunItems_PairsMismatchesByTitle(t *testing.T) {
 	t.Parallel()
 
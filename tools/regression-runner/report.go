@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// report is the --report JSON document and the --baseline input.
+type report struct {
+	GeneratedAt time.Time   `json:"generated_at"`
+	Dataset     string      `json:"dataset"`
+	Backends    []runResult `json:"backends"`
+}
+
+// writeReport writes r as indented JSON to path.
+func writeReport(path string, r *report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// loadReport reads a report written by writeReport.
+func loadReport(path string) (*report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// JUnit XML shapes, the subset CI test reporters read.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// buildJUnit maps r to one test suite per backend and one test case
+// per dataset item. A misclassification or product-key mismatch is a
+// failure; an extraction error is an error.
+func buildJUnit(r *report) *junitTestSuites {
+	out := &junitTestSuites{Suites: make([]junitTestSuite, 0, len(r.Backends))}
+	for i := range r.Backends {
+		b := &r.Backends[i]
+		suite := junitTestSuite{
+			Name:  "regression." + b.Backend,
+			Tests: len(b.Items),
+			Time:  junitSeconds(b.Duration),
+			Cases: make([]junitTestCase, 0, len(b.Items)),
+		}
+		for j := range b.Items {
+			item := &b.Items[j]
+			tc := junitTestCase{
+				Name:      item.Title,
+				Classname: suite.Name + "." + string(item.Expected),
+				Time:      junitSeconds(item.Latency),
+			}
+			switch {
+			case item.Error != "":
+				tc.Error = &junitMessage{Message: item.Error}
+				suite.Errors++
+			case item.Actual != item.Expected:
+				tc.Failure = &junitMessage{
+					Message: fmt.Sprintf("classified as %s, expected %s", item.Actual, item.Expected),
+				}
+				suite.Failures++
+			case item.ExpectedKey != "" && item.ActualKey != item.ExpectedKey:
+				tc.Failure = &junitMessage{
+					Message: fmt.Sprintf("product key %q, expected %q", item.ActualKey, item.ExpectedKey),
+				}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		out.Suites = append(out.Suites, suite)
+	}
+	return out
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// writeJUnit writes r as JUnit XML to path.
+func writeJUnit(path string, r *report) error {
+	data, err := xml.MarshalIndent(buildJUnit(r), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// checkBaseline compares cur against the report at path, printing any
+// regressions and exiting 1 when there are some.
+func checkBaseline(path string, cur *report, tolerance float64) {
+	base, err := loadReport(path)
+	if err != nil {
+		fatal("loading baseline: %v", err)
+	}
+	regressions := compareReports(base, cur, tolerance)
+	if len(regressions) == 0 {
+		fmt.Fprintf(os.Stderr, "No regressions against baseline %s (tolerance %.1f pts)\n", path, tolerance)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Regressions against baseline %s (tolerance %.1f pts):\n", path, tolerance)
+	for _, r := range regressions {
+		fmt.Fprintln(os.Stderr, "  "+r)
+	}
+	os.Exit(1)
+}
+
+// compareReports returns a line for every score in cur that dropped
+// more than tolerance percentage points below base, and for an error
+// rate that rose by more than tolerance. Backends and components are
+// matched by name; scores either side has no labelled items for are
+// skipped.
+func compareReports(base, cur *report, tolerance float64) []string {
+	baseByBackend := make(map[string]*runResult, len(base.Backends))
+	for i := range base.Backends {
+		baseByBackend[base.Backends[i].Backend] = &base.Backends[i]
+	}
+
+	var out []string
+	for i := range cur.Backends {
+		c := &cur.Backends[i]
+		b, ok := baseByBackend[c.Backend]
+		if !ok {
+			continue
+		}
+		check := func(name string, baseVal, curVal float64) {
+			if baseVal-curVal > tolerance {
+				out = append(out, fmt.Sprintf("%s: %s %.1f%% → %.1f%%", c.Backend, name, baseVal, curVal))
+			}
+		}
+
+		check("accuracy", b.Accuracy, c.Accuracy)
+		if c.ErrorRate-b.ErrorRate > tolerance {
+			out = append(out, fmt.Sprintf("%s: error rate %.1f%% → %.1f%%", c.Backend, b.ErrorRate, c.ErrorRate))
+		}
+		checkAttributes(check, "", b.Attributes, c.Attributes)
+		if b.KeyTotal > 0 && c.KeyTotal > 0 {
+			check("product key accuracy", b.KeyAccuracy, c.KeyAccuracy)
+		}
+
+		baseComps := make(map[domain.ComponentType]*componentResult, len(b.PerComp))
+		for j := range b.PerComp {
+			baseComps[b.PerComp[j].Component] = &b.PerComp[j]
+		}
+		for j := range c.PerComp {
+			cc := &c.PerComp[j]
+			bc, ok := baseComps[cc.Component]
+			if !ok {
+				continue
+			}
+			prefix := string(cc.Component) + " "
+			check(prefix+"accuracy", bc.Accuracy(), cc.Accuracy())
+			checkAttributes(check, prefix, bc.Attributes, cc.Attributes)
+			if bc.KeyTotal > 0 && cc.KeyTotal > 0 {
+				check(prefix+"product key accuracy", bc.KeyAccuracy(), cc.KeyAccuracy())
+			}
+		}
+	}
+	return out
+}
+
+// checkAttributes runs check on attribute precision and recall when
+// both sides scored any.
+func checkAttributes(check func(string, float64, float64), prefix string, base, cur attrCounts) {
+	if base.Extracted > 0 && cur.Extracted > 0 {
+		check(prefix+"attribute precision", base.Precision(), cur.Precision())
+	}
+	if base.Expected > 0 && cur.Expected > 0 {
+		check(prefix+"attribute recall", base.Recall(), cur.Recall())
+	}
+}
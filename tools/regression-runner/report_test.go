@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func TestWriteReport_RoundTrips(t *testing.T) {
+	t.Parallel()
+
+	cost := 0.25
+	want := &report{
+		GeneratedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Dataset:     "testdata/golden_classifications.json",
+		Backends: []runResult{{
+			Backend:    "ollama",
+			Accuracy:   90,
+			Attributes: attrCounts{Expected: 10, Extracted: 12, Correct: 9},
+			CostUSD:    &cost,
+			PerComp: []componentResult{{
+				Component: domain.ComponentCPU,
+				Total:     4,
+				Correct:   3,
+				Fields:    []fieldResult{{Field: "model", attrCounts: attrCounts{Expected: 4, Extracted: 4, Correct: 3}}},
+			}},
+			Items: []itemResult{{Title: "dropped from JSON"}},
+		}},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeReport(path, want))
+	got, err := loadReport(path)
+	require.NoError(t, err)
+
+	want.Backends[0].Items = nil
+	assert.Equal(t, want, got)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	r := &report{Backends: []runResult{{
+		Backend:  "ollama",
+		Duration: 3 * time.Second,
+		Items: []itemResult{
+			{Title: "ok", Expected: domain.ComponentRAM, Actual: domain.ComponentRAM, Latency: 500 * time.Millisecond},
+			{Title: "misclassified", Expected: domain.ComponentRAM, Actual: domain.ComponentOther},
+			{
+				Title:       "wrong key",
+				Expected:    domain.ComponentCPU,
+				Actual:      domain.ComponentCPU,
+				ExpectedKey: "cpu:intel:xeon:6130",
+				ActualKey:   "cpu:intel:xeon:6148",
+			},
+			{Title: "failed", Expected: domain.ComponentGPU, Error: "backend down"},
+		},
+	}}}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	require.NoError(t, writeJUnit(path, r))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.True(t, strings.HasPrefix(out, "<?xml"))
+	assert.Contains(t, out, `<testsuite name="regression.ollama" tests="4" failures="2" errors="1" time="3.000">`)
+	assert.Contains(t, out, `<testcase name="ok" classname="regression.ollama.ram" time="0.500"></testcase>`)
+	assert.Contains(t, out, `<failure message="classified as other, expected ram"></failure>`)
+	assert.Contains(t, out, `<failure message="product key &#34;cpu:intel:xeon:6148&#34;, expected &#34;cpu:intel:xeon:6130&#34;"></failure>`)
+	assert.Contains(t, out, `<error message="backend down"></error>`)
+}
+
+func TestCompareReports(t *testing.T) {
+	t.Parallel()
+
+	base := &report{Backends: []runResult{{
+		Backend:     "ollama",
+		Accuracy:    90,
+		ErrorRate:   1,
+		Attributes:  attrCounts{Expected: 100, Extracted: 100, Correct: 90},
+		KeyTotal:    10,
+		KeyCorrect:  9,
+		KeyAccuracy: 90,
+		PerComp: []componentResult{
+			{Component: domain.ComponentCPU, Total: 10, Correct: 9},
+			{Component: domain.ComponentRAM, Total: 10, Correct: 10},
+		},
+	}}}
+
+	tests := []struct {
+		name string
+		cur  runResult
+		want []string
+	}{
+		{
+			name: "within tolerance",
+			cur: runResult{
+				Backend:     "ollama",
+				Accuracy:    89.5,
+				ErrorRate:   1.5,
+				Attributes:  attrCounts{Expected: 100, Extracted: 100, Correct: 90},
+				KeyTotal:    10,
+				KeyCorrect:  9,
+				KeyAccuracy: 90,
+				PerComp: []componentResult{
+					{Component: domain.ComponentCPU, Total: 10, Correct: 9},
+					{Component: domain.ComponentRAM, Total: 10, Correct: 10},
+				},
+			},
+		},
+		{
+			name: "regressions beyond tolerance",
+			cur: runResult{
+				Backend:     "ollama",
+				Accuracy:    85,
+				ErrorRate:   5,
+				Attributes:  attrCounts{Expected: 100, Extracted: 100, Correct: 80},
+				KeyTotal:    10,
+				KeyCorrect:  9,
+				KeyAccuracy: 90,
+				PerComp: []componentResult{
+					{Component: domain.ComponentCPU, Total: 10, Correct: 9},
+					{Component: domain.ComponentRAM, Total: 10, Correct: 8},
+					{Component: domain.ComponentGPU, Total: 10, Correct: 0},
+				},
+			},
+			want: []string{
+				"ollama: accuracy 90.0% → 85.0%",
+				"ollama: error rate 1.0% → 5.0%",
+				"ollama: attribute precision 90.0% → 80.0%",
+				"ollama: attribute recall 90.0% → 80.0%",
+				"ollama: ram accuracy 100.0% → 80.0%",
+			},
+		},
+		{
+			name: "unlabelled scores and new backends are skipped",
+			cur: runResult{
+				Backend:  "anthropic",
+				Accuracy: 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := compareReports(base, &report{Backends: []runResult{tt.cur}}, 1.0)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
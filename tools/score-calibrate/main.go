@@ -0,0 +1,236 @@
+// Package main is the operator-facing CLI for calibrating the composite
+// deal-score weights against labelled alerts.
+//
+// Labels come from the alerts the tracker already fired:
+//
+//   - operator dismissals in the review UI are bad alerts;
+//   - operator restores (alerts.restored_at) are good alerts;
+//   - otherwise the LLM-as-judge verdict decides, split at --judge-min.
+//
+// For the pooled label set and for each component type, the runner
+// grid-searches factor weights (non-negative, summing to 1) and the
+// score threshold that best separate good from bad alerts on F-beta,
+// then compares that against the weights currently configured:
+//
+//	go run ./tools/score-calibrate --config configs/config.dev.yaml \
+//	    [--lookback 2160h] [--step 0.05] [--beta 0.5] > scoring.yaml
+//
+// The per-type report (samples, precision/recall, lift) goes to stderr;
+// stdout is a `scoring:` YAML block to review and merge into the
+// server config. Suggested thresholds are emitted as comments because
+// thresholds live on watches, not in config.
+//
+// The runner is operator-only; it reads the DB and writes nothing.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	"github.com/donaldgifford/server-price-tracker/internal/engine"
+	"github.com/donaldgifford/server-price-tracker/internal/store"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+// pooledKey labels the calibration over every component type at once;
+// its weights become the top-level `scoring.weights` block.
+const pooledKey = "(all)"
+
+// result is one calibrated group — the pooled set or a component type.
+type result struct {
+	Key         string
+	Calibration score.Calibration
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	configPath := flag.String("config", "configs/config.dev.yaml", "path to YAML config file")
+	lookback := flag.Duration(
+		"lookback", 90*24*time.Hour,
+		"alerts.created_at window for the label query",
+	)
+	judgeMin := flag.Float64(
+		"judge-min", 0.5,
+		"judge verdict at or above which an unlabelled alert counts as good",
+	)
+	step := flag.Float64("step", score.DefaultCalibrationStep, "weight grid resolution")
+	beta := flag.Float64(
+		"beta", score.DefaultCalibrationBeta,
+		"F-beta objective; < 1 favours precision (fewer noise alerts)",
+	)
+	minSamples := flag.Int(
+		"min-samples", score.DefaultCalibrationMinSamples,
+		"minimum labelled alerts before a component type is calibrated",
+	)
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("loading config %s: %w", *configPath, err)
+	}
+
+	ctx := context.Background()
+	st, err := store.NewPostgresStore(ctx, cfg.Database.DSN())
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer st.Close()
+
+	labels, err := st.ListScoringLabels(ctx, &store.ScoringLabelsQuery{
+		Lookback:      *lookback,
+		JudgeMinScore: *judgeMin,
+	})
+	if err != nil {
+		return fmt.Errorf("listing scoring labels: %w", err)
+	}
+	if len(labels) == 0 {
+		fmt.Fprintln(os.Stderr, "no labelled alerts matched — dismiss/restore alerts or enable the judge, or increase --lookback")
+		return nil
+	}
+
+	opts := score.CalibrationOptions{Step: *step, Beta: *beta, MinSamples: *minSamples}
+	results := calibrate(labels, engine.WeightsFromConfig(cfg.Scoring), opts)
+
+	writeReport(os.Stderr, results)
+	writeConfig(os.Stdout, results, len(labels), *lookback)
+	return nil
+}
+
+// calibrate runs the pooled calibration first, then one per component
+// type in sorted order. Each group is measured against the weights the
+// engine would use for it today.
+func calibrate(labels []domain.ScoringLabel, current score.WeightSet, opts score.CalibrationOptions) []result {
+	groups := map[string][]score.Sample{}
+	all := make([]score.Sample, 0, len(labels))
+	for i := range labels {
+		s := toSample(&labels[i])
+		all = append(all, s)
+		ct := string(labels[i].ComponentType)
+		groups[ct] = append(groups[ct], s)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]result, 0, len(keys)+1)
+	out = append(out, result{
+		Key:         pooledKey,
+		Calibration: score.Calibrate(all, current.For(""), opts),
+	})
+	for _, k := range keys {
+		out = append(out, result{
+			Key:         k,
+			Calibration: score.Calibrate(groups[k], current.For(k), opts),
+		})
+	}
+	return out
+}
+
+func toSample(l *domain.ScoringLabel) score.Sample {
+	return score.Sample{
+		Factors: score.Breakdown{
+			Price:     l.Breakdown.Price,
+			Seller:    l.Breakdown.Seller,
+			Condition: l.Breakdown.Condition,
+			Quantity:  l.Breakdown.Quantity,
+			Quality:   l.Breakdown.Quality,
+			Time:      l.Breakdown.Time,
+			Value:     l.Breakdown.Value,
+		},
+		Threshold: l.Threshold,
+		Good:      l.Good,
+	}
+}
+
+func writeReport(w io.Writer, results []result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tSAMPLES\tGOOD\tCUR P/R\tFIT P/R\tTHRESHOLD\tF-BETA LIFT\tNOTE")
+	for i := range results {
+		c := &results[i].Calibration
+		if c.Skipped != "" {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f/%.2f\t—\t—\t—\t%s\n",
+				results[i].Key, c.Samples, c.Positives,
+				c.Current.Precision, c.Current.Recall, c.Skipped)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f/%.2f\t%.2f/%.2f\t%d\t%+.3f\t\n",
+			results[i].Key, c.Samples, c.Positives,
+			c.Current.Precision, c.Current.Recall,
+			c.Fitted.Precision, c.Fitted.Recall,
+			c.Threshold, c.Lift())
+	}
+	_ = tw.Flush()
+}
+
+// writeConfig emits the reviewable `scoring:` block. Skipped component
+// types are listed as comments so the operator can see why they have
+// no override; the pooled weights are always emitted (they equal the
+// current weights when the pooled fit was skipped).
+func writeConfig(w io.Writer, results []result, labels int, lookback time.Duration) {
+	fmt.Fprintf(w, "# Generated by tools/score-calibrate from %d labelled alerts (lookback %s).\n",
+		labels, lookback)
+	fmt.Fprintln(w, "# Review the stderr report before merging into the server config.")
+	fmt.Fprintln(w, "scoring:")
+
+	pooled := &results[0].Calibration
+	fmt.Fprintf(w, "  weights:%s\n", thresholdComment(pooled))
+	writeWeights(w, "    ", pooled.Weights)
+
+	var overrides, skipped []result
+	for _, r := range results[1:] {
+		if r.Calibration.Skipped != "" {
+			skipped = append(skipped, r)
+			continue
+		}
+		overrides = append(overrides, r)
+	}
+
+	if len(overrides) > 0 {
+		fmt.Fprintln(w, "  component_weights:")
+		for i := range overrides {
+			fmt.Fprintf(w, "    %s:%s\n", overrides[i].Key, thresholdComment(&overrides[i].Calibration))
+			writeWeights(w, "      ", overrides[i].Calibration.Weights)
+		}
+	}
+	for i := range skipped {
+		c := &skipped[i].Calibration
+		fmt.Fprintf(w, "  # %s: not calibrated (%s, %d labels)\n", skipped[i].Key, c.Skipped, c.Samples)
+	}
+}
+
+func thresholdComment(c *score.Calibration) string {
+	if c.Skipped != "" {
+		return fmt.Sprintf(" # unchanged: %s", c.Skipped)
+	}
+	return fmt.Sprintf(" # suggested watch score_threshold: %d (F-beta %.2f -> %.2f)",
+		c.Threshold, c.Current.FBeta, c.Fitted.FBeta)
+}
+
+func writeWeights(w io.Writer, indent string, ws score.Weights) {
+	fmt.Fprintf(w, "%sprice: %.2f\n", indent, ws.Price)
+	fmt.Fprintf(w, "%sseller: %.2f\n", indent, ws.Seller)
+	fmt.Fprintf(w, "%scondition: %.2f\n", indent, ws.Condition)
+	fmt.Fprintf(w, "%squantity: %.2f\n", indent, ws.Quantity)
+	fmt.Fprintf(w, "%squality: %.2f\n", indent, ws.Quality)
+	fmt.Fprintf(w, "%stime: %.2f\n", indent, ws.Time)
+	if ws.Value > 0 {
+		fmt.Fprintf(w, "%svalue: %.2f\n", indent, ws.Value)
+	}
+}
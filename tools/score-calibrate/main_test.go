@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/donaldgifford/server-price-tracker/internal/config"
+	score "github.com/donaldgifford/server-price-tracker/pkg/scorer"
+	domain "github.com/donaldgifford/server-price-tracker/pkg/types"
+)
+
+func labelsFor(ct domain.ComponentType, n int) []domain.ScoringLabel {
+	out := make([]domain.ScoringLabel, 0, 2*n)
+	for range n {
+		out = append(out,
+			domain.ScoringLabel{
+				ComponentType: ct,
+				Breakdown:     domain.ScoreBreakdown{Price: 100, Seller: 40, Condition: 70, Quantity: 50, Quality: 40, Time: 30},
+				Threshold:     30,
+				Good:          true,
+				Source:        "restored",
+			},
+			domain.ScoringLabel{
+				ComponentType: ct,
+				Breakdown:     domain.ScoreBreakdown{Price: 20, Seller: 40, Condition: 70, Quantity: 50, Quality: 40, Time: 30},
+				Threshold:     30,
+				Good:          false,
+				Source:        "dismissed",
+			},
+		)
+	}
+	return out
+}
+
+func TestCalibrate_PooledFirstThenSortedTypes(t *testing.T) {
+	t.Parallel()
+
+	labels := append(labelsFor(domain.ComponentRAM, 20), labelsFor(domain.ComponentCPU, 2)...)
+	got := calibrate(labels, score.WeightSet{}, score.CalibrationOptions{Step: 0.1})
+
+	require.Len(t, got, 3)
+	assert.Equal(t, pooledKey, got[0].Key)
+	assert.Equal(t, "cpu", got[1].Key)
+	assert.Equal(t, "ram", got[2].Key)
+	assert.Equal(t, 44, got[0].Calibration.Samples)
+	assert.NotEmpty(t, got[1].Calibration.Skipped, "4 labels is below the default minimum")
+	assert.Empty(t, got[2].Calibration.Skipped)
+}
+
+func TestWriteConfig_IsValidScoringBlock(t *testing.T) {
+	t.Parallel()
+
+	labels := append(labelsFor(domain.ComponentRAM, 20), labelsFor(domain.ComponentCPU, 2)...)
+	results := calibrate(labels, score.WeightSet{}, score.CalibrationOptions{Step: 0.1})
+
+	var buf bytes.Buffer
+	writeConfig(&buf, results, len(labels), 24*time.Hour)
+	out := buf.String()
+
+	assert.Contains(t, out, "suggested watch score_threshold")
+	assert.Contains(t, out, "# cpu: not calibrated")
+
+	var parsed struct {
+		Scoring config.ScoringConfig `yaml:"scoring"`
+	}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &parsed))
+	require.Contains(t, parsed.Scoring.ComponentWeights, "ram")
+	assert.NotContains(t, parsed.Scoring.ComponentWeights, "cpu")
+	assert.InDelta(t, 1.0, parsed.Scoring.Weights.Sum(), 0.01)
+	assert.InDelta(t, 1.0, parsed.Scoring.ComponentWeights["ram"].Sum(), 0.01)
+}